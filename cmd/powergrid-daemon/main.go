@@ -1,8 +1,11 @@
 package main
 
 import (
+	"flag"
 	"os"
 
+	cfg "powergrid/internal/config"
+	"powergrid/internal/daemon/ipc"
 	"powergrid/internal/daemon/server"
 )
 
@@ -11,8 +14,24 @@ var BuildID string
 var BuildIDSource string
 var BuildDirty string
 
+// GitCommit, BuildTime (RFC 3339) and Version are stamped the same way.
+var GitCommit string
+var BuildTime string
+var Version string
+
 func main() {
-	if err := server.Run(BuildID, BuildIDSource, BuildDirty == "true"); err != nil {
+	socket := flag.String("socket", "", "serve on this socket path instead of "+ipc.DefaultSocketPath+" (or $"+ipc.SocketPathEnv+")")
+	flag.Parse()
+
+	defaultLimit := cfg.DefaultChargeLimitFromEnv(server.DefaultChargeLimit)
+	if err := server.Run(server.BuildInfo{
+		ID:        BuildID,
+		IDSource:  BuildIDSource,
+		Dirty:     BuildDirty == "true",
+		GitCommit: GitCommit,
+		Time:      BuildTime,
+		Version:   Version,
+	}, defaultLimit, ipc.ResolveSocketPath(*socket)); err != nil {
 		_, _ = os.Stderr.WriteString(err.Error() + "\n")
 		os.Exit(1)
 	}
@@ -2,12 +2,17 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"fmt"
 	"io"
 	"log"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"time"
+
+	cfg "powergrid/internal/config"
 )
 
 const (
@@ -19,6 +24,7 @@ const (
 	daemonInstallPath = installDir + "/" + daemonName
 	cliInstallPath    = installDir + "/" + cliName
 	plistInstallPath  = launchDaemonsDir + "/" + plistName
+	launchctlTimeout  = 30 * time.Second
 )
 
 func main() {
@@ -45,8 +51,12 @@ func main() {
 			log.Fatalf("FATAL: Installation failed: %v", err)
 		}
 	case "uninstall":
-		log.Printf("Action: uninstall.")
-		if err := uninstall(); err != nil {
+		opts, err := parseUninstallArgs(os.Args[2:])
+		if err != nil {
+			log.Fatalf("FATAL: %v", err)
+		}
+		log.Printf("Action: uninstall (purge=%v).", opts.purge)
+		if err := uninstall(opts); err != nil {
 			log.Fatalf("FATAL: Uninstallation failed: %v", err)
 		}
 	default:
@@ -61,8 +71,7 @@ func install(resourcesPath string) error {
 
 	if _, err := os.Stat(plistInstallPath); err == nil {
 		log.Println("Unloading existing service...")
-		cmd := exec.Command("launchctl", "unload", plistInstallPath)
-		if output, err := cmd.CombinedOutput(); err != nil {
+		if output, err := runLaunchctl("unload", plistInstallPath); err != nil {
 			log.Printf("Warning: 'launchctl unload' failed, but continuing. Output: %s", output)
 		}
 	}
@@ -107,9 +116,8 @@ func install(resourcesPath string) error {
 	log.Println("✅ launchd plist installed.")
 
 	log.Println("Loading new service with launchctl...")
-	cmd := exec.Command("launchctl", "load", plistInstallPath)
-	if output, err := cmd.CombinedOutput(); err != nil {
-		return fmt.Errorf("failed to load service: %s", output)
+	if output, err := runLaunchctl("load", plistInstallPath); err != nil {
+		return fmt.Errorf("failed to load service: %v: %s", err, output)
 	}
 	log.Println("✅ Service loaded.")
 
@@ -117,13 +125,34 @@ func install(resourcesPath string) error {
 	return nil
 }
 
-func uninstall() error {
+type uninstallOptions struct {
+	purge   bool
+	homeDir string
+}
+
+func parseUninstallArgs(args []string) (uninstallOptions, error) {
+	var opts uninstallOptions
+	fs := flag.NewFlagSet("uninstall", flag.ContinueOnError)
+	fs.BoolVar(&opts.purge, "purge", false, "also remove the system preferences and, with --home, the user preferences")
+	fs.StringVar(&opts.homeDir, "home", "", "home directory whose PowerGrid preferences are removed with --purge")
+	if err := fs.Parse(args); err != nil {
+		return opts, err
+	}
+	if fs.NArg() != 0 {
+		return opts, fmt.Errorf("unexpected uninstall arguments: %v", fs.Args())
+	}
+	if opts.homeDir != "" && !opts.purge {
+		return opts, fmt.Errorf("--home requires --purge")
+	}
+	return opts, nil
+}
+
+func uninstall(opts uninstallOptions) error {
 	log.Println("--- Starting PowerGrid Daemon Uninstallation ---")
 
 	if _, err := os.Stat(plistInstallPath); err == nil {
 		log.Println("Unloading service...")
-		cmd := exec.Command("launchctl", "unload", plistInstallPath)
-		if output, err := cmd.CombinedOutput(); err != nil {
+		if output, err := runLaunchctl("unload", plistInstallPath); err != nil {
 			log.Printf("Warning: 'launchctl unload' failed, but continuing. Output: %s", output)
 		}
 	} else {
@@ -149,6 +178,14 @@ func uninstall() error {
 	}
 	log.Println("✅ CLI binary removed.")
 
+	if opts.purge {
+		if err := purgeSettings(opts.homeDir); err != nil {
+			return err
+		}
+	} else {
+		log.Println("Preserving PowerGrid settings; pass --purge to remove them.")
+	}
+
 	log.Println("--- Uninstallation Complete ---")
 	return nil
 }
@@ -180,3 +217,33 @@ func copyFile(src, dst string) (err error) {
 
 	return destFile.Sync()
 }
+
+// purgeSettings removes saved preferences for a clean reinstall. Logs under
+// /var/log/powergrid are left for troubleshooting.
+func purgeSettings(homeDir string) error {
+	paths := []string{cfg.SystemPlistPath}
+	if homeDir != "" {
+		paths = append(paths, cfg.UserPlistPath(homeDir))
+	}
+
+	for _, path := range paths {
+		log.Printf("Removing preferences: %s", path)
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove preferences %s: %w", path, err)
+		}
+	}
+	log.Println("✅ Settings purged.")
+	return nil
+}
+
+// runLaunchctl bounds launchctl so a wedged launchd cannot hang the installer.
+func runLaunchctl(args ...string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), launchctlTimeout)
+	defer cancel()
+
+	output, err := exec.CommandContext(ctx, "launchctl", args...).CombinedOutput()
+	if ctx.Err() == context.DeadlineExceeded {
+		return output, fmt.Errorf("launchctl %s timed out after %s", args[0], launchctlTimeout)
+	}
+	return output, err
+}
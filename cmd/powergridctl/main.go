@@ -11,25 +11,33 @@ import (
 	"strings"
 	"time"
 
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
 	grpcstatus "google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
 
+	"powergrid/internal/daemon/ipc"
 	rpc "powergrid/internal/rpc"
 )
 
 const (
-	socketPath   = "/var/run/powergrid.sock"
-	dialTimeout  = 3 * time.Second
-	rpcTimeout   = 5 * time.Second
-	actionGet    = "get"
-	stateOff     = "off"
-	stateOn      = "on"
-	sleepSystem  = "system"
-	sleepDisplay = "display"
-	usageText    = "powergridctl: control PowerGrid through the local daemon\n\nUsage:\n  powergridctl status\n  powergridctl limit [60-100|off]\n  powergridctl lowpower [get|on|off|toggle]\n  powergridctl discharge [get|on|off]\n  powergridctl sleep [get|off|system|display]\n  powergridctl help\n"
+	socketFlag          = "--socket"
+	tokenEnv            = "POWERGRID_TOKEN"
+	dialTimeout         = 3 * time.Second
+	rpcTimeout          = 5 * time.Second
+	actionGet           = "get"
+	stateOff            = "off"
+	stateOn             = "on"
+	sleepSystem         = "system"
+	sleepDisplay        = "display"
+	defaultBoostMinutes = 30
+	defaultSuspendMins  = 60
+	defaultLogLines     = 50
+	usageText           = "powergridctl: control PowerGrid through the local daemon\n\nUsage:\n  powergridctl status [--refresh]\n  powergridctl limit [<min>-100|off]\n  powergridctl lowpower [get|on|off|toggle]\n  powergridctl discharge [get|on|off|band <floor> <ceiling>|band off]\n  powergridctl sleep [get|off|system|display|persist [on|off]]\n  powergridctl manage [get|on|off]\n  powergridctl charging [get|on|off|release]\n  powergridctl led [get|auto|off|system|history|green [full|limit]]\n  powergridctl adapter [limit <<min>-100|off|clear>]\n  powergridctl profile [list|use <name>]\n  powergridctl settings\n  powergridctl auto\n  powergridctl boost [minutes|off]\n  powergridctl suspend [minutes|off]\n  powergridctl pin <pid|off>\n  powergridctl fullby <HH:MM|off> [<min>-100]\n  powergridctl logs [lines]\n  powergridctl bundle\n  powergridctl counters [reset]\n  powergridctl smc <key>...\n  powergridctl users\n  powergridctl estimate\n  powergridctl session\n  powergridctl wear\n  powergridctl help\n\n<min> is the daemon's MinChargeLimit, 60 unless an administrator changed it.\nPut --socket <path> before the command, or set POWERGRID_SOCKET, to reach a daemon on another socket.\n"
 )
 
 type commandClient struct {
@@ -41,6 +49,11 @@ func main() {
 }
 
 func run(args []string, stdout, stderr io.Writer) int {
+	socket, args, err := splitSocketFlag(args)
+	if err != nil {
+		_ = writeLine(stderr, err.Error())
+		return 1
+	}
 	if len(args) == 0 {
 		if err := printUsage(stdout); err != nil {
 			_ = writeLine(stderr, err.Error())
@@ -57,7 +70,7 @@ func run(args []string, stdout, stderr io.Writer) int {
 		return 0
 	}
 
-	conn, client, err := newCommandClient()
+	conn, client, err := newCommandClient(ipc.ResolveSocketPath(socket))
 	if err != nil {
 		_ = writeLine(stderr, formatCommandError(err))
 		return 1
@@ -74,18 +87,40 @@ func run(args []string, stdout, stderr io.Writer) int {
 	return 0
 }
 
-func newCommandClient() (*grpc.ClientConn, *commandClient, error) {
+// splitSocketFlag takes a leading --socket <path> or --socket=<path> off
+// args.
+func splitSocketFlag(args []string) (string, []string, error) {
+	if len(args) == 0 {
+		return "", args, nil
+	}
+	if path, ok := strings.CutPrefix(args[0], socketFlag+"="); ok {
+		if path == "" {
+			return "", nil, fmt.Errorf("usage: powergridctl --socket <path> <command>")
+		}
+		return path, args[1:], nil
+	}
+	if args[0] != socketFlag {
+		return "", args, nil
+	}
+	if len(args) < 2 || args[1] == "" {
+		return "", nil, fmt.Errorf("usage: powergridctl --socket <path> <command>")
+	}
+	return args[1], args[2:], nil
+}
+
+func newCommandClient(socket string) (*grpc.ClientConn, *commandClient, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), dialTimeout)
 	defer cancel()
 
 	dialer := func(ctx context.Context, _ string) (net.Conn, error) {
-		return (&net.Dialer{}).DialContext(ctx, "unix", socketPath)
+		return (&net.Dialer{}).DialContext(ctx, "unix", socket)
 	}
 
 	conn, err := grpc.NewClient(
 		"passthrough:///powergrid",
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
 		grpc.WithContextDialer(dialer),
+		grpc.WithUnaryInterceptor(tokenInterceptor(mutationToken())),
 	)
 	if err != nil {
 		return nil, nil, err
@@ -98,6 +133,29 @@ func newCommandClient() (*grpc.ClientConn, *commandClient, error) {
 	return conn, &commandClient{rpc: rpc.NewPowerGridClient(conn)}, nil
 }
 
+// mutationToken returns the shared secret to present on mutations: the
+// POWERGRID_TOKEN environment variable, or the daemon's token file when it
+// is readable (that is, when running as root).
+func mutationToken() string {
+	if token := strings.TrimSpace(os.Getenv(tokenEnv)); token != "" {
+		return token
+	}
+	data, err := os.ReadFile(ipc.MutationTokenPath)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+func tokenInterceptor(token string) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if token != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
 func waitForReady(ctx context.Context, conn *grpc.ClientConn) error {
 	conn.Connect()
 	for {
@@ -131,31 +189,92 @@ func dispatch(client *commandClient, args []string, stdout io.Writer) error {
 		return handleDischarge(client, rest, stdout)
 	case "sleep":
 		return handleSleep(client, rest, stdout)
+	case "manage":
+		return handleManage(client, rest, stdout)
+	case "charging":
+		return handleCharging(client, rest, stdout)
+	case "led":
+		return handleLED(client, rest, stdout)
+	case "adapter":
+		return handleAdapter(client, rest, stdout)
+	case "profile":
+		return handleProfile(client, rest, stdout)
+	case "settings":
+		return handleSettings(client, rest, stdout)
+	case "auto":
+		return handleAuto(client, rest, stdout)
+	case "logs":
+		return handleLogs(client, rest, stdout)
+	case "bundle":
+		return handleBundle(client, rest, stdout)
+	case "counters":
+		return handleCounters(client, rest, stdout)
+	case "smc":
+		return handleSMC(client, rest, stdout)
+	case "users":
+		return handleUsers(client, rest, stdout)
+	case "estimate":
+		return handleEstimate(client, rest, stdout)
+	case "session":
+		return handleSession(client, rest, stdout)
+	case "wear":
+		return handleWear(client, rest, stdout)
+	case "boost":
+		return handleBoost(client, rest, stdout)
+	case "pin":
+		return handlePin(client, rest, stdout)
+	case "suspend":
+		return handleSuspend(client, rest, stdout)
+	case "fullby":
+		return handleFullBy(client, rest, stdout)
 	default:
 		return fmt.Errorf("unknown command %q", command)
 	}
 }
 
 func handleStatus(client *commandClient, args []string, stdout io.Writer) error {
-	if len(args) != 0 {
-		return fmt.Errorf("status does not take any arguments")
+	refresh := len(args) == 1 && args[0] == "--refresh"
+	if len(args) != 0 && !refresh {
+		return fmt.Errorf("status only accepts --refresh")
 	}
 
-	status, err := client.getStatus()
+	var status *rpc.StatusResponse
+	var err error
+	if refresh {
+		status, err = client.refresh()
+	} else {
+		status, err = client.getStatus()
+	}
 	if err != nil {
 		return err
 	}
+	if status.GetBatteryMissing() {
+		return writef(stdout, "Battery: not present (charge control suspended)\nPower: %s\nManagement: %s\n",
+			formatPowerSource(status),
+			formatManagement(status),
+		)
+	}
 
+	if err := writef(stdout, "%s%s%s%s%s%s", formatWarmUpNote(status), formatSafeModeWarning(status), formatManualChargingWarning(status), formatCriticalWarning(status), formatConflictWarning(status), formatStallWarning(status)); err != nil {
+		return err
+	}
 	return writef(
 		stdout,
-		"Charge: %d%%\nLimit: %s\nCharging: %s\nConnected: %s\nForce discharge: %s\nSleep mode: %s\nLow Power Mode: %s\n",
-		status.GetCurrentCharge(),
-		formatLimit(status.GetChargeLimit()),
+		"Charge: %s\nLimit: %s\nCharging: %s\nPaused: %s\nConnected: %s\nBattery: %.2fV, %+.2fA, %+.2fW (%s)\nForce discharge: %s\nSleep mode: %s\nLow Power Mode: %s\nManagement: %s\n%s",
+		formatCharge(status),
+		formatStatusLimit(status),
 		formatBinaryState(status.GetIsCharging()),
+		formatPauseReason(status.GetChargingPauseReason()),
 		formatBinaryState(status.GetIsConnected()),
+		status.GetBatteryVoltage(),
+		status.GetBatteryAmperage(),
+		status.GetBatteryWattage(),
+		formatBatteryState(status.GetBatteryState()),
 		formatBinaryState(status.GetForceDischargeActive()),
 		sleepModeFromStatus(status),
 		lowPowerModeState(status),
+		formatManagement(status),
+		formatExternalSleepNote(status),
 	)
 }
 
@@ -168,7 +287,7 @@ func handleLimit(client *commandClient, args []string, stdout io.Writer) error {
 		return writef(stdout, "Charge limit: %s\n", formatLimit(status.GetChargeLimit()))
 	}
 	if len(args) != 1 {
-		return fmt.Errorf("usage: powergridctl limit [60-100|off]")
+		return fmt.Errorf("usage: powergridctl limit [<min>-100|off]")
 	}
 
 	limit, err := parseLimitValue(args[0])
@@ -223,6 +342,9 @@ func handleLowPower(client *commandClient, args []string, stdout io.Writer) erro
 }
 
 func handleDischarge(client *commandClient, args []string, stdout io.Writer) error {
+	if len(args) > 0 && args[0] == "band" {
+		return handleDischargeBand(client, args[1:], stdout)
+	}
 	action := actionGet
 	if len(args) > 1 {
 		return fmt.Errorf("usage: powergridctl discharge [get|on|off]")
@@ -237,7 +359,7 @@ func handleDischarge(client *commandClient, args []string, stdout io.Writer) err
 		if err != nil {
 			return err
 		}
-		return writef(stdout, "Force discharge: %s\n", formatBinaryState(status.GetForceDischargeActive()))
+		return writef(stdout, "Force discharge: %s\nDischarge band: %s\n", formatForceDischarge(status), formatDischargeBand(status))
 	case stateOn, stateOff:
 		enable := action == stateOn
 		if err := client.setPowerFeature(rpc.PowerFeature_FORCE_DISCHARGE, enable); err != nil {
@@ -249,7 +371,33 @@ func handleDischarge(client *commandClient, args []string, stdout io.Writer) err
 	}
 }
 
+func handleDischargeBand(client *commandClient, args []string, stdout io.Writer) error {
+	var floor, ceiling int32
+	switch {
+	case len(args) == 1 && args[0] == stateOff:
+	case len(args) == 2:
+		lo, errLo := strconv.Atoi(args[0])
+		hi, errHi := strconv.Atoi(args[1])
+		if errLo != nil || errHi != nil {
+			return fmt.Errorf("usage: powergridctl discharge band <floor> <ceiling>|off")
+		}
+		floor, ceiling = int32(lo), int32(hi)
+	default:
+		return fmt.Errorf("usage: powergridctl discharge band <floor> <ceiling>|off")
+	}
+	if err := client.setDischargeBand(floor, ceiling); err != nil {
+		return err
+	}
+	if ceiling == 0 {
+		return writef(stdout, "Discharge band cleared.\n")
+	}
+	return writef(stdout, "Discharge band set to %d%%-%d%%.\n", floor, ceiling)
+}
+
 func handleSleep(client *commandClient, args []string, stdout io.Writer) error {
+	if len(args) > 0 && args[0] == "persist" {
+		return handleSleepPersist(client, args[1:], stdout)
+	}
 	action := actionGet
 	if len(args) > 1 {
 		return fmt.Errorf("usage: powergridctl sleep [get|off|system|display]")
@@ -264,7 +412,7 @@ func handleSleep(client *commandClient, args []string, stdout io.Writer) error {
 		if err != nil {
 			return err
 		}
-		return writef(stdout, "Sleep mode: %s\n", sleepModeFromStatus(status))
+		return writef(stdout, "Sleep mode: %s\n%s", sleepModeFromStatus(status), formatExternalSleepNote(status))
 	case stateOff:
 		if err := client.setPowerFeature(rpc.PowerFeature_PREVENT_DISPLAY_SLEEP, false); err != nil {
 			return err
@@ -294,109 +442,1177 @@ func handleSleep(client *commandClient, args []string, stdout io.Writer) error {
 	}
 }
 
-func (c *commandClient) getStatus() (*rpc.StatusResponse, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
-	defer cancel()
+func handleSleepPersist(client *commandClient, args []string, stdout io.Writer) error {
+	action := actionGet
+	if len(args) > 1 {
+		return fmt.Errorf("usage: powergridctl sleep persist [get|on|off]")
+	}
+	if len(args) == 1 {
+		action = args[0]
+	}
 
-	return c.rpc.GetStatus(ctx, &rpc.Empty{})
+	switch action {
+	case actionGet:
+		status, err := client.getStatus()
+		if err != nil {
+			return err
+		}
+		return writef(stdout, "Persist across sleep: %s\n", formatBinaryState(status.GetPersistSleepPreventionActive()))
+	case stateOn, stateOff:
+		enable := action == stateOn
+		if err := client.setPowerFeature(rpc.PowerFeature_PERSIST_SLEEP_PREVENTION, enable); err != nil {
+			return err
+		}
+		return writef(stdout, "Persist across sleep %s.\n", formatAppliedState(enable))
+	default:
+		return fmt.Errorf("usage: powergridctl sleep persist [get|on|off]")
+	}
 }
 
-func (c *commandClient) setLimit(limit int32) error {
-	ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
-	defer cancel()
+func handleManage(client *commandClient, args []string, stdout io.Writer) error {
+	action := actionGet
+	if len(args) > 1 {
+		return fmt.Errorf("usage: powergridctl manage [get|on|off]")
+	}
+	if len(args) == 1 {
+		action = args[0]
+	}
 
-	_, err := c.rpc.ApplyMutation(ctx, &rpc.MutationRequest{
-		Operation: rpc.MutationOperation_SET_CHARGE_LIMIT,
-		Limit:     limit,
-	})
+	switch action {
+	case actionGet:
+		status, err := client.getStatus()
+		if err != nil {
+			return err
+		}
+		return writef(stdout, "Management: %s\n", formatManagement(status))
+	case stateOn, stateOff:
+		enable := action == stateOn
+		if err := client.setPowerFeature(rpc.PowerFeature_CHARGE_MANAGEMENT, enable); err != nil {
+			return err
+		}
+		return writef(stdout, "Management %s.\n", formatAppliedState(enable))
+	default:
+		return fmt.Errorf("usage: powergridctl manage [get|on|off]")
+	}
+}
+
+// handleCharging sets or releases the root-only manual charging override,
+// which holds SMC charging on or off regardless of the limit.
+func handleCharging(client *commandClient, args []string, stdout io.Writer) error {
+	action := actionGet
+	if len(args) > 1 {
+		return fmt.Errorf("usage: powergridctl charging [get|on|off|release]")
+	}
+	if len(args) == 1 {
+		action = args[0]
+	}
+
+	var mode rpc.ManualCharging
+	switch action {
+	case actionGet:
+		status, err := client.getStatus()
+		if err != nil {
+			return err
+		}
+		return writef(stdout, "Manual charging: %s\n", formatManualCharging(status.GetManualCharging()))
+	case stateOn:
+		mode = rpc.ManualCharging_MANUAL_CHARGING_ON
+	case stateOff:
+		mode = rpc.ManualCharging_MANUAL_CHARGING_OFF
+	case "release":
+		mode = rpc.ManualCharging_MANUAL_CHARGING_NONE
+	default:
+		return fmt.Errorf("usage: powergridctl charging [get|on|off|release]")
+	}
+
+	if err := client.setManualCharging(mode); err != nil {
+		return err
+	}
+	if mode == rpc.ManualCharging_MANUAL_CHARGING_NONE {
+		return writef(stdout, "Manual charging released; the charge limit is enforced again.\n")
+	}
+	return writef(stdout, "Charging held %s until released (powergridctl charging release or auto).\n", action)
+}
+
+func handleAdapter(client *commandClient, args []string, stdout io.Writer) error {
+	if len(args) == 2 && args[0] == "limit" {
+		return handleAdapterLimit(client, args[1], stdout)
+	}
+	if len(args) != 0 {
+		return fmt.Errorf("usage: powergridctl adapter [limit <<min>-100|off|clear>]")
+	}
+
+	details, err := client.getAdapterDetails()
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(stdout, formatAdapterDetails(details))
 	return err
 }
 
-func (c *commandClient) setPowerFeature(feature rpc.PowerFeature, enable bool) error {
-	ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
-	defer cancel()
+func handleSettings(client *commandClient, args []string, stdout io.Writer) error {
+	if len(args) != 0 {
+		return fmt.Errorf("settings does not take any arguments")
+	}
 
-	_, err := c.rpc.ApplyMutation(ctx, &rpc.MutationRequest{
-		Operation: rpc.MutationOperation_SET_POWER_FEATURE,
-		Feature:   feature,
-		Enable:    enable,
-	})
+	settings, err := client.getEffectiveSettings()
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(stdout, formatEffectiveSettings(settings))
 	return err
 }
 
-func parseLimitValue(arg string) (int32, error) {
-	if strings.EqualFold(arg, stateOff) {
-		return 100, nil
+func handleAdapterLimit(client *commandClient, arg string, stdout io.Writer) error {
+	if arg == "clear" {
+		if err := client.setAdapterLimit(0); err != nil {
+			return err
+		}
+		return writef(stdout, "Adapter charge limit cleared.\n")
 	}
 
-	limit, err := strconv.Atoi(arg)
+	limit, err := parseLimitValue(arg)
 	if err != nil {
-		return 0, fmt.Errorf("invalid limit %q", arg)
+		return err
 	}
-	if limit < 60 || limit > 100 {
-		return 0, fmt.Errorf("limit must be between 60 and 100, or 'off'")
+	if err := client.setAdapterLimit(limit); err != nil {
+		return err
 	}
-	return int32(limit), nil
+
+	return writef(stdout, "Charge limit for this adapter set to %s.\n", formatLimit(limit))
 }
 
-func formatLimit(limit int32) string {
-	if limit >= 100 {
-		return "off"
+func handleAuto(client *commandClient, args []string, stdout io.Writer) error {
+	if len(args) != 0 {
+		return fmt.Errorf("auto does not take any arguments")
 	}
-	return fmt.Sprintf("%d%%", limit)
+	if err := client.clearOverrides(); err != nil {
+		return err
+	}
+	return writef(stdout, "Overrides cleared; automatic management resumed.\n")
 }
 
-func formatBinaryState(enabled bool) string {
-	if enabled {
-		return stateOn
+func handleLED(client *commandClient, args []string, stdout io.Writer) error {
+	if len(args) > 0 && strings.EqualFold(args[0], "green") {
+		return handleLEDGreen(client, args[1:], stdout)
+	}
+	if len(args) == 1 && strings.EqualFold(args[0], "history") {
+		status, err := client.getStatus()
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(stdout, formatLEDHistory(status))
+		return err
+	}
+	action := actionGet
+	if len(args) > 1 {
+		return fmt.Errorf("usage: powergridctl led [get|auto|off|system]")
+	}
+	if len(args) == 1 {
+		action = strings.ToLower(args[0])
 	}
-	return stateOff
-}
 
-func formatAppliedState(enabled bool) string {
-	if enabled {
-		return "enabled"
+	var mode rpc.MagsafeLedMode
+	switch action {
+	case actionGet:
+		status, err := client.getStatus()
+		if err != nil {
+			return err
+		}
+		return writef(stdout, "MagSafe LED: %s\n", formatLEDMode(status.GetMagsafeLedMode()))
+	case "auto":
+		mode = rpc.MagsafeLedMode_MAGSAFE_LED_MODE_AUTO
+	case stateOff:
+		mode = rpc.MagsafeLedMode_MAGSAFE_LED_MODE_OFF
+	case sleepSystem:
+		mode = rpc.MagsafeLedMode_MAGSAFE_LED_MODE_SYSTEM
+	default:
+		return fmt.Errorf("usage: powergridctl led [get|auto|off|system]")
 	}
-	return "disabled"
+
+	if err := client.setMagsafeLEDMode(mode); err != nil {
+		return err
+	}
+	return writef(stdout, "MagSafe LED set to %s.\n", formatLEDMode(mode))
 }
 
-func sleepModeFromStatus(status *rpc.StatusResponse) string {
+// handleLEDGreen picks when auto mode shows green: at the limit, or only
+// once the battery is full.
+func handleLEDGreen(client *commandClient, args []string, stdout io.Writer) error {
 	switch {
-	case status.GetPreventDisplaySleepActive():
-		return sleepDisplay
-	case status.GetPreventSystemSleepActive():
-		return sleepSystem
+	case len(args) == 0:
+		status, err := client.getStatus()
+		if err != nil {
+			return err
+		}
+		return writef(stdout, "MagSafe LED green: %s\n", formatLEDGreen(status.GetMagsafeLedGreenOnlyWhenFull()))
+	case len(args) == 1 && (args[0] == "full" || args[0] == "limit"):
+		fullOnly := args[0] == "full"
+		if err := client.setPowerFeature(rpc.PowerFeature_MAGSAFE_LED_GREEN_ONLY_WHEN_FULL, fullOnly); err != nil {
+			return err
+		}
+		return writef(stdout, "MagSafe LED green set to %s.\n", formatLEDGreen(fullOnly))
 	default:
-		return stateOff
+		return fmt.Errorf("usage: powergridctl led green [full|limit]")
 	}
 }
 
-func lowPowerModeState(status *rpc.StatusResponse) string {
-	if !status.GetLowPowerModeAvailable() {
-		return "not available"
+func handleBoost(client *commandClient, args []string, stdout io.Writer) error {
+	if len(args) > 1 {
+		return fmt.Errorf("usage: powergridctl boost [minutes|off]")
 	}
-	if status.GetLowPowerModeEnabled() {
-		return stateOn
+
+	minutes := int32(defaultBoostMinutes)
+	if len(args) == 1 {
+		if strings.EqualFold(args[0], stateOff) {
+			minutes = 0
+		} else {
+			n, err := strconv.Atoi(args[0])
+			if err != nil || n <= 0 {
+				return fmt.Errorf("invalid boost duration %q", args[0])
+			}
+			minutes = int32(n)
+		}
 	}
-	return stateOff
+
+	if err := client.setChargeBoost(minutes); err != nil {
+		return err
+	}
+	if minutes == 0 {
+		return writef(stdout, "Charge boost cancelled.\n")
+	}
+	return writef(stdout, "Charging past the limit for %d minutes.\n", minutes)
 }
 
-func formatCommandError(err error) string {
-	st, ok := grpcstatus.FromError(err)
-	if !ok {
-		return err.Error()
+func handleSuspend(client *commandClient, args []string, stdout io.Writer) error {
+	if len(args) > 1 {
+		return fmt.Errorf("usage: powergridctl suspend [minutes|off]")
 	}
 
-	switch st.Code() {
-	case codes.Unavailable:
-		return "PowerGrid daemon is unavailable. Install the app or start the daemon first."
-	case codes.PermissionDenied:
-		return "Permission denied. Run as root or as the active console user."
-	case codes.Unimplemented:
-		return "The installed daemon is too old for this command. Upgrade PowerGrid."
-	default:
-		return st.Message()
+	minutes := int32(defaultSuspendMins)
+	if len(args) == 1 {
+		if strings.EqualFold(args[0], stateOff) {
+			minutes = 0
+		} else {
+			n, err := strconv.Atoi(args[0])
+			if err != nil || n <= 0 {
+				return fmt.Errorf("invalid suspension duration %q", args[0])
+			}
+			minutes = int32(n)
+		}
+	}
+
+	if err := client.suspendManagement(minutes); err != nil {
+		return err
+	}
+	if minutes == 0 {
+		return writef(stdout, "Management resumed.\n")
+	}
+	return writef(stdout, "Management suspended for %d minutes; it resumes on its own.\n", minutes)
+}
+
+func handlePin(client *commandClient, args []string, stdout io.Writer) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: powergridctl pin <pid|off>")
+	}
+
+	var pid int32
+	if !strings.EqualFold(args[0], stateOff) {
+		n, err := strconv.Atoi(args[0])
+		if err != nil || n <= 0 {
+			return fmt.Errorf("invalid PID %q", args[0])
+		}
+		pid = int32(n)
+	}
+
+	if err := client.setFullChargePin(pid); err != nil {
+		return err
+	}
+	if pid == 0 {
+		return writef(stdout, "Full-charge pin removed.\n")
+	}
+	return writef(stdout, "Charging to 100%% while PID %d runs.\n", pid)
+}
+
+func handleFullBy(client *commandClient, args []string, stdout io.Writer) error {
+	if len(args) < 1 || len(args) > 2 {
+		return fmt.Errorf("usage: powergridctl fullby <HH:MM|off> [<min>-100]")
+	}
+
+	if strings.EqualFold(args[0], stateOff) {
+		if len(args) != 1 {
+			return fmt.Errorf("usage: powergridctl fullby <HH:MM|off> [<min>-100]")
+		}
+		if err := client.setFullBy(time.Time{}, 0); err != nil {
+			return err
+		}
+		return writef(stdout, "Full-by charge plan cancelled.\n")
+	}
+
+	deadline, err := parseFullByTime(args[0], time.Now())
+	if err != nil {
+		return err
+	}
+	target := int32(100)
+	if len(args) == 2 {
+		if target, err = parseLimitValue(args[1]); err != nil {
+			return err
+		}
+	}
+
+	if err := client.setFullBy(deadline, target); err != nil {
+		return err
+	}
+	return writef(stdout, "Charging to %d%% by %s.\n", target, deadline.Format("Mon 15:04"))
+}
+
+// parseFullByTime reads an RFC 3339 time, or an HH:MM clock time meaning its
+// next occurrence after now.
+func parseFullByTime(value string, now time.Time) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	clockTime, err := time.ParseInLocation("15:04", value, now.Location())
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %q (want HH:MM or RFC 3339)", value)
+	}
+	t := time.Date(now.Year(), now.Month(), now.Day(), clockTime.Hour(), clockTime.Minute(), 0, 0, now.Location())
+	if !t.After(now) {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t, nil
+}
+
+func handleLogs(client *commandClient, args []string, stdout io.Writer) error {
+	if len(args) > 1 {
+		return fmt.Errorf("usage: powergridctl logs [lines]")
+	}
+
+	lines := int32(defaultLogLines)
+	if len(args) == 1 {
+		n, err := strconv.Atoi(args[0])
+		if err != nil || n <= 0 {
+			return fmt.Errorf("invalid line count %q", args[0])
+		}
+		lines = int32(n)
+	}
+
+	logs, err := client.getLogs(lines)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(stdout, formatLogs(logs))
+	return err
+}
+
+func handleBundle(client *commandClient, args []string, stdout io.Writer) error {
+	if len(args) != 0 {
+		return fmt.Errorf("bundle does not take any arguments")
+	}
+
+	bundle, err := client.getSupportBundle()
+	if err != nil {
+		return err
+	}
+
+	out, err := protojson.MarshalOptions{Multiline: true, EmitUnpopulated: true}.Marshal(bundle)
+	if err != nil {
+		return fmt.Errorf("encode support bundle: %w", err)
+	}
+	return writef(stdout, "%s\n", out)
+}
+
+func handleCounters(client *commandClient, args []string, stdout io.Writer) error {
+	switch {
+	case len(args) == 0:
+		counters, err := client.getCounters()
+		if err != nil {
+			return err
+		}
+		return writef(stdout, "%s", formatCounters(counters))
+	case len(args) == 1 && args[0] == "reset":
+		if err := client.resetCounters(); err != nil {
+			return err
+		}
+		return writef(stdout, "Charging counters reset.\n")
+	default:
+		return fmt.Errorf("usage: powergridctl counters [reset]")
+	}
+}
+
+func handleSMC(client *commandClient, args []string, stdout io.Writer) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: powergridctl smc <key>...")
+	}
+	resp, err := client.readSMCKeys(args)
+	if err != nil {
+		return err
+	}
+	return writef(stdout, "%s", formatSMCKeys(resp))
+}
+
+func handleUsers(client *commandClient, args []string, stdout io.Writer) error {
+	if len(args) != 0 {
+		return fmt.Errorf("usage: powergridctl users")
+	}
+	resp, err := client.listUserSettings()
+	if err != nil {
+		return err
+	}
+	return writef(stdout, "%s", formatUserSettings(resp))
+}
+
+func handleEstimate(client *commandClient, args []string, stdout io.Writer) error {
+	if len(args) != 0 {
+		return fmt.Errorf("usage: powergridctl estimate")
+	}
+	resp, err := client.getTimeEstimates()
+	if err != nil {
+		return err
+	}
+	return writef(stdout, "%s", formatTimeEstimates(resp))
+}
+
+func handleSession(client *commandClient, args []string, stdout io.Writer) error {
+	if len(args) != 0 {
+		return fmt.Errorf("usage: powergridctl session")
+	}
+	resp, err := client.getSessionStats()
+	if err != nil {
+		return err
+	}
+	return writef(stdout, "%s", formatSessionStats(resp))
+}
+
+func handleWear(client *commandClient, args []string, stdout io.Writer) error {
+	if len(args) != 0 {
+		return fmt.Errorf("usage: powergridctl wear")
+	}
+	resp, err := client.getWearTrend()
+	if err != nil {
+		return err
+	}
+	return writef(stdout, "%s", formatWearTrend(resp))
+}
+
+func handleProfile(client *commandClient, args []string, stdout io.Writer) error {
+	switch {
+	case len(args) == 0 || (len(args) == 1 && args[0] == "list"):
+		profiles, err := client.listProfiles()
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(stdout, formatProfiles(profiles))
+		return err
+	case len(args) == 2 && args[0] == "use":
+		if err := client.applyProfile(args[1]); err != nil {
+			return err
+		}
+		return writef(stdout, "Profile %s applied.\n", args[1])
+	default:
+		return fmt.Errorf("usage: powergridctl profile [list|use <name>]")
+	}
+}
+
+func (c *commandClient) getStatus() (*rpc.StatusResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
+	defer cancel()
+
+	return c.rpc.GetStatus(ctx, &rpc.Empty{})
+}
+
+func (c *commandClient) refresh() (*rpc.StatusResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
+	defer cancel()
+
+	return c.rpc.Refresh(ctx, &rpc.Empty{})
+}
+
+func (c *commandClient) getAdapterDetails() (*rpc.AdapterDetailsResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
+	defer cancel()
+
+	return c.rpc.GetAdapterDetails(ctx, &rpc.Empty{})
+}
+
+func (c *commandClient) getEffectiveSettings() (*rpc.EffectiveSettingsResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
+	defer cancel()
+
+	return c.rpc.GetEffectiveSettings(ctx, &rpc.Empty{})
+}
+
+func (c *commandClient) getLogs(lines int32) (*rpc.LogsResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
+	defer cancel()
+
+	return c.rpc.GetLogs(ctx, &rpc.LogsRequest{Lines: lines})
+}
+
+func (c *commandClient) getSupportBundle() (*rpc.SupportBundleResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
+	defer cancel()
+
+	return c.rpc.GetSupportBundle(ctx, &rpc.Empty{})
+}
+
+func (c *commandClient) getCounters() (*rpc.CountersResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
+	defer cancel()
+
+	return c.rpc.GetCounters(ctx, &rpc.Empty{})
+}
+
+func (c *commandClient) readSMCKeys(keys []string) (*rpc.SMCKeysResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
+	defer cancel()
+
+	return c.rpc.ReadSMCKeys(ctx, &rpc.SMCKeysRequest{Keys: keys})
+}
+
+func (c *commandClient) getTimeEstimates() (*rpc.TimeEstimatesResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
+	defer cancel()
+
+	return c.rpc.GetTimeEstimates(ctx, &rpc.Empty{})
+}
+
+func (c *commandClient) getSessionStats() (*rpc.SessionStatsResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
+	defer cancel()
+
+	return c.rpc.GetSessionStats(ctx, &rpc.Empty{})
+}
+
+func (c *commandClient) getWearTrend() (*rpc.WearTrendResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
+	defer cancel()
+
+	return c.rpc.GetWearTrend(ctx, &rpc.Empty{})
+}
+
+func (c *commandClient) listUserSettings() (*rpc.UserSettingsResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
+	defer cancel()
+
+	return c.rpc.ListUserSettings(ctx, &rpc.Empty{})
+}
+
+func (c *commandClient) resetCounters() error {
+	ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
+	defer cancel()
+
+	_, err := c.rpc.ApplyMutation(ctx, &rpc.MutationRequest{Operation: rpc.MutationOperation_RESET_COUNTERS})
+	return err
+}
+
+func (c *commandClient) clearOverrides() error {
+	ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
+	defer cancel()
+
+	_, err := c.rpc.ApplyMutation(ctx, &rpc.MutationRequest{
+		Operation: rpc.MutationOperation_CLEAR_OVERRIDES,
+	})
+	return err
+}
+
+func (c *commandClient) setChargeBoost(minutes int32) error {
+	ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
+	defer cancel()
+
+	_, err := c.rpc.ApplyMutation(ctx, &rpc.MutationRequest{
+		Operation:    rpc.MutationOperation_CHARGE_BOOST,
+		BoostMinutes: minutes,
+	})
+	return err
+}
+
+func (c *commandClient) setFullBy(deadline time.Time, target int32) error {
+	ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
+	defer cancel()
+
+	var deadlineUnix int64
+	if !deadline.IsZero() {
+		deadlineUnix = deadline.Unix()
+	}
+	_, err := c.rpc.ApplyMutation(ctx, &rpc.MutationRequest{
+		Operation:  rpc.MutationOperation_FULL_BY,
+		Limit:      target,
+		FullByUnix: deadlineUnix,
+	})
+	return err
+}
+
+func (c *commandClient) setMagsafeLEDMode(mode rpc.MagsafeLedMode) error {
+	ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
+	defer cancel()
+
+	_, err := c.rpc.ApplyMutation(ctx, &rpc.MutationRequest{
+		Operation:      rpc.MutationOperation_SET_MAGSAFE_LED_MODE,
+		MagsafeLedMode: mode,
+	})
+	return err
+}
+
+func (c *commandClient) setManualCharging(mode rpc.ManualCharging) error {
+	ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
+	defer cancel()
+
+	_, err := c.rpc.ApplyMutation(ctx, &rpc.MutationRequest{
+		Operation:      rpc.MutationOperation_MANUAL_CHARGING,
+		ManualCharging: mode,
+	})
+	return err
+}
+
+func (c *commandClient) suspendManagement(minutes int32) error {
+	ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
+	defer cancel()
+
+	_, err := c.rpc.ApplyMutation(ctx, &rpc.MutationRequest{
+		Operation:      rpc.MutationOperation_SUSPEND_MANAGEMENT,
+		SuspendMinutes: minutes,
+	})
+	return err
+}
+
+func (c *commandClient) setFullChargePin(pid int32) error {
+	ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
+	defer cancel()
+
+	_, err := c.rpc.ApplyMutation(ctx, &rpc.MutationRequest{
+		Operation: rpc.MutationOperation_PIN_FULL_CHARGE,
+		Pid:       pid,
+	})
+	return err
+}
+
+func (c *commandClient) setAdapterLimit(limit int32) error {
+	ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
+	defer cancel()
+
+	_, err := c.rpc.ApplyMutation(ctx, &rpc.MutationRequest{
+		Operation: rpc.MutationOperation_SET_ADAPTER_LIMIT,
+		Limit:     limit,
+	})
+	return err
+}
+
+func (c *commandClient) setDischargeBand(floor, ceiling int32) error {
+	ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
+	defer cancel()
+
+	_, err := c.rpc.ApplyMutation(ctx, &rpc.MutationRequest{
+		Operation:            rpc.MutationOperation_SET_DISCHARGE_BAND,
+		DischargeBandFloor:   floor,
+		DischargeBandCeiling: ceiling,
+	})
+	return err
+}
+
+func (c *commandClient) listProfiles() (*rpc.ProfileListResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
+	defer cancel()
+
+	return c.rpc.ListProfiles(ctx, &rpc.Empty{})
+}
+
+func (c *commandClient) applyProfile(name string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
+	defer cancel()
+
+	_, err := c.rpc.ApplyMutation(ctx, &rpc.MutationRequest{
+		Operation:   rpc.MutationOperation_APPLY_PROFILE,
+		ProfileName: name,
+	})
+	return err
+}
+
+func (c *commandClient) setLimit(limit int32) error {
+	ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
+	defer cancel()
+
+	_, err := c.rpc.ApplyMutation(ctx, &rpc.MutationRequest{
+		Operation: rpc.MutationOperation_SET_CHARGE_LIMIT,
+		Limit:     limit,
+	})
+	return err
+}
+
+func (c *commandClient) setPowerFeature(feature rpc.PowerFeature, enable bool) error {
+	ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
+	defer cancel()
+
+	_, err := c.rpc.ApplyMutation(ctx, &rpc.MutationRequest{
+		Operation: rpc.MutationOperation_SET_POWER_FEATURE,
+		Feature:   feature,
+		Enable:    enable,
+	})
+	return err
+}
+
+func parseLimitValue(arg string) (int32, error) {
+	if strings.EqualFold(arg, stateOff) {
+		return 100, nil
+	}
+
+	limit, err := strconv.Atoi(arg)
+	if err != nil {
+		return 0, fmt.Errorf("invalid limit %q", arg)
+	}
+	// The daemon enforces the configured floor (MinChargeLimit); only the
+	// hard floor is checked here.
+	if limit < 40 || limit > 100 {
+		return 0, fmt.Errorf("limit must be between 40 and 100, or 'off'")
+	}
+	return int32(limit), nil
+}
+
+func formatLimit(limit int32) string {
+	if limit >= 100 {
+		return "off"
+	}
+	return fmt.Sprintf("%d%%", limit)
+}
+
+// formatStatusLimit shows the enforced limit, naming the adapter when a
+// per-adapter limit overrides the regular one and any active charge boost.
+func formatStatusLimit(status *rpc.StatusResponse) string {
+	limit := formatLimit(status.GetChargeLimit())
+	if key := status.GetMatchedAdapterKey(); key != "" {
+		limit = fmt.Sprintf("%s for %s (default %s)", formatLimit(status.GetEffectiveChargeLimit()), key, formatLimit(status.GetChargeLimit()))
+	}
+	if ramped := status.GetRampedChargeLimit(); ramped > 0 {
+		limit += fmt.Sprintf(", ramping down (now %d%%)", ramped)
+	}
+	if status.GetLimitCurrentlyEnforced() {
+		limit += ", holding charging off"
+	}
+	if remaining := status.GetBoostRemainingSeconds(); remaining > 0 {
+		limit += fmt.Sprintf(", boosted for %dm", (remaining+59)/60)
+	}
+	if pid := status.GetPinnedPid(); pid != 0 {
+		limit += fmt.Sprintf(", pinned to 100%% by PID %d", pid)
+	}
+	if deadline := status.GetFullByUnix(); deadline != 0 {
+		limit += fmt.Sprintf(", %d%% by %s (from %s)", status.GetFullByTarget(),
+			time.Unix(deadline, 0).Format("15:04"), time.Unix(status.GetFullByStartUnix(), 0).Format("15:04"))
+	}
+	return limit
+}
+
+// formatPowerSource names what powers a Mac without an internal battery.
+func formatPowerSource(status *rpc.StatusResponse) string {
+	switch {
+	case status.GetPowerSource() == rpc.PowerSource_POWER_SOURCE_UPS:
+		return "UPS (on UPS battery)"
+	case status.GetUpsAttached():
+		return "external power source (UPS attached)"
+	case status.GetIsConnected():
+		return "AC"
+	default:
+		return "unknown"
+	}
+}
+
+// formatManagement shows a timed suspension in place of the plain on/off
+// state while one is running.
+func formatManagement(status *rpc.StatusResponse) string {
+	if remaining := status.GetSuspendRemainingSeconds(); remaining > 0 && status.GetManagementEnabled() {
+		return fmt.Sprintf("suspended for %dm", (remaining+59)/60)
+	}
+	return formatBinaryState(status.GetManagementEnabled())
+}
+
+func formatLEDGreen(fullOnly bool) string {
+	if fullOnly {
+		return "only when full"
+	}
+	return "at the limit"
+}
+
+func formatLEDMode(mode rpc.MagsafeLedMode) string {
+	switch mode {
+	case rpc.MagsafeLedMode_MAGSAFE_LED_MODE_AUTO:
+		return "auto"
+	case rpc.MagsafeLedMode_MAGSAFE_LED_MODE_OFF:
+		return stateOff
+	default:
+		return sleepSystem
+	}
+}
+
+func formatForceDischarge(status *rpc.StatusResponse) string {
+	state := formatBinaryState(status.GetForceDischargeActive())
+	switch {
+	case status.GetForceDischargeActive() && status.GetForceDischargeFloor() > 0:
+		return fmt.Sprintf("%s (stops at %d%%)", state, status.GetForceDischargeFloor())
+	case status.GetForceDischargeFloorReached():
+		return fmt.Sprintf("%s (stopped at %d%% floor)", state, status.GetForceDischargeFloor())
+	default:
+		return state
+	}
+}
+
+// formatDischargeBand shows the band and whether it is discharging now.
+func formatDischargeBand(status *rpc.StatusResponse) string {
+	if status.GetDischargeBandCeiling() == 0 {
+		return stateOff
+	}
+	band := fmt.Sprintf("%d%%-%d%%", status.GetDischargeBandFloor(), status.GetDischargeBandCeiling())
+	if status.GetDischargeBandActive() {
+		band += " (discharging)"
+	}
+	return band
+}
+
+func formatBinaryState(enabled bool) string {
+	if enabled {
+		return stateOn
+	}
+	return stateOff
+}
+
+func formatAppliedState(enabled bool) string {
+	if enabled {
+		return "enabled"
+	}
+	return "disabled"
+}
+
+func formatAdapterDetails(details *rpc.AdapterDetailsResponse) string {
+	if !details.GetConnected() {
+		return "Adapter: not connected\n"
+	}
+
+	description := details.GetDescription()
+	if description == "" {
+		description = "unknown"
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Adapter: %s\n", description)
+	fmt.Fprintf(&b, "Rated: %dW (%.2fV, %.2fA)\n", details.GetMaxWatts(), details.GetMaxVoltage(), details.GetMaxAmperage())
+	if details.GetTelemetryAvailable() {
+		fmt.Fprintf(&b, "Input: %.1fW (%.2fV, %.2fA)\n", details.GetInputWatts(), details.GetInputVoltage(), details.GetInputAmperage())
+	} else {
+		b.WriteString("Input: not available\n")
+	}
+	return b.String()
+}
+
+func formatProfiles(resp *rpc.ProfileListResponse) string {
+	var b strings.Builder
+	for _, p := range resp.GetProfiles() {
+		marker := " "
+		if p.GetName() == resp.GetActiveProfile() {
+			marker = "*"
+		}
+		fmt.Fprintf(&b, "%s %s: limit %s, LED %s, sleep charging guard %s\n",
+			marker,
+			p.GetName(),
+			formatLimit(p.GetChargeLimit()),
+			formatBinaryState(p.GetControlMagsafeLed()),
+			formatBinaryState(p.GetDisableChargingBeforeSleep()),
+		)
+	}
+	return b.String()
+}
+
+func formatEffectiveSettings(resp *rpc.EffectiveSettingsResponse) string {
+	var b strings.Builder
+	for _, s := range resp.GetSettings() {
+		fmt.Fprintf(&b, "%s: %s (%s)\n", s.GetKey(), s.GetValue(), s.GetSource())
+	}
+	return b.String()
+}
+
+func formatCharge(status *rpc.StatusResponse) string {
+	charge := fmt.Sprintf("%d%%", status.GetCurrentCharge())
+	if !status.GetChargedAtLimit() {
+		return charge
+	}
+	if limit := status.GetEffectiveChargeLimit(); limit > 0 && limit < 100 && status.GetBoostRemainingSeconds() == 0 {
+		return fmt.Sprintf("%s (charged, limited to %d%%)", charge, limit)
+	}
+	return charge + " (charged)"
+}
+
+func formatConflictWarning(status *rpc.StatusResponse) string {
+	if !status.GetChargeManagerConflict() {
+		return ""
+	}
+	return fmt.Sprintf("Warning: another charge manager may be active (%s)\n", status.GetChargeManagerConflictDetail())
+}
+
+// formatLEDHistory lists the committed MagSafe LED state, when each state was
+// last written, and any failed write, for debugging LED reports.
+func formatLEDHistory(status *rpc.StatusResponse) string {
+	var b strings.Builder
+	committed := status.GetMagsafeLedCommittedState()
+	if committed == "" {
+		committed = "none written yet"
+	}
+	fmt.Fprintf(&b, "Committed: %s\n", committed)
+	for _, tr := range status.GetMagsafeLedTransitions() {
+		fmt.Fprintf(&b, "  %-7s %s\n", tr.GetState(), time.Unix(tr.GetAppliedUnix(), 0).Format("2006-01-02 15:04:05"))
+	}
+	if status.GetMagsafeLedLastFailureUnix() != 0 {
+		fmt.Fprintf(&b, "Last failure: %s (%s)\n", time.Unix(status.GetMagsafeLedLastFailureUnix(), 0).Format("2006-01-02 15:04:05"), status.GetMagsafeLedLastError())
+	}
+	if status.GetMagsafeLedWritePending() {
+		b.WriteString("Rewrite pending after a failed write\n")
+	}
+	return b.String()
+}
+
+func formatManualCharging(mode rpc.ManualCharging) string {
+	switch mode {
+	case rpc.ManualCharging_MANUAL_CHARGING_ON:
+		return "held on"
+	case rpc.ManualCharging_MANUAL_CHARGING_OFF:
+		return "held off"
+	default:
+		return stateOff
+	}
+}
+
+func formatManualChargingWarning(status *rpc.StatusResponse) string {
+	if status.GetManualCharging() == rpc.ManualCharging_MANUAL_CHARGING_NONE {
+		return ""
+	}
+	return fmt.Sprintf("Warning: charging is manually %s; the charge limit is not enforced until released\n", formatManualCharging(status.GetManualCharging()))
+}
+
+func formatCriticalWarning(status *rpc.StatusResponse) string {
+	if !status.GetCriticalCharge() {
+		return ""
+	}
+	return fmt.Sprintf("Warning: battery critically low at %d%% and not charging; connect an adapter\n", status.GetCurrentCharge())
+}
+
+func formatWarmUpNote(status *rpc.StatusResponse) string {
+	if !status.GetWarmingUp() {
+		return ""
+	}
+	return "Note: daemon just started; charging changes wait a few seconds for readings to settle\n"
+}
+
+func formatSafeModeWarning(status *rpc.StatusResponse) string {
+	if !status.GetSafeMode() {
+		return ""
+	}
+	return "Warning: read-only safe mode; SMC writes are denied, so charging is not managed. Fix SMC access and restart the daemon\n"
+}
+
+func formatStallWarning(status *rpc.StatusResponse) string {
+	if !status.GetChargingStalled() {
+		return ""
+	}
+	return "Warning: charging is enabled but charge is not increasing; check the adapter, cable and port\n"
+}
+
+func formatCounters(resp *rpc.CountersResponse) string {
+	return fmt.Sprintf("Day: %s\nCharging enabled: %d\nCharging disabled: %d\n",
+		resp.GetDay(), resp.GetChargingEnabled(), resp.GetChargingDisabled())
+}
+
+func formatSMCKeys(resp *rpc.SMCKeysResponse) string {
+	var b strings.Builder
+	for _, v := range resp.GetValues() {
+		fmt.Fprintf(&b, "%s [%s] %d bytes: % x\n", v.GetKey(), strings.TrimSpace(v.GetDataType()), v.GetDataSize(), v.GetData())
+	}
+	return b.String()
+}
+
+func formatUserSettings(resp *rpc.UserSettingsResponse) string {
+	if len(resp.GetUsers()) == 0 {
+		return "No users have PowerGrid settings.\n"
+	}
+	var b strings.Builder
+	for _, u := range resp.GetUsers() {
+		limit := "unset"
+		if u.GetChargeLimit() > 0 {
+			limit = fmt.Sprintf("%d%%", u.GetChargeLimit())
+		}
+		fmt.Fprintf(&b, "%s (%d): limit %s (effective %d%%), LED %s, stop before sleep %s\n",
+			u.GetUsername(), u.GetUid(), limit, u.GetEffectiveChargeLimit(),
+			formatLEDMode(u.GetMagsafeLedMode()), formatBinaryState(u.GetDisableChargingBeforeSleep()))
+	}
+	return b.String()
+}
+
+func formatTimeEstimates(resp *rpc.TimeEstimatesResponse) string {
+	smoothed := "not enough charge history yet"
+	switch {
+	case resp.GetSmoothedTimeToLimitMinutes() > 0:
+		smoothed = fmt.Sprintf("%dm to %d%%", resp.GetSmoothedTimeToLimitMinutes(), resp.GetTargetCharge())
+	case resp.GetSmoothedTimeToEmptyMinutes() > 0:
+		smoothed = fmt.Sprintf("%dm to empty", resp.GetSmoothedTimeToEmptyMinutes())
+	}
+	return fmt.Sprintf("IOKit: %dm to full, %dm to empty\nSmoothed: %s (confidence %s, %d samples over %dm)\n",
+		resp.GetIokitTimeToFullMinutes(), resp.GetIokitTimeToEmptyMinutes(), smoothed,
+		formatEstimateConfidence(resp.GetConfidence()), resp.GetSampleCount(), resp.GetSampleSpanSeconds()/60)
+}
+
+// formatSessionStats summarizes the charge session since the last plug-in.
+func formatSessionStats(resp *rpc.SessionStatsResponse) string {
+	if resp.GetStartedUnix() == 0 {
+		return "No charge session yet.\n"
+	}
+	state := "unplugged"
+	if resp.GetConnected() {
+		state = "plugged in"
+	}
+	return fmt.Sprintf("Session: since %s (%s)\nEnergy added: %.1f Wh over %dm of charging\nCharge power: %.1f W average, %.1f W peak\nEfficiency: %.0f%% of %.1f Wh from the adapter\n",
+		time.Unix(resp.GetStartedUnix(), 0).Format("15:04"), state,
+		resp.GetEnergyAddedWh(), resp.GetChargingSeconds()/60,
+		resp.GetAverageChargeWatts(), resp.GetPeakChargeWatts(),
+		resp.GetEfficiency()*100, resp.GetAdapterEnergyWh())
+}
+
+// formatWearTrend shows the latest health sample, how much history backs
+// the trend, and the estimated wear rate once there is enough of it.
+func formatWearTrend(resp *rpc.WearTrendResponse) string {
+	samples := resp.GetSamples()
+	if len(samples) == 0 {
+		return "No battery health history yet.\n"
+	}
+	first, latest := samples[0], samples[len(samples)-1]
+	wear := "not enough history yet (needs 10 cycles)"
+	if resp.GetRateKnown() {
+		wear = fmt.Sprintf("%.1f%% per 100 cycles", resp.GetLossPerHundredCycles())
+	}
+	return fmt.Sprintf("Health: %.1f%% at %d cycles (%d daily samples since %s)\nWear: %s\n",
+		latest.GetHealthPercent(), latest.GetCycleCount(), len(samples),
+		time.Unix(first.GetUnix(), 0).Format("2006-01-02"), wear)
+}
+
+func formatEstimateConfidence(c rpc.EstimateConfidence) string {
+	switch c {
+	case rpc.EstimateConfidence_ESTIMATE_CONFIDENCE_LOW:
+		return "low"
+	case rpc.EstimateConfidence_ESTIMATE_CONFIDENCE_MEDIUM:
+		return "medium"
+	case rpc.EstimateConfidence_ESTIMATE_CONFIDENCE_HIGH:
+		return "high"
+	default:
+		return "none"
+	}
+}
+
+func formatLogs(resp *rpc.LogsResponse) string {
+	var b strings.Builder
+	for _, e := range resp.GetEntries() {
+		ts := time.UnixMilli(e.GetTimestampUnixMs()).Format("2006-01-02 15:04:05")
+		fmt.Fprintf(&b, "%s [%s] %s\n", ts, e.GetLevel(), e.GetMessage())
+	}
+	return b.String()
+}
+
+func formatPauseReason(reason rpc.ChargingPauseReason) string {
+	switch reason {
+	case rpc.ChargingPauseReason_PAUSE_AT_LIMIT:
+		return "at limit"
+	case rpc.ChargingPauseReason_PAUSE_FORCE_DISCHARGE:
+		return "force discharge"
+	case rpc.ChargingPauseReason_PAUSE_BEFORE_SLEEP:
+		return "before sleep"
+	case rpc.ChargingPauseReason_PAUSE_EXTERNAL_HOLD:
+		return "held outside PowerGrid"
+	case rpc.ChargingPauseReason_PAUSE_WEAK_ADAPTER:
+		return "weak adapter"
+	case rpc.ChargingPauseReason_PAUSE_MANUAL:
+		return "manual override"
+	default:
+		return "no"
+	}
+}
+
+func formatBatteryState(state rpc.BatteryState) string {
+	switch state {
+	case rpc.BatteryState_BATTERY_CHARGING:
+		return "charging"
+	case rpc.BatteryState_BATTERY_DISCHARGING:
+		return "discharging"
+	case rpc.BatteryState_BATTERY_IDLE:
+		return "idle"
+	default:
+		return "unknown"
+	}
+}
+
+func sleepModeFromStatus(status *rpc.StatusResponse) string {
+	switch {
+	case status.GetPreventDisplaySleepAuto():
+		return sleepDisplay + " (external display)"
+	case status.GetPreventDisplaySleepActive():
+		return sleepDisplay
+	case status.GetPreventSystemSleepActive():
+		return sleepSystem
+	default:
+		return stateOff
+	}
+}
+
+// formatExternalSleepNote names other apps holding sleep assertions, which
+// keep the Mac awake even when PowerGrid's sleep mode is off.
+func formatExternalSleepNote(status *rpc.StatusResponse) string {
+	holders := status.GetExternalSleepAssertions()
+	if len(holders) == 0 {
+		return ""
+	}
+	return "Note: also kept awake by " + strings.Join(holders, ", ") + "\n"
+}
+
+func lowPowerModeState(status *rpc.StatusResponse) string {
+	if !status.GetLowPowerModeAvailable() {
+		return "not available"
+	}
+	if status.GetLowPowerModeEnabled() {
+		return stateOn
+	}
+	return stateOff
+}
+
+func formatCommandError(err error) string {
+	st, ok := grpcstatus.FromError(err)
+	if !ok {
+		return err.Error()
+	}
+
+	switch errorReason(st) {
+	case rpc.ErrorReason_ERROR_SMC_BUSY.String():
+		return "The SMC did not accept the change. Try again."
+	case rpc.ErrorReason_ERROR_NO_CONSOLE_USER.String():
+		return "This setting is per user and needs someone logged in at the console."
+	}
+
+	switch st.Code() {
+	case codes.Unavailable:
+		return "PowerGrid daemon is unavailable. Install the app or start the daemon first."
+	case codes.PermissionDenied:
+		return "Permission denied. Run as root or as the active console user."
+	case codes.Unauthenticated:
+		return "Mutation token required. Set POWERGRID_TOKEN or run as root."
+	case codes.Unimplemented:
+		return "The installed daemon is too old for this command. Upgrade PowerGrid."
+	default:
+		return st.Message()
+	}
+}
+
+// errorReason returns the daemon's ErrorInfo reason, if the status has one.
+func errorReason(st *grpcstatus.Status) string {
+	for _, d := range st.Details() {
+		if info, ok := d.(*errdetails.ErrorInfo); ok {
+			return info.GetReason()
+		}
 	}
+	return ""
 }
 
 func printUsage(w io.Writer) error {
@@ -1,7 +1,16 @@
 package main
 
 import (
+	"context"
+	"strings"
 	"testing"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	grpcstatus "google.golang.org/grpc/status"
 
 	rpc "powergrid/internal/rpc"
 )
@@ -17,7 +26,7 @@ func TestParseLimitValue(t *testing.T) {
 	}{
 		{name: "off", input: "off", want: 100},
 		{name: "numeric", input: "80", want: 80},
-		{name: "too low", input: "59", wantErr: true},
+		{name: "too low", input: "39", wantErr: true},
 		{name: "not a number", input: "banana", wantErr: true},
 	}
 
@@ -43,6 +52,39 @@ func TestParseLimitValue(t *testing.T) {
 	}
 }
 
+func TestParseFullByTime(t *testing.T) {
+	now := time.Date(2026, 5, 3, 22, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		input   string
+		want    time.Time
+		wantErr bool
+	}{
+		{name: "later today", input: "23:15", want: time.Date(2026, 5, 3, 23, 15, 0, 0, time.UTC)},
+		{name: "tomorrow", input: "07:00", want: time.Date(2026, 5, 4, 7, 0, 0, 0, time.UTC)},
+		{name: "rfc3339", input: "2026-05-05T06:45:00Z", want: time.Date(2026, 5, 5, 6, 45, 0, 0, time.UTC)},
+		{name: "invalid", input: "tomorrow", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := parseFullByTime(tc.input, now)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error for %q", tc.input)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseFullByTime(%q) returned error: %v", tc.input, err)
+			}
+			if !got.Equal(tc.want) {
+				t.Fatalf("unexpected time: got=%v want=%v", got, tc.want)
+			}
+		})
+	}
+}
+
 func TestSleepModeFromStatus(t *testing.T) {
 	t.Parallel()
 
@@ -61,6 +103,14 @@ func TestSleepModeFromStatus(t *testing.T) {
 			},
 			want: "display",
 		},
+		{
+			name: "external display",
+			status: &rpc.StatusResponse{
+				PreventDisplaySleepActive: true,
+				PreventDisplaySleepAuto:   true,
+			},
+			want: "display (external display)",
+		},
 	}
 
 	for _, tc := range tests {
@@ -75,3 +125,550 @@ func TestSleepModeFromStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestFormatAdapterDetails(t *testing.T) {
+	tests := []struct {
+		name    string
+		details *rpc.AdapterDetailsResponse
+		want    string
+	}{
+		{
+			name:    "disconnected",
+			details: &rpc.AdapterDetailsResponse{},
+			want:    "Adapter: not connected\n",
+		},
+		{
+			name: "connected with telemetry",
+			details: &rpc.AdapterDetailsResponse{
+				Connected:          true,
+				Description:        "pd charger",
+				MaxWatts:           96,
+				MaxVoltage:         20,
+				MaxAmperage:        4.8,
+				InputVoltage:       20.1,
+				InputAmperage:      2.5,
+				InputWatts:         50.25,
+				TelemetryAvailable: true,
+			},
+			want: "Adapter: pd charger\nRated: 96W (20.00V, 4.80A)\nInput: 50.2W (20.10V, 2.50A)\n",
+		},
+		{
+			name: "connected without telemetry",
+			details: &rpc.AdapterDetailsResponse{
+				Connected: true,
+				MaxWatts:  30,
+			},
+			want: "Adapter: unknown\nRated: 30W (0.00V, 0.00A)\nInput: not available\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatAdapterDetails(tt.details); got != tt.want {
+				t.Fatalf("unexpected output: got=%q want=%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatProfiles(t *testing.T) {
+	resp := &rpc.ProfileListResponse{
+		ActiveProfile: "daily",
+		Profiles: []*rpc.ChargeProfile{
+			{Name: "daily", ChargeLimit: 80, DisableChargingBeforeSleep: true},
+			{Name: "travel", ChargeLimit: 100},
+		},
+	}
+	want := "* daily: limit 80%, LED off, sleep charging guard on\n  travel: limit off, LED off, sleep charging guard off\n"
+	if got := formatProfiles(resp); got != want {
+		t.Fatalf("unexpected output: got=%q want=%q", got, want)
+	}
+}
+
+func TestFormatEffectiveSettings(t *testing.T) {
+	resp := &rpc.EffectiveSettingsResponse{
+		Settings: []*rpc.EffectiveSetting{
+			{Key: "ChargeLimit", Value: "80", Source: "system"},
+			{Key: "ControlMagsafeLED", Value: "true", Source: "user"},
+		},
+	}
+	want := "ChargeLimit: 80 (system)\nControlMagsafeLED: true (user)\n"
+	if got := formatEffectiveSettings(resp); got != want {
+		t.Fatalf("unexpected output: got=%q want=%q", got, want)
+	}
+}
+
+func TestFormatStatusLimit(t *testing.T) {
+	tests := []struct {
+		name   string
+		status *rpc.StatusResponse
+		want   string
+	}{
+		{name: "regular limit", status: &rpc.StatusResponse{ChargeLimit: 80, EffectiveChargeLimit: 80}, want: "80%"},
+		{
+			name:   "adapter override",
+			status: &rpc.StatusResponse{ChargeLimit: 80, EffectiveChargeLimit: 100, MatchedAdapterKey: "desk 96W"},
+			want:   "off for desk 96W (default 80%)",
+		},
+		{
+			name:   "boost active",
+			status: &rpc.StatusResponse{ChargeLimit: 80, EffectiveChargeLimit: 80, BoostRemainingSeconds: 1501},
+			want:   "80%, boosted for 26m",
+		},
+		{
+			name:   "pinned",
+			status: &rpc.StatusResponse{ChargeLimit: 80, EffectiveChargeLimit: 80, PinnedPid: 4242},
+			want:   "80%, pinned to 100% by PID 4242",
+		},
+		{
+			name:   "ramping",
+			status: &rpc.StatusResponse{ChargeLimit: 60, EffectiveChargeLimit: 60, RampedChargeLimit: 84},
+			want:   "60%, ramping down (now 84%)",
+		},
+		{
+			name:   "enforced now",
+			status: &rpc.StatusResponse{ChargeLimit: 80, EffectiveChargeLimit: 80, LimitCurrentlyEnforced: true},
+			want:   "80%, holding charging off",
+		},
+		{
+			name: "full-by plan",
+			status: &rpc.StatusResponse{
+				ChargeLimit:          80,
+				EffectiveChargeLimit: 80,
+				FullByUnix:           time.Date(2026, 5, 4, 7, 0, 0, 0, time.Local).Unix(),
+				FullByTarget:         100,
+				FullByStartUnix:      time.Date(2026, 5, 4, 5, 50, 0, 0, time.Local).Unix(),
+			},
+			want: "80%, 100% by 07:00 (from 05:50)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatStatusLimit(tt.status); got != tt.want {
+				t.Fatalf("unexpected output: got=%q want=%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatPowerSource(t *testing.T) {
+	tests := []struct {
+		name   string
+		status *rpc.StatusResponse
+		want   string
+	}{
+		{name: "ac", status: &rpc.StatusResponse{IsConnected: true, PowerSource: rpc.PowerSource_POWER_SOURCE_AC}, want: "AC"},
+		{name: "ups on mains", status: &rpc.StatusResponse{IsConnected: true, UpsAttached: true, PowerSource: rpc.PowerSource_POWER_SOURCE_AC}, want: "external power source (UPS attached)"},
+		{name: "ups on battery", status: &rpc.StatusResponse{UpsAttached: true, PowerSource: rpc.PowerSource_POWER_SOURCE_UPS}, want: "UPS (on UPS battery)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatPowerSource(tt.status); got != tt.want {
+				t.Fatalf("unexpected output: got=%q want=%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatManagement(t *testing.T) {
+	tests := []struct {
+		name   string
+		status *rpc.StatusResponse
+		want   string
+	}{
+		{name: "managing", status: &rpc.StatusResponse{ManagementEnabled: true}, want: "on"},
+		{name: "disabled", status: &rpc.StatusResponse{}, want: "off"},
+		{name: "suspended", status: &rpc.StatusResponse{ManagementEnabled: true, SuspendRemainingSeconds: 3540}, want: "suspended for 59m"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatManagement(tt.status); got != tt.want {
+				t.Fatalf("unexpected output: got=%q want=%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatLEDMode(t *testing.T) {
+	tests := []struct {
+		mode rpc.MagsafeLedMode
+		want string
+	}{
+		{mode: rpc.MagsafeLedMode_MAGSAFE_LED_MODE_AUTO, want: "auto"},
+		{mode: rpc.MagsafeLedMode_MAGSAFE_LED_MODE_OFF, want: "off"},
+		{mode: rpc.MagsafeLedMode_MAGSAFE_LED_MODE_SYSTEM, want: "system"},
+		{mode: rpc.MagsafeLedMode_MAGSAFE_LED_MODE_UNSPECIFIED, want: "system"},
+	}
+
+	for _, tt := range tests {
+		if got := formatLEDMode(tt.mode); got != tt.want {
+			t.Fatalf("formatLEDMode(%v) = %q, want %q", tt.mode, got, tt.want)
+		}
+	}
+}
+
+func TestFormatForceDischarge(t *testing.T) {
+	tests := []struct {
+		name   string
+		status *rpc.StatusResponse
+		want   string
+	}{
+		{name: "off", status: &rpc.StatusResponse{ForceDischargeFloor: 20}, want: "off"},
+		{name: "active", status: &rpc.StatusResponse{ForceDischargeActive: true, ForceDischargeFloor: 20}, want: "on (stops at 20%)"},
+		{name: "stopped at floor", status: &rpc.StatusResponse{ForceDischargeFloor: 20, ForceDischargeFloorReached: true}, want: "off (stopped at 20% floor)"},
+		{name: "older daemon", status: &rpc.StatusResponse{ForceDischargeActive: true}, want: "on"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatForceDischarge(tt.status); got != tt.want {
+				t.Fatalf("unexpected output: got=%q want=%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTokenInterceptor(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		token string
+		want  []string
+	}{
+		{name: "token set", token: "s3cret", want: []string{"Bearer s3cret"}},
+		{name: "no token", token: "", want: nil},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			var got []string
+			invoker := func(ctx context.Context, _ string, _, _ any, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+				md, _ := metadata.FromOutgoingContext(ctx)
+				got = md.Get("authorization")
+				return nil
+			}
+			if err := tokenInterceptor(tc.token)(context.Background(), "/rpc.PowerGrid/ApplyMutation", nil, nil, nil, invoker); err != nil {
+				t.Fatalf("interceptor returned error: %v", err)
+			}
+			if len(got) != len(tc.want) || (len(got) == 1 && got[0] != tc.want[0]) {
+				t.Fatalf("unexpected authorization metadata: got=%v want=%v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFormatCommandErrorUsesReason(t *testing.T) {
+	t.Parallel()
+
+	st, err := grpcstatus.New(codes.Unavailable, "failed to set force discharge: timeout").WithDetails(&errdetails.ErrorInfo{
+		Reason: rpc.ErrorReason_ERROR_SMC_BUSY.String(),
+		Domain: "com.neutronstar.powergrid",
+	})
+	if err != nil {
+		t.Fatalf("WithDetails returned error: %v", err)
+	}
+	if got, want := formatCommandError(st.Err()), "The SMC did not accept the change. Try again."; got != want {
+		t.Fatalf("unexpected message: got=%q want=%q", got, want)
+	}
+	if got, want := formatCommandError(grpcstatus.Error(codes.Unavailable, "down")), "PowerGrid daemon is unavailable. Install the app or start the daemon first."; got != want {
+		t.Fatalf("unexpected message without reason: got=%q want=%q", got, want)
+	}
+}
+
+func TestFormatSMCKeys(t *testing.T) {
+	resp := &rpc.SMCKeysResponse{Values: []*rpc.SMCKeyValue{
+		{Key: "CHTE", DataType: "ui32", DataSize: 4, Data: []byte{0, 0, 0, 1}},
+		{Key: "AC-W", DataType: "si8 ", DataSize: 1, Data: []byte{0xfe}},
+	}}
+	want := "CHTE [ui32] 4 bytes: 00 00 00 01\nAC-W [si8] 1 bytes: fe\n"
+	if got := formatSMCKeys(resp); got != want {
+		t.Fatalf("unexpected output: got=%q want=%q", got, want)
+	}
+}
+
+func TestFormatUserSettings(t *testing.T) {
+	resp := &rpc.UserSettingsResponse{Users: []*rpc.UserSettings{
+		{Username: "alice", Uid: 501, ChargeLimit: 70, EffectiveChargeLimit: 70, MagsafeLedMode: rpc.MagsafeLedMode_MAGSAFE_LED_MODE_AUTO, DisableChargingBeforeSleep: true},
+		{Username: "bob", Uid: 502, EffectiveChargeLimit: 80, MagsafeLedMode: rpc.MagsafeLedMode_MAGSAFE_LED_MODE_SYSTEM},
+	}}
+	want := "alice (501): limit 70% (effective 70%), LED auto, stop before sleep on\n" +
+		"bob (502): limit unset (effective 80%), LED system, stop before sleep off\n"
+	if got := formatUserSettings(resp); got != want {
+		t.Fatalf("unexpected output:\ngot=%q\nwant=%q", got, want)
+	}
+	if got := formatUserSettings(&rpc.UserSettingsResponse{}); got != "No users have PowerGrid settings.\n" {
+		t.Fatalf("unexpected empty output: %q", got)
+	}
+}
+
+func TestFormatTimeEstimates(t *testing.T) {
+	t.Parallel()
+
+	resp := &rpc.TimeEstimatesResponse{
+		IokitTimeToFullMinutes:     5,
+		SmoothedTimeToLimitMinutes: 50,
+		TargetCharge:               80,
+		Confidence:                 rpc.EstimateConfidence_ESTIMATE_CONFIDENCE_HIGH,
+		SampleCount:                6,
+		SampleSpanSeconds:          600,
+	}
+	want := "IOKit: 5m to full, 0m to empty\nSmoothed: 50m to 80% (confidence high, 6 samples over 10m)\n"
+	if got := formatTimeEstimates(resp); got != want {
+		t.Fatalf("unexpected output:\ngot=%q\nwant=%q", got, want)
+	}
+	want = "IOKit: 0m to full, 0m to empty\nSmoothed: not enough charge history yet (confidence none, 0 samples over 0m)\n"
+	if got := formatTimeEstimates(&rpc.TimeEstimatesResponse{}); got != want {
+		t.Fatalf("unexpected empty output:\ngot=%q\nwant=%q", got, want)
+	}
+}
+
+func TestFormatSessionStats(t *testing.T) {
+	t.Parallel()
+
+	resp := &rpc.SessionStatsResponse{
+		Connected:          true,
+		StartedUnix:        time.Date(2026, 10, 16, 9, 0, 0, 0, time.Local).Unix(),
+		EnergyAddedWh:      13,
+		AdapterEnergyWh:    16.3,
+		AverageChargeWatts: 52,
+		PeakChargeWatts:    60,
+		Efficiency:         0.8,
+		ChargingSeconds:    900,
+	}
+	want := "Session: since 09:00 (plugged in)\nEnergy added: 13.0 Wh over 15m of charging\nCharge power: 52.0 W average, 60.0 W peak\nEfficiency: 80% of 16.3 Wh from the adapter\n"
+	if got := formatSessionStats(resp); got != want {
+		t.Fatalf("unexpected output:\ngot=%q\nwant=%q", got, want)
+	}
+	if got, want := formatSessionStats(&rpc.SessionStatsResponse{}), "No charge session yet.\n"; got != want {
+		t.Fatalf("unexpected empty output:\ngot=%q\nwant=%q", got, want)
+	}
+}
+
+func TestFormatWearTrend(t *testing.T) {
+	t.Parallel()
+
+	resp := &rpc.WearTrendResponse{
+		Samples: []*rpc.HealthSample{
+			{Unix: time.Date(2026, 1, 1, 12, 0, 0, 0, time.Local).Unix(), CycleCount: 100, HealthPercent: 98},
+			{Unix: time.Date(2026, 3, 1, 12, 0, 0, 0, time.Local).Unix(), CycleCount: 200, HealthPercent: 96},
+		},
+		RateKnown:            true,
+		LossPerHundredCycles: 2,
+	}
+	want := "Health: 96.0% at 200 cycles (2 daily samples since 2026-01-01)\nWear: 2.0% per 100 cycles\n"
+	if got := formatWearTrend(resp); got != want {
+		t.Fatalf("unexpected output:\ngot=%q\nwant=%q", got, want)
+	}
+	resp.RateKnown = false
+	want = "Health: 96.0% at 200 cycles (2 daily samples since 2026-01-01)\nWear: not enough history yet (needs 10 cycles)\n"
+	if got := formatWearTrend(resp); got != want {
+		t.Fatalf("unexpected output without a rate:\ngot=%q\nwant=%q", got, want)
+	}
+	if got, want := formatWearTrend(&rpc.WearTrendResponse{}), "No battery health history yet.\n"; got != want {
+		t.Fatalf("unexpected empty output:\ngot=%q\nwant=%q", got, want)
+	}
+}
+
+func TestFormatLogs(t *testing.T) {
+	t.Parallel()
+
+	ts := time.Date(2026, 4, 20, 10, 0, 0, 0, time.Local)
+	resp := &rpc.LogsResponse{Entries: []*rpc.LogEntry{
+		{TimestampUnixMs: ts.UnixMilli(), Level: "default", Message: "Daemon event stream started."},
+		{TimestampUnixMs: ts.Add(time.Second).UnixMilli(), Level: "error", Message: "Failed to enable charging: timeout"},
+	}}
+
+	want := "2026-04-20 10:00:00 [default] Daemon event stream started.\n" +
+		"2026-04-20 10:00:01 [error] Failed to enable charging: timeout\n"
+	if got := formatLogs(resp); got != want {
+		t.Fatalf("unexpected logs output:\ngot=%q\nwant=%q", got, want)
+	}
+}
+
+func TestFormatCharge(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		status *rpc.StatusResponse
+		want   string
+	}{
+		{name: "charging", status: &rpc.StatusResponse{CurrentCharge: 60, EffectiveChargeLimit: 80}, want: "60%"},
+		{name: "held at limit", status: &rpc.StatusResponse{CurrentCharge: 80, EffectiveChargeLimit: 80, ChargedAtLimit: true}, want: "80% (charged, limited to 80%)"},
+		{name: "full", status: &rpc.StatusResponse{CurrentCharge: 100, EffectiveChargeLimit: 100, ChargedAtLimit: true}, want: "100% (charged)"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			if got := formatCharge(tc.status); got != tc.want {
+				t.Fatalf("unexpected charge: got=%q want=%q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFormatLEDHistory(t *testing.T) {
+	t.Parallel()
+
+	if got := formatLEDHistory(&rpc.StatusResponse{}); got != "Committed: none written yet\n" {
+		t.Fatalf("unexpected empty history: %q", got)
+	}
+
+	at := time.Date(2026, 10, 16, 9, 30, 0, 0, time.Local)
+	status := &rpc.StatusResponse{
+		MagsafeLedCommittedState: "amber",
+		MagsafeLedTransitions: []*rpc.MagsafeLedTransition{
+			{State: "amber", AppliedUnix: at.Unix()},
+			{State: "green", AppliedUnix: at.Add(-time.Hour).Unix()},
+		},
+		MagsafeLedWritePending:    true,
+		MagsafeLedLastFailureUnix: at.Add(time.Minute).Unix(),
+		MagsafeLedLastError:       "operation timed out after 2s",
+	}
+	want := "Committed: amber\n" +
+		"  amber   2026-10-16 09:30:00\n" +
+		"  green   2026-10-16 08:30:00\n" +
+		"Last failure: 2026-10-16 09:31:00 (operation timed out after 2s)\n" +
+		"Rewrite pending after a failed write\n"
+	if got := formatLEDHistory(status); got != want {
+		t.Fatalf("formatLEDHistory() = %q, want %q", got, want)
+	}
+}
+
+func TestFormatCriticalWarning(t *testing.T) {
+	t.Parallel()
+
+	if got := formatCriticalWarning(&rpc.StatusResponse{CurrentCharge: 8}); got != "" {
+		t.Fatalf("expected no warning, got %q", got)
+	}
+	want := "Warning: battery critically low at 8% and not charging; connect an adapter\n"
+	if got := formatCriticalWarning(&rpc.StatusResponse{CurrentCharge: 8, CriticalCharge: true}); got != want {
+		t.Fatalf("formatCriticalWarning() = %q, want %q", got, want)
+	}
+}
+
+func TestFormatManualChargingWarning(t *testing.T) {
+	t.Parallel()
+
+	if got := formatManualChargingWarning(&rpc.StatusResponse{}); got != "" {
+		t.Fatalf("expected no warning without an override, got %q", got)
+	}
+	status := &rpc.StatusResponse{ManualCharging: rpc.ManualCharging_MANUAL_CHARGING_OFF}
+	want := "Warning: charging is manually held off; the charge limit is not enforced until released\n"
+	if got := formatManualChargingWarning(status); got != want {
+		t.Fatalf("formatManualChargingWarning() = %q, want %q", got, want)
+	}
+}
+
+func TestFormatExternalSleepNote(t *testing.T) {
+	t.Parallel()
+
+	if got := formatExternalSleepNote(&rpc.StatusResponse{SystemSleepPrevented: true}); got != "" {
+		t.Fatalf("expected no note without external holders, got %q", got)
+	}
+	status := &rpc.StatusResponse{ExternalSleepAssertions: []string{"caffeinate (system)", "IINA (display)"}}
+	want := "Note: also kept awake by caffeinate (system), IINA (display)\n"
+	if got := formatExternalSleepNote(status); got != want {
+		t.Fatalf("formatExternalSleepNote() = %q, want %q", got, want)
+	}
+}
+
+func TestFormatConflictWarning(t *testing.T) {
+	t.Parallel()
+
+	if got := formatConflictWarning(&rpc.StatusResponse{}); got != "" {
+		t.Fatalf("expected no warning, got %q", got)
+	}
+	status := &rpc.StatusResponse{ChargeManagerConflict: true, ChargeManagerConflictDetail: "running: AlDente"}
+	if got, want := formatConflictWarning(status), "Warning: another charge manager may be active (running: AlDente)\n"; got != want {
+		t.Fatalf("unexpected warning: got=%q want=%q", got, want)
+	}
+}
+
+func TestFormatStallWarning(t *testing.T) {
+	t.Parallel()
+
+	if got := formatStallWarning(&rpc.StatusResponse{}); got != "" {
+		t.Fatalf("expected no warning, got %q", got)
+	}
+	if got := formatStallWarning(&rpc.StatusResponse{ChargingStalled: true}); !strings.HasPrefix(got, "Warning: charging is enabled") {
+		t.Fatalf("unexpected warning: %q", got)
+	}
+}
+
+func TestSplitSocketFlag(t *testing.T) {
+	cases := []struct {
+		args     []string
+		socket   string
+		rest     []string
+		wantsErr bool
+	}{
+		{args: []string{"status"}, rest: []string{"status"}},
+		{args: []string{"--socket", "/tmp/pg.sock", "status"}, socket: "/tmp/pg.sock", rest: []string{"status"}},
+		{args: []string{"--socket=/tmp/pg.sock", "limit", "80"}, socket: "/tmp/pg.sock", rest: []string{"limit", "80"}},
+		{args: []string{"--socket"}, wantsErr: true},
+		{args: []string{"--socket="}, wantsErr: true},
+	}
+	for _, tc := range cases {
+		socket, rest, err := splitSocketFlag(tc.args)
+		if tc.wantsErr {
+			if err == nil {
+				t.Fatalf("splitSocketFlag(%q): expected an error", tc.args)
+			}
+			continue
+		}
+		if err != nil || socket != tc.socket || strings.Join(rest, " ") != strings.Join(tc.rest, " ") {
+			t.Fatalf("splitSocketFlag(%q) = %q, %q, %v", tc.args, socket, rest, err)
+		}
+	}
+}
+
+func TestFormatDischargeBand(t *testing.T) {
+	t.Parallel()
+
+	if got := formatDischargeBand(&rpc.StatusResponse{}); got != "off" {
+		t.Fatalf("expected off, got %q", got)
+	}
+	status := &rpc.StatusResponse{DischargeBandFloor: 60, DischargeBandCeiling: 80, DischargeBandActive: true}
+	if got, want := formatDischargeBand(status), "60%-80% (discharging)"; got != want {
+		t.Fatalf("unexpected band: got=%q want=%q", got, want)
+	}
+}
+
+func TestFormatWarmUpNote(t *testing.T) {
+	t.Parallel()
+
+	if got := formatWarmUpNote(&rpc.StatusResponse{}); got != "" {
+		t.Fatalf("expected no note, got %q", got)
+	}
+	if got := formatWarmUpNote(&rpc.StatusResponse{WarmingUp: true}); !strings.HasPrefix(got, "Note: daemon just started") {
+		t.Fatalf("unexpected note: %q", got)
+	}
+}
+
+func TestFormatSafeModeWarning(t *testing.T) {
+	t.Parallel()
+
+	if got := formatSafeModeWarning(&rpc.StatusResponse{}); got != "" {
+		t.Fatalf("expected no warning, got %q", got)
+	}
+	if got := formatSafeModeWarning(&rpc.StatusResponse{SafeMode: true}); !strings.HasPrefix(got, "Warning: read-only safe mode") {
+		t.Fatalf("unexpected warning: %q", got)
+	}
+}
+
+func TestFormatCounters(t *testing.T) {
+	t.Parallel()
+
+	got := formatCounters(&rpc.CountersResponse{Day: "2026-04-20", ChargingEnabled: 3, ChargingDisabled: 4})
+	want := "Day: 2026-04-20\nCharging enabled: 3\nCharging disabled: 4\n"
+	if got != want {
+		t.Fatalf("unexpected output: got=%q want=%q", got, want)
+	}
+}
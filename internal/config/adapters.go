@@ -0,0 +1,48 @@
+package config
+
+import "os"
+
+// ReadUserAdapterLimits returns the per-adapter charge limits keyed by
+// adapter identity (see engine.AdapterKey). Out-of-range entries are dropped.
+func ReadUserAdapterLimits(homeDir string) map[string]int {
+	out := map[string]int{}
+	if homeDir == "" {
+		return out
+	}
+	var stored map[string]int
+	found, err := readJSON(userPlistPath(homeDir), KeyAdapterLimits, &stored)
+	if err != nil || !found {
+		return out
+	}
+	for key, limit := range stored {
+		if key != "" && limit >= 60 && limit <= 100 {
+			out[key] = limit
+		}
+	}
+	return out
+}
+
+// WriteUserAdapterLimit stores limit for adapterKey; a limit of 0 removes the
+// mapping so the adapter falls back to the regular charge limit.
+func WriteUserAdapterLimit(homeDir string, uid, gid uint32, adapterKey string, limit int) error {
+	if homeDir == "" || adapterKey == "" {
+		return os.ErrInvalid
+	}
+	path := userPlistPath(homeDir)
+	stored := map[string]int{}
+	if _, err := readJSON(path, KeyAdapterLimits, &stored); err != nil {
+		return err
+	}
+	if stored == nil {
+		stored = map[string]int{}
+	}
+	if limit == 0 {
+		delete(stored, adapterKey)
+	} else {
+		stored[adapterKey] = clampLimit(limit)
+	}
+	if err := writeJSON(path, KeyAdapterLimits, stored); err != nil {
+		return err
+	}
+	return chownUserPlist(path, uid, gid)
+}
@@ -0,0 +1,142 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"sync"
+	"syscall"
+)
+
+// AdminConfigPath holds system settings provided by an administrator or a
+// configuration-management tool. Keys present there take precedence over
+// the Preferences plist, and the daemon never writes to it.
+const AdminConfigPath = "/etc/powergrid/system.json"
+
+var adminConfigPath = AdminConfigPath
+
+// adminConfigOwner is the uid the admin config must belong to, root outside
+// of tests.
+var adminConfigOwner uint32
+
+// ReportAdminConfigError is called with the reason the admin config was
+// ignored, once per distinct problem rather than on every read. The daemon
+// points it at its logger.
+var ReportAdminConfigError = func(error) {}
+
+var adminReport struct {
+	mu   sync.Mutex
+	last string
+}
+
+// adminSettings is what gets cached for the admin config, so a broken file
+// is also read at most once per cache TTL.
+type adminSettings struct {
+	values map[string]any
+	err    error
+}
+
+// readAdminSettings returns the admin-provided settings. A missing file is
+// not an error; an unreadable, malformed or insecure one is, and yields no
+// settings. The returned map is shared through the cache and must not be
+// modified.
+func readAdminSettings() (map[string]any, error) {
+	path := adminConfigPath
+	cached, _, _ := cachedRead(path, "", func() (adminSettings, bool, error) {
+		values, err := loadAdminSettings(path)
+		noteAdminConfigError(err)
+		return adminSettings{values: values, err: err}, err == nil, nil
+	})
+	return cached.values, cached.err
+}
+
+// loadAdminSettings reads and parses the admin config. Because the file can
+// redirect the event log and pin charge management, it is only trusted when
+// root owns it and nobody else can write to it, like the mutation token.
+func loadAdminSettings(path string) (map[string]any, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return map[string]any{}, nil
+		}
+		return map[string]any{}, err
+	}
+	if st, ok := fi.Sys().(*syscall.Stat_t); ok && st.Uid != adminConfigOwner {
+		return map[string]any{}, fmt.Errorf("%s must be owned by root", path)
+	}
+	if fi.Mode().Perm()&0o022 != 0 {
+		return map[string]any{}, fmt.Errorf("%s must not be writable by group or others (mode %04o)", path, fi.Mode().Perm())
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return map[string]any{}, err
+	}
+	settings := map[string]any{}
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return map[string]any{}, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return settings, nil
+}
+
+// noteAdminConfigError reports err unless it repeats the last report. A good
+// read clears the memory, so the file breaking again is reported again.
+func noteAdminConfigError(err error) {
+	adminReport.mu.Lock()
+	defer adminReport.mu.Unlock()
+	if err == nil {
+		adminReport.last = ""
+		return
+	}
+	if msg := err.Error(); msg != adminReport.last {
+		adminReport.last = msg
+		ReportAdminConfigError(err)
+	}
+}
+
+func adminValue(key string) (any, bool) {
+	settings, err := readAdminSettings()
+	if err != nil {
+		return nil, false
+	}
+	val, ok := settings[key]
+	return val, ok
+}
+
+func adminInt(key string) (int, bool) {
+	val, ok := adminValue(key)
+	if !ok {
+		return 0, false
+	}
+	f, ok := val.(float64)
+	if !ok || f != math.Trunc(f) {
+		return 0, false
+	}
+	return int(f), true
+}
+
+func adminBool(key string) (bool, bool) {
+	val, ok := adminValue(key)
+	if !ok {
+		return false, false
+	}
+	b, ok := val.(bool)
+	return b, ok
+}
+
+func adminString(key string) (string, bool) {
+	val, ok := adminValue(key)
+	if !ok {
+		return "", false
+	}
+	s, ok := val.(string)
+	return s, ok
+}
+
+// AdminManaged reports whether key is pinned by the admin config, in which
+// case changing it through the daemon would have no effect.
+func AdminManaged(key string) bool {
+	_, ok := adminValue(key)
+	return ok
+}
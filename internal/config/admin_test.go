@@ -0,0 +1,121 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func useAdminConfig(t *testing.T, contents string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "system.json")
+	if contents != "" {
+		if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+			t.Fatalf("failed to write admin config: %v", err)
+		}
+	}
+	old, oldOwner := adminConfigPath, adminConfigOwner
+	adminConfigPath, adminConfigOwner = path, uint32(os.Getuid())
+	t.Cleanup(func() { adminConfigPath, adminConfigOwner = old, oldOwner })
+}
+
+func TestAdminSettingsTyped(t *testing.T) {
+	useAdminConfig(t, `{"ChargeLimit": 70, "ManagementEnabled": false, "EventLogPath": "/tmp/e.log", "ConnectGraceSeconds": 1.5}`)
+
+	if n, ok := adminInt(KeyChargeLimit); !ok || n != 70 {
+		t.Fatalf("unexpected ChargeLimit: got=(%d,%v) want=(%d,%v)", n, ok, 70, true)
+	}
+	if b, ok := adminBool(KeyManagement); !ok || b {
+		t.Fatalf("unexpected ManagementEnabled: got=(%v,%v) want=(%v,%v)", b, ok, false, true)
+	}
+	if s, ok := adminString(KeyEventLogPath); !ok || s != "/tmp/e.log" {
+		t.Fatalf("unexpected EventLogPath: got=(%q,%v)", s, ok)
+	}
+	if _, ok := adminInt(KeyConnectGrace); ok {
+		t.Fatal("expected fractional number to be ignored for an int key")
+	}
+	if _, ok := adminBool(KeyChargeLimit); ok {
+		t.Fatal("expected mistyped value to be ignored")
+	}
+	if !AdminManaged(KeyManagement) || AdminManaged(KeyRestoreOnExit) {
+		t.Fatal("unexpected AdminManaged result")
+	}
+}
+
+func TestAdminSettingsMissingOrInvalid(t *testing.T) {
+	useAdminConfig(t, "")
+	if settings, err := readAdminSettings(); err != nil || len(settings) != 0 {
+		t.Fatalf("expected no settings for missing file, got %v (err=%v)", settings, err)
+	}
+
+	useAdminConfig(t, "{not json")
+	if _, err := readAdminSettings(); err == nil {
+		t.Fatal("expected error for malformed file")
+	}
+	if AdminManaged(KeyChargeLimit) {
+		t.Fatal("malformed file must not manage any key")
+	}
+}
+
+func TestAdminSettingsRejectsInsecureFile(t *testing.T) {
+	usePrefCache(t)
+	useAdminConfig(t, `{"EventLogPath": "/tmp/e.log"}`)
+
+	if err := os.Chmod(adminConfigPath, 0o666); err != nil {
+		t.Fatalf("chmod failed: %v", err)
+	}
+	if _, err := readAdminSettings(); err == nil {
+		t.Fatal("expected error for a world-writable file")
+	}
+
+	usePrefCache(t)
+	if err := os.Chmod(adminConfigPath, 0o644); err != nil {
+		t.Fatalf("chmod failed: %v", err)
+	}
+	adminConfigOwner = uint32(os.Getuid()) + 1
+	if _, err := readAdminSettings(); err == nil {
+		t.Fatal("expected error for a file owned by another user")
+	}
+	if AdminManaged(KeyEventLogPath) {
+		t.Fatal("an insecure file must not manage any key")
+	}
+}
+
+func TestAdminSettingsCachedAndReportedOnce(t *testing.T) {
+	usePrefCache(t)
+	useAdminConfig(t, "{not json")
+	var reports []error
+	old := ReportAdminConfigError
+	ReportAdminConfigError = func(err error) { reports = append(reports, err) }
+	t.Cleanup(func() { ReportAdminConfigError = old })
+
+	for range 3 {
+		if _, ok := adminInt(KeyChargeLimit); ok {
+			t.Fatal("malformed file must not manage any key")
+		}
+	}
+	if len(reports) != 1 {
+		t.Fatalf("expected one report, got %v", reports)
+	}
+
+	// Within the TTL the cached parse answers, even for a fixed file.
+	if err := os.WriteFile(adminConfigPath, []byte(`{"ChargeLimit": 70}`), 0o644); err != nil {
+		t.Fatalf("failed to rewrite admin config: %v", err)
+	}
+	if _, ok := adminInt(KeyChargeLimit); ok {
+		t.Fatal("expected the cached result within the TTL")
+	}
+	prefs.invalidate(adminConfigPath)
+	if n, ok := adminInt(KeyChargeLimit); !ok || n != 70 {
+		t.Fatalf("unexpected ChargeLimit after reload: got=(%d,%v)", n, ok)
+	}
+
+	if err := os.WriteFile(adminConfigPath, []byte("{"), 0o644); err != nil {
+		t.Fatalf("failed to rewrite admin config: %v", err)
+	}
+	prefs.invalidate(adminConfigPath)
+	adminInt(KeyChargeLimit)
+	if len(reports) != 2 {
+		t.Fatalf("expected the file breaking again to be reported, got %v", reports)
+	}
+}
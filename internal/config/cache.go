@@ -0,0 +1,84 @@
+package config
+
+import (
+	"sync"
+	"time"
+)
+
+// prefCacheTTL bounds how long a cached preference can lag an edit made by
+// another process, such as defaults write on the system plist. Writes made
+// through this package update the cache directly.
+const prefCacheTTL = 2 * time.Second
+
+type prefKey struct {
+	path string
+	key  string
+}
+
+type prefEntry struct {
+	value any
+	found bool
+	at    time.Time
+}
+
+// prefCache keeps recent plist reads so the bursts of lookups on console
+// user changes and logic runs parse each file once.
+type prefCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	now     func() time.Time
+	entries map[prefKey]prefEntry
+}
+
+var prefs = newPrefCache(prefCacheTTL)
+
+func newPrefCache(ttl time.Duration) *prefCache {
+	return &prefCache{ttl: ttl, now: time.Now, entries: map[prefKey]prefEntry{}}
+}
+
+func (c *prefCache) get(path, key string) (prefEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	k := prefKey{path, key}
+	e, ok := c.entries[k]
+	if !ok {
+		return prefEntry{}, false
+	}
+	if c.now().Sub(e.at) >= c.ttl {
+		delete(c.entries, k)
+		return prefEntry{}, false
+	}
+	return e, true
+}
+
+func (c *prefCache) put(path, key string, value any, found bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[prefKey{path, key}] = prefEntry{value: value, found: found, at: c.now()}
+}
+
+// invalidate drops every cached key of the plist at path.
+func (c *prefCache) invalidate(path string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for k := range c.entries {
+		if k.path == path {
+			delete(c.entries, k)
+		}
+	}
+}
+
+// cachedRead returns the cached value for path and key, or calls read and
+// caches a successful result. An entry of another type counts as a miss.
+func cachedRead[T any](path, key string, read func() (T, bool, error)) (T, bool, error) {
+	if e, ok := prefs.get(path, key); ok {
+		if v, typed := e.value.(T); typed {
+			return v, e.found, nil
+		}
+	}
+	v, found, err := read()
+	if err == nil {
+		prefs.put(path, key, v, found)
+	}
+	return v, found, err
+}
@@ -0,0 +1,59 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func usePrefCache(t *testing.T) *prefCache {
+	t.Helper()
+	old := prefs
+	prefs = newPrefCache(prefCacheTTL)
+	t.Cleanup(func() { prefs = old })
+	return prefs
+}
+
+func TestPrefCacheExpiresAndInvalidates(t *testing.T) {
+	c := newPrefCache(time.Second)
+	now := time.Date(2026, 4, 20, 10, 0, 0, 0, time.UTC)
+	c.now = func() time.Time { return now }
+
+	c.put("a.plist", "ChargeLimit", 70, true)
+	c.put("b.plist", "ChargeLimit", 90, true)
+	if e, ok := c.get("a.plist", "ChargeLimit"); !ok || e.value != 70 {
+		t.Fatalf("expected cached value, got %v ok=%t", e.value, ok)
+	}
+
+	c.invalidate("a.plist")
+	if _, ok := c.get("a.plist", "ChargeLimit"); ok {
+		t.Fatal("expected invalidate to drop the entry")
+	}
+	now = now.Add(time.Second)
+	if _, ok := c.get("b.plist", "ChargeLimit"); ok {
+		t.Fatal("expected the entry to expire after the TTL")
+	}
+}
+
+func TestWriteThenReadUsesCache(t *testing.T) {
+	c := usePrefCache(t)
+	path := filepath.Join(t.TempDir(), UserDomain+".plist")
+
+	if err := writeInt(path, KeyChargeLimit, 70); err != nil {
+		t.Fatalf("writeInt returned error: %v", err)
+	}
+	// With the file gone, only the cache can answer.
+	if err := os.Remove(path); err != nil {
+		t.Fatalf("remove failed: %v", err)
+	}
+	n, found, err := readInt(path, KeyChargeLimit)
+	if err != nil || !found || n != 70 {
+		t.Fatalf("unexpected cached read: n=%d found=%t err=%v", n, found, err)
+	}
+
+	c.now = func() time.Time { return time.Now().Add(prefCacheTTL) }
+	if _, found, _ := readInt(path, KeyChargeLimit); found {
+		t.Fatal("expected an expired entry to be read from disk")
+	}
+}
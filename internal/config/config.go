@@ -61,6 +61,127 @@ static int pg_read_bool(const char *plistPath, const char *key, int *outValue, i
     }
 }
 
+static int pg_read_string(const char *plistPath, const char *key, char **outValue, int *found) {
+    @autoreleasepool {
+        NSString *path = [NSString stringWithUTF8String:plistPath];
+        NSString *k = [NSString stringWithUTF8String:key];
+        NSDictionary *dict = [NSDictionary dictionaryWithContentsOfFile:path];
+        if (dict == nil) {
+            *found = 0;
+            return 0;
+        }
+
+        id value = [dict objectForKey:k];
+        if (value == nil || ![value isKindOfClass:[NSString class]]) {
+            *found = 0;
+            return 0;
+        }
+
+        *outValue = strdup([(NSString *)value UTF8String]);
+        if (*outValue == NULL) {
+            return -1;
+        }
+        *found = 1;
+        return 0;
+    }
+}
+
+static int pg_read_json(const char *plistPath, const char *key, char **outJSON, int *found) {
+    @autoreleasepool {
+        NSString *path = [NSString stringWithUTF8String:plistPath];
+        NSString *k = [NSString stringWithUTF8String:key];
+        NSDictionary *dict = [NSDictionary dictionaryWithContentsOfFile:path];
+        if (dict == nil) {
+            *found = 0;
+            return 0;
+        }
+
+        id value = [dict objectForKey:k];
+        if (value == nil || ![NSJSONSerialization isValidJSONObject:value]) {
+            *found = 0;
+            return 0;
+        }
+
+        NSData *data = [NSJSONSerialization dataWithJSONObject:value options:0 error:nil];
+        if (data == nil) {
+            return -1;
+        }
+        NSString *json = [[NSString alloc] initWithData:data encoding:NSUTF8StringEncoding];
+        *outJSON = strdup([json UTF8String]);
+        if (*outJSON == NULL) {
+            return -1;
+        }
+        *found = 1;
+        return 0;
+    }
+}
+
+static int pg_write_json(const char *plistPath, const char *key, const char *json) {
+    @autoreleasepool {
+        NSString *path = [NSString stringWithUTF8String:plistPath];
+        NSString *k = [NSString stringWithUTF8String:key];
+        NSData *data = [[NSString stringWithUTF8String:json] dataUsingEncoding:NSUTF8StringEncoding];
+        id value = [NSJSONSerialization JSONObjectWithData:data options:0 error:nil];
+        if (value == nil) {
+            return -1;
+        }
+
+        NSMutableDictionary *dict = [NSMutableDictionary dictionaryWithContentsOfFile:path];
+        if (dict == nil) {
+            dict = [NSMutableDictionary dictionary];
+        }
+
+        [dict setObject:value forKey:k];
+        BOOL ok = [dict writeToFile:path atomically:YES];
+        return ok ? 0 : -1;
+    }
+}
+
+static int pg_read_plist_json(const char *plistPath, char **outJSON, int *found) {
+    @autoreleasepool {
+        NSString *path = [NSString stringWithUTF8String:plistPath];
+        NSDictionary *dict = [NSDictionary dictionaryWithContentsOfFile:path];
+        if (dict == nil) {
+            if ([[NSFileManager defaultManager] fileExistsAtPath:path]) {
+                return -1;
+            }
+            *found = 0;
+            return 0;
+        }
+        if (![NSJSONSerialization isValidJSONObject:dict]) {
+            return -1;
+        }
+
+        NSData *data = [NSJSONSerialization dataWithJSONObject:dict options:0 error:nil];
+        if (data == nil) {
+            return -1;
+        }
+        NSString *json = [[NSString alloc] initWithData:data encoding:NSUTF8StringEncoding];
+        *outJSON = strdup([json UTF8String]);
+        if (*outJSON == NULL) {
+            return -1;
+        }
+        *found = 1;
+        return 0;
+    }
+}
+
+static int pg_write_string(const char *plistPath, const char *key, const char *value) {
+    @autoreleasepool {
+        NSString *path = [NSString stringWithUTF8String:plistPath];
+        NSString *k = [NSString stringWithUTF8String:key];
+
+        NSMutableDictionary *dict = [NSMutableDictionary dictionaryWithContentsOfFile:path];
+        if (dict == nil) {
+            dict = [NSMutableDictionary dictionary];
+        }
+
+        [dict setObject:[NSString stringWithUTF8String:value] forKey:k];
+        BOOL ok = [dict writeToFile:path atomically:YES];
+        return ok ? 0 : -1;
+    }
+}
+
 static int pg_write_int(const char *plistPath, const char *key, int value) {
     @autoreleasepool {
         NSString *path = [NSString stringWithUTF8String:plistPath];
@@ -96,23 +217,116 @@ static int pg_write_bool(const char *plistPath, const char *key, int value) {
 import "C"
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"unsafe"
 )
 
 const (
-	SystemPlistPath = "/Library/Preferences/com.neutronstar.powergrid.daemon.plist"
-	UserDomain      = "com.neutronstar.powergrid"
-	KeyChargeLimit  = "ChargeLimit"
-	KeyMagsafeLED   = "ControlMagsafeLED"
-	KeyDisableCBS   = "DisableChargingBeforeSleep"
+	SystemPlistPath  = "/Library/Preferences/com.neutronstar.powergrid.daemon.plist"
+	UserDomain       = "com.neutronstar.powergrid"
+	KeyChargeLimit   = "ChargeLimit"
+	KeyMagsafeLED    = "ControlMagsafeLED"
+	KeyDisableCBS    = "DisableChargingBeforeSleep"
+	KeyManagement    = "ManagementEnabled"
+	KeyRestoreOnExit = "RestoreChargingOnShutdown"
+	KeyAdapterFloor  = "AdapterUnderperformPercent"
+	KeyEventLog      = "EventLogEnabled"
+	KeyEventLogPath  = "EventLogPath"
+	KeyEventLogSize  = "EventLogMaxBytes"
+	KeyProfiles      = "Profiles"
+	KeyActiveProfile = "ActiveProfile"
+	KeyConnectGrace  = "ConnectGraceSeconds"
+	KeyConfigVersion = "ConfigVersion"
+	KeyAdapterLimits = "AdapterChargeLimits"
+	KeyReflection    = "GRPCReflectionEnabled"
+	KeyDischargeStop = "ForceDischargeFloorPercent"
+	KeyMinAdapterW   = "MinChargingAdapterWatts"
+	KeySleepFloor    = "MinChargeBeforeSleepPercent"
+	KeySleepDelay    = "PreSleepDisableDelaySeconds"
+	KeyChargedAtLim  = "ReportChargedAtLimit"
+	KeyTextControl   = "TextControlEnabled"
+	KeyDeferOnLoad   = "DeferChargingUnderLoad"
+	KeyLEDRetries    = "MagsafeLEDRetries"
+	KeyCounters      = "ChargingCounters"
+	KeyPersistSleep  = "PersistSleepPreventionAcrossSleep"
+	KeyExtDisplay    = "PreventDisplaySleepWithExternalDisplay"
+	KeyLimitRamp     = "ChargeLimitRampMinutes"
+	KeyRememberLim   = "RememberLastUserChargeLimit"
+	KeyLastUserLim   = "LastUserChargeLimit"
+	KeyMinLimit      = "MinChargeLimit"
+	KeyLEDMode       = "MagsafeLEDMode"
+	KeyPollingOnly   = "PollingOnlyMode"
+	KeyPollInterval  = "PollIntervalSeconds"
+	KeySMCReads      = "SMCKeyReadsEnabled"
+	KeyPersistEff    = "PersistEffectiveChargeLimit"
+	KeyEffectiveLim  = "EffectiveChargeLimit"
+	KeyLEDGreenFull  = "MagsafeLEDGreenOnlyWhenFull"
+	KeyStartupGrace  = "StartupGraceSeconds"
+	KeyLogDecisions  = "LogChargingDecisions"
+	KeyLoginLimit    = "LoginWindowChargeLimit"
+	KeyBandFloor     = "DischargeBandFloor"
+	KeyBandCeiling   = "DischargeBandCeiling"
+	KeyCriticalLevel = "CriticalChargePercent"
+	KeyCriticalActs  = "CriticalChargeActions"
+	KeyWatchPrefs    = "WatchPreferenceChanges"
+	KeyChargeInDisch = "EnableChargingDuringDischarge"
+	KeyRestoreOvr    = "RestoreOverridesAcrossRestart"
+	KeyOverrides     = "SavedOverrides"
+	KeyHealthHist    = "HealthHistory"
+
+	defaultAdapterUnderperformPercent = 50
+	maxConnectGraceSeconds            = 600
+	defaultForceDischargeFloorPercent = 20
+	maxMinChargingAdapterWatts        = 240
+	defaultMinChargeBeforeSleep       = 30
+	maxPreSleepDisableDelaySeconds    = 60
+	defaultMagsafeLEDRetries          = 3
+	maxChargeLimitRampMinutes         = 240
+	defaultPollIntervalSeconds        = 10
+	minPollIntervalSeconds            = 2
+	maxPollIntervalSeconds            = 60
+	defaultStartupGraceSeconds        = 10
+	maxStartupGraceSeconds            = 120
+	defaultCriticalChargePercent      = 10
+	maxCriticalChargePercent          = 50
+	defaultCriticalChargeActions      = "notify,charge"
+)
+
+// DefaultChargeLimitEnv replaces the built-in default charge limit, for test
+// harnesses on machines without a normal config. Configured limits still win.
+const DefaultChargeLimitEnv = "POWERGRID_DEFAULT_LIMIT"
+
+// DefaultMinChargeLimit is the lowest charge limit allowed when
+// MinChargeLimit is unset; HardMinChargeLimit bounds how far an admin may
+// lower it.
+const (
+	DefaultMinChargeLimit = 60
+	HardMinChargeLimit    = 40
 )
 
+// DefaultChargeLimitFromEnv returns DefaultChargeLimitEnv clamped between
+// the MinChargeLimit floor and 100, or builtin when the variable is unset or
+// not a number.
+func DefaultChargeLimitFromEnv(builtin int) int {
+	raw, ok := os.LookupEnv(DefaultChargeLimitEnv)
+	if !ok {
+		return builtin
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil {
+		return builtin
+	}
+	return clampLimit(n)
+}
+
 func clampLimit(v int) int {
-	if v < 60 {
-		return 60
+	if floor := ReadSystemMinChargeLimit(); v < floor {
+		return floor
 	}
 	if v > 100 {
 		return 100
@@ -120,36 +334,185 @@ func clampLimit(v int) int {
 	return v
 }
 
+// userPlistPath names a user's preferences file. Per-user settings are read
+// and written by parsing this file directly, never through defaults or
+// cfprefsd, so lookups need no HOME or USER and reads for different users
+// cannot pick up each other's domain cache.
 func userPlistPath(homeDir string) string {
 	return filepath.Join(homeDir, "Library", "Preferences", UserDomain+".plist")
 }
 
+// UserPlistPath returns the per-user preferences file under homeDir.
+func UserPlistPath(homeDir string) string {
+	return userPlistPath(homeDir)
+}
+
 func readInt(path, key string) (int, bool, error) {
+	return cachedRead(path, key, func() (int, bool, error) {
+		cPath := C.CString(path)
+		cKey := C.CString(key)
+		defer C.free(unsafe.Pointer(cPath))
+		defer C.free(unsafe.Pointer(cKey))
+
+		var out C.int
+		var found C.int
+		if rc := C.pg_read_int(cPath, cKey, &out, &found); rc != 0 {
+			return 0, false, fmt.Errorf("failed to read int key %q from %q", key, path)
+		}
+		return int(out), found == 1, nil
+	})
+}
+
+func readBool(path, key string) (bool, bool, error) {
+	return cachedRead(path, key, func() (bool, bool, error) {
+		cPath := C.CString(path)
+		cKey := C.CString(key)
+		defer C.free(unsafe.Pointer(cPath))
+		defer C.free(unsafe.Pointer(cKey))
+
+		var out C.int
+		var found C.int
+		if rc := C.pg_read_bool(cPath, cKey, &out, &found); rc != 0 {
+			return false, false, fmt.Errorf("failed to read bool key %q from %q", key, path)
+		}
+		return out == 1, found == 1, nil
+	})
+}
+
+// readSystemInt, readSystemBool and readSystemString read a system setting,
+// preferring a correctly typed value from AdminConfigPath over the plist.
+func readSystemInt(key string) (int, bool, error) {
+	if n, ok := adminInt(key); ok {
+		return n, true, nil
+	}
+	return readInt(SystemPlistPath, key)
+}
+
+func readSystemBool(key string) (bool, bool, error) {
+	if b, ok := adminBool(key); ok {
+		return b, true, nil
+	}
+	return readBool(SystemPlistPath, key)
+}
+
+func readSystemString(key string) (string, bool, error) {
+	if v, ok := adminString(key); ok {
+		return v, true, nil
+	}
+	return readString(SystemPlistPath, key)
+}
+
+func readString(path, key string) (string, bool, error) {
+	return cachedRead(path, key, func() (string, bool, error) {
+		cPath := C.CString(path)
+		cKey := C.CString(key)
+		defer C.free(unsafe.Pointer(cPath))
+		defer C.free(unsafe.Pointer(cKey))
+
+		var out *C.char
+		var found C.int
+		if rc := C.pg_read_string(cPath, cKey, &out, &found); rc != 0 {
+			return "", false, fmt.Errorf("failed to read string key %q from %q", key, path)
+		}
+		if found != 1 {
+			return "", false, nil
+		}
+		defer C.free(unsafe.Pointer(out))
+		return C.GoString(out), true, nil
+	})
+}
+
+// readJSON decodes a plist value (dictionary, array, or scalar) into out by
+// round-tripping it through JSON.
+func readJSON(path, key string, out any) (bool, error) {
+	raw, found, err := readRawJSON(path, key)
+	if err != nil || !found {
+		return false, err
+	}
+	if err := json.Unmarshal([]byte(raw), out); err != nil {
+		return false, fmt.Errorf("failed to decode key %q from %q: %w", key, path, err)
+	}
+	return true, nil
+}
+
+// readRawJSON returns a plist value encoded as JSON. The encoded form is
+// what gets cached, so callers always decode into a fresh value.
+func readRawJSON(path, key string) (string, bool, error) {
+	return cachedRead(path, key, func() (string, bool, error) {
+		cPath := C.CString(path)
+		cKey := C.CString(key)
+		defer C.free(unsafe.Pointer(cPath))
+		defer C.free(unsafe.Pointer(cKey))
+
+		var raw *C.char
+		var found C.int
+		if rc := C.pg_read_json(cPath, cKey, &raw, &found); rc != 0 {
+			return "", false, fmt.Errorf("failed to read key %q from %q", key, path)
+		}
+		if found != 1 {
+			return "", false, nil
+		}
+		defer C.free(unsafe.Pointer(raw))
+		return C.GoString(raw), true, nil
+	})
+}
+
+func writeJSON(path, key string, value any) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to encode key %q: %w", key, err)
+	}
 	cPath := C.CString(path)
 	cKey := C.CString(key)
+	cJSON := C.CString(string(data))
 	defer C.free(unsafe.Pointer(cPath))
 	defer C.free(unsafe.Pointer(cKey))
+	defer C.free(unsafe.Pointer(cJSON))
+
+	if rc := C.pg_write_json(cPath, cKey, cJSON); rc != 0 {
+		prefs.invalidate(path)
+		return fmt.Errorf("failed to write key %q to %q", key, path)
+	}
+	prefs.put(path, key, string(data), true)
+	return nil
+}
+
+// readPlistJSON decodes the whole plist dictionary at path into out. A
+// missing file is not found; a file that exists but cannot be read or holds
+// values JSON cannot represent, such as dates or data, is an error.
+func readPlistJSON(path string, out any) (bool, error) {
+	cPath := C.CString(path)
+	defer C.free(unsafe.Pointer(cPath))
 
-	var out C.int
+	var raw *C.char
 	var found C.int
-	if rc := C.pg_read_int(cPath, cKey, &out, &found); rc != 0 {
-		return 0, false, fmt.Errorf("failed to read int key %q from %q", key, path)
+	if rc := C.pg_read_plist_json(cPath, &raw, &found); rc != 0 {
+		return false, fmt.Errorf("failed to read %q", path)
 	}
-	return int(out), found == 1, nil
+	if found != 1 {
+		return false, nil
+	}
+	defer C.free(unsafe.Pointer(raw))
+	if err := json.Unmarshal([]byte(C.GoString(raw)), out); err != nil {
+		return false, fmt.Errorf("failed to decode %q: %w", path, err)
+	}
+	return true, nil
 }
 
-func readBool(path, key string) (bool, bool, error) {
+func writeString(path, key, value string) error {
 	cPath := C.CString(path)
 	cKey := C.CString(key)
+	cValue := C.CString(value)
 	defer C.free(unsafe.Pointer(cPath))
 	defer C.free(unsafe.Pointer(cKey))
+	defer C.free(unsafe.Pointer(cValue))
 
-	var out C.int
-	var found C.int
-	if rc := C.pg_read_bool(cPath, cKey, &out, &found); rc != 0 {
-		return false, false, fmt.Errorf("failed to read bool key %q from %q", key, path)
+	if rc := C.pg_write_string(cPath, cKey, cValue); rc != 0 {
+		prefs.invalidate(path)
+		return fmt.Errorf("failed to write string key %q to %q", key, path)
 	}
-	return out == 1, found == 1, nil
+	prefs.put(path, key, value, true)
+	return nil
 }
 
 func writeInt(path, key string, value int) error {
@@ -159,8 +522,10 @@ func writeInt(path, key string, value int) error {
 	defer C.free(unsafe.Pointer(cKey))
 
 	if rc := C.pg_write_int(cPath, cKey, C.int(value)); rc != 0 {
+		prefs.invalidate(path)
 		return fmt.Errorf("failed to write int key %q to %q", key, path)
 	}
+	prefs.put(path, key, value, true)
 	return nil
 }
 
@@ -175,8 +540,10 @@ func writeBool(path, key string, value bool) error {
 		intVal = 1
 	}
 	if rc := C.pg_write_bool(cPath, cKey, C.int(intVal)); rc != 0 {
+		prefs.invalidate(path)
 		return fmt.Errorf("failed to write bool key %q to %q", key, path)
 	}
+	prefs.put(path, key, value, true)
 	return nil
 }
 
@@ -194,14 +561,32 @@ func chownUserPlist(path string, uid, gid uint32) error {
 	return nil
 }
 
+// ReadSystemChargeLimit returns the admin's system-wide ChargeLimit, or 0
+// when it is unset or unreadable. 0 always means "unset" here, never a
+// limit; set values are clamped to MinChargeLimit-100.
 func ReadSystemChargeLimit() int {
-	n, found, err := readInt(SystemPlistPath, KeyChargeLimit)
+	n, found, err := readSystemInt(KeyChargeLimit)
 	if err != nil || !found {
 		return 0
 	}
 	return clampLimit(n)
 }
 
+// ReadSystemLoginWindowChargeLimit returns the limit enforced while nobody is
+// logged in, or 0 when unset. Unlike ChargeLimit it is not a fallback for
+// users without a preference; it only applies at the login window.
+func ReadSystemLoginWindowChargeLimit() int {
+	n, found, err := readSystemInt(KeyLoginLimit)
+	if err != nil || !found || n <= 0 {
+		return 0
+	}
+	return clampLimit(n)
+}
+
+// ReadUserChargeLimit returns the user's ChargeLimit with the same 0-means-
+// unset contract as ReadSystemChargeLimit. User settings are keyed by home
+// directory because that is where the plist lives; the uid and gid the
+// writers take only set the file's ownership.
 func ReadUserChargeLimit(homeDir string) int {
 	if homeDir == "" {
 		return 0
@@ -214,13 +599,8 @@ func ReadUserChargeLimit(homeDir string) int {
 }
 
 func EffectiveChargeLimit(userLimit, systemLimit, defaultLimit int) int {
-	if userLimit > 0 {
-		return clampLimit(userLimit)
-	}
-	if systemLimit > 0 {
-		return clampLimit(systemLimit)
-	}
-	return clampLimit(defaultLimit)
+	limit, _ := ResolveChargeLimit(userLimit, systemLimit, defaultLimit)
+	return limit
 }
 
 func EnsureUserConfigOwnership(homeDir string, uid, gid uint32) error {
@@ -248,23 +628,100 @@ func WriteUserChargeLimit(homeDir string, uid, gid uint32, limit int) error {
 	return chownUserPlist(path, uid, gid)
 }
 
-func ReadUserMagsafeLED(homeDir string) bool {
+// ReadUserDischargeBand returns the user's discharge band, or 0, 0 when it
+// is unset or its bounds do not describe a band.
+func ReadUserDischargeBand(homeDir string) (floor, ceiling int) {
 	if homeDir == "" {
-		return false
+		return 0, 0
+	}
+	path := userPlistPath(homeDir)
+	floor, foundFloor, err := readInt(path, KeyBandFloor)
+	if err != nil || !foundFloor {
+		return 0, 0
+	}
+	ceiling, foundCeiling, err := readInt(path, KeyBandCeiling)
+	if err != nil || !foundCeiling || floor <= 0 || ceiling <= floor || ceiling > 100 {
+		return 0, 0
+	}
+	return floor, ceiling
+}
+
+// WriteUserDischargeBand stores the discharge band; 0, 0 clears it.
+func WriteUserDischargeBand(homeDir string, uid, gid uint32, floor, ceiling int) error {
+	if homeDir == "" {
+		return os.ErrInvalid
+	}
+	path := userPlistPath(homeDir)
+	if err := writeInt(path, KeyBandFloor, floor); err != nil {
+		return err
+	}
+	if err := writeInt(path, KeyBandCeiling, ceiling); err != nil {
+		return err
+	}
+	return chownUserPlist(path, uid, gid)
+}
+
+// LEDMode selects how the daemon drives the MagSafe LED.
+type LEDMode string
+
+const (
+	// LEDModeSystem leaves the LED to macOS.
+	LEDModeSystem LEDMode = "system"
+	// LEDModeAuto shows the charging state relative to the limit.
+	LEDModeAuto LEDMode = "auto"
+	// LEDModeOff keeps the LED dark whatever the charging state.
+	LEDModeOff LEDMode = "off"
+)
+
+// ParseLEDMode accepts the stored form of an LEDMode.
+func ParseLEDMode(value string) (LEDMode, bool) {
+	switch mode := LEDMode(value); mode {
+	case LEDModeSystem, LEDModeAuto, LEDModeOff:
+		return mode, true
+	default:
+		return "", false
+	}
+}
+
+// LEDModeFromControl maps the legacy ControlMagsafeLED flag to a mode.
+func LEDModeFromControl(control bool) LEDMode {
+	if control {
+		return LEDModeAuto
 	}
-	val, found, err := readBool(userPlistPath(homeDir), KeyMagsafeLED)
+	return LEDModeSystem
+}
+
+// ReadUserLEDMode returns the user's MagsafeLEDMode. Preferences written
+// before the mode existed only carry ControlMagsafeLED, which maps to auto
+// or system.
+func ReadUserLEDMode(homeDir string) LEDMode {
+	if homeDir == "" {
+		return LEDModeSystem
+	}
+	path := userPlistPath(homeDir)
+	if val, found, err := readString(path, KeyLEDMode); err == nil && found {
+		if mode, ok := ParseLEDMode(val); ok {
+			return mode
+		}
+	}
+	control, found, err := readBool(path, KeyMagsafeLED)
 	if err != nil || !found {
-		return false
+		return LEDModeSystem
 	}
-	return val
+	return LEDModeFromControl(control)
 }
 
-func WriteUserMagsafeLED(homeDir string, uid, gid uint32, enabled bool) error {
+// WriteUserLEDMode stores mode and keeps ControlMagsafeLED in step so an
+// older daemon reading the same file still sees whether the LED is managed.
+func WriteUserLEDMode(homeDir string, uid, gid uint32, mode LEDMode) error {
 	if homeDir == "" {
 		return os.ErrInvalid
 	}
 	path := userPlistPath(homeDir)
-	if err := writeBool(path, KeyMagsafeLED, enabled); err != nil {
+	if err := writeString(path, KeyLEDMode, string(mode)); err != nil {
+		return err
+	}
+	if err := writeBool(path, KeyMagsafeLED, mode != LEDModeSystem); err != nil {
 		return err
 	}
 	return chownUserPlist(path, uid, gid)
@@ -292,9 +749,542 @@ func WriteUserDisableChargingBeforeSleep(homeDir string, uid, gid uint32, enable
 	return chownUserPlist(path, uid, gid)
 }
 
+// ReadUserPersistSleepPrevention reports whether Prevent Display Sleep and
+// Prevent System Sleep are re-applied after the Mac wakes. Defaults to true.
+func ReadUserPersistSleepPrevention(homeDir string) bool {
+	if homeDir == "" {
+		return true
+	}
+	val, found, err := readBool(userPlistPath(homeDir), KeyPersistSleep)
+	if err != nil || !found {
+		return true
+	}
+	return val
+}
+
+func WriteUserPersistSleepPrevention(homeDir string, uid, gid uint32, enabled bool) error {
+	if homeDir == "" {
+		return os.ErrInvalid
+	}
+	path := userPlistPath(homeDir)
+	if err := writeBool(path, KeyPersistSleep, enabled); err != nil {
+		return err
+	}
+	return chownUserPlist(path, uid, gid)
+}
+
+// ReadUserLEDGreenOnlyWhenFull reports whether the MagSafe LED stays amber
+// below 100% even when charging is held at a lower limit. Defaults to false.
+func ReadUserLEDGreenOnlyWhenFull(homeDir string) bool {
+	if homeDir == "" {
+		return false
+	}
+	val, found, err := readBool(userPlistPath(homeDir), KeyLEDGreenFull)
+	if err != nil || !found {
+		return false
+	}
+	return val
+}
+
+func WriteUserLEDGreenOnlyWhenFull(homeDir string, uid, gid uint32, enabled bool) error {
+	if homeDir == "" {
+		return os.ErrInvalid
+	}
+	path := userPlistPath(homeDir)
+	if err := writeBool(path, KeyLEDGreenFull, enabled); err != nil {
+		return err
+	}
+	return chownUserPlist(path, uid, gid)
+}
+
+func ReadSystemManagementEnabled() bool {
+	val, found, err := readSystemBool(KeyManagement)
+	if err != nil || !found {
+		return true
+	}
+	return val
+}
+
+func WriteSystemManagementEnabled(enabled bool) error {
+	return writeBool(SystemPlistPath, KeyManagement, enabled)
+}
+
+// ReadSystemRestoreOnShutdown reports whether the daemon should re-enable
+// charging and the adapter when it exits. Defaults to true.
+func ReadSystemRestoreOnShutdown() bool {
+	val, found, err := readSystemBool(KeyRestoreOnExit)
+	if err != nil || !found {
+		return true
+	}
+	return val
+}
+
+// ReadSystemMagsafeLEDRetries returns how many timed retries follow a failed
+// MagSafe LED write. Defaults to 3; 0 leaves retries to the next logic run.
+func ReadSystemMagsafeLEDRetries() int {
+	n, found, err := readSystemInt(KeyLEDRetries)
+	if err != nil || !found {
+		return defaultMagsafeLEDRetries
+	}
+	if n < 0 {
+		return 0
+	}
+	if n > 10 {
+		return 10
+	}
+	return n
+}
+
+// ReadSystemDeferChargingUnderLoad reports whether charging enables near the
+// limit may wait while the system is busy. Defaults to false.
+func ReadSystemDeferChargingUnderLoad() bool {
+	val, found, err := readSystemBool(KeyDeferOnLoad)
+	if err != nil || !found {
+		return false
+	}
+	return val
+}
+
+// ReadSystemEnableChargingDuringDischarge reports whether the charging logic
+// may re-enable charging while a force discharge or discharge band holds the
+// adapter off. Defaults to false.
+func ReadSystemEnableChargingDuringDischarge() bool {
+	val, found, err := readSystemBool(KeyChargeInDisch)
+	if err != nil || !found {
+		return false
+	}
+	return val
+}
+
+// ReadSystemRestoreOverridesAcrossRestart reports whether temporary overrides
+// are saved at shutdown and restored at the next start. Defaults to false.
+func ReadSystemRestoreOverridesAcrossRestart() bool {
+	val, found, err := readSystemBool(KeyRestoreOvr)
+	if err != nil || !found {
+		return false
+	}
+	return val
+}
+
+// ReadSystemPollingOnly reports whether the daemon skips the powerkit event
+// stream and relies on periodic reads alone. Defaults to false.
+func ReadSystemPollingOnly() bool {
+	val, found, err := readSystemBool(KeyPollingOnly)
+	if err != nil || !found {
+		return false
+	}
+	return val
+}
+
+// ReadSystemPollIntervalSeconds returns the read interval used in
+// polling-only mode, clamped to 2-60 seconds. Defaults to 10.
+func ReadSystemPollIntervalSeconds() int {
+	n, found, err := readSystemInt(KeyPollInterval)
+	if err != nil || !found {
+		return defaultPollIntervalSeconds
+	}
+	if n < minPollIntervalSeconds {
+		return minPollIntervalSeconds
+	}
+	if n > maxPollIntervalSeconds {
+		return maxPollIntervalSeconds
+	}
+	return n
+}
+
+// ReadSystemPreventDisplaySleepWithExternalDisplay reports whether display
+// sleep is prevented automatically while an external display is attached.
+// Defaults to false.
+func ReadSystemPreventDisplaySleepWithExternalDisplay() bool {
+	val, found, err := readSystemBool(KeyExtDisplay)
+	if err != nil || !found {
+		return false
+	}
+	return val
+}
+
+// ReadSystemTextControlEnabled reports whether the daemon serves the plain
+// text control socket. Defaults to false.
+func ReadSystemTextControlEnabled() bool {
+	val, found, err := readSystemBool(KeyTextControl)
+	if err != nil || !found {
+		return false
+	}
+	return val
+}
+
+// ReadSystemReportChargedAtLimit reports whether status should flag the
+// battery as charged once it reaches a limit below 100%. Defaults to true.
+func ReadSystemReportChargedAtLimit() bool {
+	val, found, err := readSystemBool(KeyChargedAtLim)
+	if err != nil || !found {
+		return true
+	}
+	return val
+}
+
+// ReadSystemAdapterUnderperformPercent returns the share of an adapter's rated
+// wattage below which it is reported as underperforming while charging.
+// Defaults to 50; 0 disables the check.
+func ReadSystemAdapterUnderperformPercent() int {
+	n, found, err := readSystemInt(KeyAdapterFloor)
+	if err != nil || !found {
+		return defaultAdapterUnderperformPercent
+	}
+	if n < 0 {
+		return 0
+	}
+	if n > 100 {
+		return 100
+	}
+	return n
+}
+
+// ReadSystemForceDischargeFloorPercent returns the charge at which a forced
+// discharge is stopped automatically. Defaults to 20 and is kept within
+// 5-95 so the safety stop cannot be configured away.
+func ReadSystemForceDischargeFloorPercent() int {
+	n, found, err := readSystemInt(KeyDischargeStop)
+	if err != nil || !found {
+		return defaultForceDischargeFloorPercent
+	}
+	if n < 5 {
+		return 5
+	}
+	if n > 95 {
+		return 95
+	}
+	return n
+}
+
+// ReadSystemMinChargeBeforeSleepPercent returns the charge below which the
+// pre-sleep charging disable is skipped. Defaults to 30; 0 disables the guard.
+func ReadSystemMinChargeBeforeSleepPercent() int {
+	n, found, err := readSystemInt(KeySleepFloor)
+	if err != nil || !found {
+		return defaultMinChargeBeforeSleep
+	}
+	if n < 0 {
+		return 0
+	}
+	if n > 99 {
+		return 99
+	}
+	return n
+}
+
+// ReadSystemMinChargingAdapterWatts returns the adapter rating below which
+// the battery is not charged and the system runs from the adapter alone.
+// Defaults to 0 (off).
+func ReadSystemMinChargingAdapterWatts() int {
+	n, found, err := readSystemInt(KeyMinAdapterW)
+	if err != nil || !found || n < 0 {
+		return 0
+	}
+	if n > maxMinChargingAdapterWatts {
+		return maxMinChargingAdapterWatts
+	}
+	return n
+}
+
+// ReadSystemPreSleepDisableDelaySeconds returns how long the pre-sleep
+// charging disable waits so a quick wake can cancel it. 0 (the default)
+// disables charging immediately.
+func ReadSystemPreSleepDisableDelaySeconds() int {
+	n, found, err := readSystemInt(KeySleepDelay)
+	if err != nil || !found || n < 0 {
+		return 0
+	}
+	if n > maxPreSleepDisableDelaySeconds {
+		return maxPreSleepDisableDelaySeconds
+	}
+	return n
+}
+
+// ReadSystemConnectGraceSeconds returns how long charging may continue past
+// the limit after the adapter is plugged in. Defaults to 0 (off); capped at
+// ten minutes.
+func ReadSystemConnectGraceSeconds() int {
+	n, found, err := readSystemInt(KeyConnectGrace)
+	if err != nil || !found || n < 0 {
+		return 0
+	}
+	if n > maxConnectGraceSeconds {
+		return maxConnectGraceSeconds
+	}
+	return n
+}
+
+// ReadSystemLogChargingDecisions reports whether each charging decision is
+// logged with the inputs that drove it. Defaults to false.
+func ReadSystemLogChargingDecisions() bool {
+	val, found, err := readSystemBool(KeyLogDecisions)
+	if err != nil || !found {
+		return false
+	}
+	return val
+}
+
+// ReadSystemCriticalChargePercent returns the charge at or below which the
+// daemon takes its critical-charge actions. Defaults to 10; 0 turns them
+// off; capped at 50.
+func ReadSystemCriticalChargePercent() int {
+	n, found, err := readSystemInt(KeyCriticalLevel)
+	if err != nil || !found {
+		return defaultCriticalChargePercent
+	}
+	if n < 0 {
+		return 0
+	}
+	if n > maxCriticalChargePercent {
+		return maxCriticalChargePercent
+	}
+	return n
+}
+
+// CriticalActions selects what the daemon does once charge reaches
+// CriticalChargePercent without charging.
+type CriticalActions struct {
+	LowPowerMode   bool // Turn on Low Power Mode while on battery
+	Notify         bool // Log a fault and report critical_charge for clients to alert on
+	ResumeCharging bool // Cancel a pre-sleep charging pause while an adapter is connected
+}
+
+// ReadSystemCriticalChargeActions returns the configured critical-charge
+// actions, a comma-separated list of lowpower, notify and charge. Defaults
+// to notify,charge.
+func ReadSystemCriticalChargeActions() CriticalActions {
+	val, found, err := readSystemString(KeyCriticalActs)
+	if err != nil || !found {
+		return ParseCriticalActions(defaultCriticalChargeActions)
+	}
+	return ParseCriticalActions(val)
+}
+
+// ParseCriticalActions reads a comma-separated action list. Names are case
+// insensitive and unknown ones are ignored, so an empty list selects none.
+func ParseCriticalActions(list string) CriticalActions {
+	var actions CriticalActions
+	for _, name := range strings.Split(list, ",") {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "lowpower":
+			actions.LowPowerMode = true
+		case "notify":
+			actions.Notify = true
+		case "charge":
+			actions.ResumeCharging = true
+		}
+	}
+	return actions
+}
+
+func (a CriticalActions) String() string {
+	var names []string
+	if a.LowPowerMode {
+		names = append(names, "lowpower")
+	}
+	if a.Notify {
+		names = append(names, "notify")
+	}
+	if a.ResumeCharging {
+		names = append(names, "charge")
+	}
+	return strings.Join(names, ",")
+}
+
+// ReadSystemWatchPreferenceChanges reports whether the daemon re-reads the
+// console user's preferences periodically, so edits made with defaults write
+// take effect without a user switch. Defaults to false.
+func ReadSystemWatchPreferenceChanges() bool {
+	val, found, err := readSystemBool(KeyWatchPrefs)
+	if err != nil || !found {
+		return false
+	}
+	return val
+}
+
+// ReadSystemStartupGraceSeconds returns how long after launch the daemon
+// only reads status, so SMC and IOKit readings can settle before it changes
+// charging. Defaults to 10; 0 turns the grace period off; capped at two
+// minutes.
+func ReadSystemStartupGraceSeconds() int {
+	n, found, err := readSystemInt(KeyStartupGrace)
+	if err != nil || !found {
+		return defaultStartupGraceSeconds
+	}
+	if n < 0 {
+		return 0
+	}
+	if n > maxStartupGraceSeconds {
+		return maxStartupGraceSeconds
+	}
+	return n
+}
+
+// ReadSystemChargeLimitRampMinutes returns how long a lowered charge limit
+// takes to ease down to its new value. Defaults to 0 (applied at once);
+// capped at four hours.
+func ReadSystemChargeLimitRampMinutes() int {
+	n, found, err := readSystemInt(KeyLimitRamp)
+	if err != nil || !found || n < 0 {
+		return 0
+	}
+	if n > maxChargeLimitRampMinutes {
+		return maxChargeLimitRampMinutes
+	}
+	return n
+}
+
+// ReadSystemMinChargeLimit returns the lowest charge limit clients may set,
+// for heavily degraded batteries that should stay lower than the default
+// floor allows. Defaults to DefaultMinChargeLimit and never goes below
+// HardMinChargeLimit.
+func ReadSystemMinChargeLimit() int {
+	n, found, err := readSystemInt(KeyMinLimit)
+	if err != nil || !found {
+		return DefaultMinChargeLimit
+	}
+	if n < HardMinChargeLimit {
+		return HardMinChargeLimit
+	}
+	if n > 100 {
+		return 100
+	}
+	return n
+}
+
+// ReadSystemRememberLastUserChargeLimit reports whether the last console
+// user's limit applies before anyone logs in, e.g. after an overnight reboot.
+// Defaults to false.
+func ReadSystemRememberLastUserChargeLimit() bool {
+	val, found, err := readSystemBool(KeyRememberLim)
+	if err != nil || !found {
+		return false
+	}
+	return val
+}
+
+// ReadSystemLastUserChargeLimit returns the limit the last console user had,
+// or 0 when none was recorded.
+func ReadSystemLastUserChargeLimit() int {
+	n, found, err := readInt(SystemPlistPath, KeyLastUserLim)
+	if err != nil || !found || n <= 0 {
+		return 0
+	}
+	return clampLimit(n)
+}
+
+// WriteSystemLastUserChargeLimit records the console user's limit. The daemon
+// owns this entry; it is state rather than a setting.
+func WriteSystemLastUserChargeLimit(limit int) error {
+	return writeInt(SystemPlistPath, KeyLastUserLim, clampLimit(limit))
+}
+
+// ReadSystemPersistEffectiveChargeLimit reports whether the daemon mirrors
+// the limit it enforces into EffectiveChargeLimit. Defaults to false.
+func ReadSystemPersistEffectiveChargeLimit() bool {
+	val, found, err := readSystemBool(KeyPersistEff)
+	if err != nil || !found {
+		return false
+	}
+	return val
+}
+
+// WriteSystemEffectiveChargeLimit records the limit the daemon enforces so
+// scripts can read it without the socket. Like LastUserChargeLimit it is
+// state the daemon owns, not a setting.
+func WriteSystemEffectiveChargeLimit(limit int) error {
+	return writeInt(SystemPlistPath, KeyEffectiveLim, clampLimit(limit))
+}
+
+// EventLogSettings controls the on-disk audit log. Empty Path and zero
+// MaxBytes mean the eventlog package defaults.
+type EventLogSettings struct {
+	Enabled  bool
+	Path     string
+	MaxBytes int64
+}
+
+// ReadSystemEventLogSettings returns the audit log settings. The log is off
+// unless EventLogEnabled is set.
+func ReadSystemEventLogSettings() EventLogSettings {
+	var out EventLogSettings
+	if val, found, err := readSystemBool(KeyEventLog); err == nil && found {
+		out.Enabled = val
+	}
+	if val, found, err := readSystemString(KeyEventLogPath); err == nil && found {
+		out.Path = val
+	}
+	if n, found, err := readSystemInt(KeyEventLogSize); err == nil && found && n > 0 {
+		out.MaxBytes = int64(n)
+	}
+	return out
+}
+
+// ReadSystemReflectionEnabled reports whether gRPC server reflection is
+// served on the socket. It is a debugging aid and off by default.
+func ReadSystemReflectionEnabled() bool {
+	val, found, err := readSystemBool(KeyReflection)
+	if err != nil || !found {
+		return false
+	}
+	return val
+}
+
+// ReadSystemSMCKeyReadsEnabled reports whether ReadSMCKeys serves raw SMC
+// reads. Defaults to false.
+func ReadSystemSMCKeyReadsEnabled() bool {
+	val, found, err := readSystemBool(KeySMCReads)
+	if err != nil || !found {
+		return false
+	}
+	return val
+}
+
+// EnsureSystemConfig creates the system preferences on first run and
+// migrates older ones to CurrentConfigVersion.
 func EnsureSystemConfig(defaultLimit int) error {
-	if ReadSystemChargeLimit() == 0 {
-		return writeInt(SystemPlistPath, KeyChargeLimit, clampLimit(defaultLimit))
+	return ensureSystemConfigAt(SystemPlistPath, defaultLimit)
+}
+
+// ensureSystemConfigAt reads only the keys the migrations look at and writes
+// back only the ones they change, so other keys in the file, including
+// values JSON cannot represent, are left as they are. ConfigVersion is
+// written last, so an interrupted migration runs again.
+func ensureSystemConfigAt(path string, defaultLimit int) error {
+	settings := map[string]any{}
+	for _, key := range []string{KeyConfigVersion, KeyChargeLimit} {
+		v, found, err := readInt(path, key)
+		if err != nil {
+			return err
+		}
+		if found {
+			settings[key] = v
+		}
+	}
+	for _, key := range []string{KeyManagement, KeyRestoreOnExit} {
+		v, found, err := readBool(path, key)
+		if err != nil {
+			return err
+		}
+		if found {
+			settings[key] = v
+		}
+	}
+
+	for _, key := range migrateSystemSettings(settings, defaultLimit) {
+		var err error
+		switch v := settings[key].(type) {
+		case int:
+			err = writeInt(path, key, v)
+		case bool:
+			err = writeBool(path, key, v)
+		default:
+			err = fmt.Errorf("unexpected %T for migrated key %q", v, key)
+		}
+		if err != nil {
+			return err
+		}
 	}
 	return nil
 }
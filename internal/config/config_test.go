@@ -0,0 +1,85 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDefaultChargeLimitFromEnv(t *testing.T) {
+	cases := []struct {
+		name string
+		env  string
+		set  bool
+		want int
+	}{
+		{name: "unset", want: 80},
+		{name: "valid", env: "90", set: true, want: 90},
+		{name: "clamped low", env: "20", set: true, want: 60},
+		{name: "clamped high", env: "150", set: true, want: 100},
+		{name: "invalid", env: "eighty", set: true, want: 80},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.set {
+				t.Setenv(DefaultChargeLimitEnv, tc.env)
+			}
+			if got := DefaultChargeLimitFromEnv(80); got != tc.want {
+				t.Fatalf("unexpected default limit: got=%d want=%d", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestUserChargeLimitZeroMeansUnset(t *testing.T) {
+	usePrefCache(t)
+	home := t.TempDir()
+	if err := os.MkdirAll(filepath.Dir(userPlistPath(home)), 0o755); err != nil {
+		t.Fatalf("mkdir failed: %v", err)
+	}
+
+	if got := ReadUserChargeLimit(""); got != 0 {
+		t.Fatalf("expected 0 without a home directory, got %d", got)
+	}
+	if got := ReadUserChargeLimit(home); got != 0 {
+		t.Fatalf("expected 0 before anything is written, got %d", got)
+	}
+
+	if err := WriteUserChargeLimit(home, 0, 0, 75); err != nil {
+		t.Fatalf("WriteUserChargeLimit returned error: %v", err)
+	}
+	if got := ReadUserChargeLimit(home); got != 75 {
+		t.Fatalf("unexpected limit after write: got=%d want=75", got)
+	}
+	// The file, not just the cache, must hold the value.
+	prefs.invalidate(userPlistPath(home))
+	if got := ReadUserChargeLimit(home); got != 75 {
+		t.Fatalf("unexpected limit read from disk: got=%d want=75", got)
+	}
+
+	if err := WriteUserChargeLimit(home, 0, 0, 150); err != nil {
+		t.Fatalf("WriteUserChargeLimit returned error: %v", err)
+	}
+	if got := ReadUserChargeLimit(home); got != 100 {
+		t.Fatalf("expected writes clamped to 100, got %d", got)
+	}
+}
+
+func TestParseCriticalActions(t *testing.T) {
+	tests := []struct {
+		list string
+		want CriticalActions
+	}{
+		{list: "", want: CriticalActions{}},
+		{list: "notify,charge", want: CriticalActions{Notify: true, ResumeCharging: true}},
+		{list: " LowPower , bogus", want: CriticalActions{LowPowerMode: true}},
+	}
+	for _, tc := range tests {
+		if got := ParseCriticalActions(tc.list); got != tc.want {
+			t.Fatalf("ParseCriticalActions(%q) = %+v, want %+v", tc.list, got, tc.want)
+		}
+	}
+	if got := ParseCriticalActions("charge,lowpower,notify").String(); got != "lowpower,notify,charge" {
+		t.Fatalf("unexpected String(): %q", got)
+	}
+}
@@ -0,0 +1,59 @@
+package config
+
+// ChargingCounters are the charging transitions the daemon actuated on Day
+// (YYYY-MM-DD in local time). Field names double as the plist keys inside the
+// ChargingCounters dictionary.
+type ChargingCounters struct {
+	Day      string `json:"Day"`
+	Enabled  int    `json:"Enabled"`
+	Disabled int    `json:"Disabled"`
+}
+
+// ReadSystemChargingCounters returns the stored counters, or zero counters
+// when none are stored or the entry is unreadable.
+func ReadSystemChargingCounters() ChargingCounters {
+	var c ChargingCounters
+	if found, err := readJSON(SystemPlistPath, KeyCounters, &c); err != nil || !found {
+		return ChargingCounters{}
+	}
+	return c
+}
+
+// WriteSystemChargingCounters stores c in the system preferences. The
+// daemon owns this entry; it is state rather than a setting.
+func WriteSystemChargingCounters(c ChargingCounters) error {
+	return writeJSON(SystemPlistPath, KeyCounters, c)
+}
+
+// OverrideSnapshot holds the daemon's temporary overrides across a restart,
+// such as an upgrade of the daemon. Times are Unix seconds, except
+// PinnedStartUnixNano, and zero means the override was not active. Field
+// names double as the plist keys inside the SavedOverrides dictionary.
+type OverrideSnapshot struct {
+	Version             int   `json:"Version"`
+	SavedUnix           int64 `json:"SavedUnix"`
+	BoostUntilUnix      int64 `json:"BoostUntilUnix"`
+	PinnedPID           int32 `json:"PinnedPID"`
+	PinnedStartUnixNano int64 `json:"PinnedStartUnixNano"`
+	FullByDeadlineUnix  int64 `json:"FullByDeadlineUnix"`
+	FullByTarget        int   `json:"FullByTarget"`
+	FullByStartedUnix   int64 `json:"FullByStartedUnix"`
+	SuspendUntilUnix    int64 `json:"SuspendUntilUnix"`
+	ForceDischarge      bool  `json:"ForceDischarge"`
+}
+
+// ReadSystemOverrideSnapshot returns the stored override snapshot, or a zero
+// snapshot when none is stored or the entry is unreadable.
+func ReadSystemOverrideSnapshot() OverrideSnapshot {
+	var s OverrideSnapshot
+	if found, err := readJSON(SystemPlistPath, KeyOverrides, &s); err != nil || !found {
+		return OverrideSnapshot{}
+	}
+	return s
+}
+
+// WriteSystemOverrideSnapshot stores s in the system preferences. Like the
+// counters, the daemon owns this entry.
+func WriteSystemOverrideSnapshot(s OverrideSnapshot) error {
+	return writeJSON(SystemPlistPath, KeyOverrides, s)
+}
@@ -0,0 +1,25 @@
+package config
+
+// HealthSample is one daily battery health reading kept for the wear trend.
+// Field names double as the plist keys inside each HealthHistory entry.
+type HealthSample struct {
+	Unix   int64   `json:"Unix"`
+	Cycles int     `json:"Cycles"`
+	Health float64 `json:"Health"`
+}
+
+// ReadSystemHealthHistory returns the stored health samples, oldest first,
+// or nil when none are stored or the entry is unreadable.
+func ReadSystemHealthHistory() []HealthSample {
+	var samples []HealthSample
+	if found, err := readJSON(SystemPlistPath, KeyHealthHist, &samples); err != nil || !found {
+		return nil
+	}
+	return samples
+}
+
+// WriteSystemHealthHistory stores the health samples in the system
+// preferences. Like the counters, the daemon owns this entry.
+func WriteSystemHealthHistory(samples []HealthSample) error {
+	return writeJSON(SystemPlistPath, KeyHealthHist, samples)
+}
@@ -0,0 +1,82 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// CurrentConfigVersion is the system preferences schema the daemon writes.
+// Unversioned files are treated as version 1.
+const CurrentConfigVersion = 2
+
+type systemMigration struct {
+	to    int
+	apply func(settings map[string]any, defaultLimit int)
+}
+
+// systemMigrations run in order; each upgrades from to-1 to to.
+var systemMigrations = []systemMigration{
+	{to: 2, apply: migrateSystemV1ToV2},
+}
+
+// migrateSystemV1ToV2 clamps the legacy limit into range and writes the
+// management keys explicitly so later defaults changes do not flip them.
+func migrateSystemV1ToV2(settings map[string]any, defaultLimit int) {
+	limit, ok := settingInt(settings, KeyChargeLimit)
+	if !ok || limit == 0 {
+		limit = defaultLimit
+	}
+	settings[KeyChargeLimit] = clampLimit(limit)
+
+	if _, ok := settings[KeyManagement].(bool); !ok {
+		settings[KeyManagement] = true
+	}
+	if _, ok := settings[KeyRestoreOnExit].(bool); !ok {
+		settings[KeyRestoreOnExit] = true
+	}
+}
+
+// migrateSystemSettings upgrades settings in place and returns the keys it
+// changed, sorted, with ConfigVersion last. Files from a newer daemon are
+// left untouched.
+func migrateSystemSettings(settings map[string]any, defaultLimit int) []string {
+	version, ok := settingInt(settings, KeyConfigVersion)
+	if !ok || version < 1 {
+		version = 1
+	}
+	if version >= CurrentConfigVersion {
+		return nil
+	}
+
+	before := maps.Clone(settings)
+	for _, m := range systemMigrations {
+		if m.to <= version {
+			continue
+		}
+		m.apply(settings, defaultLimit)
+		version = m.to
+	}
+
+	var changed []string
+	for key, v := range settings {
+		if old, ok := before[key]; !ok || fmt.Sprint(old) != fmt.Sprint(v) {
+			changed = append(changed, key)
+		}
+	}
+	slices.Sort(changed)
+	settings[KeyConfigVersion] = version
+	return append(changed, KeyConfigVersion)
+}
+
+// settingInt reads a number decoded from JSON, which arrives as float64.
+func settingInt(settings map[string]any, key string) (int, bool) {
+	switch v := settings[key].(type) {
+	case float64:
+		return int(v), true
+	case int:
+		return v, true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,114 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMigrateSystemSettings(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      map[string]any
+		want    map[string]any
+		changed []string
+	}{
+		{
+			name: "fresh install",
+			in:   map[string]any{},
+			want: map[string]any{
+				KeyChargeLimit:   80,
+				KeyManagement:    true,
+				KeyRestoreOnExit: true,
+				KeyConfigVersion: CurrentConfigVersion,
+			},
+			changed: []string{KeyChargeLimit, KeyManagement, KeyRestoreOnExit, KeyConfigVersion},
+		},
+		{
+			name: "v1 with out-of-range limit and passthrough",
+			in: map[string]any{
+				KeyChargeLimit: float64(55),
+				KeyManagement:  false,
+			},
+			want: map[string]any{
+				KeyChargeLimit:   60,
+				KeyManagement:    false,
+				KeyRestoreOnExit: true,
+				KeyConfigVersion: CurrentConfigVersion,
+			},
+			changed: []string{KeyChargeLimit, KeyRestoreOnExit, KeyConfigVersion},
+		},
+		{
+			name: "already current",
+			in: map[string]any{
+				KeyChargeLimit:   float64(90),
+				KeyConfigVersion: float64(CurrentConfigVersion),
+			},
+			want: map[string]any{
+				KeyChargeLimit:   float64(90),
+				KeyConfigVersion: float64(CurrentConfigVersion),
+			},
+		},
+		{
+			name: "newer than daemon",
+			in:   map[string]any{KeyConfigVersion: float64(CurrentConfigVersion + 1)},
+			want: map[string]any{KeyConfigVersion: float64(CurrentConfigVersion + 1)},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			changed := migrateSystemSettings(tc.in, 80)
+			if !reflect.DeepEqual(changed, tc.changed) {
+				t.Fatalf("unexpected changed: got=%v want=%v", changed, tc.changed)
+			}
+			if !reflect.DeepEqual(tc.in, tc.want) {
+				t.Fatalf("unexpected settings: got=%v want=%v", tc.in, tc.want)
+			}
+		})
+	}
+}
+
+func TestEnsureSystemConfigKeepsOtherKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "daemon.plist")
+	const plist = `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>ChargeLimit</key>
+	<integer>55</integer>
+	<key>EventLogEnabled</key>
+	<true/>
+	<key>InstalledAt</key>
+	<date>2026-01-01T12:00:00Z</date>
+</dict>
+</plist>
+`
+	if err := os.WriteFile(path, []byte(plist), 0o644); err != nil {
+		t.Fatalf("failed to write plist: %v", err)
+	}
+	if _, err := readPlistJSON(path, &map[string]any{}); err == nil {
+		t.Fatal("expected an error reading a plist with a date as JSON")
+	}
+
+	if err := ensureSystemConfigAt(path, 80); err != nil {
+		t.Fatalf("ensureSystemConfigAt returned error: %v", err)
+	}
+	if v, found, err := readInt(path, KeyConfigVersion); err != nil || !found || v != CurrentConfigVersion {
+		t.Fatalf("unexpected config version: got=%d found=%v err=%v", v, found, err)
+	}
+	if v, _, _ := readInt(path, KeyChargeLimit); v != 60 {
+		t.Fatalf("expected the limit clamped to 60, got %d", v)
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read plist: %v", err)
+	}
+	for _, want := range []string{"<key>EventLogEnabled</key>", "<key>InstalledAt</key>", "<date>2026-01-01T12:00:00Z</date>"} {
+		if !strings.Contains(string(raw), want) {
+			t.Fatalf("expected %s kept in the migrated plist:\n%s", want, raw)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package config
+
+import (
+	"fmt"
+	"os"
+)
+
+// ChargeProfile bundles the per-user charging settings that can be switched
+// together. Field names double as the plist keys inside the Profiles
+// dictionary so the stored form reads like the flat preferences.
+type ChargeProfile struct {
+	ChargeLimit                int  `json:"ChargeLimit"`
+	ControlMagsafeLED          bool `json:"ControlMagsafeLED"`
+	DisableChargingBeforeSleep bool `json:"DisableChargingBeforeSleep"`
+}
+
+// BuiltinProfiles are offered to every user and can be overridden by storing
+// a profile with the same name.
+func BuiltinProfiles() map[string]ChargeProfile {
+	return map[string]ChargeProfile{
+		"travel":    {ChargeLimit: 100},
+		"daily":     {ChargeLimit: 80, DisableChargingBeforeSleep: true},
+		"longevity": {ChargeLimit: 60, DisableChargingBeforeSleep: true},
+	}
+}
+
+// ValidateProfile rejects profiles the daemon could not apply as-is.
+func ValidateProfile(name string, p ChargeProfile) error {
+	if name == "" {
+		return fmt.Errorf("profile name must not be empty")
+	}
+	if p.ChargeLimit < ReadSystemMinChargeLimit() || p.ChargeLimit > 100 {
+		return fmt.Errorf("profile %q charge limit out of range: %d", name, p.ChargeLimit)
+	}
+	return nil
+}
+
+// ReadUserProfiles returns the built-in profiles merged with the user's
+// stored ones; stored profiles win on name collisions.
+func ReadUserProfiles(homeDir string) map[string]ChargeProfile {
+	out := BuiltinProfiles()
+	if homeDir == "" {
+		return out
+	}
+	var stored map[string]ChargeProfile
+	found, err := readJSON(userPlistPath(homeDir), KeyProfiles, &stored)
+	if err != nil || !found {
+		return out
+	}
+	for name, p := range stored {
+		if ValidateProfile(name, p) == nil {
+			out[name] = p
+		}
+	}
+	return out
+}
+
+func WriteUserProfile(homeDir string, uid, gid uint32, name string, p ChargeProfile) error {
+	if homeDir == "" {
+		return os.ErrInvalid
+	}
+	if err := ValidateProfile(name, p); err != nil {
+		return err
+	}
+	path := userPlistPath(homeDir)
+	stored := map[string]ChargeProfile{}
+	if _, err := readJSON(path, KeyProfiles, &stored); err != nil {
+		return err
+	}
+	if stored == nil {
+		stored = map[string]ChargeProfile{}
+	}
+	stored[name] = p
+	if err := writeJSON(path, KeyProfiles, stored); err != nil {
+		return err
+	}
+	return chownUserPlist(path, uid, gid)
+}
+
+// ReadUserActiveProfile returns the name of the last applied profile, or ""
+// when settings were changed individually since.
+func ReadUserActiveProfile(homeDir string) string {
+	if homeDir == "" {
+		return ""
+	}
+	name, found, err := readString(userPlistPath(homeDir), KeyActiveProfile)
+	if err != nil || !found {
+		return ""
+	}
+	return name
+}
+
+func WriteUserActiveProfile(homeDir string, uid, gid uint32, name string) error {
+	if homeDir == "" {
+		return os.ErrInvalid
+	}
+	path := userPlistPath(homeDir)
+	if err := writeString(path, KeyActiveProfile, name); err != nil {
+		return err
+	}
+	return chownUserPlist(path, uid, gid)
+}
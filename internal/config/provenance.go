@@ -0,0 +1,113 @@
+package config
+
+import "fmt"
+
+// Source names where an effective setting came from.
+type Source string
+
+const (
+	SourceUser    Source = "user"
+	SourceAdmin   Source = "admin"
+	SourceSystem  Source = "system"
+	SourceDefault Source = "default"
+)
+
+// ResolvedSetting is an effective value together with its origin.
+type ResolvedSetting struct {
+	Key    string
+	Value  string
+	Source Source
+}
+
+// ResolveChargeLimit applies the user > system > default precedence and
+// reports which layer won.
+func ResolveChargeLimit(userLimit, systemLimit, defaultLimit int) (int, Source) {
+	if userLimit > 0 {
+		return clampLimit(userLimit), SourceUser
+	}
+	if systemLimit > 0 {
+		return clampLimit(systemLimit), SourceSystem
+	}
+	return clampLimit(defaultLimit), SourceDefault
+}
+
+// ResolveSettings reports every setting the daemon reads from preferences
+// with the value it resolves to for homeDir ("" when no console user).
+func ResolveSettings(homeDir string, defaultLimit int) []ResolvedSetting {
+	admin, _ := readAdminSettings()
+	system := plistKeys(SystemPlistPath)
+	user := map[string]any{}
+	if homeDir != "" {
+		user = plistKeys(userPlistPath(homeDir))
+	}
+	sourceIn := func(layer map[string]any, src Source) func(string) Source {
+		return func(key string) Source {
+			if _, ok := layer[key]; ok {
+				return src
+			}
+			return SourceDefault
+		}
+	}
+	userSource := sourceIn(user, SourceUser)
+	// A legacy ControlMagsafeLED flag still counts as the user's LED mode.
+	ledModeSource := userSource(KeyLEDMode)
+	if ledModeSource == SourceDefault {
+		ledModeSource = userSource(KeyMagsafeLED)
+	}
+	systemSource := func(key string) Source {
+		if _, ok := admin[key]; ok {
+			return SourceAdmin
+		}
+		return sourceIn(system, SourceSystem)(key)
+	}
+
+	limit, limitSource := ResolveChargeLimit(ReadUserChargeLimit(homeDir), ReadSystemChargeLimit(), defaultLimit)
+	if limitSource == SourceSystem {
+		limitSource = systemSource(KeyChargeLimit)
+	}
+	return []ResolvedSetting{
+		{Key: KeyChargeLimit, Value: fmt.Sprint(limit), Source: limitSource},
+		{Key: KeyLEDMode, Value: string(ReadUserLEDMode(homeDir)), Source: ledModeSource},
+		{Key: KeyLEDGreenFull, Value: fmt.Sprint(ReadUserLEDGreenOnlyWhenFull(homeDir)), Source: userSource(KeyLEDGreenFull)},
+		{Key: KeyDisableCBS, Value: fmt.Sprint(ReadUserDisableChargingBeforeSleep(homeDir)), Source: userSource(KeyDisableCBS)},
+		{Key: KeyPersistSleep, Value: fmt.Sprint(ReadUserPersistSleepPrevention(homeDir)), Source: userSource(KeyPersistSleep)},
+		{Key: KeyManagement, Value: fmt.Sprint(ReadSystemManagementEnabled()), Source: systemSource(KeyManagement)},
+		{Key: KeyRestoreOnExit, Value: fmt.Sprint(ReadSystemRestoreOnShutdown()), Source: systemSource(KeyRestoreOnExit)},
+		{Key: KeyAdapterFloor, Value: fmt.Sprint(ReadSystemAdapterUnderperformPercent()), Source: systemSource(KeyAdapterFloor)},
+		{Key: KeyDischargeStop, Value: fmt.Sprint(ReadSystemForceDischargeFloorPercent()), Source: systemSource(KeyDischargeStop)},
+		{Key: KeyMinAdapterW, Value: fmt.Sprint(ReadSystemMinChargingAdapterWatts()), Source: systemSource(KeyMinAdapterW)},
+		{Key: KeySleepFloor, Value: fmt.Sprint(ReadSystemMinChargeBeforeSleepPercent()), Source: systemSource(KeySleepFloor)},
+		{Key: KeySleepDelay, Value: fmt.Sprint(ReadSystemPreSleepDisableDelaySeconds()), Source: systemSource(KeySleepDelay)},
+		{Key: KeyChargedAtLim, Value: fmt.Sprint(ReadSystemReportChargedAtLimit()), Source: systemSource(KeyChargedAtLim)},
+		{Key: KeyConnectGrace, Value: fmt.Sprint(ReadSystemConnectGraceSeconds()), Source: systemSource(KeyConnectGrace)},
+		{Key: KeyMinLimit, Value: fmt.Sprint(ReadSystemMinChargeLimit()), Source: systemSource(KeyMinLimit)},
+		{Key: KeyLimitRamp, Value: fmt.Sprint(ReadSystemChargeLimitRampMinutes()), Source: systemSource(KeyLimitRamp)},
+		{Key: KeyPersistEff, Value: fmt.Sprint(ReadSystemPersistEffectiveChargeLimit()), Source: systemSource(KeyPersistEff)},
+		{Key: KeyRememberLim, Value: fmt.Sprint(ReadSystemRememberLastUserChargeLimit()), Source: systemSource(KeyRememberLim)},
+		{Key: KeyLoginLimit, Value: fmt.Sprint(ReadSystemLoginWindowChargeLimit()), Source: systemSource(KeyLoginLimit)},
+		{Key: KeyEventLog, Value: fmt.Sprint(ReadSystemEventLogSettings().Enabled), Source: systemSource(KeyEventLog)},
+		{Key: KeyLEDRetries, Value: fmt.Sprint(ReadSystemMagsafeLEDRetries()), Source: systemSource(KeyLEDRetries)},
+		{Key: KeyDeferOnLoad, Value: fmt.Sprint(ReadSystemDeferChargingUnderLoad()), Source: systemSource(KeyDeferOnLoad)},
+		{Key: KeyRestoreOvr, Value: fmt.Sprint(ReadSystemRestoreOverridesAcrossRestart()), Source: systemSource(KeyRestoreOvr)},
+		{Key: KeyChargeInDisch, Value: fmt.Sprint(ReadSystemEnableChargingDuringDischarge()), Source: systemSource(KeyChargeInDisch)},
+		{Key: KeyPollingOnly, Value: fmt.Sprint(ReadSystemPollingOnly()), Source: systemSource(KeyPollingOnly)},
+		{Key: KeyPollInterval, Value: fmt.Sprint(ReadSystemPollIntervalSeconds()), Source: systemSource(KeyPollInterval)},
+		{Key: KeyStartupGrace, Value: fmt.Sprint(ReadSystemStartupGraceSeconds()), Source: systemSource(KeyStartupGrace)},
+		{Key: KeyCriticalLevel, Value: fmt.Sprint(ReadSystemCriticalChargePercent()), Source: systemSource(KeyCriticalLevel)},
+		{Key: KeyCriticalActs, Value: ReadSystemCriticalChargeActions().String(), Source: systemSource(KeyCriticalActs)},
+		{Key: KeyWatchPrefs, Value: fmt.Sprint(ReadSystemWatchPreferenceChanges()), Source: systemSource(KeyWatchPrefs)},
+		{Key: KeyLogDecisions, Value: fmt.Sprint(ReadSystemLogChargingDecisions()), Source: systemSource(KeyLogDecisions)},
+		{Key: KeyExtDisplay, Value: fmt.Sprint(ReadSystemPreventDisplaySleepWithExternalDisplay()), Source: systemSource(KeyExtDisplay)},
+		{Key: KeyTextControl, Value: fmt.Sprint(ReadSystemTextControlEnabled()), Source: systemSource(KeyTextControl)},
+		{Key: KeyReflection, Value: fmt.Sprint(ReadSystemReflectionEnabled()), Source: systemSource(KeyReflection)},
+		{Key: KeySMCReads, Value: fmt.Sprint(ReadSystemSMCKeyReadsEnabled()), Source: systemSource(KeySMCReads)},
+	}
+}
+
+func plistKeys(path string) map[string]any {
+	out := map[string]any{}
+	if _, err := readPlistJSON(path, &out); err != nil {
+		return map[string]any{}
+	}
+	return out
+}
@@ -0,0 +1,27 @@
+package config
+
+import "testing"
+
+func TestResolveChargeLimit(t *testing.T) {
+	tests := []struct {
+		name       string
+		user       int
+		system     int
+		wantLimit  int
+		wantSource Source
+	}{
+		{name: "user wins", user: 70, system: 90, wantLimit: 70, wantSource: SourceUser},
+		{name: "system when user unset", system: 90, wantLimit: 90, wantSource: SourceSystem},
+		{name: "default when both unset", wantLimit: 80, wantSource: SourceDefault},
+		{name: "user clamped", user: 40, wantLimit: 60, wantSource: SourceUser},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			limit, source := ResolveChargeLimit(tc.user, tc.system, 80)
+			if limit != tc.wantLimit || source != tc.wantSource {
+				t.Fatalf("unexpected resolution: got=(%d,%s) want=(%d,%s)", limit, source, tc.wantLimit, tc.wantSource)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package consoleuser
+
+import (
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// usersDir holds local home directories on macOS.
+const usersDir = "/Users"
+
+// LocalUsers lists the accounts whose home directory is under /Users,
+// skipping Shared and hidden entries. Directories the directory service
+// cannot resolve to an account, or whose account lives elsewhere, are left
+// out.
+func LocalUsers() ([]ConsoleUser, error) {
+	entries, err := os.ReadDir(usersDir)
+	if err != nil {
+		return nil, err
+	}
+	var out []ConsoleUser
+	for _, e := range entries {
+		name := e.Name()
+		if !e.IsDir() || name == "Shared" || strings.HasPrefix(name, ".") {
+			continue
+		}
+		u, err := user.Lookup(name)
+		if err != nil || filepath.Clean(u.HomeDir) != filepath.Join(usersDir, name) {
+			continue
+		}
+		uid, err := strconv.Atoi(u.Uid)
+		if err != nil {
+			continue
+		}
+		cu := ConsoleUser{Username: u.Username, UID: uint32(uid), HomeDir: u.HomeDir}
+		if gid, err := strconv.Atoi(u.Gid); err == nil {
+			cu.GID = uint32(gid)
+		}
+		out = append(out, cu)
+	}
+	return out, nil
+}
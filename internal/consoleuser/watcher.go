@@ -7,6 +7,7 @@ package consoleuser
 #cgo LDFLAGS: -framework CoreFoundation -framework SystemConfiguration
 #include <SystemConfiguration/SystemConfiguration.h>
 #include <CoreFoundation/CoreFoundation.h>
+#include <stdlib.h>
 
 void consoleUserChangedCallback(SCDynamicStoreRef store, CFArrayRef changedKeys, void *info);
 */
@@ -14,45 +15,128 @@ import "C"
 
 import (
 	"log"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
 	"unsafe"
 )
 
-var notificationChannel = make(chan struct{}, 1)
+// Backoff between attempts to re-create the SystemConfiguration session.
+const (
+	watchRetryMin = time.Second
+	watchRetryMax = time.Minute
+)
+
+// subscriber is the channel of the most recent Watch call, which the
+// exported callback signals.
+var (
+	subscriberMu sync.Mutex
+	subscriber   chan struct{}
+)
+
+// watchDegraded is set while no SCDynamicStore session is delivering
+// console user notifications.
+var watchDegraded atomic.Bool
 
 //export consoleUserChangedCallback
 func consoleUserChangedCallback(store C.SCDynamicStoreRef, changedKeys C.CFArrayRef, info unsafe.Pointer) {
+	notify()
+}
+
+func notify() {
+	subscriberMu.Lock()
+	defer subscriberMu.Unlock()
 	select {
-	case notificationChannel <- struct{}{}:
+	case subscriber <- struct{}{}:
 	default:
 	}
 }
 
+// WatchDegraded reports whether console user notifications are currently
+// unavailable because the SystemConfiguration session could not be created.
+func WatchDegraded() bool {
+	return watchDegraded.Load()
+}
+
+// Watch delivers a signal whenever the console user may have changed. If the
+// SystemConfiguration session cannot be created, it retries with backoff and
+// signals once it recovers so missed changes are picked up. The channel is
+// closed if the watcher stops, so callers can tell that apart from silence
+// and call Watch again.
 func Watch() <-chan struct{} {
+	ch := make(chan struct{}, 1)
+	subscriberMu.Lock()
+	subscriber = ch
+	subscriberMu.Unlock()
+
 	go func() {
-		key := C.CFStringCreateWithCString(C.kCFAllocatorDefault, C.CString("State:/Users/ConsoleUser"), C.kCFStringEncodingUTF8)
-		defer C.CFRelease(C.CFTypeRef(key))
+		defer func() {
+			subscriberMu.Lock()
+			if subscriber == ch {
+				subscriber = nil
+			}
+			subscriberMu.Unlock()
+			close(ch)
+		}()
+		// The run loop belongs to the thread that created the session.
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+		delay := watchRetryMin
+		for {
+			store := createStore()
+			if store != 0 {
+				if watchDegraded.Swap(false) {
+					log.Println("consoleuser watcher re-established SCDynamicStore session")
+					notify()
+				}
+				runStore(store)
+				log.Println("ERROR: consoleuser watcher run loop exited")
+				return
+			}
+			watchDegraded.Store(true)
+			log.Printf("ERROR: Failed to create SCDynamicStore session in consoleuser watcher; retrying in %s", delay)
+			time.Sleep(delay)
+			delay = min(2*delay, watchRetryMax)
+		}
+	}()
 
-		keysToWatch := C.CFArrayCreate(C.kCFAllocatorDefault, (*unsafe.Pointer)(unsafe.Pointer(&key)), 1, &C.kCFTypeArrayCallBacks)
-		defer C.CFRelease(C.CFTypeRef(keysToWatch))
+	return ch
+}
 
-		appName := C.CFStringCreateWithCString(C.kCFAllocatorDefault, C.CString("com.neutronstar.powergrid"), C.kCFStringEncodingUTF8)
-		defer C.CFRelease(C.CFTypeRef(appName))
+// createStore opens a SystemConfiguration session notified on console user
+// changes, or returns 0.
+func createStore() C.SCDynamicStoreRef {
+	cAppName := C.CString("com.neutronstar.powergrid")
+	defer C.free(unsafe.Pointer(cAppName))
+	appName := C.CFStringCreateWithCString(C.kCFAllocatorDefault, cAppName, C.kCFStringEncodingUTF8)
+	defer C.CFRelease(C.CFTypeRef(appName))
 
-		store := C.SCDynamicStoreCreate(C.kCFAllocatorDefault, appName, C.SCDynamicStoreCallBack(C.consoleUserChangedCallback), nil)
-		if store == 0 {
-			log.Println("ERROR: Failed to create SCDynamicStore session in consoleuser watcher")
-			return
-		}
-		defer C.CFRelease(C.CFTypeRef(store))
+	store := C.SCDynamicStoreCreate(C.kCFAllocatorDefault, appName, C.SCDynamicStoreCallBack(C.consoleUserChangedCallback), nil)
+	if store == 0 {
+		return 0
+	}
 
-		C.SCDynamicStoreSetNotificationKeys(store, keysToWatch, C.CFArrayRef(unsafe.Pointer(nil)))
+	cKey := C.CString("State:/Users/ConsoleUser")
+	defer C.free(unsafe.Pointer(cKey))
+	key := C.CFStringCreateWithCString(C.kCFAllocatorDefault, cKey, C.kCFStringEncodingUTF8)
+	defer C.CFRelease(C.CFTypeRef(key))
 
-		runLoopSource := C.SCDynamicStoreCreateRunLoopSource(C.kCFAllocatorDefault, store, 0)
-		C.CFRunLoopAddSource(C.CFRunLoopGetCurrent(), runLoopSource, C.kCFRunLoopDefaultMode)
-		defer C.CFRelease(C.CFTypeRef(runLoopSource))
+	keysToWatch := C.CFArrayCreate(C.kCFAllocatorDefault, (*unsafe.Pointer)(unsafe.Pointer(&key)), 1, &C.kCFTypeArrayCallBacks)
+	defer C.CFRelease(C.CFTypeRef(keysToWatch))
 
-		C.CFRunLoopRun()
-	}()
+	C.SCDynamicStoreSetNotificationKeys(store, keysToWatch, C.CFArrayRef(unsafe.Pointer(nil)))
+	return store
+}
+
+// runStore delivers notifications for store on the current thread's run
+// loop until the run loop exits.
+func runStore(store C.SCDynamicStoreRef) {
+	defer C.CFRelease(C.CFTypeRef(store))
+
+	runLoopSource := C.SCDynamicStoreCreateRunLoopSource(C.kCFAllocatorDefault, store, 0)
+	C.CFRunLoopAddSource(C.CFRunLoopGetCurrent(), runLoopSource, C.kCFRunLoopDefaultMode)
+	defer C.CFRelease(C.CFTypeRef(runLoopSource))
 
-	return notificationChannel
+	C.CFRunLoopRun()
 }
@@ -1,6 +1,13 @@
 package engine
 
-import "github.com/peterneutron/powerkit-go/pkg/powerkit"
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/peterneutron/powerkit-go/pkg/powerkit"
+)
 
 type ChargingDecision int
 
@@ -10,7 +17,16 @@ const (
 	ChargingDisable
 )
 
+// DecideCharging compares charge with the limit. A limit of 100 only ever
+// enables charging: macOS already stops at full, and disabling at 100% just
+// toggles the SMC each time the charge dips to 99%.
 func DecideCharging(charge, limit int, smcChargingEnabled bool) ChargingDecision {
+	if limit >= 100 {
+		if !smcChargingEnabled {
+			return ChargingEnable
+		}
+		return ChargingNoop
+	}
 	if charge >= limit && smcChargingEnabled {
 		return ChargingDisable
 	}
@@ -20,6 +36,314 @@ func DecideCharging(charge, limit int, smcChargingEnabled bool) ChargingDecision
 	return ChargingNoop
 }
 
+// DischargeHoldsCharging reports whether the adapter is off on purpose, for
+// a force discharge or a discharge band, so re-enabling charging would only
+// contradict it. An adapter turned off by something else does not count.
+func DischargeHoldsCharging(adapterEnabled, forceDischarge, bandDischarging bool) bool {
+	return !adapterEnabled && (forceDischarge || bandDischarging)
+}
+
+// LimitEnforced reports whether the limit itself is holding charging off:
+// the adapter is connected, SMC charging is disabled and charge has reached
+// a limit below 100. Charging disabled for any other reason, or a limit that
+// charge has not reached yet, does not count.
+func LimitEnforced(charge, limit int, connected, smcChargingEnabled bool) bool {
+	return connected && !smcChargingEnabled && limit < 100 && charge >= limit
+}
+
+// ExternalHoldInput describes the state used to spot something outside
+// PowerGrid holding charge below the limit. The cause is not visible here: it
+// is often Optimized Battery Charging, but thermal limits, heavy load or a slow
+// adapter look the same.
+type ExternalHoldInput struct {
+	IsConnected        bool
+	IsCharging         bool
+	FullyCharged       bool
+	Charge             int
+	Limit              int
+	SMCChargingEnabled bool
+	SMCAdapterEnabled  bool
+}
+
+// IsExternalChargeHold reports whether charging is permitted by the SMC and the
+// battery is below the limit, yet it is not charging.
+func IsExternalChargeHold(in ExternalHoldInput) bool {
+	if !in.IsConnected || in.IsCharging || in.FullyCharged {
+		return false
+	}
+	if !in.SMCChargingEnabled || !in.SMCAdapterEnabled {
+		return false
+	}
+	return in.Charge < in.Limit && in.Charge < 100
+}
+
+// AdapterPerformanceInput compares measured adapter input power against the
+// adapter's advertised rating.
+type AdapterPerformanceInput struct {
+	IsConnected        bool
+	IsCharging         bool
+	TelemetryAvailable bool
+	RatedWatts         int
+	MeasuredWatts      float64
+	MinPercent         int
+}
+
+// IsAdapterUnderperforming reports whether the adapter delivers less than
+// MinPercent of its rating while the battery is charging. Outside of charging
+// the draw follows system load, so a low reading says nothing about the
+// cable or port. A MinPercent of zero disables the check.
+func IsAdapterUnderperforming(in AdapterPerformanceInput) bool {
+	if in.MinPercent <= 0 || in.RatedWatts <= 0 {
+		return false
+	}
+	if !in.IsConnected || !in.IsCharging || !in.TelemetryAvailable {
+		return false
+	}
+	return in.MeasuredWatts*100 < float64(in.RatedWatts*in.MinPercent)
+}
+
+// AdapterKey identifies an adapter by its reported description and rating.
+// IOKit exposes no adapter serial, so two identical chargers share a key.
+// It returns "" when no adapter is present.
+func AdapterKey(description string, maxWatts int) string {
+	if maxWatts <= 0 {
+		return ""
+	}
+	description = strings.TrimSpace(description)
+	if description == "" {
+		description = "adapter"
+	}
+	return fmt.Sprintf("%s %dW", description, maxWatts)
+}
+
+// SelectChargeLimit returns the limit mapped to adapterKey and the key that
+// matched, or baseLimit and "" for unknown adapters and on battery.
+func SelectChargeLimit(baseLimit int, adapterKey string, adapterLimits map[string]int) (int, string) {
+	if adapterKey == "" {
+		return baseLimit, ""
+	}
+	if limit, ok := adapterLimits[adapterKey]; ok {
+		return limit, adapterKey
+	}
+	return baseLimit, ""
+}
+
+// IsAdapterTooWeak reports whether a connected adapter is rated below
+// minWatts, in which case the battery is not charged so the adapter can carry
+// the system. An unknown rating or a minWatts of zero never blocks charging.
+func IsAdapterTooWeak(connected bool, maxWatts, minWatts int) bool {
+	return minWatts > 0 && connected && maxWatts > 0 && maxWatts < minWatts
+}
+
+// BatteryPresent reports whether IOKit describes a usable battery. Desktops
+// and portables with the battery disconnected for service report an all-zero
+// battery, which must not drive charging decisions.
+func BatteryPresent(b powerkit.IOKitBattery) bool {
+	if b.CurrentCharge < 0 || b.CurrentCharge > 100 {
+		return false
+	}
+	return b.DesignCapacity > 0 || b.Voltage > 0
+}
+
+// ShouldStopForceDischarge reports whether a user-requested forced discharge
+// has drained the battery to the safety floor. A floor of zero disables the
+// stop.
+func ShouldStopForceDischarge(requested bool, charge, floor int) bool {
+	return requested && floor > 0 && charge <= floor
+}
+
+const (
+	// HighLoadPerCPU is the one-minute load average per CPU above which
+	// non-urgent charging transitions may be deferred.
+	HighLoadPerCPU = 1.0
+	// deferWithinPercent bounds how close to the limit an enable must be to
+	// count as non-urgent.
+	deferWithinPercent = 5
+	// deferMinCharge is the charge at or below which an enable is always
+	// urgent.
+	deferMinCharge = 20
+)
+
+// ShouldDeferEnable reports whether re-enabling charging can wait for a
+// quieter moment: the system is busy and the battery is only a few percent
+// under the limit. Low-battery enables are never deferred.
+func ShouldDeferEnable(charge, limit int, loadPerCPU float64) bool {
+	return loadPerCPU >= HighLoadPerCPU && charge > deferMinCharge && charge >= limit-deferWithinPercent
+}
+
+// ChargedAtLimit reports whether a connected battery has reached the charge
+// it is being held to, so UIs can show it as charged below 100%.
+func ChargedAtLimit(connected, fullyCharged bool, charge, limit int) bool {
+	return connected && (fullyCharged || charge >= limit)
+}
+
+// BelowSleepFloor reports whether charge is too low to sleep with charging
+// disabled. An unknown charge (negative) or a floor of zero never blocks the
+// pre-sleep disable.
+func BelowSleepFloor(charge, floor int) bool {
+	return floor > 0 && charge >= 0 && charge < floor
+}
+
+type BatteryFlow int
+
+const (
+	BatteryIdle BatteryFlow = iota
+	BatteryCharging
+	BatteryDischarging
+)
+
+// RampLimit moves a charge limit linearly from from to to over duration,
+// truncating toward from so the target is only reached when the ramp ends.
+func RampLimit(from, to int, elapsed, duration time.Duration) int {
+	if duration <= 0 || elapsed >= duration || from == to {
+		return to
+	}
+	if elapsed <= 0 {
+		return from
+	}
+	return from + int(float64(to-from)*float64(elapsed)/float64(duration))
+}
+
+// batteryIdleWatts is the battery power below which readings count as
+// sensor noise rather than charging or discharging.
+const batteryIdleWatts = 0.5
+
+// DecideBatteryFlow classifies battery power, positive into the battery. A
+// small positive reading while the SMC has charging disabled is balancing or
+// noise, not charging.
+func DecideBatteryFlow(watts float64, smcChargingEnabled bool) BatteryFlow {
+	switch {
+	case watts <= -batteryIdleWatts:
+		return BatteryDischarging
+	case watts >= batteryIdleWatts && smcChargingEnabled:
+		return BatteryCharging
+	default:
+		return BatteryIdle
+	}
+}
+
+// batteryIdleAmps is the battery current below which readings count as
+// sensor noise.
+const batteryIdleAmps = 0.05
+
+// CurrentIntoBattery reports whether current is measurably flowing into the
+// battery, whatever the SMC enable flag or IOKit IsCharging claim.
+func CurrentIntoBattery(amps float64) bool {
+	return amps >= batteryIdleAmps
+}
+
+// ChargeSample is one reading for charging-stall detection. Expecting is set
+// when the battery should be gaining charge: connected, charging and the
+// adapter enabled, below the target and not held outside PowerGrid.
+type ChargeSample struct {
+	At        time.Time
+	Charge    int
+	Expecting bool
+}
+
+const (
+	// StallWindow is how long charge must stay flat while charging is
+	// expected before it counts as stalled.
+	StallWindow = 10 * time.Minute
+	// stallMaxCharge excludes the slow top-off, where the percentage can
+	// legitimately sit still for a long time.
+	stallMaxCharge = 95
+)
+
+// IsChargingStalled reports whether charging was expected in every sample
+// spanning the last window, yet the newest charge is no higher than the
+// charge at the start of it. Samples are oldest first.
+func IsChargingStalled(samples []ChargeSample, window time.Duration) bool {
+	if len(samples) == 0 {
+		return false
+	}
+	latest := samples[len(samples)-1]
+	if latest.Charge >= stallMaxCharge {
+		return false
+	}
+	for i := len(samples) - 1; i >= 0; i-- {
+		if !samples[i].Expecting {
+			return false
+		}
+		if latest.At.Sub(samples[i].At) >= window {
+			return latest.Charge <= samples[i].Charge
+		}
+	}
+	return false
+}
+
+type PauseReason int
+
+const (
+	PauseNone PauseReason = iota
+	PauseAtLimit
+	PauseForceDischarge
+	PauseBeforeSleep
+	PauseExternalHold
+	PauseWeakAdapter
+	PauseManual
+)
+
+func (r PauseReason) String() string {
+	switch r {
+	case PauseAtLimit:
+		return "at_limit"
+	case PauseForceDischarge:
+		return "force_discharge"
+	case PauseBeforeSleep:
+		return "before_sleep"
+	case PauseExternalHold:
+		return "external_hold"
+	case PauseWeakAdapter:
+		return "weak_adapter"
+	case PauseManual:
+		return "manual"
+	default:
+		return "none"
+	}
+}
+
+// PauseInput is the post-decision state used to explain why a connected Mac
+// is not charging.
+type PauseInput struct {
+	IsConnected     bool
+	IsCharging      bool
+	FullyCharged    bool
+	ForceDischarge  bool
+	ManualOff       bool
+	WeakAdapter     bool
+	SleepTransition bool
+	ExternalHold    bool
+	Charge          int
+	Limit           int
+}
+
+// DecidePauseReason picks the single most specific reason charging is held
+// off. The limit check ignores IsCharging because the snapshot predates the
+// write that just disabled charging.
+func DecidePauseReason(in PauseInput) PauseReason {
+	switch {
+	case !in.IsConnected:
+		return PauseNone
+	case in.ForceDischarge:
+		return PauseForceDischarge
+	case in.ManualOff:
+		return PauseManual
+	case in.WeakAdapter:
+		return PauseWeakAdapter
+	case in.Limit < 100 && in.Charge >= in.Limit:
+		return PauseAtLimit
+	case in.SleepTransition:
+		return PauseBeforeSleep
+	case in.IsCharging || in.FullyCharged:
+		return PauseNone
+	case in.ExternalHold:
+		return PauseExternalHold
+	default:
+		return PauseNone
+	}
+}
+
 type LEDInput struct {
 	AdapterPresent     bool
 	Charge             int
@@ -28,6 +352,9 @@ type LEDInput struct {
 	IsConnected        bool
 	SMCChargingEnabled bool
 	ForceDischarge     bool
+	// GreenOnlyWhenFull keeps the LED amber below 100% when charging is
+	// held at a lower limit, so green always means a full battery.
+	GreenOnlyWhenFull bool
 }
 
 func DecideMagsafeLED(in LEDInput) (powerkit.MagsafeLEDState, bool) {
@@ -53,6 +380,302 @@ func DecideMagsafeLED(in LEDInput) (powerkit.MagsafeLEDState, bool) {
 		if in.IsCharging && in.SMCChargingEnabled && in.Charge < in.Limit {
 			return powerkit.LEDAmber, true
 		}
+		if in.GreenOnlyWhenFull && in.Charge < 100 {
+			return powerkit.LEDAmber, true
+		}
 		return powerkit.LEDGreen, true
 	}
 }
+
+// magsafeLEDFallbacks lists what to show instead of a state the firmware
+// ignores; states without an entry fall back to system control.
+var magsafeLEDFallbacks = map[powerkit.MagsafeLEDState]powerkit.MagsafeLEDState{
+	powerkit.LEDErrorPermSlow: powerkit.LEDAmber,
+}
+
+// MagsafeLEDFallback returns target, or the nearest state honored reports as
+// working. System control is always honored.
+func MagsafeLEDFallback(target powerkit.MagsafeLEDState, honored func(powerkit.MagsafeLEDState) bool) powerkit.MagsafeLEDState {
+	for target != powerkit.LEDSystem && !honored(target) {
+		next, ok := magsafeLEDFallbacks[target]
+		if !ok {
+			return powerkit.LEDSystem
+		}
+		target = next
+	}
+	return target
+}
+
+const (
+	// DefaultChargePerPercent is the charge rate assumed for a full-by plan
+	// until charging has been observed; it is on the slow side so the plan
+	// errs toward starting early.
+	DefaultChargePerPercent = 2 * time.Minute
+	// FullByMargin is added to every full-by plan to absorb the slow top-off
+	// above 90%.
+	FullByMargin = 30 * time.Minute
+	// minRateGain is the smallest observed charge gain trusted as a rate.
+	minRateGain = 2
+)
+
+// ChargePerPercent estimates how long one percent takes from the newest run
+// of samples that expected charging, falling back to DefaultChargePerPercent
+// when the run gained too little to measure. Samples are oldest first.
+func ChargePerPercent(samples []ChargeSample) time.Duration {
+	if len(samples) == 0 || !samples[len(samples)-1].Expecting {
+		return DefaultChargePerPercent
+	}
+	latest := samples[len(samples)-1]
+	first := latest
+	for i := len(samples) - 2; i >= 0 && samples[i].Expecting; i-- {
+		first = samples[i]
+	}
+	gain := latest.Charge - first.Charge
+	if gain < minRateGain {
+		return DefaultChargePerPercent
+	}
+	return latest.At.Sub(first.At) / time.Duration(gain)
+}
+
+// FullByStart returns when charging past the limit has to begin for charge
+// to reach target by deadline at perPercent, including FullByMargin.
+func FullByStart(deadline time.Time, charge, target int, perPercent time.Duration) time.Time {
+	if charge >= target {
+		return deadline
+	}
+	return deadline.Add(-time.Duration(target-charge)*perPercent - FullByMargin)
+}
+
+// DecideBandDischarge reports whether the adapter should be off to keep
+// charge inside a discharge band: discharge starts above ceiling and runs
+// down to floor, and between the two the current state holds so the adapter
+// is not toggled around a single threshold. A zero ceiling means no band.
+func DecideBandDischarge(charge, floor, ceiling int, discharging bool) bool {
+	switch {
+	case ceiling == 0:
+		return false
+	case charge > ceiling:
+		return true
+	case charge <= floor:
+		return false
+	default:
+		return discharging
+	}
+}
+
+// CriticalHysteresis is how far above the critical threshold charge must read
+// before a critical state ends, so a reading bouncing across the threshold
+// does not repeat the critical actions.
+const CriticalHysteresis = 2
+
+// InCriticalCharge reports whether the battery is critically low: at or
+// below threshold and not charging. A critical state lasts until charging
+// starts or charge reads more than CriticalHysteresis above threshold. A zero
+// threshold turns the check off.
+func InCriticalCharge(charge, threshold int, charging, critical bool) bool {
+	switch {
+	case threshold <= 0 || charging:
+		return false
+	case charge <= threshold:
+		return true
+	default:
+		return critical && charge <= threshold+CriticalHysteresis
+	}
+}
+
+// EstimateConfidence says how far a smoothed time estimate can be trusted.
+type EstimateConfidence int
+
+const (
+	EstimateNone EstimateConfidence = iota
+	EstimateLow
+	EstimateMedium
+	EstimateHigh
+)
+
+const (
+	// estimateMinSpan is the shortest run of samples that yields an estimate.
+	estimateMinSpan = 2 * time.Minute
+	// estimateSteadySpan is how long a run must cover before its estimate
+	// can be more than low confidence.
+	estimateSteadySpan = 8 * time.Minute
+	// estimateMaxDrift is how far the rates over a run's two halves may
+	// differ, as a fraction of the overall rate, for high confidence.
+	estimateMaxDrift = 0.25
+)
+
+// TimeEstimate is a time projected from the observed charge rate rather
+// than IOKit's instantaneous figure. ToTarget is set while charge is rising
+// below target and ToEmpty while it is falling; both are zero when the rate
+// cannot be measured.
+type TimeEstimate struct {
+	ToTarget   time.Duration
+	ToEmpty    time.Duration
+	Confidence EstimateConfidence
+	Samples    int
+	Span       time.Duration
+}
+
+// EstimateTimes projects the newest run of samples that agree on Expecting,
+// so charging and discharging stretches are never mixed. Confidence is low
+// for short runs or small changes and high only when both halves of a long
+// run moved at about the same rate. Samples are oldest first.
+func EstimateTimes(samples []ChargeSample, target int) TimeEstimate {
+	if len(samples) == 0 {
+		return TimeEstimate{}
+	}
+	latest := samples[len(samples)-1]
+	start := len(samples) - 1
+	for start > 0 && samples[start-1].Expecting == latest.Expecting {
+		start--
+	}
+	run := samples[start:]
+	first := run[0]
+	est := TimeEstimate{Samples: len(run), Span: latest.At.Sub(first.At)}
+	change := latest.Charge - first.Charge
+	if est.Span < estimateMinSpan || change == 0 {
+		return est
+	}
+
+	perPercent := est.Span / time.Duration(absInt(change))
+	switch {
+	case change > 0 && latest.Charge < target:
+		est.ToTarget = time.Duration(target-latest.Charge) * perPercent
+	case change < 0:
+		est.ToEmpty = time.Duration(latest.Charge) * perPercent
+	default:
+		return est
+	}
+
+	est.Confidence = EstimateLow
+	if est.Span < estimateSteadySpan || absInt(change) < minRateGain {
+		return est
+	}
+	est.Confidence = EstimateMedium
+	mid := run[0]
+	for _, s := range run {
+		if s.At.Sub(first.At) > est.Span/2 {
+			break
+		}
+		mid = s
+	}
+	early, late := rateOver(first, mid), rateOver(mid, latest)
+	overall := rateOver(first, latest)
+	if early != 0 && late != 0 && math.Abs(early-late) <= estimateMaxDrift*math.Abs(overall) {
+		est.Confidence = EstimateHigh
+	}
+	return est
+}
+
+// rateOver returns the charge change per minute from a to b.
+func rateOver(a, b ChargeSample) float64 {
+	minutes := b.At.Sub(a.At).Minutes()
+	if minutes <= 0 {
+		return 0
+	}
+	return float64(b.Charge-a.Charge) / minutes
+}
+
+func absInt(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// sessionMaxGap is the longest interval between power readings that is
+// counted toward a charge session. Longer gaps, such as sleep, are skipped
+// rather than assumed to have run at the last reading's power.
+const sessionMaxGap = 5 * time.Minute
+
+// ChargeSession accumulates battery and adapter energy since the adapter
+// was connected. Only intervals that begin with the battery charging count,
+// so BatteryWh is energy added, and AdapterWh is what the adapter supplied
+// over the same intervals, system load included.
+type ChargeSession struct {
+	Start        time.Time
+	LastAt       time.Time
+	BatteryWatts float64 // Latest reading; positive while charging
+	AdapterWatts float64
+	BatteryWh    float64
+	AdapterWh    float64
+	PeakWatts    float64
+	ChargingFor  time.Duration
+	Connected    bool
+}
+
+// AddSessionReading advances a session to a new power reading. The interval
+// since the previous reading is counted at the previous reading's power,
+// and only when the battery was charging and the gap is under
+// sessionMaxGap.
+func AddSessionReading(session ChargeSession, at time.Time, batteryWatts, adapterWatts float64) ChargeSession {
+	if gap := at.Sub(session.LastAt); !session.LastAt.IsZero() && gap > 0 && gap <= sessionMaxGap && session.BatteryWatts > 0 {
+		hours := gap.Hours()
+		session.BatteryWh += session.BatteryWatts * hours
+		session.AdapterWh += session.AdapterWatts * hours
+		session.ChargingFor += gap
+	}
+	if batteryWatts > session.PeakWatts {
+		session.PeakWatts = batteryWatts
+	}
+	session.LastAt, session.BatteryWatts, session.AdapterWatts = at, batteryWatts, adapterWatts
+	return session
+}
+
+// AverageWatts is the mean charge power over the time the battery charged.
+func (c ChargeSession) AverageWatts() float64 {
+	if c.ChargingFor <= 0 {
+		return 0
+	}
+	return c.BatteryWh / c.ChargingFor.Hours()
+}
+
+// Efficiency is the share of adapter energy that reached the battery while
+// charging, from 0 to 1. It is 0 until the adapter has supplied energy.
+func (c ChargeSession) Efficiency() float64 {
+	if c.AdapterWh <= 0 {
+		return 0
+	}
+	return math.Min(c.BatteryWh/c.AdapterWh, 1)
+}
+
+// wearMinCycles is the cycle span health samples must cover before a wear
+// rate is estimated; over fewer cycles rounding in the reported capacity
+// outweighs the trend.
+const wearMinCycles = 10
+
+// HealthSample is one daily reading of battery health against cycle count.
+// Health is the maximum capacity as a percentage of design capacity.
+type HealthSample struct {
+	At     time.Time
+	Cycles int
+	Health float64
+}
+
+// WearPer100Cycles fits a least-squares line of health against cycle count
+// and returns the capacity lost per 100 cycles, positive as health falls.
+// It reports false until the samples span wearMinCycles cycles.
+func WearPer100Cycles(samples []HealthSample) (float64, bool) {
+	if len(samples) < 2 {
+		return 0, false
+	}
+	lo, hi := samples[0].Cycles, samples[0].Cycles
+	var sumX, sumY float64
+	for _, s := range samples {
+		lo, hi = min(lo, s.Cycles), max(hi, s.Cycles)
+		sumX += float64(s.Cycles)
+		sumY += s.Health
+	}
+	if hi-lo < wearMinCycles {
+		return 0, false
+	}
+	n := float64(len(samples))
+	meanX, meanY := sumX/n, sumY/n
+	var cov, varX float64
+	for _, s := range samples {
+		dx := float64(s.Cycles) - meanX
+		cov += dx * (s.Health - meanY)
+		varX += dx * dx
+	}
+	return -cov / varX * 100, true
+}
@@ -1,7 +1,9 @@
 package engine
 
 import (
+	"math"
 	"testing"
+	"time"
 
 	"github.com/peterneutron/powerkit-go/pkg/powerkit"
 )
@@ -18,6 +20,8 @@ func TestDecideCharging(t *testing.T) {
 		{name: "enable below limit when charging disabled", charge: 79, limit: 80, smcChargingEnabled: false, want: ChargingEnable},
 		{name: "noop below limit when charging enabled", charge: 79, limit: 80, smcChargingEnabled: true, want: ChargingNoop},
 		{name: "noop above limit when charging disabled", charge: 90, limit: 80, smcChargingEnabled: false, want: ChargingNoop},
+		{name: "noop at full with a 100 limit", charge: 100, limit: 100, smcChargingEnabled: true, want: ChargingNoop},
+		{name: "enable at full with a 100 limit when charging disabled", charge: 100, limit: 100, smcChargingEnabled: false, want: ChargingEnable},
 	}
 
 	for _, tc := range tests {
@@ -30,6 +34,359 @@ func TestDecideCharging(t *testing.T) {
 	}
 }
 
+func TestIsExternalChargeHold(t *testing.T) {
+	held := ExternalHoldInput{
+		IsConnected:        true,
+		Charge:             70,
+		Limit:              80,
+		SMCChargingEnabled: true,
+		SMCAdapterEnabled:  true,
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(*ExternalHoldInput)
+		want   bool
+	}{
+		{name: "connected below limit not charging", mutate: func(*ExternalHoldInput) {}, want: true},
+		{name: "actively charging", mutate: func(in *ExternalHoldInput) { in.IsCharging = true }, want: false},
+		{name: "on battery", mutate: func(in *ExternalHoldInput) { in.IsConnected = false }, want: false},
+		{name: "daemon disabled charging", mutate: func(in *ExternalHoldInput) { in.SMCChargingEnabled = false }, want: false},
+		{name: "force discharge", mutate: func(in *ExternalHoldInput) { in.SMCAdapterEnabled = false }, want: false},
+		{name: "at limit", mutate: func(in *ExternalHoldInput) { in.Charge = 80 }, want: false},
+		{name: "fully charged", mutate: func(in *ExternalHoldInput) { in.FullyCharged = true }, want: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			in := held
+			tc.mutate(&in)
+			if got := IsExternalChargeHold(in); got != tc.want {
+				t.Fatalf("unexpected hold detection: got=%v want=%v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestIsAdapterUnderperforming(t *testing.T) {
+	weak := AdapterPerformanceInput{
+		IsConnected:        true,
+		IsCharging:         true,
+		TelemetryAvailable: true,
+		RatedWatts:         96,
+		MeasuredWatts:      45,
+		MinPercent:         50,
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(*AdapterPerformanceInput)
+		want   bool
+	}{
+		{name: "charging well below rating", mutate: func(*AdapterPerformanceInput) {}, want: true},
+		{name: "charging near rating", mutate: func(in *AdapterPerformanceInput) { in.MeasuredWatts = 90 }, want: false},
+		{name: "exactly at threshold", mutate: func(in *AdapterPerformanceInput) { in.MeasuredWatts = 48 }, want: false},
+		{name: "not charging", mutate: func(in *AdapterPerformanceInput) { in.IsCharging = false }, want: false},
+		{name: "no telemetry", mutate: func(in *AdapterPerformanceInput) { in.TelemetryAvailable = false }, want: false},
+		{name: "unknown rating", mutate: func(in *AdapterPerformanceInput) { in.RatedWatts = 0 }, want: false},
+		{name: "check disabled", mutate: func(in *AdapterPerformanceInput) { in.MinPercent = 0 }, want: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			in := weak
+			tc.mutate(&in)
+			if got := IsAdapterUnderperforming(in); got != tc.want {
+				t.Fatalf("unexpected underperforming flag: got=%v want=%v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSelectChargeLimit(t *testing.T) {
+	limits := map[string]int{"pd charger 96W": 100, "travel 30W": 80}
+
+	tests := []struct {
+		name      string
+		key       string
+		wantLimit int
+		wantKey   string
+	}{
+		{name: "mapped adapter", key: AdapterKey(" pd charger ", 96), wantLimit: 100, wantKey: "pd charger 96W"},
+		{name: "unknown adapter", key: AdapterKey("pd charger", 140), wantLimit: 70, wantKey: ""},
+		{name: "on battery", key: AdapterKey("", 0), wantLimit: 70, wantKey: ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			limit, key := SelectChargeLimit(70, tc.key, limits)
+			if limit != tc.wantLimit || key != tc.wantKey {
+				t.Fatalf("unexpected selection: got=(%d,%q) want=(%d,%q)", limit, key, tc.wantLimit, tc.wantKey)
+			}
+		})
+	}
+}
+
+func TestIsAdapterTooWeak(t *testing.T) {
+	tests := []struct {
+		name      string
+		connected bool
+		maxWatts  int
+		minWatts  int
+		want      bool
+	}{
+		{name: "below minimum", connected: true, maxWatts: 30, minWatts: 45, want: true},
+		{name: "at minimum", connected: true, maxWatts: 45, minWatts: 45, want: false},
+		{name: "policy off", connected: true, maxWatts: 30, want: false},
+		{name: "unknown rating", connected: true, minWatts: 45, want: false},
+		{name: "on battery", maxWatts: 30, minWatts: 45, want: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := IsAdapterTooWeak(tc.connected, tc.maxWatts, tc.minWatts); got != tc.want {
+				t.Fatalf("unexpected weak adapter flag: got=%v want=%v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestBatteryPresent(t *testing.T) {
+	tests := []struct {
+		name    string
+		battery powerkit.IOKitBattery
+		want    bool
+	}{
+		{name: "normal battery", battery: powerkit.IOKitBattery{CurrentCharge: 80, DesignCapacity: 5000, Voltage: 12.4}, want: true},
+		{name: "capacity only", battery: powerkit.IOKitBattery{CurrentCharge: 0, DesignCapacity: 5000}, want: true},
+		{name: "all zero", battery: powerkit.IOKitBattery{}, want: false},
+		{name: "charge out of range", battery: powerkit.IOKitBattery{CurrentCharge: 255, DesignCapacity: 5000}, want: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := BatteryPresent(tc.battery); got != tc.want {
+				t.Fatalf("unexpected presence: got=%v want=%v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestShouldStopForceDischarge(t *testing.T) {
+	tests := []struct {
+		name      string
+		requested bool
+		charge    int
+		floor     int
+		want      bool
+	}{
+		{name: "above floor", requested: true, charge: 21, floor: 20, want: false},
+		{name: "at floor", requested: true, charge: 20, floor: 20, want: true},
+		{name: "below floor", requested: true, charge: 12, floor: 20, want: true},
+		{name: "not requested", charge: 12, floor: 20, want: false},
+		{name: "floor disabled", requested: true, charge: 12, want: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := ShouldStopForceDischarge(tc.requested, tc.charge, tc.floor); got != tc.want {
+				t.Fatalf("unexpected stop decision: got=%v want=%v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestShouldDeferEnable(t *testing.T) {
+	tests := []struct {
+		name   string
+		charge int
+		limit  int
+		load   float64
+		want   bool
+	}{
+		{name: "busy near limit", charge: 77, limit: 80, load: 1.5, want: true},
+		{name: "idle near limit", charge: 77, limit: 80, load: 0.4, want: false},
+		{name: "busy far below limit", charge: 60, limit: 80, load: 1.5, want: false},
+		{name: "busy low battery", charge: 20, limit: 60, load: 3, want: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := ShouldDeferEnable(tc.charge, tc.limit, tc.load); got != tc.want {
+				t.Fatalf("unexpected defer decision: got=%v want=%v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDecideBatteryFlow(t *testing.T) {
+	tests := []struct {
+		name        string
+		watts       float64
+		smcCharging bool
+		want        BatteryFlow
+	}{
+		{name: "charging", watts: 30, smcCharging: true, want: BatteryCharging},
+		{name: "discharging", watts: -12, smcCharging: true, want: BatteryDischarging},
+		{name: "discharging with charging disabled", watts: -12, want: BatteryDischarging},
+		{name: "noise", watts: 0.2, smcCharging: true, want: BatteryIdle},
+		{name: "held at limit", watts: 1.5, want: BatteryIdle},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := DecideBatteryFlow(tc.watts, tc.smcCharging); got != tc.want {
+				t.Fatalf("unexpected battery flow: got=%v want=%v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestIsChargingStalled(t *testing.T) {
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	sample := func(minute, charge int, expecting bool) ChargeSample {
+		return ChargeSample{At: start.Add(time.Duration(minute) * time.Minute), Charge: charge, Expecting: expecting}
+	}
+	tests := []struct {
+		name    string
+		samples []ChargeSample
+		want    bool
+	}{
+		{name: "flat for the window", samples: []ChargeSample{sample(0, 50, true), sample(5, 50, true), sample(10, 50, true)}, want: true},
+		{name: "rising", samples: []ChargeSample{sample(0, 50, true), sample(5, 51, true), sample(10, 52, true)}, want: false},
+		{name: "window not yet covered", samples: []ChargeSample{sample(0, 50, true), sample(9, 50, true)}, want: false},
+		{name: "charging not expected mid-window", samples: []ChargeSample{sample(0, 50, true), sample(5, 50, false), sample(10, 50, true)}, want: false},
+		{name: "top-off", samples: []ChargeSample{sample(0, 96, true), sample(10, 96, true)}, want: false},
+		{name: "empty", want: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := IsChargingStalled(tc.samples, StallWindow); got != tc.want {
+				t.Fatalf("unexpected stall decision: got=%v want=%v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestChargedAtLimit(t *testing.T) {
+	tests := []struct {
+		name         string
+		connected    bool
+		fullyCharged bool
+		charge       int
+		limit        int
+		want         bool
+	}{
+		{name: "at limit", connected: true, charge: 80, limit: 80, want: true},
+		{name: "below limit", connected: true, charge: 79, limit: 80, want: false},
+		{name: "full without limit", connected: true, fullyCharged: true, charge: 100, limit: 100, want: true},
+		{name: "on battery", charge: 80, limit: 80, want: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := ChargedAtLimit(tc.connected, tc.fullyCharged, tc.charge, tc.limit); got != tc.want {
+				t.Fatalf("unexpected charged flag: got=%v want=%v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestBelowSleepFloor(t *testing.T) {
+	tests := []struct {
+		name   string
+		charge int
+		floor  int
+		want   bool
+	}{
+		{name: "below floor", charge: 12, floor: 30, want: true},
+		{name: "at floor", charge: 30, floor: 30, want: false},
+		{name: "guard disabled", charge: 12, want: false},
+		{name: "unknown charge", charge: -1, floor: 30, want: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := BelowSleepFloor(tc.charge, tc.floor); got != tc.want {
+				t.Fatalf("unexpected floor check: got=%v want=%v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRampLimit(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to int
+		elapsed  time.Duration
+		duration time.Duration
+		want     int
+	}{
+		{name: "start", from: 100, to: 60, elapsed: 0, duration: time.Hour, want: 100},
+		{name: "halfway", from: 100, to: 60, elapsed: 30 * time.Minute, duration: time.Hour, want: 80},
+		{name: "truncates toward from", from: 100, to: 60, elapsed: 59 * time.Minute, duration: time.Hour, want: 61},
+		{name: "finished", from: 100, to: 60, elapsed: 2 * time.Hour, duration: time.Hour, want: 60},
+		{name: "no ramp", from: 100, to: 60, elapsed: time.Minute, want: 60},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := RampLimit(tc.from, tc.to, tc.elapsed, tc.duration); got != tc.want {
+				t.Fatalf("unexpected ramped limit: got=%v want=%v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCurrentIntoBattery(t *testing.T) {
+	tests := []struct {
+		name string
+		amps float64
+		want bool
+	}{
+		{name: "charging", amps: 2.4, want: true},
+		{name: "trickle", amps: 0.05, want: true},
+		{name: "noise", amps: 0.02, want: false},
+		{name: "discharging", amps: -1.1, want: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := CurrentIntoBattery(tc.amps); got != tc.want {
+				t.Fatalf("unexpected current check: got=%v want=%v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDecidePauseReason(t *testing.T) {
+	tests := []struct {
+		name string
+		in   PauseInput
+		want PauseReason
+	}{
+		{name: "on battery", in: PauseInput{Charge: 80, Limit: 80}, want: PauseNone},
+		{name: "at limit", in: PauseInput{IsConnected: true, IsCharging: true, Charge: 80, Limit: 80}, want: PauseAtLimit},
+		{name: "force discharge wins", in: PauseInput{IsConnected: true, ForceDischarge: true, Charge: 90, Limit: 80}, want: PauseForceDischarge},
+		{name: "limit off at full", in: PauseInput{IsConnected: true, FullyCharged: true, Charge: 100, Limit: 100}, want: PauseNone},
+		{name: "pre-sleep transition", in: PauseInput{IsConnected: true, SleepTransition: true, Charge: 50, Limit: 80}, want: PauseBeforeSleep},
+		{name: "charging below limit", in: PauseInput{IsConnected: true, IsCharging: true, Charge: 50, Limit: 80}, want: PauseNone},
+		{name: "external hold", in: PauseInput{IsConnected: true, ExternalHold: true, Charge: 70, Limit: 80}, want: PauseExternalHold},
+		{name: "weak adapter", in: PauseInput{IsConnected: true, WeakAdapter: true, Charge: 40, Limit: 0}, want: PauseWeakAdapter},
+		{name: "manual off", in: PauseInput{IsConnected: true, ManualOff: true, Charge: 40, Limit: 80}, want: PauseManual},
+		{name: "manual off on battery", in: PauseInput{ManualOff: true, Charge: 40, Limit: 80}, want: PauseNone},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := DecidePauseReason(tc.in); got != tc.want {
+				t.Fatalf("unexpected pause reason: got=%v want=%v", got, tc.want)
+			}
+		})
+	}
+}
+
 func TestDecideMagsafeLED(t *testing.T) {
 	tests := []struct {
 		name string
@@ -73,6 +430,18 @@ func TestDecideMagsafeLED(t *testing.T) {
 			want: powerkit.LEDGreen,
 			ok:   true,
 		},
+		{
+			name: "paused at limit with green reserved for full",
+			in:   LEDInput{AdapterPresent: true, Charge: 80, Limit: 80, GreenOnlyWhenFull: true},
+			want: powerkit.LEDAmber,
+			ok:   true,
+		},
+		{
+			name: "full at a lower limit with green reserved for full",
+			in:   LEDInput{AdapterPresent: true, Charge: 100, Limit: 80, GreenOnlyWhenFull: true},
+			want: powerkit.LEDGreen,
+			ok:   true,
+		},
 	}
 
 	for _, tc := range tests {
@@ -87,3 +456,250 @@ func TestDecideMagsafeLED(t *testing.T) {
 		})
 	}
 }
+
+func TestMagsafeLEDFallback(t *testing.T) {
+	none := map[powerkit.MagsafeLEDState]bool{}
+	tests := []struct {
+		name      string
+		target    powerkit.MagsafeLEDState
+		unhonored map[powerkit.MagsafeLEDState]bool
+		want      powerkit.MagsafeLEDState
+	}{
+		{name: "honored", target: powerkit.LEDGreen, unhonored: none, want: powerkit.LEDGreen},
+		{name: "error degrades to amber", target: powerkit.LEDErrorPermSlow, unhonored: map[powerkit.MagsafeLEDState]bool{powerkit.LEDErrorPermSlow: true}, want: powerkit.LEDAmber},
+		{name: "error and amber degrade to system", target: powerkit.LEDErrorPermSlow, unhonored: map[powerkit.MagsafeLEDState]bool{powerkit.LEDErrorPermSlow: true, powerkit.LEDAmber: true}, want: powerkit.LEDSystem},
+		{name: "green degrades to system", target: powerkit.LEDGreen, unhonored: map[powerkit.MagsafeLEDState]bool{powerkit.LEDGreen: true}, want: powerkit.LEDSystem},
+		{name: "system always honored", target: powerkit.LEDSystem, unhonored: map[powerkit.MagsafeLEDState]bool{powerkit.LEDSystem: true}, want: powerkit.LEDSystem},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := MagsafeLEDFallback(tc.target, func(s powerkit.MagsafeLEDState) bool { return !tc.unhonored[s] })
+			if got != tc.want {
+				t.Fatalf("unexpected LED: got=%v want=%v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestChargePerPercent(t *testing.T) {
+	base := time.Date(2026, 3, 1, 22, 0, 0, 0, time.UTC)
+	sample := func(minutes, charge int, expecting bool) ChargeSample {
+		return ChargeSample{At: base.Add(time.Duration(minutes) * time.Minute), Charge: charge, Expecting: expecting}
+	}
+	tests := []struct {
+		name    string
+		samples []ChargeSample
+		want    time.Duration
+	}{
+		{name: "no samples", want: DefaultChargePerPercent},
+		{name: "not charging", samples: []ChargeSample{sample(0, 50, true), sample(9, 55, false)}, want: DefaultChargePerPercent},
+		{name: "too little gain", samples: []ChargeSample{sample(0, 50, true), sample(9, 51, true)}, want: DefaultChargePerPercent},
+		{name: "observed rate", samples: []ChargeSample{sample(0, 50, true), sample(6, 54, true)}, want: 90 * time.Second},
+		{name: "only newest run", samples: []ChargeSample{sample(0, 40, true), sample(2, 50, false), sample(4, 50, true), sample(10, 53, true)}, want: 2 * time.Minute},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := ChargePerPercent(tc.samples); got != tc.want {
+				t.Fatalf("unexpected rate: got=%v want=%v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFullByStart(t *testing.T) {
+	deadline := time.Date(2026, 3, 2, 7, 0, 0, 0, time.UTC)
+	if got := FullByStart(deadline, 100, 100, time.Minute); !got.Equal(deadline) {
+		t.Fatalf("unexpected start when already full: got=%v want=%v", got, deadline)
+	}
+	want := deadline.Add(-20*2*time.Minute - FullByMargin)
+	if got := FullByStart(deadline, 80, 100, 2*time.Minute); !got.Equal(want) {
+		t.Fatalf("unexpected start: got=%v want=%v", got, want)
+	}
+}
+
+func TestDecideBandDischarge(t *testing.T) {
+	tests := []struct {
+		name        string
+		charge      int
+		discharging bool
+		ceiling     int
+		want        bool
+	}{
+		{name: "no band", charge: 95, ceiling: 0, want: false},
+		{name: "above ceiling starts", charge: 81, ceiling: 80, want: true},
+		{name: "inside band stays idle", charge: 70, ceiling: 80, want: false},
+		{name: "inside band keeps discharging", charge: 70, discharging: true, ceiling: 80, want: true},
+		{name: "floor stops", charge: 60, discharging: true, ceiling: 80, want: false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := DecideBandDischarge(tc.charge, 60, tc.ceiling, tc.discharging); got != tc.want {
+				t.Fatalf("unexpected decision: got=%t want=%t", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDischargeHoldsCharging(t *testing.T) {
+	if !DischargeHoldsCharging(false, true, false) || !DischargeHoldsCharging(false, false, true) {
+		t.Fatal("expected a requested discharge to hold charging off")
+	}
+	if DischargeHoldsCharging(true, true, true) {
+		t.Fatal("expected an enabled adapter not to hold charging off")
+	}
+	if DischargeHoldsCharging(false, false, false) {
+		t.Fatal("expected an adapter turned off elsewhere not to hold charging off")
+	}
+}
+
+func TestLimitEnforced(t *testing.T) {
+	tests := []struct {
+		name       string
+		charge     int
+		limit      int
+		connected  bool
+		smcEnabled bool
+		want       bool
+	}{
+		{name: "held at limit", charge: 80, limit: 80, connected: true, want: true},
+		{name: "below limit", charge: 70, limit: 80, connected: true, want: false},
+		{name: "charging still enabled", charge: 80, limit: 80, connected: true, smcEnabled: true, want: false},
+		{name: "on battery", charge: 85, limit: 80, want: false},
+		{name: "no limit", charge: 100, limit: 100, connected: true, want: false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := LimitEnforced(tc.charge, tc.limit, tc.connected, tc.smcEnabled); got != tc.want {
+				t.Fatalf("unexpected result: got=%t want=%t", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestInCriticalCharge(t *testing.T) {
+	tests := []struct {
+		name     string
+		charge   int
+		charging bool
+		critical bool
+		want     bool
+	}{
+		{name: "above threshold", charge: 15, want: false},
+		{name: "at threshold", charge: 10, want: true},
+		{name: "charging is never critical", charge: 5, charging: true, want: false},
+		{name: "stays critical within hysteresis", charge: 12, critical: true, want: true},
+		{name: "ends past hysteresis", charge: 13, critical: true, want: false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := InCriticalCharge(tc.charge, 10, tc.charging, tc.critical); got != tc.want {
+				t.Fatalf("unexpected result: got=%t want=%t", got, tc.want)
+			}
+		})
+	}
+	if InCriticalCharge(0, 0, false, true) {
+		t.Fatalf("expected a zero threshold to turn the check off")
+	}
+}
+
+func TestEstimateTimes(t *testing.T) {
+	base := time.Date(2026, 3, 1, 22, 0, 0, 0, time.UTC)
+	sample := func(minutes, charge int, expecting bool) ChargeSample {
+		return ChargeSample{At: base.Add(time.Duration(minutes) * time.Minute), Charge: charge, Expecting: expecting}
+	}
+	tests := []struct {
+		name       string
+		samples    []ChargeSample
+		toTarget   time.Duration
+		toEmpty    time.Duration
+		confidence EstimateConfidence
+	}{
+		{name: "no samples"},
+		{name: "too short", samples: []ChargeSample{sample(0, 50, true), sample(1, 51, true)}},
+		{name: "short run", samples: []ChargeSample{sample(0, 50, true), sample(4, 52, true)}, toTarget: 56 * time.Minute, confidence: EstimateLow},
+		{
+			name:       "steady charging",
+			samples:    []ChargeSample{sample(0, 50, true), sample(2, 51, true), sample(4, 52, true), sample(6, 53, true), sample(8, 54, true), sample(10, 55, true)},
+			toTarget:   50 * time.Minute,
+			confidence: EstimateHigh,
+		},
+		{
+			name:       "uneven charging",
+			samples:    []ChargeSample{sample(0, 50, true), sample(5, 50, true), sample(10, 55, true)},
+			toTarget:   50 * time.Minute,
+			confidence: EstimateMedium,
+		},
+		{
+			name:       "steady discharging",
+			samples:    []ChargeSample{sample(0, 60, false), sample(4, 58, false), sample(8, 56, false), sample(10, 55, false)},
+			toEmpty:    110 * time.Minute,
+			confidence: EstimateHigh,
+		},
+		{
+			name:    "only newest run",
+			samples: []ChargeSample{sample(0, 40, true), sample(10, 60, true), sample(11, 60, false)},
+		},
+		{name: "at target", samples: []ChargeSample{sample(0, 78, true), sample(10, 80, true)}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := EstimateTimes(tc.samples, 80)
+			if got.ToTarget != tc.toTarget || got.ToEmpty != tc.toEmpty || got.Confidence != tc.confidence {
+				t.Fatalf("unexpected estimate: got=%+v want to_target=%v to_empty=%v confidence=%v", got, tc.toTarget, tc.toEmpty, tc.confidence)
+			}
+		})
+	}
+}
+
+func TestAddSessionReading(t *testing.T) {
+	start := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	session := ChargeSession{Start: start, Connected: true}
+	session = AddSessionReading(session, start, 60, 75)
+	session = AddSessionReading(session, start.Add(4*time.Minute), 30, 40)
+	// Slept for an hour: the gap is skipped.
+	session = AddSessionReading(session, start.Add(64*time.Minute), 20, 25)
+	// Charging stopped at the limit, so the next interval adds nothing.
+	session = AddSessionReading(session, start.Add(65*time.Minute), 0, 10)
+	session = AddSessionReading(session, start.Add(69*time.Minute), 0, 10)
+
+	if got, want := session.BatteryWh, 4+20.0/60; math.Abs(got-want) > 1e-9 {
+		t.Fatalf("unexpected energy added: got=%v want=%v", got, want)
+	}
+	if got, want := session.ChargingFor, 5*time.Minute; got != want {
+		t.Fatalf("unexpected charging time: got=%v want=%v", got, want)
+	}
+	if session.PeakWatts != 60 {
+		t.Fatalf("unexpected peak power: got=%v want=60", session.PeakWatts)
+	}
+	if got := session.AverageWatts(); math.Abs(got-52) > 1e-9 {
+		t.Fatalf("unexpected average power: got=%v want=52", got)
+	}
+	if got := session.Efficiency(); math.Abs(got-0.8) > 1e-9 {
+		t.Fatalf("unexpected efficiency: got=%v want=0.8", got)
+	}
+	if (ChargeSession{}).Efficiency() != 0 || (ChargeSession{}).AverageWatts() != 0 {
+		t.Fatal("expected an empty session to report zero")
+	}
+}
+
+func TestWearPer100Cycles(t *testing.T) {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	sample := func(day, cycles int, health float64) HealthSample {
+		return HealthSample{At: start.AddDate(0, 0, day), Cycles: cycles, Health: health}
+	}
+
+	if _, ok := WearPer100Cycles([]HealthSample{sample(0, 100, 98)}); ok {
+		t.Fatal("expected no rate from a single sample")
+	}
+	if _, ok := WearPer100Cycles([]HealthSample{sample(0, 100, 98), sample(30, 105, 97.5)}); ok {
+		t.Fatal("expected no rate over fewer than 10 cycles")
+	}
+
+	got, ok := WearPer100Cycles([]HealthSample{sample(0, 100, 98), sample(30, 150, 97), sample(60, 200, 96)})
+	if !ok || math.Abs(got-2) > 1e-9 {
+		t.Fatalf("unexpected wear rate: got=%v ok=%t want=2", got, ok)
+	}
+}
@@ -3,6 +3,7 @@ package ipc
 import (
 	"context"
 	"fmt"
+	"net"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -29,6 +30,29 @@ func AuthUnaryInterceptor(activeUID ActiveUIDProvider) grpc.UnaryServerIntercept
 	}
 }
 
+// AuthStreamInterceptor applies the same caller checks as
+// AuthUnaryInterceptor to streaming RPCs such as health Watch.
+func AuthStreamInterceptor(activeUID ActiveUIDProvider) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		uid, err := callerUIDFromContext(ss.Context())
+		if err != nil {
+			return status.Error(codes.PermissionDenied, err.Error())
+		}
+
+		if !isAuthorized(uid, info.FullMethod, activeUID) {
+			return status.Errorf(codes.PermissionDenied, "unauthorized caller uid=%d for method=%s", uid, info.FullMethod)
+		}
+
+		return handler(srv, ss)
+	}
+}
+
+// UIDAddr is a peer address that carries the connecting process's uid.
+type UIDAddr interface {
+	net.Addr
+	UID() uint32
+}
+
 func callerUIDFromContext(ctx context.Context) (uint32, error) {
 	p, ok := peer.FromContext(ctx)
 	if !ok || p.Addr == nil {
@@ -43,6 +67,18 @@ func callerUIDFromContext(ctx context.Context) (uint32, error) {
 	return addr.UID(), nil
 }
 
+// CallerUID returns the peer uid of a gRPC call, for handlers that restrict
+// one operation of an RPC the allowlist otherwise opens to the console user.
+func CallerUID(ctx context.Context) (uint32, error) {
+	return callerUIDFromContext(ctx)
+}
+
+// Authorized applies the RPC allowlist to endpoints outside gRPC, such as
+// the text control socket, using the gRPC method they stand in for.
+func Authorized(uid uint32, fullMethod string, activeUID ActiveUIDProvider) bool {
+	return isAuthorized(uid, fullMethod, activeUID)
+}
+
 func isAuthorized(uid uint32, fullMethod string, activeUID ActiveUIDProvider) bool {
 	if uid == 0 {
 		return true
@@ -54,7 +90,14 @@ func isAuthorized(uid uint32, fullMethod string, activeUID ActiveUIDProvider) bo
 	}
 
 	switch fullMethod {
-	case "/rpc.PowerGrid/GetStatus", "/rpc.PowerGrid/GetVersion", "/rpc.PowerGrid/GetDaemonInfo", "/rpc.PowerGrid/ApplyMutation":
+	case "/rpc.PowerGrid/GetStatus", "/rpc.PowerGrid/GetVersion", "/rpc.PowerGrid/GetDaemonInfo", "/rpc.PowerGrid/ApplyMutation",
+		"/rpc.PowerGrid/GetAdapterDetails", "/rpc.PowerGrid/ListProfiles",
+		"/rpc.PowerGrid/GetEffectiveSettings", "/rpc.PowerGrid/GetLogs", "/rpc.PowerGrid/Refresh",
+		"/rpc.PowerGrid/GetSupportBundle", "/rpc.PowerGrid/GetCounters", "/rpc.PowerGrid/GetTimeEstimates",
+		"/rpc.PowerGrid/GetSessionStats", "/rpc.PowerGrid/GetWearTrend",
+		"/grpc.health.v1.Health/Check", "/grpc.health.v1.Health/Watch", "/grpc.health.v1.Health/List",
+		"/grpc.reflection.v1.ServerReflection/ServerReflectionInfo",
+		"/grpc.reflection.v1alpha.ServerReflection/ServerReflectionInfo":
 		return uid == current
 	default:
 		return false
@@ -46,12 +46,63 @@ func TestIsAuthorized(t *testing.T) {
 	if !isAuthorized(502, "/rpc.PowerGrid/GetDaemonInfo", active) {
 		t.Fatal("active user should be authorized for daemon info")
 	}
+	if !isAuthorized(502, "/rpc.PowerGrid/GetAdapterDetails", active) {
+		t.Fatal("active user should be authorized for adapter details")
+	}
+	if !isAuthorized(502, "/rpc.PowerGrid/ListProfiles", active) {
+		t.Fatal("active user should be authorized for profile listing")
+	}
+	if !isAuthorized(502, "/rpc.PowerGrid/GetEffectiveSettings", active) {
+		t.Fatal("active user should be authorized for effective settings")
+	}
+	if !isAuthorized(502, "/rpc.PowerGrid/GetLogs", active) {
+		t.Fatal("active user should be authorized for daemon logs")
+	}
+	if !isAuthorized(502, "/rpc.PowerGrid/GetCounters", active) {
+		t.Fatal("active user should be authorized for counters")
+	}
+	if !isAuthorized(502, "/rpc.PowerGrid/GetSupportBundle", active) {
+		t.Fatal("active user should be authorized for support bundle")
+	}
+	if !isAuthorized(502, "/rpc.PowerGrid/Refresh", active) {
+		t.Fatal("active user should be authorized for refresh")
+	}
+	if !isAuthorized(502, "/grpc.health.v1.Health/Check", active) {
+		t.Fatal("active user should be authorized for health checks")
+	}
+	if !isAuthorized(502, "/grpc.reflection.v1.ServerReflection/ServerReflectionInfo", active) {
+		t.Fatal("active user should be authorized for server reflection")
+	}
+	if isAuthorized(503, "/grpc.health.v1.Health/Watch", active) {
+		t.Fatal("non-active non-root caller should not be authorized for health watch")
+	}
 	if !isAuthorized(502, "/rpc.PowerGrid/ApplyMutation", active) {
 		t.Fatal("active user should be authorized for mutating calls")
 	}
 	if isAuthorized(503, "/rpc.PowerGrid/ApplyMutation", active) {
 		t.Fatal("non-active non-root caller should not be authorized")
 	}
+	if isAuthorized(502, "/rpc.PowerGrid/ReadSMCKeys", active) {
+		t.Fatal("raw SMC reads should be limited to root")
+	}
+	if !isAuthorized(0, "/rpc.PowerGrid/ReadSMCKeys", active) {
+		t.Fatal("root caller should be authorized for raw SMC reads")
+	}
+	if !isAuthorized(502, "/rpc.PowerGrid/GetTimeEstimates", active) {
+		t.Fatal("active user should be authorized for time estimates")
+	}
+	if !isAuthorized(502, "/rpc.PowerGrid/GetSessionStats", active) {
+		t.Fatal("active user should be authorized for charge session stats")
+	}
+	if !isAuthorized(502, "/rpc.PowerGrid/GetWearTrend", active) {
+		t.Fatal("active user should be authorized for the wear trend")
+	}
+	if isAuthorized(502, "/rpc.PowerGrid/ListUserSettings", active) {
+		t.Fatal("other users' settings should be limited to root")
+	}
+	if !isAuthorized(0, "/rpc.PowerGrid/ListUserSettings", active) {
+		t.Fatal("root caller should be authorized to list user settings")
+	}
 	if isAuthorized(502, "/rpc.PowerGrid/Unknown", active) {
 		t.Fatal("unknown method should not be authorized")
 	}
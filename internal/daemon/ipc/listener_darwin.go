@@ -3,22 +3,33 @@
 package ipc
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
 	"net"
 	"os"
+	"path/filepath"
 	"syscall"
+	"time"
 
 	"golang.org/x/sys/unix"
 )
 
 const (
 	SocketMode os.FileMode = 0o660
+
+	// staleProbeTimeout bounds the connect test that tells a live socket
+	// from one left behind by a process that died.
+	staleProbeTimeout = 500 * time.Millisecond
 )
 
-type UIDAddr interface {
-	net.Addr
-	UID() uint32
-}
+// ErrSocketInUse reports that another process is still accepting
+// connections on the socket, e.g. a second daemon instance.
+var ErrSocketInUse = errors.New("socket is in use by a running process")
+
+// ErrUnexpectedSocketFile reports that the socket path holds something the
+// daemon would not have created, so it refuses to remove it.
+var ErrUnexpectedSocketFile = errors.New("refusing to replace unexpected file")
 
 type peerCredAddr struct {
 	base net.Addr
@@ -117,23 +128,37 @@ func PrepareSecureSocket(path string) error {
 	}
 
 	if fi.Mode()&os.ModeSocket == 0 {
-		return fmt.Errorf("refusing to remove non-socket at %s", path)
+		return fmt.Errorf("%w: %s is not a socket", ErrUnexpectedSocketFile, path)
 	}
 
 	st, ok := fi.Sys().(*syscall.Stat_t)
 	if !ok {
 		return fmt.Errorf("failed to inspect socket ownership for %s", path)
 	}
-	if st.Uid != 0 {
-		return fmt.Errorf("refusing to remove socket with unexpected owner uid=%d at %s", st.Uid, path)
+	if st.Uid != uint32(os.Geteuid()) {
+		return fmt.Errorf("%w: socket %s is owned by uid=%d", ErrUnexpectedSocketFile, path, st.Uid)
 	}
 	if fi.Mode().Perm() != SocketMode {
-		return fmt.Errorf("refusing to remove socket with unexpected permissions %o at %s", fi.Mode().Perm(), path)
+		return fmt.Errorf("%w: socket %s has permissions %o", ErrUnexpectedSocketFile, path, fi.Mode().Perm())
+	}
+	if socketInUse(path) {
+		return fmt.Errorf("%w: %s", ErrSocketInUse, path)
 	}
 
 	return os.Remove(path)
 }
 
+// socketInUse reports whether something accepts connections on path. A
+// socket file whose owner has exited refuses the connection.
+func socketInUse(path string) bool {
+	conn, err := net.DialTimeout("unix", path, staleProbeTimeout)
+	if err != nil {
+		return false
+	}
+	_ = conn.Close()
+	return true
+}
+
 func Listen(path string) (net.Listener, error) {
 	if err := PrepareSecureSocket(path); err != nil {
 		return nil, err
@@ -144,9 +169,12 @@ func Listen(path string) (net.Listener, error) {
 		return nil, err
 	}
 
-	if err := os.Chown(path, 0, 0); err != nil {
-		_ = lis.Close()
-		return nil, err
+	// A daemon run without root for tests keeps the socket as its own user.
+	if os.Geteuid() == 0 {
+		if err := os.Chown(path, 0, 0); err != nil {
+			_ = lis.Close()
+			return nil, err
+		}
 	}
 	if err := os.Chmod(path, SocketMode); err != nil {
 		_ = lis.Close()
@@ -162,10 +190,31 @@ func Listen(path string) (net.Listener, error) {
 	return &secureUnixListener{base: unixLis}, nil
 }
 
-// SetSocketGroupAccess updates the socket group while preserving root ownership and mode.
-// This allows the active console user's primary group to open the socket.
+// ListenHint explains a Listen failure on path and how to fix it, for the
+// fault logged before the daemon exits.
+func ListenHint(path string, err error) string {
+	dir := filepath.Dir(path)
+	switch {
+	case errors.Is(err, ErrSocketInUse):
+		return "Another process, most likely a second PowerGrid daemon, is serving this socket; stop it before starting this one."
+	case errors.Is(err, ErrUnexpectedSocketFile):
+		return fmt.Sprintf("Something other than a stale PowerGrid socket is at %s; inspect it and remove it if it is left over.", path)
+	case errors.Is(err, syscall.EROFS):
+		return fmt.Sprintf("%s is on a read-only file system; the socket needs a writable directory.", dir)
+	case errors.Is(err, fs.ErrNotExist):
+		return fmt.Sprintf("%s does not exist; create it or use a socket path in an existing directory.", dir)
+	case errors.Is(err, fs.ErrPermission):
+		return fmt.Sprintf("No permission to create or own the socket in %s; the daemon must run as root.", dir)
+	default:
+		return "Check that the socket directory exists and is writable by root."
+	}
+}
+
+// SetSocketGroupAccess updates the socket group while preserving the
+// daemon's ownership and mode. This allows the active console user's primary
+// group to open the socket.
 func SetSocketGroupAccess(path string, gid uint32) error {
-	if err := os.Chown(path, 0, int(gid)); err != nil {
+	if err := os.Chown(path, os.Geteuid(), int(gid)); err != nil {
 		return err
 	}
 	if err := os.Chmod(path, SocketMode); err != nil {
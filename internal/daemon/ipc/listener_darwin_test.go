@@ -0,0 +1,50 @@
+//go:build darwin
+
+package ipc
+
+import (
+	"net"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSocketInUseDistinguishesLiveFromStale(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.sock")
+	lis, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	lis.(*net.UnixListener).SetUnlinkOnClose(false)
+
+	if !socketInUse(path) {
+		t.Fatal("expected a listening socket to be reported in use")
+	}
+	if err := lis.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	if socketInUse(path) {
+		t.Fatal("expected a socket with no listener to be reported stale")
+	}
+}
+
+func TestListenHintNamesTheCause(t *testing.T) {
+	missing := filepath.Join(t.TempDir(), "missing", "test.sock")
+	_, err := Listen(missing)
+	if err == nil {
+		t.Fatal("expected listening in a missing directory to fail")
+	}
+	if hint := ListenHint(missing, err); !strings.Contains(hint, "does not exist") {
+		t.Fatalf("unexpected hint for a missing directory: %q", hint)
+	}
+
+	path := filepath.Join(t.TempDir(), "test.sock")
+	lis, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer lis.Close()
+	if hint := ListenHint(path, PrepareSecureSocket(path)); !strings.Contains(hint, "not a stale PowerGrid socket") {
+		t.Fatalf("unexpected hint for a socket with the wrong mode: %q", hint)
+	}
+}
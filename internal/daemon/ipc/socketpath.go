@@ -0,0 +1,35 @@
+package ipc
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultSocketPath is where the daemon serves gRPC unless told otherwise.
+const DefaultSocketPath = "/var/run/powergrid.sock"
+
+// SocketPathEnv moves the socket for tests and sandboxed runs. A --socket
+// flag takes precedence over it.
+const SocketPathEnv = "POWERGRID_SOCKET"
+
+// textSocketName is the text control socket, kept beside the gRPC socket.
+const textSocketName = "powergrid-ctl.sock"
+
+// ResolveSocketPath returns flagValue when set, then SocketPathEnv, then
+// DefaultSocketPath.
+func ResolveSocketPath(flagValue string) string {
+	if path := strings.TrimSpace(flagValue); path != "" {
+		return path
+	}
+	if path := strings.TrimSpace(os.Getenv(SocketPathEnv)); path != "" {
+		return path
+	}
+	return DefaultSocketPath
+}
+
+// TextSocketPath returns the text control socket in the same directory as
+// socket.
+func TextSocketPath(socket string) string {
+	return filepath.Join(filepath.Dir(socket), textSocketName)
+}
@@ -0,0 +1,17 @@
+package ipc
+
+import "testing"
+
+func TestResolveSocketPath(t *testing.T) {
+	t.Setenv(SocketPathEnv, "")
+	if got := ResolveSocketPath(""); got != DefaultSocketPath {
+		t.Fatalf("expected the default socket, got %q", got)
+	}
+	t.Setenv(SocketPathEnv, "/tmp/env.sock")
+	if got := ResolveSocketPath(""); got != "/tmp/env.sock" {
+		t.Fatalf("expected the environment socket, got %q", got)
+	}
+	if got := ResolveSocketPath("/tmp/flag.sock"); got != "/tmp/flag.sock" {
+		t.Fatalf("expected the flag to win, got %q", got)
+	}
+}
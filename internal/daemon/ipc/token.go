@@ -0,0 +1,97 @@
+package ipc
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"syscall"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// MutationTokenPath holds an optional shared secret that callers must
+// present in the authorization metadata of mutating RPCs. It must be owned
+// by root and not readable by group or others.
+const MutationTokenPath = "/etc/powergrid/token"
+
+// AuthModeToken is reported by GetDaemonInfo while a mutation token is
+// required in addition to the peer-credential check.
+const AuthModeToken = AuthMode + "+mutation-token"
+
+const authorizationKey = "authorization"
+
+// LoadMutationToken reads the token at path. A missing file returns an
+// empty token, which leaves mutations open to any authorized caller. A file
+// with unsafe ownership or permissions, or an empty one, is an error.
+func LoadMutationToken(path string) (string, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", nil
+		}
+		return "", err
+	}
+	if st, ok := fi.Sys().(*syscall.Stat_t); ok && st.Uid != 0 {
+		return "", fmt.Errorf("%s must be owned by root", path)
+	}
+	if fi.Mode().Perm()&0o077 != 0 {
+		return "", fmt.Errorf("%s must not be accessible by group or others (mode %04o)", path, fi.Mode().Perm())
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	token := strings.TrimSpace(string(data))
+	if token == "" {
+		return "", fmt.Errorf("%s is empty", path)
+	}
+	return token, nil
+}
+
+// MutationTokenUnaryInterceptor rejects mutating RPCs whose authorization
+// metadata does not carry token. Reads are never checked. When required is
+// set but token is empty (the token file could not be loaded), every
+// mutation is rejected rather than silently left open.
+func MutationTokenUnaryInterceptor(token string, required bool) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if !required || !isMutation(info.FullMethod) {
+			return handler(ctx, req)
+		}
+		if token == "" {
+			return nil, status.Error(codes.Unauthenticated, "mutation token unavailable; check "+MutationTokenPath)
+		}
+		if !tokenMatches(ctx, token) {
+			return nil, status.Errorf(codes.Unauthenticated, "missing or invalid mutation token for method=%s", info.FullMethod)
+		}
+		return handler(ctx, req)
+	}
+}
+
+func isMutation(fullMethod string) bool {
+	return fullMethod == "/rpc.PowerGrid/ApplyMutation"
+}
+
+func tokenMatches(ctx context.Context, token string) bool {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return false
+	}
+	for _, v := range md.Get(authorizationKey) {
+		if ValidToken(strings.TrimPrefix(v, "Bearer "), token) {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidToken compares a presented token against the configured one in
+// constant time.
+func ValidToken(presented, token string) bool {
+	return token != "" && subtle.ConstantTimeCompare([]byte(strings.TrimSpace(presented)), []byte(token)) == 1
+}
@@ -0,0 +1,74 @@
+package ipc
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestMutationTokenUnaryInterceptor(t *testing.T) {
+	handler := func(context.Context, any) (any, error) { return "ok", nil }
+	withAuth := func(v string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", v))
+	}
+
+	tests := []struct {
+		name     string
+		token    string
+		required bool
+		method   string
+		ctx      context.Context
+		want     codes.Code
+	}{
+		{name: "not required", method: "/rpc.PowerGrid/ApplyMutation", ctx: context.Background(), want: codes.OK},
+		{name: "read stays open", token: "s3cret", required: true, method: "/rpc.PowerGrid/GetStatus", ctx: context.Background(), want: codes.OK},
+		{name: "bearer token", token: "s3cret", required: true, method: "/rpc.PowerGrid/ApplyMutation", ctx: withAuth("Bearer s3cret"), want: codes.OK},
+		{name: "raw token", token: "s3cret", required: true, method: "/rpc.PowerGrid/ApplyMutation", ctx: withAuth("s3cret"), want: codes.OK},
+		{name: "wrong token", token: "s3cret", required: true, method: "/rpc.PowerGrid/ApplyMutation", ctx: withAuth("Bearer nope"), want: codes.Unauthenticated},
+		{name: "missing token", token: "s3cret", required: true, method: "/rpc.PowerGrid/ApplyMutation", ctx: context.Background(), want: codes.Unauthenticated},
+		{name: "unloadable token fails closed", required: true, method: "/rpc.PowerGrid/ApplyMutation", ctx: withAuth("Bearer "), want: codes.Unauthenticated},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			interceptor := MutationTokenUnaryInterceptor(tc.token, tc.required)
+			_, err := interceptor(tc.ctx, nil, &grpc.UnaryServerInfo{FullMethod: tc.method}, handler)
+			if got := status.Code(err); got != tc.want {
+				t.Fatalf("unexpected code: got=%v want=%v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestLoadMutationToken(t *testing.T) {
+	dir := t.TempDir()
+
+	if token, err := LoadMutationToken(filepath.Join(dir, "missing")); err != nil || token != "" {
+		t.Fatalf("unexpected result for missing file: got=(%q,%v)", token, err)
+	}
+
+	open := filepath.Join(dir, "open")
+	if err := os.WriteFile(open, []byte("s3cret\n"), 0o644); err != nil {
+		t.Fatalf("failed to write token: %v", err)
+	}
+	if _, err := LoadMutationToken(open); err == nil {
+		t.Fatal("expected error for a world-readable token file")
+	}
+
+	if os.Geteuid() != 0 {
+		t.Skip("ownership check requires root")
+	}
+	private := filepath.Join(dir, "private")
+	if err := os.WriteFile(private, []byte("s3cret\n"), 0o600); err != nil {
+		t.Fatalf("failed to write token: %v", err)
+	}
+	if token, err := LoadMutationToken(private); err != nil || token != "s3cret" {
+		t.Fatalf("unexpected token: got=(%q,%v) want=(%q,nil)", token, err, "s3cret")
+	}
+}
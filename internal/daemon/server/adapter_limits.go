@@ -0,0 +1,65 @@
+package server
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	cfg "powergrid/internal/config"
+	"powergrid/internal/daemon/engine"
+)
+
+// currentAdapterKeyLocked identifies the connected adapter, or returns ""
+// on battery and before the first status snapshot.
+func (s *Daemon) currentAdapterKeyLocked() string {
+	if s.lastIOKitStatus == nil || !s.lastIOKitStatus.State.IsConnected {
+		return ""
+	}
+	return engine.AdapterKey(s.lastIOKitStatus.Adapter.Description, s.lastIOKitStatus.Adapter.MaxWatts)
+}
+
+// effectiveLimitLocked returns the limit to enforce for the connected adapter
+// along with the adapter key that selected it ("" for the regular limit).
+func (s *Daemon) effectiveLimitLocked() (int, string) {
+	return engine.SelectChargeLimit(int(s.currentLimit), s.currentAdapterKeyLocked(), s.adapterLimits)
+}
+
+// applySetAdapterLimit maps an adapter to its own charge limit. An empty key
+// targets the connected adapter; a limit of 0 removes the mapping.
+func (s *Daemon) applySetAdapterLimit(adapterKey string, limit int32) error {
+	if floor := s.chargeLimitFloor(); limit != 0 && (int(limit) < floor || limit > 100) {
+		return outOfRangeError("limit", int(limit), floor, 100)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	u := s.currentConsoleUser
+	if u == nil {
+		return noConsoleUserError("adapter limits")
+	}
+	if adapterKey == "" {
+		adapterKey = s.currentAdapterKeyLocked()
+		if adapterKey == "" {
+			return status.Error(codes.FailedPrecondition, "no adapter connected")
+		}
+	}
+
+	if err := cfg.WriteUserAdapterLimit(u.HomeDir, u.UID, u.GID, adapterKey, int(limit)); err != nil {
+		logger.Error("Failed to persist adapter limit for %s: %v", adapterKey, err)
+		return status.Errorf(codes.Internal, "failed to persist adapter limit: %v", err)
+	}
+	if s.adapterLimits == nil {
+		s.adapterLimits = map[string]int{}
+	}
+	if limit == 0 {
+		delete(s.adapterLimits, adapterKey)
+		logger.Default("Removed charge limit for adapter %q.", adapterKey)
+	} else {
+		s.adapterLimits[adapterKey] = int(limit)
+		logger.Default("Set charge limit %d%% for adapter %q.", limit, adapterKey)
+	}
+	s.reconcileSleepChargingStateLocked()
+
+	s.runChargingLogicCachedLocked()
+	return nil
+}
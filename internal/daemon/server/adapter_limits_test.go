@@ -0,0 +1,56 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/peterneutron/powerkit-go/pkg/powerkit"
+
+	rpc "powergrid/internal/rpc"
+)
+
+func connectedInfo(charge int, smcChargingEnabled bool, description string, maxWatts int) *powerkit.SystemInfo {
+	info := testSystemInfo(charge, smcChargingEnabled)
+	info.IOKit.State.IsConnected = true
+	info.IOKit.Adapter.Description = description
+	info.IOKit.Adapter.MaxWatts = maxWatts
+	return info
+}
+
+func TestRunChargingLogicUsesAdapterLimit(t *testing.T) {
+	resetServerTestGlobals(t)
+
+	var actions []powerkit.ChargingAction
+	setChargingStateFn = func(action powerkit.ChargingAction) error {
+		actions = append(actions, action)
+		return nil
+	}
+
+	d := &Daemon{currentLimit: 80, adapterLimits: map[string]int{"desk 96W": 100}}
+
+	d.runChargingLogicLocked(connectedInfo(85, false, "desk", 96))
+	if len(actions) != 1 || actions[0] != powerkit.ChargingActionOn {
+		t.Fatalf("expected desk adapter to allow charging past 80%%, got %v", actions)
+	}
+
+	d.runChargingLogicLocked(connectedInfo(85, true, "travel", 30))
+	if len(actions) != 2 || actions[1] != powerkit.ChargingActionOff {
+		t.Fatalf("expected unknown adapter to fall back to 80%%, got %v", actions)
+	}
+}
+
+func TestGetStatusReportsMatchedAdapter(t *testing.T) {
+	d := &Daemon{currentLimit: 80, adapterLimits: map[string]int{"desk 96W": 100}}
+	d.updateCachedStatusLocked(connectedInfo(50, true, "desk", 96))
+
+	resp, err := d.GetStatus(context.Background(), &rpc.Empty{})
+	if err != nil {
+		t.Fatalf("GetStatus returned error: %v", err)
+	}
+	if resp.GetAdapterKey() != "desk 96W" || resp.GetMatchedAdapterKey() != "desk 96W" {
+		t.Fatalf("unexpected adapter keys: got=(%q,%q) want=(%q,%q)", resp.GetAdapterKey(), resp.GetMatchedAdapterKey(), "desk 96W", "desk 96W")
+	}
+	if resp.GetChargeLimit() != 80 || resp.GetEffectiveChargeLimit() != 100 {
+		t.Fatalf("unexpected limits: got=(%d,%d) want=(%d,%d)", resp.GetChargeLimit(), resp.GetEffectiveChargeLimit(), 80, 100)
+	}
+}
@@ -0,0 +1,47 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/peterneutron/powerkit-go/pkg/powerkit"
+
+	rpc "powergrid/internal/rpc"
+)
+
+func TestGetAdapterDetails(t *testing.T) {
+	d := &Daemon{}
+	if _, err := d.GetAdapterDetails(context.Background(), &rpc.Empty{}); status.Code(err) != codes.Unavailable {
+		t.Fatalf("unexpected error before first status: got=%v want=%v", status.Code(err), codes.Unavailable)
+	}
+
+	d.lastIOKitStatus = &powerkit.IOKitData{}
+	resp, err := d.GetAdapterDetails(context.Background(), &rpc.Empty{})
+	if err != nil {
+		t.Fatalf("GetAdapterDetails returned error: %v", err)
+	}
+	if resp.GetConnected() || resp.GetMaxWatts() != 0 {
+		t.Fatalf("expected empty disconnected response, got %+v", resp)
+	}
+
+	d.lastIOKitStatus.State.IsConnected = true
+	d.lastIOKitStatus.Adapter.Description = "pd charger"
+	d.lastIOKitStatus.Adapter.MaxWatts = 96
+	d.lastIOKitStatus.Adapter.InputVoltage = 20
+	d.lastIOKitStatus.Adapter.TelemetryAvailable = true
+	d.lastAdapterWattage = 45
+
+	resp, err = d.GetAdapterDetails(context.Background(), &rpc.Empty{})
+	if err != nil {
+		t.Fatalf("GetAdapterDetails returned error: %v", err)
+	}
+	if !resp.GetConnected() || resp.GetDescription() != "pd charger" || resp.GetMaxWatts() != 96 {
+		t.Fatalf("unexpected adapter descriptor: %+v", resp)
+	}
+	if resp.GetInputVoltage() != 20 || resp.GetInputWatts() != 45 || !resp.GetTelemetryAvailable() {
+		t.Fatalf("unexpected adapter telemetry: %+v", resp)
+	}
+}
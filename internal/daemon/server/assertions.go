@@ -0,0 +1,60 @@
+package server
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/peterneutron/powerkit-go/pkg/powerkit"
+)
+
+// assertionTracker records the sleep assertion types the daemon holds so
+// they can be released one by one on shutdown rather than left for process
+// exit to clean up. It has its own lock because assertions are taken both
+// with and without s.mu held.
+type assertionTracker struct {
+	mu   sync.Mutex
+	held map[powerkit.AssertionType]bool
+}
+
+// holdAssertion creates a sleep assertion of the given type and tracks it.
+// powerkit keeps at most one assertion per type, so repeating it is harmless.
+func (s *Daemon) holdAssertion(assertionType powerkit.AssertionType, reason string) error {
+	if _, err := createAssertionFn(assertionType, reason); err != nil {
+		return err
+	}
+	s.assertions.mu.Lock()
+	defer s.assertions.mu.Unlock()
+	if s.assertions.held == nil {
+		s.assertions.held = map[powerkit.AssertionType]bool{}
+	}
+	s.assertions.held[assertionType] = true
+	return nil
+}
+
+// releaseAssertion releases a sleep assertion of the given type, if held.
+func (s *Daemon) releaseAssertion(assertionType powerkit.AssertionType) {
+	releaseAssertionFn(assertionType)
+	s.assertions.mu.Lock()
+	defer s.assertions.mu.Unlock()
+	delete(s.assertions.held, assertionType)
+}
+
+// releaseAllAssertions releases every tracked assertion in type order, then
+// asks powerkit to drop anything it still holds for this process. It
+// returns how many tracked assertions were released.
+func (s *Daemon) releaseAllAssertions() int {
+	s.assertions.mu.Lock()
+	defer s.assertions.mu.Unlock()
+
+	types := make([]powerkit.AssertionType, 0, len(s.assertions.held))
+	for assertionType := range s.assertions.held {
+		types = append(types, assertionType)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+	for _, assertionType := range types {
+		releaseAssertionFn(assertionType)
+	}
+	allowAllSleepFn()
+	s.assertions.held = nil
+	return len(types)
+}
@@ -0,0 +1,33 @@
+package server
+
+import (
+	"time"
+
+	"github.com/peterneutron/powerkit-go/pkg/powerkit"
+
+	"powergrid/internal/daemon/engine"
+)
+
+// applyNoBatteryLocked suspends charge control while IOKit reports no usable
+// battery: the adapter and charging stay on and the LED returns to the
+// system, exactly as in passthrough mode.
+func (s *Daemon) applyNoBatteryLocked(info *powerkit.SystemInfo) {
+	if !s.batteryMissing {
+		s.batteryMissing = true
+		logger.Default("No battery detected; suspending charge control.")
+		s.recordEvent("battery_missing", nil)
+	}
+	s.externalHoldSince = time.Time{}
+	s.setPauseReasonLocked(engine.PauseNone)
+	s.applyUnmanagedLocked(info)
+	s.markChargingLogicRun()
+}
+
+func (s *Daemon) noteBatteryPresentLocked() {
+	if !s.batteryMissing {
+		return
+	}
+	s.batteryMissing = false
+	logger.Default("Battery detected; resuming charge control.")
+	s.recordEvent("battery_present", nil)
+}
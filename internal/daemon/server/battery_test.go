@@ -0,0 +1,58 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/peterneutron/powerkit-go/pkg/powerkit"
+
+	rpc "powergrid/internal/rpc"
+)
+
+func TestRunChargingLogicWithoutBattery(t *testing.T) {
+	resetServerTestGlobals(t)
+
+	var chargingActions []powerkit.ChargingAction
+	setChargingStateFn = func(action powerkit.ChargingAction) error {
+		chargingActions = append(chargingActions, action)
+		return nil
+	}
+	var adapterActions []powerkit.AdapterAction
+	setAdapterStateFn = func(action powerkit.AdapterAction) error {
+		adapterActions = append(adapterActions, action)
+		return nil
+	}
+
+	d := &Daemon{currentLimit: 80}
+	info := testSystemInfo(0, false)
+	info.IOKit.Battery = powerkit.IOKitBattery{}
+	info.IOKit.State.IsConnected = true
+	info.SMC.State.IsAdapterEnabled = false
+	d.runChargingLogicLocked(info)
+
+	if !d.batteryMissing {
+		t.Fatal("expected missing battery to be detected")
+	}
+	if len(chargingActions) != 1 || chargingActions[0] != powerkit.ChargingActionOn {
+		t.Fatalf("expected charging left on, got %v", chargingActions)
+	}
+	if len(adapterActions) != 1 || adapterActions[0] != powerkit.AdapterActionOn {
+		t.Fatalf("expected adapter left on, got %v", adapterActions)
+	}
+
+	resp, err := d.GetStatus(context.Background(), &rpc.Empty{})
+	if err != nil {
+		t.Fatalf("GetStatus returned error: %v", err)
+	}
+	if !resp.GetBatteryMissing() {
+		t.Fatal("expected status to report the missing battery")
+	}
+
+	d.runChargingLogicLocked(testSystemInfo(85, true))
+	if d.batteryMissing {
+		t.Fatal("expected battery presence to be restored")
+	}
+	if len(chargingActions) != 2 || chargingActions[1] != powerkit.ChargingActionOff {
+		t.Fatalf("expected limit enforced once the battery returned, got %v", chargingActions)
+	}
+}
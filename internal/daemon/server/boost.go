@@ -0,0 +1,69 @@
+package server
+
+import (
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxBoostMinutes caps a single charge boost.
+const maxBoostMinutes = 240
+
+// applyChargeBoost lets charging run past the limit for the given number of
+// minutes while the adapter stays connected. Zero minutes cancels a boost.
+func (s *Daemon) applyChargeBoost(minutes int32) error {
+	if minutes < 0 || minutes > maxBoostMinutes {
+		return outOfRangeError("boost_minutes", int(minutes), 0, maxBoostMinutes)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if minutes == 0 {
+		if !s.boostUntil.IsZero() {
+			s.boostUntil = time.Time{}
+			logger.Default("Charge boost cancelled.")
+		}
+		s.runChargingLogicCachedLocked()
+		return nil
+	}
+	if s.batteryMissing {
+		return noBatteryError("charge boost")
+	}
+	if s.lastIOKitStatus != nil && !s.lastIOKitStatus.State.IsConnected {
+		return status.Error(codes.FailedPrecondition, "charge boost requires a connected adapter")
+	}
+
+	s.boostUntil = clock.Now().Add(time.Duration(minutes) * time.Minute)
+	logger.Default("Charge boost started for %d minutes (until %s).", minutes, s.boostUntil.Format(time.RFC3339))
+
+	s.runChargingLogicCachedLocked()
+	return nil
+}
+
+// boostActiveLocked reports whether a charge boost is in effect, ending it
+// once it expires or the adapter is unplugged.
+func (s *Daemon) boostActiveLocked(now time.Time, connected bool) bool {
+	if s.boostUntil.IsZero() {
+		return false
+	}
+	switch {
+	case !connected:
+		logger.Default("Charge boost ended because the adapter was unplugged.")
+	case !now.Before(s.boostUntil):
+		logger.Default("Charge boost expired.")
+	default:
+		return true
+	}
+	s.boostUntil = time.Time{}
+	s.recordEvent("charge_boost_ended", nil)
+	return false
+}
+
+func (s *Daemon) boostRemainingLocked(now time.Time) time.Duration {
+	if s.boostUntil.IsZero() || !now.Before(s.boostUntil) {
+		return 0
+	}
+	return s.boostUntil.Sub(now)
+}
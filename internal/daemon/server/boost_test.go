@@ -0,0 +1,94 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/peterneutron/powerkit-go/pkg/powerkit"
+)
+
+func TestChargeBoostIgnoresLimitUntilExpiry(t *testing.T) {
+	resetServerTestGlobals(t)
+
+	now := time.Date(2026, 4, 20, 10, 0, 0, 0, time.UTC)
+	clk := newFakeClock(now)
+	clock = clk
+
+	var actions []powerkit.ChargingAction
+	setChargingStateFn = func(action powerkit.ChargingAction) error {
+		actions = append(actions, action)
+		return nil
+	}
+
+	plugged := func(charge int, smcChargingEnabled bool) *powerkit.SystemInfo {
+		info := testSystemInfo(charge, smcChargingEnabled)
+		info.IOKit.State.IsConnected = true
+		return info
+	}
+
+	d := &Daemon{currentLimit: 80}
+	d.updateCachedStatusLocked(plugged(85, false))
+	d.statusFetchedAt = now
+	fetched := make(chan struct{}, 1)
+	getSystemInfoFn = func(...powerkit.FetchOptions) (*powerkit.SystemInfo, error) {
+		defer func() { fetched <- struct{}{} }()
+		return plugged(85, true), nil
+	}
+
+	if err := d.applyChargeBoost(30); err != nil {
+		t.Fatalf("applyChargeBoost returned error: %v", err)
+	}
+	// Let the background refresh queued by the cached run finish.
+	<-fetched
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if len(actions) != 1 || actions[0] != powerkit.ChargingActionOn {
+		t.Fatalf("expected charging enabled past the limit, got %v", actions)
+	}
+	if got := d.boostRemainingLocked(now); got != 30*time.Minute {
+		t.Fatalf("unexpected boost remaining: got=%v want=%v", got, 30*time.Minute)
+	}
+
+	now = now.Add(30 * time.Minute)
+	clk.Set(now)
+	d.runChargingLogicLocked(plugged(90, true))
+	if len(actions) != 2 || actions[1] != powerkit.ChargingActionOff {
+		t.Fatalf("expected limit enforced after the boost expired, got %v", actions)
+	}
+	if !d.boostUntil.IsZero() {
+		t.Fatal("expected expired boost to be cleared")
+	}
+}
+
+func TestChargeBoostEndsOnUnplug(t *testing.T) {
+	resetServerTestGlobals(t)
+
+	now := time.Date(2026, 4, 20, 10, 0, 0, 0, time.UTC)
+	clk := newFakeClock(now)
+	clock = clk
+	setChargingStateFn = func(powerkit.ChargingAction) error { return nil }
+
+	d := &Daemon{currentLimit: 80, boostUntil: now.Add(time.Hour)}
+	d.runChargingLogicLocked(testSystemInfo(50, true))
+	if !d.boostUntil.IsZero() {
+		t.Fatal("expected unplug to end the boost")
+	}
+}
+
+func TestChargeBoostValidation(t *testing.T) {
+	resetServerTestGlobals(t)
+
+	d := &Daemon{currentLimit: 80}
+	if err := d.applyChargeBoost(maxBoostMinutes + 1); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("unexpected error for long boost: got=%v want=%v", status.Code(err), codes.InvalidArgument)
+	}
+
+	d.updateCachedStatusLocked(testSystemInfo(50, true))
+	if err := d.applyChargeBoost(30); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("unexpected error on battery: got=%v want=%v", status.Code(err), codes.FailedPrecondition)
+	}
+}
@@ -0,0 +1,162 @@
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"golang.org/x/sys/unix"
+
+	consoleuser "powergrid/internal/consoleuser"
+	rpc "powergrid/internal/rpc"
+)
+
+// supportBundleLogLines is how many recent log lines a support bundle carries.
+const supportBundleLogLines = 200
+
+// GetSupportBundle gathers version, hardware, status, settings, logs and
+// health in one response for bug reports. The console user's name and home
+// directory are replaced with a short hash wherever they appear, and so is
+// the battery serial number.
+func (s *Daemon) GetSupportBundle(ctx context.Context, _ *rpc.Empty) (*rpc.SupportBundleResponse, error) {
+	info, err := s.GetDaemonInfo(ctx, &rpc.Empty{})
+	if err != nil {
+		return nil, err
+	}
+	status, err := s.GetStatus(ctx, &rpc.Empty{})
+	if err != nil {
+		return nil, err
+	}
+	settings, err := s.GetEffectiveSettings(ctx, &rpc.Empty{})
+	if err != nil {
+		return nil, err
+	}
+	logs, err := s.GetLogs(ctx, &rpc.LogsRequest{Lines: supportBundleLogLines})
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	redact, userHash := newRedactor(s.currentConsoleUser)
+	diagnostics := s.diagnosticsLocked()
+	s.mu.RUnlock()
+
+	for _, setting := range settings.GetSettings() {
+		setting.Value = redact.Replace(setting.Value)
+		setting.Source = redact.Replace(setting.Source)
+	}
+	for _, entry := range logs.GetEntries() {
+		entry.Message = redact.Replace(entry.Message)
+	}
+	if serial := status.GetBatterySerialNumber(); serial != "" {
+		status.BatterySerialNumber = hashedID("battery", serial)
+	}
+	model, _ := unix.Sysctl("hw.model")
+	osVersion, _ := unix.Sysctl("kern.osproductversion")
+
+	return &rpc.SupportBundleResponse{
+		GeneratedUnixMs: clock.Now().UnixMilli(),
+		DaemonInfo:      info,
+		HardwareModel:   model,
+		OsVersion:       osVersion,
+		Status:          status,
+		Settings:        settings.GetSettings(),
+		Logs:            logs.GetEntries(),
+		Diagnostics:     diagnostics,
+		ConsoleUser:     userHash,
+	}, nil
+}
+
+// diagnosticsLocked reports internal state that status does not expose.
+func (s *Daemon) diagnosticsLocked() []*rpc.Diagnostic {
+	pairs := []struct {
+		name  string
+		value any
+	}{
+		{"health", s.servingLocked()},
+		{"event_stream_up", s.eventStreamUp},
+		{"polling_only", s.pollingOnly},
+		{"console_user_watch_degraded", consoleuser.WatchDegraded()},
+		{"smc_failures", s.smcFailures},
+		{"safe_mode", s.safeMode.Load()},
+		{"hardware_write_failures", s.hardwareWriteFailures},
+		{"watchdog_tripped", s.watchdogTripped.Load()},
+		{"magsafe_led_support", s.magsafeLEDSupportLocked()},
+		{"magsafe_led_states", strings.Join(s.honoredLEDStateNamesLocked(), ",")},
+		{"charge_manager_conflict", s.chargeConflict},
+		{"text_control_enabled", s.textControlEnabled},
+		{"mutation_token_required", s.mutationTokenRequired},
+	}
+	out := make([]*rpc.Diagnostic, 0, len(pairs))
+	for _, p := range pairs {
+		out = append(out, &rpc.Diagnostic{Name: p.name, Value: fmt.Sprint(p.value)})
+	}
+	return out
+}
+
+// redactor replaces the console user's home directory and name with a hash.
+// Both only match as whole words, so a short name such as "pg" leaves text
+// that merely contains it alone.
+type redactor struct {
+	home string
+	name string
+	hash string
+}
+
+// newRedactor maps the console user's home directory and name to a stable
+// hash so bundles from one machine can be correlated without naming anyone.
+func newRedactor(u *consoleuser.ConsoleUser) (redactor, string) {
+	if u == nil || u.Username == "" {
+		return redactor{}, ""
+	}
+	hash := hashedID("user", u.Username)
+	return redactor{home: u.HomeDir, name: u.Username, hash: hash}, hash
+}
+
+func (r redactor) Replace(s string) string {
+	if r.home != "" {
+		s = replaceWord(s, r.home, "~"+r.hash)
+	}
+	if r.name != "" {
+		s = replaceWord(s, r.name, r.hash)
+	}
+	return s
+}
+
+// replaceWord replaces each occurrence of old in s that is not part of a
+// longer name, that is, not preceded or followed by a letter, digit, '_' or
+// '-'.
+func replaceWord(s, old, repl string) string {
+	var b strings.Builder
+	last := 0
+	for start := 0; start < len(s); {
+		i := strings.Index(s[start:], old)
+		if i < 0 {
+			break
+		}
+		i += start
+		end := i + len(old)
+		if (i == 0 || !isNameByte(s[i-1])) && (end == len(s) || !isNameByte(s[end])) {
+			b.WriteString(s[last:i])
+			b.WriteString(repl)
+			last = end
+			start = end
+			continue
+		}
+		start = i + 1
+	}
+	b.WriteString(s[last:])
+	return b.String()
+}
+
+func isNameByte(c byte) bool {
+	return c == '_' || c == '-' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// hashedID stands in for an identifying value in a support bundle.
+func hashedID(prefix, value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return prefix + "-" + hex.EncodeToString(sum[:4])
+}
@@ -0,0 +1,49 @@
+package server
+
+import (
+	"strings"
+	"testing"
+
+	consoleuser "powergrid/internal/consoleuser"
+)
+
+func TestNewRedactorHidesConsoleUser(t *testing.T) {
+	redact, hash := newRedactor(&consoleuser.ConsoleUser{Username: "alice", HomeDir: "/Users/alice"})
+	if !strings.HasPrefix(hash, "user-") || len(hash) != len("user-")+8 {
+		t.Fatalf("unexpected user hash: %q", hash)
+	}
+
+	got := redact.Replace("Persisted limit for alice in /Users/alice/Library/Preferences")
+	want := "Persisted limit for " + hash + " in ~" + hash + "/Library/Preferences"
+	if got != want {
+		t.Fatalf("unexpected redaction: got=%q want=%q", got, want)
+	}
+
+	redact, hash = newRedactor(nil)
+	if hash != "" || redact.Replace("alice") != "alice" {
+		t.Fatalf("unexpected redaction without a console user: hash=%q", hash)
+	}
+}
+
+func TestNewRedactorMatchesWholeNames(t *testing.T) {
+	redact, hash := newRedactor(&consoleuser.ConsoleUser{Username: "pg", HomeDir: "/Users/pg"})
+
+	got := redact.Replace("pg: upgrade kept /Users/pg/Library and /Users/pgadmin, pg-ctl, pgpool")
+	want := hash + ": upgrade kept ~" + hash + "/Library and /Users/pgadmin, pg-ctl, pgpool"
+	if got != want {
+		t.Fatalf("unexpected redaction: got=%q want=%q", got, want)
+	}
+	if got := redact.Replace("pg pg"); got != hash+" "+hash {
+		t.Fatalf("expected adjacent names redacted, got %q", got)
+	}
+}
+
+func TestHashedID(t *testing.T) {
+	got := hashedID("battery", "F8Y1234ABCD")
+	if !strings.HasPrefix(got, "battery-") || len(got) != len("battery-")+8 || strings.Contains(got, "F8Y1234ABCD") {
+		t.Fatalf("unexpected hashed id: %q", got)
+	}
+	if got != hashedID("battery", "F8Y1234ABCD") {
+		t.Fatal("expected a stable hash")
+	}
+}
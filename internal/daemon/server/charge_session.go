@@ -0,0 +1,58 @@
+package server
+
+import (
+	"context"
+
+	"github.com/peterneutron/powerkit-go/pkg/powerkit"
+
+	"powergrid/internal/daemon/engine"
+	rpc "powergrid/internal/rpc"
+)
+
+// noteChargeSessionLocked feeds a reading's battery and adapter power into
+// the charge session. Connecting the adapter starts a new session; the
+// first connected reading after startup does too, since an earlier plug-in
+// was not observed. Unplugging freezes the totals until the next plug-in.
+func (s *Daemon) noteChargeSessionLocked(next *powerkit.IOKitData) {
+	if next == nil {
+		return
+	}
+	if !next.State.IsConnected {
+		if s.chargeSession.Connected {
+			s.recordEvent("charge_session_ended", map[string]any{
+				"energy_added_wh": s.chargeSession.BatteryWh,
+				"peak_watts":      s.chargeSession.PeakWatts,
+			})
+		}
+		s.chargeSession.Connected = false
+		return
+	}
+	now := clock.Now()
+	if !s.chargeSession.Connected {
+		s.chargeSession = engine.ChargeSession{Start: now, Connected: true}
+	}
+	s.chargeSession = engine.AddSessionReading(s.chargeSession, now, next.Calculations.BatteryPower, next.Calculations.AdapterPower)
+}
+
+// GetSessionStats returns the energy put into the battery since the adapter
+// was last connected, with average and peak charge power and the share of
+// adapter energy that reached the battery.
+func (s *Daemon) GetSessionStats(_ context.Context, _ *rpc.Empty) (*rpc.SessionStatsResponse, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	session := s.chargeSession
+	resp := &rpc.SessionStatsResponse{
+		Connected:          session.Connected,
+		EnergyAddedWh:      float32(session.BatteryWh),
+		AdapterEnergyWh:    float32(session.AdapterWh),
+		AverageChargeWatts: float32(session.AverageWatts()),
+		PeakChargeWatts:    float32(session.PeakWatts),
+		Efficiency:         float32(session.Efficiency()),
+		ChargingSeconds:    int32(session.ChargingFor.Seconds()),
+	}
+	if !session.Start.IsZero() {
+		resp.StartedUnix = session.Start.Unix()
+	}
+	return resp, nil
+}
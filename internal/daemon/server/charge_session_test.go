@@ -0,0 +1,50 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/peterneutron/powerkit-go/pkg/powerkit"
+
+	rpc "powergrid/internal/rpc"
+)
+
+func TestChargeSessionResetsOnPlugIn(t *testing.T) {
+	resetServerTestGlobals(t)
+	fc := newFakeClock(time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC))
+	clock = fc
+
+	d := &Daemon{}
+	reading := func(connected bool, batteryWatts, adapterWatts float64) *powerkit.IOKitData {
+		data := &powerkit.IOKitData{}
+		data.State.IsConnected = connected
+		data.Calculations.BatteryPower = batteryWatts
+		data.Calculations.AdapterPower = adapterWatts
+		return data
+	}
+
+	d.noteChargeSessionLocked(reading(true, 60, 75))
+	fc.Advance(time.Minute)
+	d.noteChargeSessionLocked(reading(true, 60, 75))
+
+	resp, err := d.GetSessionStats(context.Background(), &rpc.Empty{})
+	if err != nil {
+		t.Fatalf("GetSessionStats returned error: %v", err)
+	}
+	if !resp.GetConnected() || resp.GetEnergyAddedWh() != 1 || resp.GetPeakChargeWatts() != 60 || resp.GetEfficiency() != 0.8 {
+		t.Fatalf("unexpected session stats: %+v", resp)
+	}
+
+	fc.Advance(time.Minute)
+	d.noteChargeSessionLocked(reading(false, -10, 0))
+	if d.chargeSession.Connected || d.chargeSession.BatteryWh != 1 {
+		t.Fatalf("expected unplugging to keep the totals, got %+v", d.chargeSession)
+	}
+
+	fc.Advance(time.Minute)
+	d.noteChargeSessionLocked(reading(true, 30, 40))
+	if d.chargeSession.BatteryWh != 0 || !d.chargeSession.Start.Equal(fc.Now()) {
+		t.Fatalf("expected plugging in to start a new session, got %+v", d.chargeSession)
+	}
+}
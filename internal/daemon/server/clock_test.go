@@ -0,0 +1,130 @@
+package server
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock is a manually advanced Clock. Due timers fire synchronously from
+// Set or Advance, in deadline order.
+type fakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*fakeTimer
+}
+
+type fakeTimer struct {
+	clock  *fakeClock
+	at     time.Time
+	fn     func()
+	ch     chan time.Time
+	active bool
+}
+
+func newFakeClock(now time.Time) *fakeClock {
+	return &fakeClock{now: now}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	return c.NewTimer(d).C()
+}
+
+func (c *fakeClock) NewTimer(d time.Duration) Timer {
+	return c.addTimer(d, nil)
+}
+
+func (c *fakeClock) AfterFunc(d time.Duration, f func()) Timer {
+	return c.addTimer(d, f)
+}
+
+func (c *fakeClock) addTimer(d time.Duration, f func()) *fakeTimer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t := &fakeTimer{clock: c, at: c.now.Add(d), fn: f, ch: make(chan time.Time, 1), active: true}
+	c.timers = append(c.timers, t)
+	return t
+}
+
+// Set moves the clock to now and fires every timer that has come due.
+func (c *fakeClock) Set(now time.Time) {
+	for {
+		c.mu.Lock()
+		c.now = now
+		var next *fakeTimer
+		for _, t := range c.timers {
+			if t.active && !t.at.After(now) && (next == nil || t.at.Before(next.at)) {
+				next = t
+			}
+		}
+		if next == nil {
+			c.mu.Unlock()
+			return
+		}
+		next.active = false
+		c.mu.Unlock()
+
+		if next.fn != nil {
+			next.fn()
+		} else {
+			next.ch <- next.at
+		}
+	}
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.Set(c.Now().Add(d))
+}
+
+func (t *fakeTimer) C() <-chan time.Time { return t.ch }
+
+func (t *fakeTimer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	was := t.active
+	t.active = false
+	return was
+}
+
+func (t *fakeTimer) Reset(d time.Duration) bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	was := t.active
+	t.at = t.clock.now.Add(d)
+	t.active = true
+	return was
+}
+
+func TestFakeClockFiresDueTimersInOrder(t *testing.T) {
+	clk := newFakeClock(time.Date(2026, 4, 20, 10, 0, 0, 0, time.UTC))
+	var fired []string
+	clk.AfterFunc(2*time.Second, func() { fired = append(fired, "second") })
+	clk.AfterFunc(time.Second, func() { fired = append(fired, "first") })
+	stopped := clk.AfterFunc(time.Second, func() { fired = append(fired, "stopped") })
+	after := clk.After(3 * time.Second)
+
+	if !stopped.Stop() {
+		t.Fatal("expected Stop to report an active timer")
+	}
+	clk.Advance(2 * time.Second)
+	if len(fired) != 2 || fired[0] != "first" || fired[1] != "second" {
+		t.Fatalf("unexpected timer order: %v", fired)
+	}
+	select {
+	case <-after:
+		t.Fatal("After fired early")
+	default:
+	}
+	clk.Advance(time.Second)
+	select {
+	case <-after:
+	default:
+		t.Fatal("expected After to fire once due")
+	}
+}
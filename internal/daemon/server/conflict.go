@@ -0,0 +1,122 @@
+package server
+
+import (
+	"strings"
+	"time"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/peterneutron/powerkit-go/pkg/powerkit"
+)
+
+const (
+	// chargeConflictReads is how many consecutive direct reads must disagree
+	// with the daemon's last charging write before another manager is
+	// assumed; one mismatch can be a read racing the write.
+	chargeConflictReads = 2
+)
+
+// Charging state the daemon last wrote; the zero value means no write is
+// being tracked.
+const (
+	chargingExpectUnknown int32 = iota
+	chargingExpectOn
+	chargingExpectOff
+)
+
+// Other tools that write the same SMC charging keys, matched in lowercase
+// against the kernel's 16-character p_comm: prefixes for app and helper
+// names, exact names where a prefix would be too broad.
+var (
+	chargeManagerPrefixes = []string{"aldente", "batfi", "battery toolki"}
+	chargeManagerNames    = []string{"batt"}
+)
+
+// noteChargingWrite records the charging state the daemon last wrote so later
+// reads can tell whether it stuck. It is safe to call with or without s.mu.
+func (s *Daemon) noteChargingWrite(action powerkit.ChargingAction) {
+	if action == powerkit.ChargingActionOn {
+		s.chargingExpect.Store(chargingExpectOn)
+		return
+	}
+	s.chargingExpect.Store(chargingExpectOff)
+}
+
+// checkChargeConflictLocked compares a direct SMC read with the last write
+// and scans for known charge-management processes at most once per
+// recompute interval.
+func (s *Daemon) checkChargeConflictLocked(info *powerkit.SystemInfo, now time.Time) {
+	switch expect := s.chargingExpect.Load(); {
+	case expect == chargingExpectUnknown:
+		s.chargeMismatches = 0
+	case info.SMC.State.IsChargingEnabled != (expect == chargingExpectOn):
+		s.chargeMismatches++
+	default:
+		s.chargeMismatches = 0
+	}
+
+	if s.conflictScanAt.IsZero() || now.Sub(s.conflictScanAt) >= recomputeInterval {
+		s.conflictScanAt = now
+		s.conflictProcesses = chargeManagerProcessesFn()
+	}
+
+	detail := ""
+	switch {
+	case len(s.conflictProcesses) > 0:
+		detail = "running: " + strings.Join(s.conflictProcesses, ", ")
+	case s.chargeMismatches >= chargeConflictReads:
+		detail = "charging state changed outside PowerGrid"
+	}
+	if detail != "" && detail != s.chargeConflict {
+		logger.Error("Another charge manager may be active (%s); PowerGrid decisions can be overridden.", detail)
+		s.recordEvent("charge_manager_conflict", map[string]any{"detail": detail})
+	}
+	if detail == "" && s.chargeConflict != "" {
+		logger.Default("Charge manager conflict cleared.")
+	}
+	s.chargeConflict = detail
+}
+
+// clearChargeConflictLocked drops conflict state while the daemon is not
+// managing charging, since macOS or another tool is then expected to.
+func (s *Daemon) clearChargeConflictLocked() {
+	s.chargingExpect.Store(chargingExpectUnknown)
+	s.chargeMismatches = 0
+	s.chargeConflict = ""
+}
+
+// findChargeManagers lists running processes whose names match a known
+// charge manager.
+func findChargeManagers() []string {
+	procs, err := unix.SysctlKinfoProcSlice("kern.proc.all")
+	if err != nil {
+		logger.InfoLimited("Could not list processes for conflict detection: %v", err)
+		return nil
+	}
+	seen := map[string]bool{}
+	var found []string
+	for i := range procs {
+		name := unix.ByteSliceToString(procs[i].Proc.P_comm[:])
+		if seen[name] || !isChargeManager(name) {
+			continue
+		}
+		seen[name] = true
+		found = append(found, name)
+	}
+	return found
+}
+
+func isChargeManager(name string) bool {
+	lower := strings.ToLower(name)
+	for _, prefix := range chargeManagerPrefixes {
+		if strings.HasPrefix(lower, prefix) {
+			return true
+		}
+	}
+	for _, exact := range chargeManagerNames {
+		if lower == exact {
+			return true
+		}
+	}
+	return false
+}
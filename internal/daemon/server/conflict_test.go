@@ -0,0 +1,63 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/peterneutron/powerkit-go/pkg/powerkit"
+)
+
+func TestChargeConflictFromUnstickyWrites(t *testing.T) {
+	resetServerTestGlobals(t)
+
+	now := time.Date(2026, 4, 20, 10, 0, 0, 0, time.UTC)
+	d := &Daemon{}
+	d.noteChargingWrite(powerkit.ChargingActionOff)
+
+	flipped := testSystemInfo(80, true)
+	d.checkChargeConflictLocked(flipped, now)
+	if d.chargeConflict != "" {
+		t.Fatalf("expected a single mismatch to be tolerated, got %q", d.chargeConflict)
+	}
+	d.checkChargeConflictLocked(flipped, now.Add(time.Minute))
+	if d.chargeConflict == "" {
+		t.Fatal("expected conflict after repeated mismatches")
+	}
+
+	d.checkChargeConflictLocked(testSystemInfo(80, false), now.Add(2*time.Minute))
+	if d.chargeConflict != "" {
+		t.Fatalf("expected conflict cleared once the write sticks, got %q", d.chargeConflict)
+	}
+}
+
+func TestChargeConflictFromProcesses(t *testing.T) {
+	resetServerTestGlobals(t)
+	chargeManagerProcessesFn = func() []string { return []string{"AlDente"} }
+
+	d := &Daemon{}
+	d.checkChargeConflictLocked(testSystemInfo(80, true), time.Now())
+	if d.chargeConflict != "running: AlDente" {
+		t.Fatalf("unexpected conflict detail: got=%q want=%q", d.chargeConflict, "running: AlDente")
+	}
+}
+
+func TestIsChargeManager(t *testing.T) {
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{name: "AlDente", want: true},
+		{name: "BatFi", want: true},
+		{name: "batt", want: true},
+		{name: "battery", want: false},
+		{name: "powergrid-daemo", want: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := isChargeManager(tc.name); got != tc.want {
+				t.Fatalf("unexpected match: got=%v want=%v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/peterneutron/powerkit-go/pkg/powerkit"
+)
+
+func TestRunChargingLogicConnectGrace(t *testing.T) {
+	resetServerTestGlobals(t)
+
+	now := time.Date(2026, 4, 20, 10, 0, 0, 0, time.UTC)
+	clk := newFakeClock(now)
+	clock = clk
+
+	var actions []powerkit.ChargingAction
+	setChargingStateFn = func(action powerkit.ChargingAction) error {
+		actions = append(actions, action)
+		return nil
+	}
+
+	d := &Daemon{currentLimit: 80, connectGrace: 2 * time.Minute}
+	d.updateCachedStatusLocked(testSystemInfo(81, false))
+
+	plugged := testSystemInfo(81, false)
+	plugged.IOKit.State.IsConnected = true
+	d.runChargingLogicLocked(plugged)
+	if len(actions) != 1 || actions[0] != powerkit.ChargingActionOn {
+		t.Fatalf("expected charging enabled during grace, got %v", actions)
+	}
+
+	now = now.Add(time.Minute)
+	clk.Set(now)
+	charging := testSystemInfo(82, true)
+	charging.IOKit.State.IsConnected = true
+	d.runChargingLogicLocked(charging)
+	if len(actions) != 1 {
+		t.Fatalf("expected no disable inside grace window, got %v", actions)
+	}
+
+	now = now.Add(time.Minute)
+	clk.Set(now)
+	d.runChargingLogicLocked(charging)
+	if len(actions) != 2 || actions[1] != powerkit.ChargingActionOff {
+		t.Fatalf("expected limit enforced after grace, got %v", actions)
+	}
+}
+
+func TestConnectGraceIgnoresStartupSnapshot(t *testing.T) {
+	resetServerTestGlobals(t)
+
+	var actions []powerkit.ChargingAction
+	setChargingStateFn = func(action powerkit.ChargingAction) error {
+		actions = append(actions, action)
+		return nil
+	}
+
+	d := &Daemon{currentLimit: 80, connectGrace: 2 * time.Minute}
+	info := testSystemInfo(81, true)
+	info.IOKit.State.IsConnected = true
+	d.runChargingLogicLocked(info)
+
+	if len(actions) != 1 || actions[0] != powerkit.ChargingActionOff {
+		t.Fatalf("expected limit enforced when daemon starts already connected, got %v", actions)
+	}
+}
@@ -0,0 +1,64 @@
+package server
+
+import (
+	"context"
+
+	cfg "powergrid/internal/config"
+	rpc "powergrid/internal/rpc"
+)
+
+// counterDayLayout names a counter day in the system time zone.
+const counterDayLayout = "2006-01-02"
+
+// todayCountersLocked returns the counters for the current local day,
+// starting fresh once the stored day has passed.
+func (s *Daemon) todayCountersLocked() cfg.ChargingCounters {
+	day := localDay(clock.Now())
+	if s.counters.Day != day {
+		return cfg.ChargingCounters{Day: day}
+	}
+	return s.counters
+}
+
+// countChargingWriteLocked records a charging transition actuated by the
+// charging logic and persists the day's counters so they survive restarts.
+func (s *Daemon) countChargingWriteLocked(enabled bool) {
+	c := s.todayCountersLocked()
+	if enabled {
+		c.Enabled++
+	} else {
+		c.Disabled++
+	}
+	s.counters = c
+	if err := writeCountersFn(c); err != nil {
+		logger.InfoLimited("Failed to persist charging counters: %v", err)
+	}
+}
+
+// resetCounters zeroes today's counters.
+func (s *Daemon) resetCounters() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.counters = cfg.ChargingCounters{Day: localDay(clock.Now())}
+	if err := writeCountersFn(s.counters); err != nil {
+		logger.Error("Failed to persist reset charging counters: %v", err)
+		return err
+	}
+	logger.Default("Charging counters reset.")
+	return nil
+}
+
+// GetCounters reports how many times today the charging logic enabled and
+// disabled charging.
+func (s *Daemon) GetCounters(_ context.Context, _ *rpc.Empty) (*rpc.CountersResponse, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	c := s.todayCountersLocked()
+	return &rpc.CountersResponse{
+		Day:              c.Day,
+		ChargingEnabled:  int32(c.Enabled),
+		ChargingDisabled: int32(c.Disabled),
+	}, nil
+}
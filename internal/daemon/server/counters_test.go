@@ -0,0 +1,93 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	cfg "powergrid/internal/config"
+	rpc "powergrid/internal/rpc"
+)
+
+func TestChargingCountersRollOverDaily(t *testing.T) {
+	resetServerTestGlobals(t)
+
+	systemLocationFn = func() *time.Location { return time.UTC }
+	now := time.Date(2026, 4, 20, 23, 0, 0, 0, time.UTC)
+	clk := newFakeClock(now)
+	clock = clk
+	var written []cfg.ChargingCounters
+	writeCountersFn = func(c cfg.ChargingCounters) error {
+		written = append(written, c)
+		return nil
+	}
+
+	d := &Daemon{counters: cfg.ChargingCounters{Day: "2026-04-20", Enabled: 2, Disabled: 1}}
+	d.countChargingWriteLocked(false)
+	if want := (cfg.ChargingCounters{Day: "2026-04-20", Enabled: 2, Disabled: 2}); d.counters != want {
+		t.Fatalf("unexpected counters: got=%+v want=%+v", d.counters, want)
+	}
+
+	now = now.Add(2 * time.Hour)
+	clk.Set(now)
+	resp, err := d.GetCounters(context.Background(), &rpc.Empty{})
+	if err != nil {
+		t.Fatalf("GetCounters returned error: %v", err)
+	}
+	if resp.GetDay() != "2026-04-21" || resp.GetChargingEnabled() != 0 || resp.GetChargingDisabled() != 0 {
+		t.Fatalf("expected fresh counters for the new day, got %+v", resp)
+	}
+
+	d.countChargingWriteLocked(true)
+	if want := (cfg.ChargingCounters{Day: "2026-04-21", Enabled: 1}); d.counters != want {
+		t.Fatalf("unexpected counters after rollover: got=%+v want=%+v", d.counters, want)
+	}
+	if len(written) != 2 || written[1] != d.counters {
+		t.Fatalf("expected every transition persisted, got %+v", written)
+	}
+}
+
+func TestLocalDayUsesSystemZoneAcrossDST(t *testing.T) {
+	resetServerTestGlobals(t)
+
+	load := func(name string) *time.Location {
+		loc, err := time.LoadLocation(name)
+		if err != nil {
+			t.Skipf("time zone %s unavailable: %v", name, err)
+		}
+		return loc
+	}
+	tests := []struct {
+		zone string
+		at   time.Time
+		want string
+	}{
+		{zone: "UTC", at: time.Date(2026, 3, 9, 3, 30, 0, 0, time.UTC), want: "2026-03-09"},
+		{zone: "Asia/Tokyo", at: time.Date(2026, 3, 8, 16, 0, 0, 0, time.UTC), want: "2026-03-09"},
+		// New York springs forward at 07:00 UTC on 2026-03-08, so local
+		// midnight moves from 05:00 UTC to 04:00 UTC.
+		{zone: "America/New_York", at: time.Date(2026, 3, 8, 4, 59, 0, 0, time.UTC), want: "2026-03-07"},
+		{zone: "America/New_York", at: time.Date(2026, 3, 9, 3, 59, 0, 0, time.UTC), want: "2026-03-08"},
+		{zone: "America/New_York", at: time.Date(2026, 3, 9, 4, 0, 0, 0, time.UTC), want: "2026-03-09"},
+		// Berlin falls back at 01:00 UTC on 2026-10-25.
+		{zone: "Europe/Berlin", at: time.Date(2026, 10, 25, 22, 59, 0, 0, time.UTC), want: "2026-10-25"},
+		{zone: "Europe/Berlin", at: time.Date(2026, 10, 25, 23, 0, 0, 0, time.UTC), want: "2026-10-26"},
+	}
+
+	for _, tc := range tests {
+		loc := load(tc.zone)
+		systemLocationFn = func() *time.Location { return loc }
+		if got := localDay(tc.at); got != tc.want {
+			t.Fatalf("unexpected day in %s at %s: got=%s want=%s", tc.zone, tc.at.Format(time.RFC3339), got, tc.want)
+		}
+	}
+}
+
+func TestZoneName(t *testing.T) {
+	if got, want := zoneName("/var/db/timezone/zoneinfo/Europe/Berlin"), "Europe/Berlin"; got != want {
+		t.Fatalf("unexpected zone name: got=%q want=%q", got, want)
+	}
+	if got, want := zoneName("/etc/custom"), "/etc/custom"; got != want {
+		t.Fatalf("unexpected zone name: got=%q want=%q", got, want)
+	}
+}
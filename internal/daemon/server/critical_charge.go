@@ -0,0 +1,53 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/peterneutron/powerkit-go/pkg/powerkit"
+
+	"powergrid/internal/daemon/engine"
+)
+
+// handleCriticalChargeLocked runs the configured CriticalChargeActions when
+// charge reaches CriticalChargePercent without charging. Low Power Mode and
+// the alert fire once per critical episode; a pre-sleep charging pause is
+// cancelled on every run while critical, so the decision that follows can
+// re-enable charging from a connected adapter.
+func (s *Daemon) handleCriticalChargeLocked(info *powerkit.SystemInfo, charge int) {
+	critical := engine.InCriticalCharge(charge, s.criticalLevel, info.IOKit.State.IsCharging, s.criticalCharge)
+	entered := critical && !s.criticalCharge
+	if !critical {
+		if s.criticalCharge {
+			logger.Default("Charge %d%% is no longer critical.", charge)
+		}
+		s.criticalCharge = false
+		return
+	}
+	s.criticalCharge = true
+
+	if entered {
+		connected := info.IOKit.State.IsConnected
+		s.recordEvent("critical_charge", map[string]any{"charge": charge, "threshold": s.criticalLevel, "connected": connected})
+		if s.criticalActions.Notify {
+			logger.Fault("Charge %d%% is at or below the critical %d%% and not charging.", charge, s.criticalLevel)
+		}
+		if s.criticalActions.LowPowerMode && !connected {
+			if err := callWithContextTimeout(opTimeout, func(ctx context.Context) error {
+				return setLowPowerModeFn(ctx, true)
+			}); err != nil {
+				logger.Error("Failed to enable Low Power Mode at critical charge: %v", err)
+			} else {
+				logger.Default("Enabled Low Power Mode at critical charge %d%%.", charge)
+			}
+		}
+	}
+
+	if s.criticalActions.ResumeCharging && info.IOKit.State.IsConnected &&
+		(s.sleepTransitionActive || !s.wakeHoldUntil.IsZero()) {
+		logger.Default("Charge %d%% is critical; cancelling the pre-sleep charging pause.", charge)
+		s.sleepTransitionActive = false
+		s.wakeHoldUntil = time.Time{}
+		s.recordEvent("critical_charge_resumed", map[string]any{"charge": charge})
+	}
+}
@@ -0,0 +1,72 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/peterneutron/powerkit-go/pkg/powerkit"
+
+	cfg "powergrid/internal/config"
+)
+
+func TestCriticalChargeCancelsPreSleepPause(t *testing.T) {
+	resetServerTestGlobals(t)
+
+	var actions []powerkit.ChargingAction
+	setChargingStateFn = func(action powerkit.ChargingAction) error {
+		actions = append(actions, action)
+		return nil
+	}
+
+	d := &Daemon{
+		currentLimit:          80,
+		criticalLevel:         10,
+		criticalActions:       cfg.CriticalActions{ResumeCharging: true},
+		sleepTransitionActive: true,
+	}
+	info := testSystemInfo(8, false)
+	info.IOKit.State.IsConnected = true
+	info.SMC.State.IsAdapterEnabled = true
+
+	d.runChargingLogicLocked(info)
+
+	if d.sleepTransitionActive {
+		t.Fatalf("expected the pre-sleep pause to be cancelled at critical charge")
+	}
+	if len(actions) != 1 || actions[0] != powerkit.ChargingActionOn {
+		t.Fatalf("expected charging re-enabled, got %v", actions)
+	}
+}
+
+func TestCriticalChargeEnablesLowPowerModeOnce(t *testing.T) {
+	resetServerTestGlobals(t)
+	setChargingStateFn = func(powerkit.ChargingAction) error { return nil }
+
+	calls := 0
+	setLowPowerModeFn = func(_ context.Context, enable bool) error {
+		if !enable {
+			t.Fatalf("expected Low Power Mode to be enabled")
+		}
+		calls++
+		return nil
+	}
+
+	d := &Daemon{
+		currentLimit:    80,
+		criticalLevel:   10,
+		criticalActions: cfg.CriticalActions{LowPowerMode: true, Notify: true},
+	}
+	d.runChargingLogicLocked(testSystemInfo(9, true))
+	d.runChargingLogicLocked(testSystemInfo(8, true))
+	if calls != 1 {
+		t.Fatalf("expected one Low Power Mode write per critical episode, got %d", calls)
+	}
+	if !d.criticalCharge {
+		t.Fatalf("expected critical charge to be reported")
+	}
+
+	d.runChargingLogicLocked(testSystemInfo(13, true))
+	if d.criticalCharge {
+		t.Fatalf("expected the critical state to end above the hysteresis band")
+	}
+}
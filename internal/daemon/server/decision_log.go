@@ -0,0 +1,53 @@
+package server
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/peterneutron/powerkit-go/pkg/powerkit"
+
+	"powergrid/internal/daemon/engine"
+)
+
+// logDecisionLocked logs a charging decision with every input that drove
+// it, for answering "why did it enable or disable here?" from the log alone.
+// limit is the effective limit; target is what the decision compared charge
+// against after grace, boost, pin, full-by and weak-adapter adjustments.
+func (s *Daemon) logDecisionLocked(info *powerkit.SystemInfo, decision engine.ChargingDecision, limit, target int, weakAdapter bool, now time.Time) {
+	charge := info.IOKit.Battery.CurrentCharge
+	smcCharging := info.SMC.State.IsChargingEnabled
+	logger.Info("Charging decision: %s (%s); charge=%d limit=%d target=%d smc_charging=%t adapter_enabled=%t connected=%t charging=%t full=%t adapter_watts=%d temperature_c=%.1f weak_adapter=%t grace=%t boost=%t pin=%t full_by=%t sleep_transition=%t wake_hold=%t external_hold=%t",
+		decisionName(decision), decisionReason(decision, charge, target, smcCharging),
+		charge, limit, target, smcCharging, info.SMC.State.IsAdapterEnabled,
+		info.IOKit.State.IsConnected, info.IOKit.State.IsCharging, info.IOKit.State.FullyCharged,
+		info.IOKit.Adapter.MaxWatts, info.IOKit.Battery.Temperature, weakAdapter,
+		s.inConnectGraceLocked(now), !s.boostUntil.IsZero(), s.pinnedPID != 0, !s.fullByDeadline.IsZero(),
+		s.sleepTransitionActive, !s.wakeHoldUntil.IsZero(), s.externalHoldReportedLocked(now))
+}
+
+func decisionName(decision engine.ChargingDecision) string {
+	switch decision {
+	case engine.ChargingEnable:
+		return "enable"
+	case engine.ChargingDisable:
+		return "disable"
+	default:
+		return "no change"
+	}
+}
+
+// decisionReason restates the DecideCharging rule that produced decision.
+func decisionReason(decision engine.ChargingDecision, charge, target int, smcCharging bool) string {
+	switch {
+	case decision == engine.ChargingDisable:
+		return fmt.Sprintf("charge %d%% >= target %d%% with SMC charging on", charge, target)
+	case decision == engine.ChargingEnable && target >= 100:
+		return fmt.Sprintf("target %d%% only ever enables and SMC charging is off", target)
+	case decision == engine.ChargingEnable:
+		return fmt.Sprintf("charge %d%% < target %d%% with SMC charging off", charge, target)
+	case smcCharging:
+		return "SMC charging is already on"
+	default:
+		return "SMC charging is already off"
+	}
+}
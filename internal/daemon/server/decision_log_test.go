@@ -0,0 +1,47 @@
+package server
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/peterneutron/powerkit-go/pkg/powerkit"
+
+	"powergrid/internal/daemon/engine"
+)
+
+func TestDecisionReason(t *testing.T) {
+	cases := []struct {
+		decision    engine.ChargingDecision
+		charge      int
+		target      int
+		smcCharging bool
+		want        string
+	}{
+		{engine.ChargingDisable, 85, 80, true, "charge 85% >= target 80% with SMC charging on"},
+		{engine.ChargingEnable, 70, 80, false, "charge 70% < target 80% with SMC charging off"},
+		{engine.ChargingEnable, 100, 100, false, "target 100% only ever enables and SMC charging is off"},
+		{engine.ChargingNoop, 70, 80, true, "SMC charging is already on"},
+		{engine.ChargingNoop, 85, 80, false, "SMC charging is already off"},
+	}
+	for _, tc := range cases {
+		if got := decisionReason(tc.decision, tc.charge, tc.target, tc.smcCharging); got != tc.want {
+			t.Fatalf("decisionReason(%v, %d, %d, %t) = %q, want %q", tc.decision, tc.charge, tc.target, tc.smcCharging, got, tc.want)
+		}
+	}
+}
+
+func TestChargingLogicLogsDecisionWhenEnabled(t *testing.T) {
+	resetServerTestGlobals(t)
+	setChargingStateFn = func(powerkit.ChargingAction) error { return nil }
+
+	d := &Daemon{currentLimit: 80, logDecisions: true}
+	d.runChargingLogicLocked(testSystemInfo(85, true))
+
+	for _, e := range logger.Recent(20) {
+		if strings.HasPrefix(e.Message, "Charging decision: disable (charge 85% >= target 80%") &&
+			strings.Contains(e.Message, "limit=80 target=80 smc_charging=true") {
+			return
+		}
+	}
+	t.Fatal("expected the decision and its inputs in the log")
+}
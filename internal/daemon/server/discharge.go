@@ -0,0 +1,99 @@
+package server
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/peterneutron/powerkit-go/pkg/powerkit"
+
+	"powergrid/internal/daemon/engine"
+)
+
+// applyForceDischarge turns the adapter off or back on for a user request and
+// tracks the intent, so the safety floor only ever ends a discharge the user
+// asked for.
+func (s *Daemon) applyForceDischarge(enable bool) error {
+	if !enable {
+		if err := callWithTimeout(opTimeout, func() error {
+			return setAdapterStateFn(powerkit.AdapterActionOn)
+		}); err != nil {
+			logger.Error("Failed to re-enable adapter: %v", err)
+			return smcError("re-enable adapter", err)
+		}
+		s.mu.Lock()
+		s.forceDischargeRequested = false
+		s.mu.Unlock()
+		return nil
+	}
+
+	s.mu.RLock()
+	missing := s.batteryMissing
+	floor := s.forceDischargeFloor
+	charge := -1
+	if s.lastIOKitStatus != nil {
+		charge = s.lastIOKitStatus.Battery.CurrentCharge
+	}
+	s.mu.RUnlock()
+	if missing {
+		return noBatteryError("force discharge")
+	}
+	if charge >= 0 && engine.ShouldStopForceDischarge(true, charge, floor) {
+		return status.Errorf(codes.FailedPrecondition, "charge %d%% is at or below the force discharge floor of %d%%", charge, floor)
+	}
+
+	if err := callWithTimeout(opTimeout, func() error {
+		return setAdapterStateFn(powerkit.AdapterActionOff)
+	}); err != nil {
+		logger.Error("Failed to force discharge (adapter off): %v", err)
+		return smcError("set force discharge", err)
+	}
+	s.mu.Lock()
+	s.forceDischargeRequested = true
+	s.forceDischargeFloorReached = false
+	s.mu.Unlock()
+	return nil
+}
+
+// enforceDischargeFloorLocked re-enables the adapter once a requested forced
+// discharge reaches the floor. info is updated so the rest of the logic run
+// sees the adapter back on.
+func (s *Daemon) enforceDischargeFloorLocked(info *powerkit.SystemInfo, charge int) {
+	if !engine.ShouldStopForceDischarge(s.forceDischargeRequested, charge, s.forceDischargeFloor) {
+		return
+	}
+
+	logger.Default("Charge %d%% reached the force discharge floor of %d%%. Re-enabling adapter.", charge, s.forceDischargeFloor)
+	if err := callWithTimeout(opTimeout, func() error {
+		return setAdapterStateFn(powerkit.AdapterActionOn)
+	}); err != nil {
+		logger.Error("Failed to stop force discharge at the floor: %v", err)
+		s.noteHardwareWriteLocked(err)
+		s.recordEvent("force_discharge_floor_failed", map[string]any{"charge": charge, "floor": s.forceDischargeFloor, "error": err.Error()})
+		return
+	}
+
+	s.noteHardwareWriteLocked(nil)
+	s.forceDischargeRequested = false
+	s.forceDischargeFloorReached = true
+	info.SMC.State.IsAdapterEnabled = true
+	s.recordEvent("force_discharge_floor_reached", map[string]any{"charge": charge, "floor": s.forceDischargeFloor})
+}
+
+// reapplyForceDischarge turns the adapter back off after wake when the user
+// had force discharge on, since macOS can re-enable it across sleep. It is a
+// no-op without that intent or while the daemon is not managing charging.
+func (s *Daemon) reapplyForceDischarge(attempt int) {
+	s.mu.RLock()
+	want := s.forceDischargeRequested && !s.managementDisabled && !s.batteryMissing
+	s.mu.RUnlock()
+	if !want || s.hardwareWritesBlocked("force discharge after wake") {
+		return
+	}
+
+	logger.Default("Re-applying force discharge after wake (attempt %d).", attempt)
+	if err := callWithTimeout(opTimeout, func() error {
+		return setAdapterStateFn(powerkit.AdapterActionOff)
+	}); err != nil {
+		logger.Error("Failed to re-apply force discharge after wake: %v", err)
+	}
+}
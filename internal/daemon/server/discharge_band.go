@@ -0,0 +1,93 @@
+package server
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/peterneutron/powerkit-go/pkg/powerkit"
+
+	cfg "powergrid/internal/config"
+	"powergrid/internal/daemon/engine"
+)
+
+const (
+	// minDischargeBandFloor keeps a band from draining the battery flat.
+	minDischargeBandFloor = 5
+	maxDischargeBandFloor = 95
+)
+
+// applySetDischargeBand stores the console user's discharge band and applies
+// it. A floor and ceiling of 0 clear the band.
+func (s *Daemon) applySetDischargeBand(floor, ceiling int32) error {
+	if floor != 0 || ceiling != 0 {
+		if floor < minDischargeBandFloor || floor > maxDischargeBandFloor {
+			return outOfRangeError("discharge_band_floor", int(floor), minDischargeBandFloor, maxDischargeBandFloor)
+		}
+		if ceiling <= floor || ceiling > 100 {
+			return outOfRangeError("discharge_band_ceiling", int(ceiling), int(floor)+1, 100)
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	u := s.currentConsoleUser
+	if u == nil {
+		return noConsoleUserError("discharge bands")
+	}
+	if err := cfg.WriteUserDischargeBand(u.HomeDir, u.UID, u.GID, int(floor), int(ceiling)); err != nil {
+		logger.Error("Failed to persist discharge band: %v", err)
+		return status.Errorf(codes.Internal, "failed to persist discharge band: %v", err)
+	}
+	s.dischargeBandFloor = int(floor)
+	s.dischargeBandCeiling = int(ceiling)
+	s.userPrefs.DischargeBandFloor, s.userPrefs.DischargeBandCeiling = int(floor), int(ceiling)
+	if ceiling == 0 {
+		logger.Default("Cleared the discharge band.")
+	} else {
+		logger.Default("Set discharge band %d%%-%d%%.", floor, ceiling)
+	}
+
+	s.runChargingLogicCachedLocked()
+	return nil
+}
+
+// applyDischargeBandLocked turns the adapter off once charge rises above the
+// band ceiling and back on at the floor, holding either state in between. It
+// stands aside while a one-shot force discharge runs, and re-asserts a
+// discharge that macOS undid, for example across sleep. info is updated so
+// the rest of the logic run sees the adapter state it set.
+func (s *Daemon) applyDischargeBandLocked(info *powerkit.SystemInfo, charge int) {
+	if s.forceDischargeRequested {
+		return
+	}
+	want := engine.DecideBandDischarge(charge, s.dischargeBandFloor, s.dischargeBandCeiling, s.bandDischarging)
+	adapterOn := info.SMC.State.IsAdapterEnabled
+	switch {
+	case want && adapterOn:
+		logger.Default("Charge %d%% is above the %d%% discharge band ceiling. Disabling adapter until %d%%.", charge, s.dischargeBandCeiling, s.dischargeBandFloor)
+		err := callWithTimeout(opTimeout, func() error {
+			return setAdapterStateFn(powerkit.AdapterActionOff)
+		})
+		s.noteHardwareWriteLocked(err)
+		if err != nil {
+			logger.Error("Failed to start band discharge: %v", err)
+			return
+		}
+		info.SMC.State.IsAdapterEnabled = false
+		s.recordEvent("discharge_band_started", map[string]any{"charge": charge, "floor": s.dischargeBandFloor, "ceiling": s.dischargeBandCeiling})
+	case !want && s.bandDischarging && !adapterOn:
+		logger.Default("Charge %d%% reached the %d%% discharge band floor. Re-enabling adapter.", charge, s.dischargeBandFloor)
+		err := callWithTimeout(opTimeout, func() error {
+			return setAdapterStateFn(powerkit.AdapterActionOn)
+		})
+		s.noteHardwareWriteLocked(err)
+		if err != nil {
+			logger.Error("Failed to stop band discharge: %v", err)
+			return
+		}
+		info.SMC.State.IsAdapterEnabled = true
+		s.recordEvent("discharge_band_stopped", map[string]any{"charge": charge, "floor": s.dischargeBandFloor, "ceiling": s.dischargeBandCeiling})
+	}
+	s.bandDischarging = want
+}
@@ -0,0 +1,76 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/peterneutron/powerkit-go/pkg/powerkit"
+
+	rpc "powergrid/internal/rpc"
+)
+
+func TestDischargeBandCyclesWithHysteresis(t *testing.T) {
+	resetServerTestGlobals(t)
+
+	var adapterActions []powerkit.AdapterAction
+	setAdapterStateFn = func(action powerkit.AdapterAction) error {
+		adapterActions = append(adapterActions, action)
+		return nil
+	}
+	setChargingStateFn = func(powerkit.ChargingAction) error { return nil }
+
+	d := &Daemon{currentLimit: 100, dischargeBandFloor: 60, dischargeBandCeiling: 80}
+	info := func(charge int, adapterOn bool) *powerkit.SystemInfo {
+		i := testSystemInfo(charge, true)
+		i.IOKit.State.IsConnected = true
+		i.SMC.State.IsAdapterEnabled = adapterOn
+		return i
+	}
+
+	d.runChargingLogicLocked(info(75, true))
+	if len(adapterActions) != 0 {
+		t.Fatalf("expected no discharge inside the band, got %v", adapterActions)
+	}
+	d.runChargingLogicLocked(info(81, true))
+	if len(adapterActions) != 1 || adapterActions[0] != powerkit.AdapterActionOff || !d.bandDischarging {
+		t.Fatalf("expected discharge above the ceiling, got %v", adapterActions)
+	}
+	d.runChargingLogicLocked(info(70, false))
+	if len(adapterActions) != 1 || !d.bandDischarging {
+		t.Fatalf("expected discharge to continue inside the band, got %v", adapterActions)
+	}
+	d.runChargingLogicLocked(info(60, false))
+	if len(adapterActions) != 2 || adapterActions[1] != powerkit.AdapterActionOn || d.bandDischarging {
+		t.Fatalf("expected the adapter back on at the floor, got %v", adapterActions)
+	}
+	d.runChargingLogicLocked(info(70, true))
+	if len(adapterActions) != 2 {
+		t.Fatalf("expected no discharge while recharging inside the band, got %v", adapterActions)
+	}
+}
+
+func TestSetDischargeBandValidates(t *testing.T) {
+	d := &Daemon{}
+	cases := []struct{ floor, ceiling int32 }{{2, 80}, {60, 60}, {60, 101}}
+	for _, tc := range cases {
+		_, err := d.ApplyMutation(context.Background(), &rpc.MutationRequest{
+			Operation:            rpc.MutationOperation_SET_DISCHARGE_BAND,
+			DischargeBandFloor:   tc.floor,
+			DischargeBandCeiling: tc.ceiling,
+		})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("band %d-%d: expected InvalidArgument, got %v", tc.floor, tc.ceiling, err)
+		}
+	}
+	_, err := d.ApplyMutation(context.Background(), &rpc.MutationRequest{
+		Operation:            rpc.MutationOperation_SET_DISCHARGE_BAND,
+		DischargeBandFloor:   60,
+		DischargeBandCeiling: 80,
+	})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition without a console user, got %v", err)
+	}
+}
@@ -0,0 +1,149 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/peterneutron/powerkit-go/pkg/powerkit"
+
+	rpc "powergrid/internal/rpc"
+)
+
+func TestForceDischargeStopsAtFloor(t *testing.T) {
+	resetServerTestGlobals(t)
+
+	var adapterActions []powerkit.AdapterAction
+	setAdapterStateFn = func(action powerkit.AdapterAction) error {
+		adapterActions = append(adapterActions, action)
+		return nil
+	}
+	setChargingStateFn = func(powerkit.ChargingAction) error { return nil }
+
+	d := &Daemon{currentLimit: 80, forceDischargeFloor: 20}
+	d.updateCachedStatusLocked(testSystemInfo(50, true))
+	if err := d.applyForceDischarge(true); err != nil {
+		t.Fatalf("applyForceDischarge returned error: %v", err)
+	}
+
+	draining := testSystemInfo(21, true)
+	draining.SMC.State.IsAdapterEnabled = false
+	d.runChargingLogicLocked(draining)
+	if len(adapterActions) != 1 || adapterActions[0] != powerkit.AdapterActionOff {
+		t.Fatalf("expected discharge to continue above the floor, got %v", adapterActions)
+	}
+
+	atFloor := testSystemInfo(20, true)
+	atFloor.SMC.State.IsAdapterEnabled = false
+	d.runChargingLogicLocked(atFloor)
+	if len(adapterActions) != 2 || adapterActions[1] != powerkit.AdapterActionOn {
+		t.Fatalf("expected adapter re-enabled at the floor, got %v", adapterActions)
+	}
+	if d.forceDischargeRequested || !d.forceDischargeFloorReached {
+		t.Fatalf("unexpected intent after floor: requested=%v reached=%v", d.forceDischargeRequested, d.forceDischargeFloorReached)
+	}
+
+	d.runChargingLogicLocked(atFloor)
+	if len(adapterActions) != 2 {
+		t.Fatalf("expected a single auto-stop, got %v", adapterActions)
+	}
+}
+
+func TestForceDischargeFloorIgnoresOtherAdapterOff(t *testing.T) {
+	resetServerTestGlobals(t)
+
+	setAdapterStateFn = func(action powerkit.AdapterAction) error {
+		t.Fatalf("unexpected adapter action %v", action)
+		return nil
+	}
+	setChargingStateFn = func(powerkit.ChargingAction) error { return nil }
+
+	d := &Daemon{currentLimit: 80, forceDischargeFloor: 20}
+	info := testSystemInfo(10, true)
+	info.SMC.State.IsAdapterEnabled = false
+	d.runChargingLogicLocked(info)
+}
+
+func TestForceDischargeBelowLimitKeepsChargingOff(t *testing.T) {
+	resetServerTestGlobals(t)
+
+	var chargingActions []powerkit.ChargingAction
+	setChargingStateFn = func(action powerkit.ChargingAction) error {
+		chargingActions = append(chargingActions, action)
+		return nil
+	}
+
+	d := &Daemon{currentLimit: 80, forceDischargeFloor: 20, forceDischargeRequested: true}
+	info := testSystemInfo(50, false)
+	info.IOKit.State.IsConnected = true
+	info.SMC.State.IsAdapterEnabled = false
+	d.runChargingLogicLocked(info)
+	if len(chargingActions) != 0 {
+		t.Fatalf("expected no charging enable during force discharge, got %v", chargingActions)
+	}
+
+	d.chargeDuringDischarge = true
+	d.runChargingLogicLocked(info)
+	if len(chargingActions) != 1 || chargingActions[0] != powerkit.ChargingActionOn {
+		t.Fatalf("expected charging enabled when allowed during discharge, got %v", chargingActions)
+	}
+}
+
+func TestForceDischargeRejectedAtFloor(t *testing.T) {
+	resetServerTestGlobals(t)
+
+	setAdapterStateFn = func(action powerkit.AdapterAction) error {
+		t.Fatalf("unexpected adapter action %v", action)
+		return nil
+	}
+
+	d := &Daemon{forceDischargeFloor: 20}
+	d.updateCachedStatusLocked(testSystemInfo(15, true))
+	err := d.applyPowerFeature(rpc.PowerFeature_FORCE_DISCHARGE, true)
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("unexpected error: got=%v want=%v", status.Code(err), codes.FailedPrecondition)
+	}
+
+	resp, err := d.GetStatus(context.Background(), &rpc.Empty{})
+	if err != nil {
+		t.Fatalf("GetStatus returned error: %v", err)
+	}
+	if resp.GetForceDischargeFloor() != 20 {
+		t.Fatalf("unexpected floor: got=%d want=%d", resp.GetForceDischargeFloor(), 20)
+	}
+}
+
+func TestReapplyForceDischargeAfterWake(t *testing.T) {
+	resetServerTestGlobals(t)
+
+	tests := []struct {
+		name string
+		d    *Daemon
+		want int
+	}{
+		{name: "requested", d: &Daemon{forceDischargeRequested: true}, want: 1},
+		{name: "not requested", d: &Daemon{}, want: 0},
+		{name: "management disabled", d: &Daemon{forceDischargeRequested: true, managementDisabled: true}, want: 0},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var actions []powerkit.AdapterAction
+			setAdapterStateFn = func(action powerkit.AdapterAction) error {
+				actions = append(actions, action)
+				return nil
+			}
+			tc.d.reapplyForceDischarge(1)
+			if len(actions) != tc.want {
+				t.Fatalf("unexpected adapter writes: got=%v want=%d", actions, tc.want)
+			}
+			for _, a := range actions {
+				if a != powerkit.AdapterActionOff {
+					t.Fatalf("expected adapter-off writes only, got %v", actions)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/peterneutron/powerkit-go/pkg/powerkit"
+)
+
+// externalDisplayPollInterval is how often the registry is checked for
+// external displays; IOKit offers no hotplug event through powerkit.
+const externalDisplayPollInterval = 5 * time.Second
+
+// startExternalDisplayWatcher keeps display sleep prevented while an external
+// display is attached, when PreventDisplaySleepWithExternalDisplay is on.
+func (s *Daemon) startExternalDisplayWatcher(ctx context.Context) {
+	if !s.externalDisplaySleep {
+		return
+	}
+	s.checkExternalDisplay()
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		ticker := time.NewTicker(externalDisplayPollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.checkExternalDisplay()
+			}
+		}
+	}()
+}
+
+func (s *Daemon) checkExternalDisplay() {
+	count, err := externalDisplayCountFn()
+	if err != nil {
+		logger.InfoLimited("External display check failed: %v", err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// Like a manual request, automatic display sleep prevention needs charge
+	// management.
+	want := count > 0 && !s.managementDisabled
+	if want == s.autoPreventDisplaySleep {
+		return
+	}
+	s.autoPreventDisplaySleep = want
+	if want {
+		logger.Default("External display attached; preventing display sleep.")
+	} else {
+		logger.Default("External display detached; no longer preventing display sleep automatically.")
+	}
+	if err := s.reconcileDisplayAssertionLocked(); err != nil {
+		logger.Error("Failed to create display sleep assertion: %v", err)
+	}
+}
+
+// reconcileDisplayAssertionLocked holds the display sleep assertion while a
+// manual request or an attached external display wants it, so releasing one
+// does not cancel the other.
+func (s *Daemon) reconcileDisplayAssertionLocked() error {
+	if s.wantPreventDisplaySleep || s.autoPreventDisplaySleep {
+		return s.holdAssertion(powerkit.AssertionTypePreventDisplaySleep, "PowerGrid: Prevent Display Sleep")
+	}
+	s.releaseAssertion(powerkit.AssertionTypePreventDisplaySleep)
+	return nil
+}
@@ -0,0 +1,63 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/peterneutron/powerkit-go/pkg/powerkit"
+
+	rpc "powergrid/internal/rpc"
+)
+
+func TestExternalDisplayHoldsDisplayAssertion(t *testing.T) {
+	resetServerTestGlobals(t)
+
+	held := false
+	createAssertionFn = func(powerkit.AssertionType, string) (powerkit.AssertionID, error) {
+		held = true
+		return 1, nil
+	}
+	releaseAssertionFn = func(powerkit.AssertionType) { held = false }
+	displays := 1
+	externalDisplayCountFn = func() (int, error) { return displays, nil }
+
+	d := &Daemon{externalDisplaySleep: true}
+	d.checkExternalDisplay()
+	if !held || !d.autoPreventDisplaySleep {
+		t.Fatalf("expected assertion on attach: held=%t auto=%t", held, d.autoPreventDisplaySleep)
+	}
+
+	// Turning the manual request off must not release the automatic one.
+	if err := d.applyPowerFeature(rpc.PowerFeature_PREVENT_DISPLAY_SLEEP, true); err != nil {
+		t.Fatalf("enable returned error: %v", err)
+	}
+	if err := d.applyPowerFeature(rpc.PowerFeature_PREVENT_DISPLAY_SLEEP, false); err != nil {
+		t.Fatalf("disable returned error: %v", err)
+	}
+	if !held {
+		t.Fatal("expected assertion kept while an external display is attached")
+	}
+
+	displays = 0
+	d.checkExternalDisplay()
+	if held || d.autoPreventDisplaySleep {
+		t.Fatalf("expected assertion released on detach: held=%t auto=%t", held, d.autoPreventDisplaySleep)
+	}
+}
+
+func TestExternalDisplayKeepsManualRequest(t *testing.T) {
+	resetServerTestGlobals(t)
+
+	held := true
+	createAssertionFn = func(powerkit.AssertionType, string) (powerkit.AssertionID, error) {
+		held = true
+		return 1, nil
+	}
+	releaseAssertionFn = func(powerkit.AssertionType) { held = false }
+	externalDisplayCountFn = func() (int, error) { return 0, nil }
+
+	d := &Daemon{externalDisplaySleep: true, wantPreventDisplaySleep: true, autoPreventDisplaySleep: true}
+	d.checkExternalDisplay()
+	if !held || d.autoPreventDisplaySleep {
+		t.Fatalf("expected manual request kept after detach: held=%t auto=%t", held, d.autoPreventDisplaySleep)
+	}
+}
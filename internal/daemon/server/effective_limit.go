@@ -0,0 +1,54 @@
+package server
+
+import "time"
+
+// effectiveLimitWriteDelay batches effective-limit changes, e.g. a limit
+// ramp stepping down or an adapter swap, into one plist write.
+const effectiveLimitWriteDelay = 5 * time.Second
+
+// noteEffectiveLimitLocked schedules mirroring the enforced limit into the
+// system plist when PersistEffectiveChargeLimit is on. Changes within
+// effectiveLimitWriteDelay collapse into a single write of the latest value.
+func (s *Daemon) noteEffectiveLimitLocked(limit int) {
+	if !s.persistEffectiveLimit {
+		return
+	}
+	s.pendingEffectiveLimit = limit
+	if limit == s.persistedEffectiveLimit {
+		s.stopEffectiveLimitTimerLocked()
+		return
+	}
+	if s.effectiveLimitTimer == nil {
+		s.effectiveLimitTimer = clock.AfterFunc(effectiveLimitWriteDelay, s.writePendingEffectiveLimit)
+	}
+}
+
+func (s *Daemon) writePendingEffectiveLimit() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.effectiveLimitTimer = nil
+	s.flushEffectiveLimitLocked()
+}
+
+// flushEffectiveLimitLocked writes a pending effective limit at once, e.g. on
+// shutdown, so the plist does not keep a stale value.
+func (s *Daemon) flushEffectiveLimitLocked() {
+	s.stopEffectiveLimitTimerLocked()
+	limit := s.pendingEffectiveLimit
+	if !s.persistEffectiveLimit || limit == 0 || limit == s.persistedEffectiveLimit {
+		return
+	}
+	if err := writeEffectiveLimitFn(limit); err != nil {
+		logger.Error("Failed to persist the effective charge limit: %v", err)
+		return
+	}
+	s.persistedEffectiveLimit = limit
+}
+
+func (s *Daemon) stopEffectiveLimitTimerLocked() {
+	if s.effectiveLimitTimer != nil {
+		s.effectiveLimitTimer.Stop()
+		s.effectiveLimitTimer = nil
+	}
+}
@@ -0,0 +1,86 @@
+package server
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestEffectiveLimitWritesAreDebounced(t *testing.T) {
+	resetServerTestGlobals(t)
+
+	clk := newFakeClock(time.Date(2026, 4, 20, 12, 0, 0, 0, time.UTC))
+	clock = clk
+	var written []int
+	writeEffectiveLimitFn = func(limit int) error {
+		written = append(written, limit)
+		return nil
+	}
+
+	d := &Daemon{persistEffectiveLimit: true}
+	d.mu.Lock()
+	d.noteEffectiveLimitLocked(80)
+	d.noteEffectiveLimitLocked(78)
+	d.noteEffectiveLimitLocked(75)
+	d.mu.Unlock()
+	if len(written) != 0 {
+		t.Fatalf("expected no write before the delay, got %v", written)
+	}
+
+	clk.Advance(effectiveLimitWriteDelay)
+	if !reflect.DeepEqual(written, []int{75}) {
+		t.Fatalf("expected one write of the latest limit, got %v", written)
+	}
+
+	// Returning to the persisted value cancels a pending write.
+	d.mu.Lock()
+	d.noteEffectiveLimitLocked(90)
+	d.noteEffectiveLimitLocked(75)
+	d.mu.Unlock()
+	clk.Advance(effectiveLimitWriteDelay)
+	if !reflect.DeepEqual(written, []int{75}) {
+		t.Fatalf("expected no write for an unchanged limit, got %v", written)
+	}
+}
+
+func TestEffectiveLimitFlushesOnShutdown(t *testing.T) {
+	resetServerTestGlobals(t)
+
+	clock = newFakeClock(time.Date(2026, 4, 20, 12, 0, 0, 0, time.UTC))
+	var written []int
+	writeEffectiveLimitFn = func(limit int) error {
+		written = append(written, limit)
+		return nil
+	}
+
+	d := &Daemon{persistEffectiveLimit: true, persistedEffectiveLimit: 80}
+	d.mu.Lock()
+	d.noteEffectiveLimitLocked(70)
+	d.mu.Unlock()
+	d.handleShutdown(false)
+
+	if !reflect.DeepEqual(written, []int{70}) {
+		t.Fatalf("expected the pending limit written on shutdown, got %v", written)
+	}
+	if d.effectiveLimitTimer != nil {
+		t.Fatal("expected the pending write timer to be stopped")
+	}
+}
+
+func TestEffectiveLimitNotPersistedByDefault(t *testing.T) {
+	resetServerTestGlobals(t)
+
+	clk := newFakeClock(time.Date(2026, 4, 20, 12, 0, 0, 0, time.UTC))
+	clock = clk
+	writeEffectiveLimitFn = func(int) error {
+		t.Fatal("unexpected effective limit write")
+		return nil
+	}
+
+	d := &Daemon{}
+	d.mu.Lock()
+	d.noteEffectiveLimitLocked(80)
+	d.mu.Unlock()
+	clk.Advance(effectiveLimitWriteDelay)
+	d.handleShutdown(false)
+}
@@ -0,0 +1,55 @@
+package server
+
+import (
+	"fmt"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	rpc "powergrid/internal/rpc"
+)
+
+// errorDomain is the google.rpc.ErrorInfo domain for daemon errors.
+const errorDomain = "com.neutronstar.powergrid"
+
+// reasonError builds a status error carrying an ErrorInfo detail, so clients
+// can switch on the reason instead of parsing the message.
+func reasonError(code codes.Code, reason rpc.ErrorReason, metadata map[string]string, format string, args ...any) error {
+	st := status.Newf(code, format, args...)
+	if detailed, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason:   reason.String(),
+		Domain:   errorDomain,
+		Metadata: metadata,
+	}); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
+
+func outOfRangeError(field string, value, lo, hi int) error {
+	return reasonError(codes.InvalidArgument, rpc.ErrorReason_ERROR_VALUE_OUT_OF_RANGE, map[string]string{
+		"field": field,
+		"value": fmt.Sprint(value),
+		"min":   fmt.Sprint(lo),
+		"max":   fmt.Sprint(hi),
+	}, "%s out of range: %d (%d-%d)", field, value, lo, hi)
+}
+
+// smcError reports a failed or timed-out SMC write. The hardware is left as
+// it was, so the call is safe to retry.
+func smcError(action string, err error) error {
+	return reasonError(codes.Unavailable, rpc.ErrorReason_ERROR_SMC_BUSY, nil, "failed to %s: %v", action, err)
+}
+
+func noConsoleUserError(what string) error {
+	return reasonError(codes.FailedPrecondition, rpc.ErrorReason_ERROR_NO_CONSOLE_USER, nil, "%s require an active console user", what)
+}
+
+func noBatteryError(what string) error {
+	return reasonError(codes.FailedPrecondition, rpc.ErrorReason_ERROR_NO_BATTERY, nil, "%s requires a battery", what)
+}
+
+func unsupportedHardwareError(feature string) error {
+	return reasonError(codes.FailedPrecondition, rpc.ErrorReason_ERROR_UNSUPPORTED_HARDWARE, map[string]string{"feature": feature}, "%s is not supported on this hardware", feature)
+}
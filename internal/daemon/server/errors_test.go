@@ -0,0 +1,82 @@
+package server
+
+import (
+	"testing"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	rpc "powergrid/internal/rpc"
+)
+
+func errorReason(t *testing.T, err error) (codes.Code, string) {
+	t.Helper()
+	st := status.Convert(err)
+	for _, d := range st.Details() {
+		if info, ok := d.(*errdetails.ErrorInfo); ok {
+			if info.GetDomain() != errorDomain {
+				t.Fatalf("unexpected error domain: got=%q want=%q", info.GetDomain(), errorDomain)
+			}
+			return st.Code(), info.GetReason()
+		}
+	}
+	return st.Code(), ""
+}
+
+func TestHandlerErrorReasons(t *testing.T) {
+	resetServerTestGlobals(t)
+
+	tests := []struct {
+		name     string
+		call     func(d *Daemon) error
+		wantCode codes.Code
+		want     rpc.ErrorReason
+	}{
+		{
+			name:     "limit out of range",
+			call:     func(d *Daemon) error { return d.applySetChargeLimit(50) },
+			wantCode: codes.InvalidArgument,
+			want:     rpc.ErrorReason_ERROR_VALUE_OUT_OF_RANGE,
+		},
+		{
+			name:     "boost out of range",
+			call:     func(d *Daemon) error { return d.applyChargeBoost(maxBoostMinutes + 1) },
+			wantCode: codes.InvalidArgument,
+			want:     rpc.ErrorReason_ERROR_VALUE_OUT_OF_RANGE,
+		},
+		{
+			name:     "no console user",
+			call:     func(d *Daemon) error { return d.applyProfile("travel") },
+			wantCode: codes.FailedPrecondition,
+			want:     rpc.ErrorReason_ERROR_NO_CONSOLE_USER,
+		},
+		{
+			name: "magsafe LED unsupported",
+			call: func(d *Daemon) error {
+				return d.applyPowerFeature(rpc.PowerFeature_CONTROL_MAGSAFE_LED, true)
+			},
+			wantCode: codes.FailedPrecondition,
+			want:     rpc.ErrorReason_ERROR_UNSUPPORTED_HARDWARE,
+		},
+		{
+			name: "no battery",
+			call: func(d *Daemon) error {
+				d.batteryMissing = true
+				return d.applyForceDischarge(true)
+			},
+			wantCode: codes.FailedPrecondition,
+			want:     rpc.ErrorReason_ERROR_NO_BATTERY,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			d := &Daemon{currentLimit: 80}
+			code, reason := errorReason(t, tc.call(d))
+			if code != tc.wantCode || reason != tc.want.String() {
+				t.Fatalf("unexpected error: got=(%v,%q) want=(%v,%q)", code, reason, tc.wantCode, tc.want.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package server
+
+import (
+	"context"
+
+	"powergrid/internal/daemon/engine"
+	rpc "powergrid/internal/rpc"
+)
+
+// GetTimeEstimates returns IOKit's time to full and empty alongside
+// estimates smoothed over the recent charge history, which stay sensible
+// right after plug-in when IOKit's figures swing wildly. The smoothed time
+// to full counts toward the effective limit, since charging stops there.
+func (s *Daemon) GetTimeEstimates(_ context.Context, _ *rpc.Empty) (*rpc.TimeEstimatesResponse, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	target, _ := s.effectiveLimitLocked()
+	est := engine.EstimateTimes(s.chargeHistory, target)
+	resp := &rpc.TimeEstimatesResponse{
+		SmoothedTimeToLimitMinutes: int32(est.ToTarget.Minutes()),
+		SmoothedTimeToEmptyMinutes: int32(est.ToEmpty.Minutes()),
+		Confidence:                 estimateConfidenceToRPC(est.Confidence),
+		TargetCharge:               int32(target),
+		SampleCount:                int32(est.Samples),
+		SampleSpanSeconds:          int32(est.Span.Seconds()),
+	}
+	if s.lastIOKitStatus != nil {
+		resp.IokitTimeToFullMinutes = int32(s.lastIOKitStatus.Battery.TimeToFull)
+		resp.IokitTimeToEmptyMinutes = int32(s.lastIOKitStatus.Battery.TimeToEmpty)
+	}
+	return resp, nil
+}
+
+func estimateConfidenceToRPC(c engine.EstimateConfidence) rpc.EstimateConfidence {
+	switch c {
+	case engine.EstimateLow:
+		return rpc.EstimateConfidence_ESTIMATE_CONFIDENCE_LOW
+	case engine.EstimateMedium:
+		return rpc.EstimateConfidence_ESTIMATE_CONFIDENCE_MEDIUM
+	case engine.EstimateHigh:
+		return rpc.EstimateConfidence_ESTIMATE_CONFIDENCE_HIGH
+	default:
+		return rpc.EstimateConfidence_ESTIMATE_CONFIDENCE_UNSPECIFIED
+	}
+}
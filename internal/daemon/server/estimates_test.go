@@ -0,0 +1,38 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/peterneutron/powerkit-go/pkg/powerkit"
+
+	"powergrid/internal/daemon/engine"
+	rpc "powergrid/internal/rpc"
+)
+
+func TestGetTimeEstimatesReturnsRawAndSmoothed(t *testing.T) {
+	start := time.Date(2026, 4, 20, 9, 0, 0, 0, time.UTC)
+	d := &Daemon{currentLimit: 80, lastIOKitStatus: &powerkit.IOKitData{}}
+	d.lastIOKitStatus.Battery.TimeToFull = 5
+	for minute := 0; minute <= 10; minute += 2 {
+		d.chargeHistory = append(d.chargeHistory, engine.ChargeSample{At: start.Add(time.Duration(minute) * time.Minute), Charge: 50 + minute/2, Expecting: true})
+	}
+
+	resp, err := d.GetTimeEstimates(context.Background(), &rpc.Empty{})
+	if err != nil {
+		t.Fatalf("GetTimeEstimates returned error: %v", err)
+	}
+	if resp.GetIokitTimeToFullMinutes() != 5 {
+		t.Fatalf("expected the raw IOKit value, got %d", resp.GetIokitTimeToFullMinutes())
+	}
+	if resp.GetSmoothedTimeToLimitMinutes() != 50 || resp.GetTargetCharge() != 80 {
+		t.Fatalf("expected 50 minutes to 80%%, got %d minutes to %d%%", resp.GetSmoothedTimeToLimitMinutes(), resp.GetTargetCharge())
+	}
+	if resp.GetConfidence() != rpc.EstimateConfidence_ESTIMATE_CONFIDENCE_HIGH {
+		t.Fatalf("expected high confidence for a steady rate, got %v", resp.GetConfidence())
+	}
+	if resp.GetSampleCount() != 6 || resp.GetSampleSpanSeconds() != 600 {
+		t.Fatalf("unexpected sample summary: %d samples over %ds", resp.GetSampleCount(), resp.GetSampleSpanSeconds())
+	}
+}
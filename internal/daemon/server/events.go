@@ -0,0 +1,44 @@
+package server
+
+import (
+	"github.com/peterneutron/powerkit-go/pkg/powerkit"
+
+	cfg "powergrid/internal/config"
+	"powergrid/internal/eventlog"
+)
+
+// openEventLog returns nil when the audit log is disabled or cannot be
+// opened; a nil log discards events so callers never need to check.
+func openEventLog(settings cfg.EventLogSettings) *eventlog.Log {
+	if !settings.Enabled {
+		return nil
+	}
+	l, err := eventlog.Open(settings.Path, settings.MaxBytes)
+	if err != nil {
+		logger.Error("Failed to open event log; continuing with os_log only: %v", err)
+		return nil
+	}
+	return l
+}
+
+func (s *Daemon) recordEvent(event string, fields map[string]any) {
+	if err := s.events.Record(event, fields); err != nil {
+		logger.Error("Failed to record %s event: %v", event, err)
+	}
+}
+
+// recordAdapterChangeLocked logs plug and unplug transitions between
+// consecutive IOKit snapshots.
+func (s *Daemon) recordAdapterChangeLocked(prev, next *powerkit.IOKitData) {
+	if prev == nil || next == nil || prev.State.IsConnected == next.State.IsConnected {
+		return
+	}
+	if !next.State.IsConnected {
+		s.recordEvent("adapter_disconnected", nil)
+		return
+	}
+	s.recordEvent("adapter_connected", map[string]any{
+		"description": next.Adapter.Description,
+		"max_watts":   next.Adapter.MaxWatts,
+	})
+}
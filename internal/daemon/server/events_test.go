@@ -0,0 +1,48 @@
+package server
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/peterneutron/powerkit-go/pkg/powerkit"
+
+	cfg "powergrid/internal/config"
+)
+
+func TestChargingDecisionRecordedToEventLog(t *testing.T) {
+	resetServerTestGlobals(t)
+	setChargingStateFn = func(powerkit.ChargingAction) error { return nil }
+
+	path := filepath.Join(t.TempDir(), "events.log")
+	d := &Daemon{currentLimit: 80, events: openEventLog(cfg.EventLogSettings{Enabled: true, Path: path})}
+
+	prev := testSystemInfo(70, true)
+	prev.IOKit.State.IsConnected = false
+	d.updateCachedStatusLocked(prev)
+
+	info := testSystemInfo(80, true)
+	info.IOKit.State.IsConnected = true
+	d.runChargingLogicLocked(info)
+
+	if err := d.events.Close(); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read event log: %v", err)
+	}
+	got := string(data)
+	for _, want := range []string{`"event":"adapter_connected"`, `"event":"charging_disabled"`, `"limit":80`} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("expected event log to contain %s, got:\n%s", want, got)
+		}
+	}
+}
+
+func TestOpenEventLogDisabled(t *testing.T) {
+	if l := openEventLog(cfg.EventLogSettings{Path: filepath.Join(t.TempDir(), "events.log")}); l != nil {
+		t.Fatal("expected no event log when disabled")
+	}
+}
@@ -0,0 +1,51 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/peterneutron/powerkit-go/pkg/powerkit"
+
+	rpc "powergrid/internal/rpc"
+)
+
+func TestExternalHoldReportedAfterGrace(t *testing.T) {
+	resetServerTestGlobals(t)
+
+	now := time.Date(2026, 4, 20, 10, 0, 0, 0, time.UTC)
+	clk := newFakeClock(now)
+	clock = clk
+	setChargingStateFn = func(powerkit.ChargingAction) error { return nil }
+
+	info := testSystemInfo(70, true)
+	info.IOKit.State.IsConnected = true
+	info.SMC.State.IsAdapterEnabled = true
+
+	d := &Daemon{currentLimit: 80}
+	d.runChargingLogicLocked(info)
+
+	resp, err := d.GetStatus(context.Background(), &rpc.Empty{})
+	if err != nil {
+		t.Fatalf("GetStatus returned error: %v", err)
+	}
+	if resp.GetExternalChargeHold() {
+		t.Fatal("expected hold to stay unreported inside the grace window")
+	}
+
+	now = now.Add(externalHoldGrace)
+	clk.Set(now)
+	resp, err = d.GetStatus(context.Background(), &rpc.Empty{})
+	if err != nil {
+		t.Fatalf("GetStatus returned error: %v", err)
+	}
+	if !resp.GetExternalChargeHold() {
+		t.Fatal("expected hold to be reported after the grace window")
+	}
+
+	info.IOKit.State.IsCharging = true
+	d.runChargingLogicLocked(info)
+	if !d.externalHoldSince.IsZero() {
+		t.Fatal("expected hold tracking to clear once charging resumes")
+	}
+}
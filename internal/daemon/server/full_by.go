@@ -0,0 +1,98 @@
+package server
+
+import (
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"powergrid/internal/daemon/engine"
+)
+
+// maxFullByHorizon bounds how far ahead a full-by deadline may be set.
+const maxFullByHorizon = 7 * 24 * time.Hour
+
+// applyFullBy plans to charge past the limit so charge reaches target by the
+// deadline. The client supplies the deadline, e.g. from the next alarm or
+// calendar event; the daemon only works backward from it using the observed
+// charge rate. A zero deadline cancels the plan and a zero target means 100%.
+func (s *Daemon) applyFullBy(deadlineUnix int64, target int32) error {
+	if target == 0 {
+		target = 100
+	}
+	if floor := s.chargeLimitFloor(); int(target) < floor || target > 100 {
+		return outOfRangeError("limit", int(target), floor, 100)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if deadlineUnix == 0 {
+		if !s.fullByDeadline.IsZero() {
+			s.clearFullByLocked()
+			logger.Default("Full-by charge plan cancelled.")
+		}
+		s.runChargingLogicCachedLocked()
+		return nil
+	}
+	if s.batteryMissing {
+		return noBatteryError("full-by charge plan")
+	}
+	deadline := time.Unix(deadlineUnix, 0)
+	now := clock.Now()
+	if !deadline.After(now) || deadline.Sub(now) > maxFullByHorizon {
+		return status.Errorf(codes.InvalidArgument, "full_by_unix must be within the next %s", maxFullByHorizon)
+	}
+
+	s.clearFullByLocked()
+	s.fullByDeadline = deadline
+	s.fullByTarget = int(target)
+	logger.Default("Planning to reach %d%% by %s (starting about %s).", target, deadline.Format(time.RFC3339), s.fullByStartLocked().Format(time.RFC3339))
+
+	s.runChargingLogicCachedLocked()
+	return nil
+}
+
+// fullByStartLocked returns when the full-by plan begins charging past the
+// limit: the recorded start once it has begun, otherwise an estimate from the
+// current charge and charge rate.
+func (s *Daemon) fullByStartLocked() time.Time {
+	if !s.fullByStarted.IsZero() {
+		return s.fullByStarted
+	}
+	charge := 0
+	if s.lastIOKitStatus != nil {
+		charge = s.lastIOKitStatus.Battery.CurrentCharge
+	}
+	return engine.FullByStart(s.fullByDeadline, charge, s.fullByTarget, engine.ChargePerPercent(s.chargeHistory))
+}
+
+// fullByActiveLocked reports whether the full-by plan is charging past the
+// limit. Once started it stays active until the deadline, since the rising
+// charge would otherwise keep pushing the estimated start later.
+func (s *Daemon) fullByActiveLocked(now time.Time) bool {
+	if s.fullByDeadline.IsZero() {
+		return false
+	}
+	if !now.Before(s.fullByDeadline) {
+		logger.Default("Full-by charge plan reached its deadline.")
+		s.recordEvent("full_by_ended", map[string]any{"target": s.fullByTarget})
+		s.clearFullByLocked()
+		return false
+	}
+	if s.fullByStarted.IsZero() {
+		if now.Before(s.fullByStartLocked()) {
+			return false
+		}
+		s.fullByStarted = now
+		logger.Default("Full-by charge plan started; charging toward %d%% by %s.", s.fullByTarget, s.fullByDeadline.Format(time.RFC3339))
+		s.recordEvent("full_by_started", map[string]any{"target": s.fullByTarget})
+	}
+	return true
+}
+
+func (s *Daemon) clearFullByLocked() {
+	s.fullByDeadline = time.Time{}
+	s.fullByTarget = 0
+	s.fullByStarted = time.Time{}
+}
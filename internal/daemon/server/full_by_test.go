@@ -0,0 +1,68 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/peterneutron/powerkit-go/pkg/powerkit"
+)
+
+func TestFullByChargesPastLimitBeforeDeadline(t *testing.T) {
+	resetServerTestGlobals(t)
+
+	deadline := time.Date(2026, 5, 4, 7, 0, 0, 0, time.UTC)
+	now := deadline.Add(-2 * time.Hour)
+	clk := newFakeClock(now)
+	clock = clk
+
+	var actions []powerkit.ChargingAction
+	setChargingStateFn = func(action powerkit.ChargingAction) error {
+		actions = append(actions, action)
+		return nil
+	}
+
+	// 20% at the default rate plus the margin starts the plan at 05:50.
+	d := &Daemon{currentLimit: 80, fullByDeadline: deadline, fullByTarget: 100}
+	d.runChargingLogicLocked(connectedInfo(80, false, "desk", 96))
+	if len(actions) != 0 {
+		t.Fatalf("expected the limit held before the planned start, got %v", actions)
+	}
+
+	now = deadline.Add(-time.Hour)
+	clk.Set(now)
+	d.runChargingLogicLocked(connectedInfo(80, false, "desk", 96))
+	if len(actions) != 1 || actions[0] != powerkit.ChargingActionOn {
+		t.Fatalf("expected charging enabled once the plan started, got %v", actions)
+	}
+	if !d.fullByStarted.Equal(now) {
+		t.Fatalf("unexpected plan start: got=%v want=%v", d.fullByStarted, now)
+	}
+
+	clk.Set(deadline)
+	d.runChargingLogicLocked(connectedInfo(95, true, "desk", 96))
+	if len(actions) != 2 || actions[1] != powerkit.ChargingActionOff {
+		t.Fatalf("expected the limit enforced after the deadline, got %v", actions)
+	}
+	if !d.fullByDeadline.IsZero() {
+		t.Fatalf("expected plan cleared, got deadline %v", d.fullByDeadline)
+	}
+}
+
+func TestFullByRejectsPastDeadline(t *testing.T) {
+	resetServerTestGlobals(t)
+
+	now := time.Date(2026, 5, 4, 7, 0, 0, 0, time.UTC)
+	clock = newFakeClock(now)
+
+	d := &Daemon{currentLimit: 80}
+	err := d.applyFullBy(now.Add(-time.Minute).Unix(), 100)
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument, got %v", err)
+	}
+	if !d.fullByDeadline.IsZero() {
+		t.Fatalf("expected no plan, got deadline %v", d.fullByDeadline)
+	}
+}
@@ -0,0 +1,64 @@
+package server
+
+import (
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	rpc "powergrid/internal/rpc"
+)
+
+// smcFailureThreshold is how many consecutive status reads without SMC data
+// flip the health service to NOT_SERVING.
+const smcFailureThreshold = 3
+
+// noteSystemInfoLocked records the outcome of a status read for the health
+// service. smcOK is false when the read failed or returned no SMC data.
+func (s *Daemon) noteSystemInfoLocked(smcOK bool) {
+	if smcOK {
+		s.systemInfoSeen = true
+		s.smcFailures = 0
+	} else {
+		s.smcFailures++
+	}
+	s.updateHealthLocked()
+}
+
+func (s *Daemon) markEventStreamUp() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.eventStreamUp = true
+	s.updateHealthLocked()
+}
+
+// servingLocked reports SERVING once the event stream is up (or polling-only
+// mode skips it) and a status read has succeeded, until SMC reads start
+// failing repeatedly.
+func (s *Daemon) servingLocked() healthpb.HealthCheckResponse_ServingStatus {
+	if (s.eventStreamUp || s.pollingOnly) && s.systemInfoSeen && s.smcFailures < smcFailureThreshold {
+		return healthpb.HealthCheckResponse_SERVING
+	}
+	return healthpb.HealthCheckResponse_NOT_SERVING
+}
+
+func (s *Daemon) updateHealthLocked() {
+	if s.health == nil {
+		return
+	}
+	next := s.servingLocked()
+	if next == s.lastHealth {
+		return
+	}
+	s.lastHealth = next
+	logger.Default("Health status changed to %s.", next)
+	s.health.SetServingStatus("", next)
+	s.health.SetServingStatus(rpc.PowerGrid_ServiceDesc.ServiceName, next)
+}
+
+// newHealthServer starts NOT_SERVING for both the overall server and the
+// PowerGrid service until the daemon reports otherwise.
+func newHealthServer() *health.Server {
+	hs := health.NewServer()
+	hs.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	hs.SetServingStatus(rpc.PowerGrid_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_NOT_SERVING)
+	return hs
+}
@@ -0,0 +1,126 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/peterneutron/powerkit-go/pkg/powerkit"
+
+	rpc "powergrid/internal/rpc"
+)
+
+func checkHealth(t *testing.T, d *Daemon) healthpb.HealthCheckResponse_ServingStatus {
+	t.Helper()
+	resp, err := d.health.Check(context.Background(), &healthpb.HealthCheckRequest{})
+	if err != nil {
+		t.Fatalf("health Check returned error: %v", err)
+	}
+	return resp.GetStatus()
+}
+
+func TestHealthTracksStreamAndSMCReads(t *testing.T) {
+	resetServerTestGlobals(t)
+
+	setChargingStateFn = func(powerkit.ChargingAction) error { return nil }
+	smcOK := true
+	getSystemInfoFn = func(...powerkit.FetchOptions) (*powerkit.SystemInfo, error) {
+		info := testSystemInfo(50, true)
+		if !smcOK {
+			info.SMC = nil
+		}
+		return info, nil
+	}
+
+	d := &Daemon{currentLimit: 80, health: newHealthServer()}
+	if got := checkHealth(t, d); got != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Fatalf("unexpected initial status: got=%v want=%v", got, healthpb.HealthCheckResponse_NOT_SERVING)
+	}
+
+	d.runChargingLogicLocked(nil)
+	if got := checkHealth(t, d); got != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Fatalf("unexpected status before event stream: got=%v want=%v", got, healthpb.HealthCheckResponse_NOT_SERVING)
+	}
+
+	d.markEventStreamUp()
+	if got := checkHealth(t, d); got != healthpb.HealthCheckResponse_SERVING {
+		t.Fatalf("unexpected status once ready: got=%v want=%v", got, healthpb.HealthCheckResponse_SERVING)
+	}
+
+	streamed := testSystemInfo(50, true)
+	streamed.SMC = nil
+	d.runChargingLogicLocked(streamed)
+	if got := checkHealth(t, d); got != healthpb.HealthCheckResponse_SERVING {
+		t.Fatalf("stream events without SMC must not count as failures: got=%v", got)
+	}
+
+	smcOK = false
+	for i := 0; i < smcFailureThreshold; i++ {
+		d.runChargingLogicLocked(nil)
+	}
+	if got := checkHealth(t, d); got != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Fatalf("unexpected status after SMC failures: got=%v want=%v", got, healthpb.HealthCheckResponse_NOT_SERVING)
+	}
+
+	smcOK = true
+	d.runChargingLogicLocked(nil)
+	if got := checkHealth(t, d); got != healthpb.HealthCheckResponse_SERVING {
+		t.Fatalf("unexpected status after recovery: got=%v want=%v", got, healthpb.HealthCheckResponse_SERVING)
+	}
+}
+
+func TestHealthNilServerIsNoop(t *testing.T) {
+	d := &Daemon{}
+	d.markEventStreamUp()
+	d.noteSystemInfoLocked(true)
+}
+
+func TestRefreshRunsLogicOnFreshRead(t *testing.T) {
+	resetServerTestGlobals(t)
+
+	var actions []powerkit.ChargingAction
+	setChargingStateFn = func(action powerkit.ChargingAction) error {
+		actions = append(actions, action)
+		return nil
+	}
+	getSystemInfoFn = func(...powerkit.FetchOptions) (*powerkit.SystemInfo, error) {
+		return connectedInfo(85, true, "desk", 96), nil
+	}
+
+	d := &Daemon{currentLimit: 80, health: newHealthServer()}
+	resp, err := d.Refresh(context.Background(), &rpc.Empty{})
+	if err != nil {
+		t.Fatalf("Refresh returned error: %v", err)
+	}
+	if len(actions) != 1 || actions[0] != powerkit.ChargingActionOff {
+		t.Fatalf("unexpected charging writes: got=%v want=%v", actions, []powerkit.ChargingAction{powerkit.ChargingActionOff})
+	}
+	if resp.GetCurrentCharge() != 85 || !resp.GetIsConnected() {
+		t.Fatalf("unexpected status: got=(%d,%t) want=(%d,%t)", resp.GetCurrentCharge(), resp.GetIsConnected(), 85, true)
+	}
+}
+
+func TestHealthServesWithoutStreamInPollingOnlyMode(t *testing.T) {
+	resetServerTestGlobals(t)
+
+	setChargingStateFn = func(powerkit.ChargingAction) error { return nil }
+	getSystemInfoFn = func(...powerkit.FetchOptions) (*powerkit.SystemInfo, error) {
+		return testSystemInfo(50, true), nil
+	}
+
+	d := &Daemon{currentLimit: 80, pollingOnly: true, pollInterval: 10 * time.Second, health: newHealthServer()}
+	d.runChargingLogicLocked(nil)
+	if got := checkHealth(t, d); got != healthpb.HealthCheckResponse_SERVING {
+		t.Fatalf("polling-only mode should serve after a read: got=%v", got)
+	}
+	if got := d.tickInterval(); got != 10*time.Second {
+		t.Fatalf("unexpected polling interval: got=%v want=%v", got, 10*time.Second)
+	}
+
+	d.pollingOnly = false
+	if got := d.tickInterval(); got != recomputeInterval {
+		t.Fatalf("event-driven mode should tick every %v, got %v", recomputeInterval, got)
+	}
+}
@@ -0,0 +1,19 @@
+package server
+
+// rememberUserLimitLocked records the console user's limit so it can apply
+// before the next login when RememberLastUserChargeLimit is on. Unchanged
+// limits are not rewritten.
+func (s *Daemon) rememberUserLimitLocked() {
+	if !s.rememberUserLimit || s.currentConsoleUser == nil {
+		return
+	}
+	limit := int(s.currentLimit)
+	if limit == s.rememberedLimit {
+		return
+	}
+	if err := writeLastUserLimitFn(limit); err != nil {
+		logger.Error("Failed to remember the last user charge limit: %v", err)
+		return
+	}
+	s.rememberedLimit = limit
+}
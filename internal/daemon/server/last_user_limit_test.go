@@ -0,0 +1,39 @@
+package server
+
+import (
+	"testing"
+
+	consoleuser "powergrid/internal/consoleuser"
+)
+
+func TestRememberUserLimitWritesChanges(t *testing.T) {
+	resetServerTestGlobals(t)
+
+	var written []int
+	writeLastUserLimitFn = func(limit int) error {
+		written = append(written, limit)
+		return nil
+	}
+
+	d := &Daemon{currentLimit: 70, rememberedLimit: 70, rememberUserLimit: true}
+	d.rememberUserLimitLocked()
+	if len(written) != 0 {
+		t.Fatalf("expected no write without a console user, got %v", written)
+	}
+
+	d.currentConsoleUser = &consoleuser.ConsoleUser{Username: "alice", HomeDir: "/Users/alice"}
+	d.rememberUserLimitLocked()
+	d.currentLimit = 90
+	d.rememberUserLimitLocked()
+	d.rememberUserLimitLocked()
+	if len(written) != 1 || written[0] != 90 {
+		t.Fatalf("expected one write of the changed limit, got %v", written)
+	}
+
+	d.rememberUserLimit = false
+	d.currentLimit = 60
+	d.rememberUserLimitLocked()
+	if len(written) != 1 {
+		t.Fatalf("expected no write while the option is off, got %v", written)
+	}
+}
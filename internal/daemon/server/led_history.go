@@ -0,0 +1,46 @@
+package server
+
+import (
+	"sort"
+	"time"
+
+	"github.com/peterneutron/powerkit-go/pkg/powerkit"
+
+	rpc "powergrid/internal/rpc"
+)
+
+// noteLEDAppliedLocked stamps when state was last written to the LED, so
+// reports such as "stuck amber" or "wrong color after wake" can be matched
+// against the daemon's actual transitions.
+func (s *Daemon) noteLEDAppliedLocked(state powerkit.MagsafeLEDState) {
+	if s.ledAppliedAt == nil {
+		s.ledAppliedAt = map[powerkit.MagsafeLEDState]time.Time{}
+	}
+	s.ledAppliedAt[state] = clock.Now()
+}
+
+// setLEDHistoryStatusLocked reports the committed LED state, when each state
+// was last written (newest first) and the most recent failed write.
+func (s *Daemon) setLEDHistoryStatusLocked(resp *rpc.StatusResponse) {
+	if len(s.ledAppliedAt) > 0 {
+		resp.MagsafeLedCommittedState = ledStateName(s.lastLEDState)
+	}
+	for state, at := range s.ledAppliedAt {
+		resp.MagsafeLedTransitions = append(resp.MagsafeLedTransitions, &rpc.MagsafeLedTransition{
+			State:       ledStateName(state),
+			AppliedUnix: at.Unix(),
+		})
+	}
+	sort.Slice(resp.MagsafeLedTransitions, func(i, j int) bool {
+		a, b := resp.MagsafeLedTransitions[i], resp.MagsafeLedTransitions[j]
+		if a.AppliedUnix != b.AppliedUnix {
+			return a.AppliedUnix > b.AppliedUnix
+		}
+		return a.State < b.State
+	})
+	resp.MagsafeLedWritePending = s.ledDirty
+	if !s.ledFailedAt.IsZero() {
+		resp.MagsafeLedLastFailureUnix = s.ledFailedAt.Unix()
+		resp.MagsafeLedLastError = s.ledLastError
+	}
+}
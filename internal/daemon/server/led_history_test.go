@@ -0,0 +1,46 @@
+package server
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/peterneutron/powerkit-go/pkg/powerkit"
+
+	rpc "powergrid/internal/rpc"
+)
+
+func TestLEDHistoryStatus(t *testing.T) {
+	resetServerTestGlobals(t)
+	start := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	clk := newFakeClock(start)
+	clock = clk
+
+	d := &Daemon{ledRetryLimit: 0}
+	resp := &rpc.StatusResponse{}
+	d.setLEDHistoryStatusLocked(resp)
+	if resp.MagsafeLedCommittedState != "" || len(resp.MagsafeLedTransitions) != 0 {
+		t.Fatalf("expected no LED history before the first write, got %+v", resp)
+	}
+
+	d.markLEDWrittenLocked(powerkit.LEDGreen)
+	clk.Advance(time.Minute)
+	d.markLEDWrittenLocked(powerkit.LEDAmber)
+	clk.Advance(time.Minute)
+	d.markLEDFailedLocked(errors.New("smc busy"))
+
+	resp = &rpc.StatusResponse{}
+	d.setLEDHistoryStatusLocked(resp)
+	if resp.MagsafeLedCommittedState != "amber" {
+		t.Fatalf("expected amber committed, got %q", resp.MagsafeLedCommittedState)
+	}
+	if len(resp.MagsafeLedTransitions) != 2 ||
+		resp.MagsafeLedTransitions[0].State != "amber" || resp.MagsafeLedTransitions[0].AppliedUnix != start.Add(time.Minute).Unix() ||
+		resp.MagsafeLedTransitions[1].State != "green" || resp.MagsafeLedTransitions[1].AppliedUnix != start.Unix() {
+		t.Fatalf("unexpected transitions: %v", resp.MagsafeLedTransitions)
+	}
+	if !resp.MagsafeLedWritePending || resp.MagsafeLedLastError != "smc busy" ||
+		resp.MagsafeLedLastFailureUnix != start.Add(2*time.Minute).Unix() {
+		t.Fatalf("expected the failed write reported, got %+v", resp)
+	}
+}
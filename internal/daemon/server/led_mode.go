@@ -0,0 +1,99 @@
+package server
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/peterneutron/powerkit-go/pkg/powerkit"
+
+	cfg "powergrid/internal/config"
+	"powergrid/internal/daemon/engine"
+	rpc "powergrid/internal/rpc"
+)
+
+// ledControlledLocked reports whether the daemon, rather than macOS, decides
+// what the MagSafe LED shows.
+func (s *Daemon) ledControlledLocked() bool {
+	return s.ledMode == cfg.LEDModeAuto || s.ledMode == cfg.LEDModeOff
+}
+
+// decideAutoLEDLocked picks the LED state for auto mode from the charging
+// state relative to the effective limit.
+func (s *Daemon) decideAutoLEDLocked(info *powerkit.SystemInfo) (powerkit.MagsafeLEDState, bool) {
+	limit, _ := s.effectiveLimitLocked()
+	return engine.DecideMagsafeLED(engine.LEDInput{
+		AdapterPresent:     info.IOKit.Adapter.MaxWatts > 0,
+		Charge:             info.IOKit.Battery.CurrentCharge,
+		Limit:              limit,
+		IsCharging:         info.IOKit.State.IsCharging,
+		IsConnected:        info.IOKit.State.IsConnected,
+		SMCChargingEnabled: info.SMC.State.IsChargingEnabled,
+		ForceDischarge:     !info.SMC.State.IsAdapterEnabled,
+		GreenOnlyWhenFull:  s.ledGreenOnlyWhenFull,
+	})
+}
+
+// applySetMagsafeLEDMode stores mode for the console user and applies it at
+// once. Switching to system hands the LED straight back to macOS.
+func (s *Daemon) applySetMagsafeLEDMode(mode rpc.MagsafeLedMode) error {
+	m, ok := ledModeFromProto(mode)
+	if !ok {
+		return status.Errorf(codes.InvalidArgument, "unsupported magsafe_led_mode: %v", mode)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if m != cfg.LEDModeSystem && !s.ledSupported {
+		logger.Default("MagSafe LED control not supported on this hardware.")
+		return unsupportedHardwareError("magsafe_led")
+	}
+	u := s.currentConsoleUser
+	if u == nil {
+		return noConsoleUserError("MagSafe LED modes")
+	}
+	if err := cfg.WriteUserLEDMode(u.HomeDir, u.UID, u.GID, m); err != nil {
+		logger.Error("Failed to persist MagSafe LED mode for %s: %v", u.Username, err)
+	} else {
+		s.userPrefs.LEDMode = m
+	}
+	wasControlled := s.ledControlledLocked()
+	s.ledMode = m
+	logger.Default("MagSafe LED mode set to %s.", m)
+	if m == cfg.LEDModeSystem {
+		if !wasControlled {
+			return nil
+		}
+		if err := s.returnLEDToSystemLocked(); err != nil {
+			logger.Error("Failed to return MagSafe LED to system control: %v", err)
+			return smcError("set magsafe LED system mode", err)
+		}
+		return nil
+	}
+	s.runChargingLogicLocked(nil)
+	return nil
+}
+
+func ledModeFromProto(mode rpc.MagsafeLedMode) (cfg.LEDMode, bool) {
+	switch mode {
+	case rpc.MagsafeLedMode_MAGSAFE_LED_MODE_AUTO:
+		return cfg.LEDModeAuto, true
+	case rpc.MagsafeLedMode_MAGSAFE_LED_MODE_OFF:
+		return cfg.LEDModeOff, true
+	case rpc.MagsafeLedMode_MAGSAFE_LED_MODE_SYSTEM:
+		return cfg.LEDModeSystem, true
+	default:
+		return "", false
+	}
+}
+
+func ledModeToProto(mode cfg.LEDMode) rpc.MagsafeLedMode {
+	switch mode {
+	case cfg.LEDModeAuto:
+		return rpc.MagsafeLedMode_MAGSAFE_LED_MODE_AUTO
+	case cfg.LEDModeOff:
+		return rpc.MagsafeLedMode_MAGSAFE_LED_MODE_OFF
+	default:
+		return rpc.MagsafeLedMode_MAGSAFE_LED_MODE_SYSTEM
+	}
+}
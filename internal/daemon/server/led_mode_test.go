@@ -0,0 +1,90 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/peterneutron/powerkit-go/pkg/powerkit"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	cfg "powergrid/internal/config"
+	rpc "powergrid/internal/rpc"
+)
+
+func TestApplyMagsafeLEDOffModeKeepsLEDDark(t *testing.T) {
+	resetServerTestGlobals(t)
+
+	var writes []powerkit.MagsafeLEDState
+	setMagsafeLEDStateFn = func(state powerkit.MagsafeLEDState) error {
+		writes = append(writes, state)
+		return nil
+	}
+
+	info := testSystemInfo(50, true)
+	info.IOKit.Adapter.MaxWatts = 96
+	info.IOKit.State.IsCharging = true
+
+	d := &Daemon{currentLimit: 80, ledMode: cfg.LEDModeOff, ledSupported: true}
+	d.applyMagsafeLED(info)
+	d.applyMagsafeLED(nil)
+
+	if len(writes) != 1 || writes[0] != powerkit.LEDOff {
+		t.Fatalf("expected a single Off write, got %v", writes)
+	}
+}
+
+func TestApplyMagsafeLEDSystemModeLeavesLEDAlone(t *testing.T) {
+	resetServerTestGlobals(t)
+
+	calls := 0
+	setMagsafeLEDStateFn = func(powerkit.MagsafeLEDState) error {
+		calls++
+		return nil
+	}
+
+	info := testSystemInfo(50, true)
+	info.IOKit.Adapter.MaxWatts = 96
+
+	d := &Daemon{currentLimit: 80, ledMode: cfg.LEDModeSystem, ledSupported: true}
+	d.applyMagsafeLED(info)
+
+	if calls != 0 {
+		t.Fatalf("expected no LED writes in system mode, got %d", calls)
+	}
+}
+
+func TestSetMagsafeLEDModeRejectsBadRequests(t *testing.T) {
+	resetServerTestGlobals(t)
+
+	cases := []struct {
+		name      string
+		supported bool
+		mode      rpc.MagsafeLedMode
+		code      codes.Code
+	}{
+		{"unspecified", true, rpc.MagsafeLedMode_MAGSAFE_LED_MODE_UNSPECIFIED, codes.InvalidArgument},
+		{"unsupported hardware", false, rpc.MagsafeLedMode_MAGSAFE_LED_MODE_OFF, codes.FailedPrecondition},
+		{"no console user", true, rpc.MagsafeLedMode_MAGSAFE_LED_MODE_AUTO, codes.FailedPrecondition},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := &Daemon{currentLimit: 80, ledSupported: tc.supported}
+			err := d.applySetMagsafeLEDMode(tc.mode)
+			if status.Code(err) != tc.code {
+				t.Fatalf("expected %v, got %v", tc.code, err)
+			}
+			if d.ledMode != "" {
+				t.Fatalf("expected mode unchanged, got %q", d.ledMode)
+			}
+		})
+	}
+}
+
+func TestLEDModeProtoRoundTrip(t *testing.T) {
+	for _, mode := range []cfg.LEDMode{cfg.LEDModeAuto, cfg.LEDModeOff, cfg.LEDModeSystem} {
+		got, ok := ledModeFromProto(ledModeToProto(mode))
+		if !ok || got != mode {
+			t.Fatalf("round trip of %q gave %q (ok=%v)", mode, got, ok)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package server
+
+import (
+	"time"
+
+	"github.com/peterneutron/powerkit-go/pkg/powerkit"
+
+	rpc "powergrid/internal/rpc"
+)
+
+// ledRetryBase is the first retry delay after a failed MagSafe LED write;
+// each further retry doubles it.
+const ledRetryBase = time.Second
+
+// markLEDWrittenLocked records a successful LED write.
+func (s *Daemon) markLEDWrittenLocked(state powerkit.MagsafeLEDState) {
+	s.lastLEDState = state
+	s.ledDirty = false
+	s.ledRetries = 0
+	s.noteLEDAppliedLocked(state)
+}
+
+// returnLEDToSystemLocked hands the MagSafe LED back to macOS and records it
+// as the last written state. It does nothing without LED support. Callers
+// hold s.mu so the write cannot interleave with applyMagsafeLED.
+func (s *Daemon) returnLEDToSystemLocked() error {
+	if !s.ledSupported {
+		return nil
+	}
+	if err := callWithTimeout(opTimeout, func() error {
+		return setMagsafeLEDStateFn(powerkit.LEDSystem)
+	}); err != nil {
+		return err
+	}
+	s.lastLEDState = powerkit.LEDSystem
+	s.noteLEDAppliedLocked(powerkit.LEDSystem)
+	return nil
+}
+
+// markLEDFailedLocked records the failure, forces the next LED update to write even if the target
+// matches lastLEDState, since the hardware may now show anything, and
+// schedules a bounded retry with exponential backoff.
+func (s *Daemon) markLEDFailedLocked(err error) {
+	s.ledDirty = true
+	s.ledFailedAt = clock.Now()
+	s.ledLastError = err.Error()
+	if s.ledRetryTimer != nil || s.ledRetries >= s.ledRetryLimit {
+		return
+	}
+	delay := ledRetryBase << s.ledRetries
+	s.ledRetries++
+	logger.Default("Retrying MagSafe LED write in %s (attempt %d of %d).", delay, s.ledRetries, s.ledRetryLimit)
+	s.ledRetryTimer = clock.AfterFunc(delay, s.retryMagsafeLED)
+}
+
+// retryMagsafeLED re-applies the LED from the cached status after a failed
+// write.
+func (s *Daemon) retryMagsafeLED() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.ledRetryTimer = nil
+	if !s.ledDirty || s.lastIOKitStatus == nil || s.lastSMCStatus == nil {
+		return
+	}
+	iokit := *s.lastIOKitStatus
+	smc := *s.lastSMCStatus
+	s.applyMagsafeLED(&powerkit.SystemInfo{IOKit: &iokit, SMC: &smc})
+}
+
+// stopLEDRetryLocked cancels a pending LED retry.
+func (s *Daemon) stopLEDRetryLocked() {
+	if s.ledRetryTimer != nil {
+		s.ledRetryTimer.Stop()
+		s.ledRetryTimer = nil
+	}
+}
+
+// magsafeLEDSupportLocked reports LED support as a tri-state so clients can
+// tell a pending startup probe from unsupported hardware.
+func (s *Daemon) magsafeLEDSupportLocked() rpc.MagsafeLedSupport {
+	switch {
+	case s.ledSupported:
+		return rpc.MagsafeLedSupport_MAGSAFE_LED_SUPPORTED
+	case s.ledProbed:
+		return rpc.MagsafeLedSupport_MAGSAFE_LED_UNSUPPORTED
+	default:
+		return rpc.MagsafeLedSupport_MAGSAFE_LED_SUPPORT_UNKNOWN
+	}
+}
@@ -0,0 +1,64 @@
+package server
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/peterneutron/powerkit-go/pkg/powerkit"
+
+	cfg "powergrid/internal/config"
+	rpc "powergrid/internal/rpc"
+)
+
+// Run with -race: disabling LED control must not touch lastLEDState
+// outside s.mu while charging-logic runs write it.
+func TestDisableMagsafeLEDDoesNotRaceLogicRuns(t *testing.T) {
+	resetServerTestGlobals(t)
+
+	var writesMu sync.Mutex
+	var writes []powerkit.MagsafeLEDState
+	setMagsafeLEDStateFn = func(state powerkit.MagsafeLEDState) error {
+		writesMu.Lock()
+		writes = append(writes, state)
+		writesMu.Unlock()
+		return nil
+	}
+	getSystemInfoFn = func(...powerkit.FetchOptions) (*powerkit.SystemInfo, error) {
+		info := testSystemInfo(50, true)
+		info.IOKit.Adapter.MaxWatts = 96
+		info.IOKit.State.IsCharging = true
+		return info, nil
+	}
+
+	d := &Daemon{currentLimit: 80, ledMode: cfg.LEDModeAuto, ledSupported: true}
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 20; i++ {
+			d.mu.Lock()
+			d.ledMode = cfg.LEDModeAuto
+			d.ledDirty = true
+			d.runChargingLogicLocked(nil)
+			d.mu.Unlock()
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 20; i++ {
+			if err := d.applyPowerFeature(rpc.PowerFeature_CONTROL_MAGSAFE_LED, false); err != nil {
+				t.Errorf("disable LED control: %v", err)
+				return
+			}
+		}
+	}()
+	wg.Wait()
+
+	if err := d.applyPowerFeature(rpc.PowerFeature_CONTROL_MAGSAFE_LED, false); err != nil {
+		t.Fatalf("disable LED control: %v", err)
+	}
+	if d.ledControlledLocked() || d.lastLEDState != powerkit.LEDSystem {
+		t.Fatalf("expected LED handed to system, got mode=%v last=%v", d.ledMode, d.lastLEDState)
+	}
+}
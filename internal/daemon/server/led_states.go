@@ -0,0 +1,136 @@
+package server
+
+import (
+	"github.com/peterneutron/powerkit-go/pkg/powerkit"
+)
+
+// probedLEDStates are the states DecideMagsafeLED can pick, in the order the
+// probe tries them.
+var probedLEDStates = []powerkit.MagsafeLEDState{
+	powerkit.LEDOff,
+	powerkit.LEDGreen,
+	powerkit.LEDAmber,
+	powerkit.LEDErrorPermSlow,
+}
+
+// startLEDProbeLocked probes the LED states in the background the first time
+// the daemon drives the LED with an adapter connected. A conclusive result
+// holds for the life of the process, since firmware support does not change
+// between connects; an inconclusive one is retried on the next connect.
+func (s *Daemon) startLEDProbeLocked() {
+	if s.ledStatesProbed || s.ledProbing || s.ledProbeTried {
+		return
+	}
+	s.ledProbing = true
+	s.ledProbeTried = true
+	gen := s.ledProbeGen
+	go func() {
+		unhonored, conclusive := probeLEDStates()
+		s.finishLEDProbe(gen, unhonored, conclusive)
+	}()
+}
+
+// noteLEDAdapterRemovedLocked lets the next connect retry an inconclusive
+// probe and discards a probe still in flight.
+func (s *Daemon) noteLEDAdapterRemovedLocked() {
+	if s.ledProbing {
+		s.ledProbeGen++
+		s.ledProbing = false
+	}
+	s.ledProbeTried = false
+}
+
+// finishLEDProbe stores the probe results unless the adapter was removed
+// while it ran, and forces the next update to rewrite the wanted state.
+func (s *Daemon) finishLEDProbe(gen int, unhonored map[powerkit.MagsafeLEDState]bool, conclusive bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if gen != s.ledProbeGen {
+		return
+	}
+	s.ledProbing = false
+	s.ledStatesProbed = conclusive
+	s.ledUnhonored = unhonored
+	s.ledDirty = true
+	if !s.ledControlledLocked() {
+		if err := s.returnLEDToSystemLocked(); err != nil {
+			logger.Info("Could not return MagSafe LED to system after probing: %v", err)
+		}
+	}
+}
+
+// probeLEDStates writes each LED state and reads it back, returning the ones
+// the firmware ignores, then restores the state it found. A state that cannot
+// be written or read back is assumed honored so a transient failure never
+// disables it, and makes the result inconclusive. It runs without s.mu;
+// applyMagsafeLED skips writes meanwhile.
+func probeLEDStates() (map[powerkit.MagsafeLEDState]bool, bool) {
+	previous, restore, err := getMagsafeLEDStateFn()
+	restore = restore && err == nil
+	unhonored := make(map[powerkit.MagsafeLEDState]bool)
+	conclusive := true
+	for _, state := range probedLEDStates {
+		if err := callWithTimeout(opTimeout, func() error {
+			return setMagsafeLEDStateFn(state)
+		}); err != nil {
+			logger.Info("Could not probe MagSafe LED state %s: %v", ledStateName(state), err)
+			conclusive = false
+			continue
+		}
+		got, available, err := getMagsafeLEDStateFn()
+		if err != nil || !available {
+			conclusive = false
+			continue
+		}
+		if got != state {
+			logger.Default("MagSafe LED state %s is not honored on this Mac (read back %d).", ledStateName(state), got)
+			unhonored[state] = true
+		}
+	}
+	if restore {
+		if err := callWithTimeout(opTimeout, func() error {
+			return setMagsafeLEDStateFn(previous)
+		}); err != nil {
+			logger.Info("Could not restore MagSafe LED state %s after probing: %v", ledStateName(previous), err)
+		}
+	}
+	return unhonored, conclusive
+}
+
+// ledStateHonoredLocked reports whether the last probe saw the firmware keep
+// state.
+func (s *Daemon) ledStateHonoredLocked(state powerkit.MagsafeLEDState) bool {
+	return !s.ledUnhonored[state]
+}
+
+// honoredLEDStateNamesLocked lists the honored LED states for status and
+// support bundles, or nil when LED control is unsupported.
+func (s *Daemon) honoredLEDStateNamesLocked() []string {
+	if !s.ledSupported {
+		return nil
+	}
+	var names []string
+	for _, state := range probedLEDStates {
+		if s.ledStateHonoredLocked(state) {
+			names = append(names, ledStateName(state))
+		}
+	}
+	return names
+}
+
+func ledStateName(state powerkit.MagsafeLEDState) string {
+	switch state {
+	case powerkit.LEDSystem:
+		return "system"
+	case powerkit.LEDOff:
+		return "off"
+	case powerkit.LEDGreen:
+		return "green"
+	case powerkit.LEDAmber:
+		return "amber"
+	case powerkit.LEDErrorPermSlow:
+		return "error"
+	default:
+		return "unknown"
+	}
+}
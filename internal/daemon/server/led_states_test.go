@@ -0,0 +1,132 @@
+package server
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/peterneutron/powerkit-go/pkg/powerkit"
+
+	cfg "powergrid/internal/config"
+)
+
+func TestProbeLEDStatesRecordsIgnoredStates(t *testing.T) {
+	resetServerTestGlobals(t)
+
+	current := powerkit.LEDAmber
+	var writes []powerkit.MagsafeLEDState
+	setMagsafeLEDStateFn = func(state powerkit.MagsafeLEDState) error {
+		writes = append(writes, state)
+		if state == powerkit.LEDGreen {
+			return errors.New("controller busy")
+		}
+		// Firmware without the error pattern keeps the previous color.
+		if state != powerkit.LEDErrorPermSlow {
+			current = state
+		}
+		return nil
+	}
+	getMagsafeLEDStateFn = func() (powerkit.MagsafeLEDState, bool, error) {
+		return current, true, nil
+	}
+
+	unhonored, conclusive := probeLEDStates()
+	if conclusive {
+		t.Fatal("expected a failed probe write to make the probe inconclusive")
+	}
+	d := &Daemon{ledSupported: true, ledUnhonored: unhonored}
+
+	if d.ledStateHonoredLocked(powerkit.LEDErrorPermSlow) {
+		t.Fatalf("expected error pattern to be recorded as ignored")
+	}
+	if !d.ledStateHonoredLocked(powerkit.LEDGreen) {
+		t.Fatalf("expected a failed probe write to leave green honored")
+	}
+	want := []string{"off", "green", "amber"}
+	if got := d.honoredLEDStateNamesLocked(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected honored states: got=%v want=%v", got, want)
+	}
+	if last := writes[len(writes)-1]; last != powerkit.LEDAmber {
+		t.Fatalf("expected the probe to restore amber, last write was %s", ledStateName(last))
+	}
+}
+
+func TestApplyMagsafeLEDFallsBackFromIgnoredState(t *testing.T) {
+	resetServerTestGlobals(t)
+
+	var states []powerkit.MagsafeLEDState
+	setMagsafeLEDStateFn = func(state powerkit.MagsafeLEDState) error {
+		states = append(states, state)
+		return nil
+	}
+
+	info := testSystemInfo(50, true)
+	info.IOKit.Adapter.MaxWatts = 96
+	info.IOKit.State.IsCharging = true
+
+	d := &Daemon{
+		currentLimit:    80,
+		ledMode:         cfg.LEDModeAuto,
+		ledSupported:    true,
+		ledStatesProbed: true,
+		ledUnhonored:    map[powerkit.MagsafeLEDState]bool{powerkit.LEDAmber: true},
+	}
+	d.applyMagsafeLED(info)
+
+	if len(states) != 1 || states[0] != powerkit.LEDSystem {
+		t.Fatalf("expected a single system LED write, got %v", states)
+	}
+}
+
+func TestLEDProbeResultsAcrossReconnects(t *testing.T) {
+	resetServerTestGlobals(t)
+
+	setMagsafeLEDStateFn = func(powerkit.MagsafeLEDState) error { return nil }
+	plugged := testSystemInfo(50, true)
+	plugged.IOKit.Adapter.MaxWatts = 96
+	unplugged := testSystemInfo(50, true)
+
+	// A conclusive probe holds for the life of the process.
+	d := &Daemon{
+		currentLimit:    80,
+		ledMode:         cfg.LEDModeAuto,
+		ledSupported:    true,
+		ledStatesProbed: true,
+		ledUnhonored:    map[powerkit.MagsafeLEDState]bool{powerkit.LEDAmber: true},
+	}
+	d.applyMagsafeLED(unplugged)
+	d.applyMagsafeLED(plugged)
+	if d.ledProbing || !d.ledStatesProbed || d.ledStateHonoredLocked(powerkit.LEDAmber) {
+		t.Fatal("expected a conclusive probe to survive a reconnect without probing again")
+	}
+
+	// An inconclusive probe is not retried on the same connect.
+	d = &Daemon{
+		currentLimit:  80,
+		ledMode:       cfg.LEDModeAuto,
+		ledSupported:  true,
+		ledProbeTried: true,
+	}
+	d.applyMagsafeLED(plugged)
+	if d.ledProbing {
+		t.Fatal("expected no second probe on the same connect")
+	}
+	d.applyMagsafeLED(unplugged)
+	if d.ledProbeTried {
+		t.Fatal("expected unplugging to allow a retry on the next connect")
+	}
+
+	// A probe that started before the unplug must not store stale results.
+	d.ledProbing = true
+	gen := d.ledProbeGen
+	d.noteLEDAdapterRemovedLocked()
+	d.finishLEDProbe(gen, map[powerkit.MagsafeLEDState]bool{powerkit.LEDGreen: true}, true)
+	if d.ledStatesProbed || d.ledUnhonored != nil {
+		t.Fatal("expected a probe from before the unplug to be discarded")
+	}
+
+	d.finishLEDProbe(d.ledProbeGen, map[powerkit.MagsafeLEDState]bool{powerkit.LEDGreen: true}, true)
+	if !d.ledStatesProbed || d.ledStateHonoredLocked(powerkit.LEDGreen) || !d.ledDirty {
+		t.Fatal("expected a current probe to store its results and force a rewrite")
+	}
+}
@@ -0,0 +1,52 @@
+package server
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/peterneutron/powerkit-go/pkg/powerkit"
+)
+
+func TestRunChargingLogicReportsLimitEnforced(t *testing.T) {
+	resetServerTestGlobals(t)
+	setChargingStateFn = func(powerkit.ChargingAction) error { return nil }
+
+	d := &Daemon{currentLimit: 80}
+
+	above := testSystemInfo(85, true)
+	above.IOKit.State.IsConnected = true
+	above.SMC.State.IsAdapterEnabled = true
+	d.runChargingLogicLocked(above)
+	if !d.limitEnforced {
+		t.Fatal("expected the limit to be enforced after disabling charging above it")
+	}
+
+	below := testSystemInfo(70, false)
+	below.IOKit.State.IsConnected = true
+	below.SMC.State.IsAdapterEnabled = true
+	d.runChargingLogicLocked(below)
+	if d.limitEnforced {
+		t.Fatal("expected the limit not to be enforced below it")
+	}
+
+	unplugged := testSystemInfo(85, false)
+	d.runChargingLogicLocked(unplugged)
+	if d.limitEnforced {
+		t.Fatal("expected the limit not to be enforced on battery")
+	}
+}
+
+func TestRunChargingLogicLimitNotEnforcedWhenDisableFails(t *testing.T) {
+	resetServerTestGlobals(t)
+	setChargingStateFn = func(powerkit.ChargingAction) error { return errors.New("smc busy") }
+
+	d := &Daemon{currentLimit: 80}
+
+	info := testSystemInfo(85, true)
+	info.IOKit.State.IsConnected = true
+	info.SMC.State.IsAdapterEnabled = true
+	d.runChargingLogicLocked(info)
+	if d.limitEnforced {
+		t.Fatal("expected the limit not to be enforced while charging is still on")
+	}
+}
@@ -0,0 +1,36 @@
+package server
+
+import (
+	"time"
+
+	"powergrid/internal/daemon/engine"
+)
+
+// rampedLimitLocked eases the enforced limit down to target over limitRamp
+// after the limit is lowered, so a partly charged battery coasts toward the
+// new limit instead of stopping at once. Raised limits apply immediately.
+func (s *Daemon) rampedLimitLocked(target int, now time.Time) int {
+	if s.limitRamp <= 0 {
+		return target
+	}
+	if target != s.rampTo {
+		if s.rampedLimit > target {
+			s.rampFrom = s.rampedLimit
+			s.rampStart = now
+			logger.Default("Ramping charge limit from %d%% to %d%% over %s.", s.rampFrom, target, s.limitRamp)
+		} else {
+			s.rampFrom = target
+		}
+		s.rampTo = target
+	}
+	s.rampedLimit = engine.RampLimit(s.rampFrom, target, now.Sub(s.rampStart), s.limitRamp)
+	return s.rampedLimit
+}
+
+// limitRampingLocked reports the limit enforced while a ramp runs, or 0.
+func (s *Daemon) limitRampingLocked() int {
+	if s.limitRamp <= 0 || s.rampedLimit == s.rampTo {
+		return 0
+	}
+	return s.rampedLimit
+}
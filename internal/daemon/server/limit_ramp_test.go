@@ -0,0 +1,57 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/peterneutron/powerkit-go/pkg/powerkit"
+)
+
+func TestRunChargingLogicRampsLoweredLimit(t *testing.T) {
+	resetServerTestGlobals(t)
+
+	now := time.Date(2026, 4, 20, 10, 0, 0, 0, time.UTC)
+	clk := newFakeClock(now)
+	clock = clk
+
+	var actions []powerkit.ChargingAction
+	setChargingStateFn = func(action powerkit.ChargingAction) error {
+		actions = append(actions, action)
+		return nil
+	}
+
+	charging := testSystemInfo(70, true)
+	charging.IOKit.State.IsConnected = true
+	d := &Daemon{currentLimit: 100, limitRamp: time.Hour}
+	d.runChargingLogicLocked(charging)
+
+	d.currentLimit = 60
+	d.runChargingLogicLocked(charging)
+	if len(actions) != 0 || d.limitRampingLocked() != 100 {
+		t.Fatalf("expected the ramp to start at the old limit: actions=%v ramped=%d", actions, d.limitRampingLocked())
+	}
+
+	clk.Advance(30 * time.Minute)
+	d.runChargingLogicLocked(charging)
+	if len(actions) != 0 || d.limitRampingLocked() != 80 {
+		t.Fatalf("expected charging to continue halfway through the ramp: actions=%v ramped=%d", actions, d.limitRampingLocked())
+	}
+
+	clk.Advance(20 * time.Minute)
+	d.runChargingLogicLocked(charging)
+	if len(actions) != 1 || actions[0] != powerkit.ChargingActionOff {
+		t.Fatalf("expected charging disabled once the ramp passed the charge, got %v", actions)
+	}
+
+	clk.Advance(time.Hour)
+	d.runChargingLogicLocked(testSystemInfo(70, false))
+	if d.limitRampingLocked() != 0 {
+		t.Fatalf("expected the ramp to finish, got %d", d.limitRampingLocked())
+	}
+
+	d.currentLimit = 90
+	d.runChargingLogicLocked(testSystemInfo(70, false))
+	if d.limitRampingLocked() != 0 {
+		t.Fatalf("expected a raised limit to apply at once, got %d", d.limitRampingLocked())
+	}
+}
@@ -0,0 +1,56 @@
+package server
+
+import (
+	"encoding/binary"
+	"fmt"
+	"runtime"
+	"time"
+
+	"golang.org/x/sys/unix"
+
+	"powergrid/internal/daemon/engine"
+)
+
+// readLoadPerCPU returns the one-minute load average divided by the number of
+// CPUs, read from the vm.loadavg sysctl (struct loadavg: three fixpt_t
+// averages, padding, then a long fscale).
+func readLoadPerCPU() (float64, error) {
+	raw, err := unix.SysctlRaw("vm.loadavg")
+	if err != nil {
+		return 0, err
+	}
+	if len(raw) < 24 {
+		return 0, fmt.Errorf("unexpected vm.loadavg size %d", len(raw))
+	}
+	load := binary.LittleEndian.Uint32(raw[0:4])
+	scale := binary.LittleEndian.Uint64(raw[16:24])
+	if scale == 0 {
+		return 0, fmt.Errorf("vm.loadavg reported zero scale")
+	}
+	return float64(load) / float64(scale) / float64(runtime.NumCPU()), nil
+}
+
+// deferEnableLocked reports whether a charging enable should wait for a later
+// run because the system is busy. A deferral lasts at most one recompute
+// interval, so the enable lands on the next ticker run at the latest.
+func (s *Daemon) deferEnableLocked(charge, limit int, now time.Time) bool {
+	if !s.deferUnderLoad {
+		return false
+	}
+	if !s.enableDeferredSince.IsZero() && now.Sub(s.enableDeferredSince) >= recomputeInterval {
+		return false
+	}
+	load, err := systemLoadFn()
+	if err != nil {
+		logger.InfoLimited("Could not read system load; not deferring charging enable: %v", err)
+		return false
+	}
+	if !engine.ShouldDeferEnable(charge, limit, load) {
+		return false
+	}
+	if s.enableDeferredSince.IsZero() {
+		s.enableDeferredSince = now
+	}
+	logger.InfoLimited("System busy (load %.2f per CPU); deferring charging enable near the %d%% limit.", load, limit)
+	return true
+}
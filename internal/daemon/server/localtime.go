@@ -0,0 +1,65 @@
+package server
+
+import (
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// localtimePath is the link macOS points at the system time zone. launchd
+// starts the daemon without TZ and time.Local is fixed at process start, so
+// the zone is re-read whenever the link changes, e.g. when automatic time
+// zone follows a trip.
+const localtimePath = "/etc/localtime"
+
+var localZone struct {
+	mu     sync.Mutex
+	target string
+	loc    *time.Location
+}
+
+// systemLocation returns the current system time zone, falling back to
+// time.Local when /etc/localtime cannot be read.
+func systemLocation() *time.Location {
+	target, err := os.Readlink(localtimePath)
+	if err != nil {
+		return time.Local
+	}
+
+	localZone.mu.Lock()
+	defer localZone.mu.Unlock()
+	if localZone.loc != nil && localZone.target == target {
+		return localZone.loc
+	}
+	data, err := os.ReadFile(localtimePath)
+	if err != nil {
+		return time.Local
+	}
+	loc, err := time.LoadLocationFromTZData(zoneName(target), data)
+	if err != nil {
+		logger.InfoLimited("Failed to load time zone from %s: %v", localtimePath, err)
+		return time.Local
+	}
+	if localZone.loc != nil {
+		logger.Default("System time zone changed to %s.", loc)
+	}
+	localZone.target = target
+	localZone.loc = loc
+	return loc
+}
+
+// zoneName turns a zoneinfo link target such as
+// /var/db/timezone/zoneinfo/Europe/Berlin into Europe/Berlin.
+func zoneName(target string) string {
+	if _, name, ok := strings.Cut(target, "zoneinfo/"); ok {
+		return name
+	}
+	return target
+}
+
+// localDay names the system-local calendar day containing t. Converting the
+// instant, rather than adding offsets, keeps DST transitions exact.
+func localDay(t time.Time) string {
+	return t.In(systemLocationFn()).Format(counterDayLayout)
+}
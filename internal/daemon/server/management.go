@@ -0,0 +1,87 @@
+package server
+
+import (
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/peterneutron/powerkit-go/pkg/powerkit"
+
+	cfg "powergrid/internal/config"
+	rpc "powergrid/internal/rpc"
+)
+
+// requiresManagement reports whether enabling a feature would mutate hardware
+// state that passthrough mode hands back to macOS.
+func requiresManagement(feature rpc.PowerFeature) bool {
+	switch feature {
+	case rpc.PowerFeature_PREVENT_DISPLAY_SLEEP,
+		rpc.PowerFeature_PREVENT_SYSTEM_SLEEP,
+		rpc.PowerFeature_FORCE_DISCHARGE:
+		return true
+	default:
+		return false
+	}
+}
+
+func (s *Daemon) applyChargeManagement(enable bool) error {
+	if cfg.AdminManaged(cfg.KeyManagement) {
+		return status.Errorf(codes.FailedPrecondition, "%s is set in %s", cfg.KeyManagement, cfg.AdminConfigPath)
+	}
+	if err := cfg.WriteSystemManagementEnabled(enable); err != nil {
+		logger.Error("Failed to persist charge management setting: %v", err)
+		return status.Errorf(codes.Internal, "failed to persist charge management setting: %v", err)
+	}
+
+	s.mu.Lock()
+	s.managementDisabled = !enable
+	if !enable {
+		s.wantPreventDisplaySleep = false
+		s.autoPreventDisplaySleep = false
+		s.wantPreventSystemSleep = false
+		s.sleepTransitionActive = false
+		s.wakeHoldUntil = time.Time{}
+	}
+	s.mu.Unlock()
+
+	if enable {
+		logger.Default("Charge management enabled; resuming limit enforcement.")
+		return nil
+	}
+
+	logger.Default("Charge management disabled; handing charging, adapter, and LED control back to macOS.")
+	s.releaseAllAssertions()
+	return nil
+}
+
+// applyUnmanagedLocked restores macOS defaults while management is disabled:
+// charging and adapter on, LED under system control. Cached status is still
+// refreshed by the caller so GetStatus keeps reporting telemetry.
+func (s *Daemon) applyUnmanagedLocked(info *powerkit.SystemInfo) {
+	if !info.SMC.State.IsChargingEnabled {
+		logger.Default("Management disabled; re-enabling charging.")
+		if err := callWithTimeout(opTimeout, func() error {
+			return setChargingStateFn(powerkit.ChargingActionOn)
+		}); err != nil {
+			logger.Error("Failed to enable charging in passthrough mode: %v", err)
+		}
+	}
+	if !info.SMC.State.IsAdapterEnabled {
+		logger.Default("Management disabled; re-enabling adapter.")
+		if err := callWithTimeout(opTimeout, func() error {
+			return setAdapterStateFn(powerkit.AdapterActionOn)
+		}); err != nil {
+			logger.Error("Failed to enable adapter in passthrough mode: %v", err)
+		}
+	}
+	if s.lastLEDState != powerkit.LEDSystem {
+		if err := s.returnLEDToSystemLocked(); err != nil {
+			logger.Error("Failed to return MagSafe LED to system control in passthrough mode: %v", err)
+		}
+	}
+	s.forceDischargeRequested = false
+	s.bandDischarging = false
+	s.manualCharging = rpc.ManualCharging_MANUAL_CHARGING_NONE
+	s.clearChargeConflictLocked()
+}
@@ -0,0 +1,79 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/peterneutron/powerkit-go/pkg/powerkit"
+
+	rpc "powergrid/internal/rpc"
+)
+
+func TestRunChargingLogicPassthroughRestoresDefaults(t *testing.T) {
+	resetServerTestGlobals(t)
+
+	var chargingActions []powerkit.ChargingAction
+	setChargingStateFn = func(action powerkit.ChargingAction) error {
+		chargingActions = append(chargingActions, action)
+		return nil
+	}
+	var adapterActions []powerkit.AdapterAction
+	setAdapterStateFn = func(action powerkit.AdapterAction) error {
+		adapterActions = append(adapterActions, action)
+		return nil
+	}
+
+	info := testSystemInfo(90, false)
+	info.SMC.State.IsAdapterEnabled = false
+
+	d := &Daemon{
+		currentLimit:       80,
+		managementDisabled: true,
+	}
+	d.runChargingLogicLocked(info)
+
+	if len(chargingActions) != 1 || chargingActions[0] != powerkit.ChargingActionOn {
+		t.Fatalf("expected charging to be re-enabled in passthrough mode, got %v", chargingActions)
+	}
+	if len(adapterActions) != 1 || adapterActions[0] != powerkit.AdapterActionOn {
+		t.Fatalf("expected adapter to be re-enabled in passthrough mode, got %v", adapterActions)
+	}
+	if d.lastIOKitStatus == nil || d.lastIOKitStatus.Battery.CurrentCharge != 90 {
+		t.Fatalf("expected cached status to be refreshed in passthrough mode")
+	}
+}
+
+func TestRunChargingLogicPassthroughNoopWhenAlreadyDefault(t *testing.T) {
+	resetServerTestGlobals(t)
+
+	writes := 0
+	setChargingStateFn = func(powerkit.ChargingAction) error {
+		writes++
+		return nil
+	}
+	setAdapterStateFn = func(powerkit.AdapterAction) error {
+		writes++
+		return nil
+	}
+
+	info := testSystemInfo(90, true)
+	info.SMC.State.IsAdapterEnabled = true
+
+	d := &Daemon{
+		currentLimit:       80,
+		managementDisabled: true,
+	}
+	d.runChargingLogicLocked(info)
+
+	if writes != 0 {
+		t.Fatalf("expected no hardware writes above limit in passthrough mode, got %d", writes)
+	}
+}
+
+func TestApplyPowerFeatureRejectsForceDischargeWhenUnmanaged(t *testing.T) {
+	resetServerTestGlobals(t)
+
+	d := &Daemon{managementDisabled: true}
+	if err := d.applyPowerFeature(rpc.PowerFeature_FORCE_DISCHARGE, true); err == nil {
+		t.Fatal("expected force discharge to be rejected while management is disabled")
+	}
+}
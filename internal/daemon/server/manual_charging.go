@@ -0,0 +1,85 @@
+package server
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/peterneutron/powerkit-go/pkg/powerkit"
+
+	rpc "powergrid/internal/rpc"
+)
+
+// requireRootCaller rejects an operation unless the gRPC peer is root. The
+// ipc allowlist works per RPC, so mutations that only root may apply are
+// checked here.
+func requireRootCaller(ctx context.Context, what string) error {
+	uid, err := callerUIDFn(ctx)
+	if err != nil || uid != 0 {
+		return status.Errorf(codes.PermissionDenied, "%s requires root", what)
+	}
+	return nil
+}
+
+// applyManualCharging writes the SMC charging state directly, bypassing the
+// limit logic, and holds it there until released; the charging logic skips
+// its own writes in the meantime. MANUAL_CHARGING_NONE releases the override
+// and hands control back to the limit logic at once.
+func (s *Daemon) applyManualCharging(ctx context.Context, mode rpc.ManualCharging) error {
+	if err := requireRootCaller(ctx, "manual charging control"); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if mode == rpc.ManualCharging_MANUAL_CHARGING_NONE {
+		if s.manualCharging != rpc.ManualCharging_MANUAL_CHARGING_NONE {
+			logger.Default("Manual charging override released; resuming limit enforcement.")
+		}
+		s.manualCharging = rpc.ManualCharging_MANUAL_CHARGING_NONE
+		s.runChargingLogicLocked(nil)
+		return nil
+	}
+
+	var action powerkit.ChargingAction
+	switch mode {
+	case rpc.ManualCharging_MANUAL_CHARGING_ON:
+		action = powerkit.ChargingActionOn
+	case rpc.ManualCharging_MANUAL_CHARGING_OFF:
+		action = powerkit.ChargingActionOff
+	default:
+		return status.Errorf(codes.InvalidArgument, "unknown manual charging mode %v", mode)
+	}
+	if s.managementDisabled || s.managementSuspendedLocked(clock.Now()) {
+		return status.Error(codes.FailedPrecondition, "manual charging control requires charge management; charging is left to macOS")
+	}
+
+	if err := callWithTimeout(opTimeout, func() error {
+		return setChargingStateFn(action)
+	}); err != nil {
+		logger.Error("Failed to set charging manually: %v", err)
+		s.noteHardwareWriteLocked(err)
+		return smcError("set charging state", err)
+	}
+	s.noteHardwareWriteLocked(nil)
+	s.noteChargingWrite(action)
+	s.manualCharging = mode
+	logger.Default("Manual charging override set: charging %s until released.", manualChargingState(mode))
+	s.runChargingLogicLocked(nil)
+	return nil
+}
+
+// manualChargingActiveLocked reports whether a manual charging override is
+// holding the charging state.
+func (s *Daemon) manualChargingActiveLocked() bool {
+	return s.manualCharging != rpc.ManualCharging_MANUAL_CHARGING_NONE
+}
+
+func manualChargingState(mode rpc.ManualCharging) string {
+	if mode == rpc.ManualCharging_MANUAL_CHARGING_ON {
+		return "on"
+	}
+	return "off"
+}
@@ -0,0 +1,137 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/peterneutron/powerkit-go/pkg/powerkit"
+
+	"powergrid/internal/daemon/engine"
+	rpc "powergrid/internal/rpc"
+)
+
+func TestManualChargingRequiresRoot(t *testing.T) {
+	resetServerTestGlobals(t)
+	callerUIDFn = func(context.Context) (uint32, error) { return 501, nil }
+	setChargingStateFn = func(powerkit.ChargingAction) error {
+		t.Fatal("unexpected charging write for a non-root caller")
+		return nil
+	}
+
+	d := &Daemon{currentLimit: 80}
+	_, err := d.ApplyMutation(context.Background(), &rpc.MutationRequest{
+		Operation:      rpc.MutationOperation_MANUAL_CHARGING,
+		ManualCharging: rpc.ManualCharging_MANUAL_CHARGING_ON,
+	})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("expected PermissionDenied, got %v", err)
+	}
+
+	callerUIDFn = func(context.Context) (uint32, error) { return 0, errors.New("missing peer information") }
+	_, err = d.ApplyMutation(context.Background(), &rpc.MutationRequest{
+		Operation:      rpc.MutationOperation_MANUAL_CHARGING,
+		ManualCharging: rpc.ManualCharging_MANUAL_CHARGING_ON,
+	})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("expected PermissionDenied without peer credentials, got %v", err)
+	}
+}
+
+func TestManualChargingHoldsUntilReleased(t *testing.T) {
+	resetServerTestGlobals(t)
+	callerUIDFn = func(context.Context) (uint32, error) { return 0, nil }
+
+	var actions []powerkit.ChargingAction
+	setChargingStateFn = func(action powerkit.ChargingAction) error {
+		actions = append(actions, action)
+		return nil
+	}
+	getSystemInfoFn = func(...powerkit.FetchOptions) (*powerkit.SystemInfo, error) {
+		return testSystemInfo(90, true), nil
+	}
+
+	d := &Daemon{currentLimit: 80}
+	if _, err := d.ApplyMutation(context.Background(), &rpc.MutationRequest{
+		Operation:      rpc.MutationOperation_MANUAL_CHARGING,
+		ManualCharging: rpc.ManualCharging_MANUAL_CHARGING_ON,
+	}); err != nil {
+		t.Fatalf("ApplyMutation: %v", err)
+	}
+	if len(actions) != 1 || actions[0] != powerkit.ChargingActionOn {
+		t.Fatalf("expected one manual enable, got %v", actions)
+	}
+
+	// Above the limit the logic would disable charging, but the override holds.
+	d.runChargingLogicLocked(testSystemInfo(90, true))
+	if len(actions) != 1 {
+		t.Fatalf("expected the limit logic to leave the override alone, got %v", actions)
+	}
+
+	if _, err := d.ApplyMutation(context.Background(), &rpc.MutationRequest{
+		Operation: rpc.MutationOperation_MANUAL_CHARGING,
+	}); err != nil {
+		t.Fatalf("release: %v", err)
+	}
+	if d.manualCharging != rpc.ManualCharging_MANUAL_CHARGING_NONE {
+		t.Fatalf("expected the override released, got %v", d.manualCharging)
+	}
+	if len(actions) != 2 || actions[1] != powerkit.ChargingActionOff {
+		t.Fatalf("expected the limit logic to disable charging after release, got %v", actions)
+	}
+}
+
+func TestManualChargingRejectedInPassthrough(t *testing.T) {
+	resetServerTestGlobals(t)
+	callerUIDFn = func(context.Context) (uint32, error) { return 0, nil }
+
+	d := &Daemon{currentLimit: 80, managementDisabled: true}
+	_, err := d.ApplyMutation(context.Background(), &rpc.MutationRequest{
+		Operation:      rpc.MutationOperation_MANUAL_CHARGING,
+		ManualCharging: rpc.ManualCharging_MANUAL_CHARGING_OFF,
+	})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition in passthrough mode, got %v", err)
+	}
+}
+
+func TestManualChargingReportsPauseReason(t *testing.T) {
+	resetServerTestGlobals(t)
+	callerUIDFn = func(context.Context) (uint32, error) { return 0, nil }
+	setChargingStateFn = func(powerkit.ChargingAction) error { return nil }
+	info := testSystemInfo(85, true)
+	info.IOKit.State.IsConnected = true
+	info.SMC.State.IsAdapterEnabled = true
+	getSystemInfoFn = func(...powerkit.FetchOptions) (*powerkit.SystemInfo, error) {
+		return info, nil
+	}
+
+	d := &Daemon{currentLimit: 80}
+	d.runChargingLogicLocked(info)
+	if d.pauseReason != engine.PauseAtLimit {
+		t.Fatalf("unexpected pause reason before the override: got=%v want=%v", d.pauseReason, engine.PauseAtLimit)
+	}
+
+	if _, err := d.ApplyMutation(context.Background(), &rpc.MutationRequest{
+		Operation:      rpc.MutationOperation_MANUAL_CHARGING,
+		ManualCharging: rpc.ManualCharging_MANUAL_CHARGING_ON,
+	}); err != nil {
+		t.Fatalf("ApplyMutation: %v", err)
+	}
+	if d.pauseReason != engine.PauseNone {
+		t.Fatalf("expected the pause reason cleared while charging is forced on, got %v", d.pauseReason)
+	}
+
+	if _, err := d.ApplyMutation(context.Background(), &rpc.MutationRequest{
+		Operation:      rpc.MutationOperation_MANUAL_CHARGING,
+		ManualCharging: rpc.ManualCharging_MANUAL_CHARGING_OFF,
+	}); err != nil {
+		t.Fatalf("ApplyMutation: %v", err)
+	}
+	if d.pauseReason != engine.PauseManual {
+		t.Fatalf("unexpected pause reason while charging is forced off: got=%v want=%v", d.pauseReason, engine.PauseManual)
+	}
+}
@@ -0,0 +1,126 @@
+package server
+
+import (
+	"strings"
+	"time"
+
+	"github.com/peterneutron/powerkit-go/pkg/powerkit"
+
+	cfg "powergrid/internal/config"
+)
+
+const (
+	// overrideSnapshotVersion is bumped whenever the meaning of a saved
+	// field changes, so a snapshot from another daemon version is ignored
+	// rather than misread.
+	overrideSnapshotVersion = 1
+	// maxOverrideSnapshotAge is how long after shutdown a snapshot still
+	// applies. It covers an upgrade restart, not a Mac that was off for a
+	// day.
+	maxOverrideSnapshotAge = 15 * time.Minute
+)
+
+// saveOverridesLocked stores the temporary overrides in effect so the next
+// daemon start can resume them. Manual charging is left out: it is a root
+// override of the hardware state, which the shutdown restore may change.
+func (s *Daemon) saveOverridesLocked() {
+	now := clock.Now()
+	snap := cfg.OverrideSnapshot{
+		Version:        overrideSnapshotVersion,
+		SavedUnix:      now.Unix(),
+		ForceDischarge: s.forceDischargeRequested,
+	}
+	if remaining := s.boostRemainingLocked(now); remaining > 0 {
+		snap.BoostUntilUnix = s.boostUntil.Unix()
+	}
+	if s.pinnedPID != 0 {
+		snap.PinnedPID = s.pinnedPID
+		snap.PinnedStartUnixNano = s.pinnedStart.UnixNano()
+	}
+	if !s.fullByDeadline.IsZero() {
+		snap.FullByDeadlineUnix = s.fullByDeadline.Unix()
+		snap.FullByTarget = s.fullByTarget
+		if !s.fullByStarted.IsZero() {
+			snap.FullByStartedUnix = s.fullByStarted.Unix()
+		}
+	}
+	if remaining := s.suspendRemainingLocked(now); remaining > 0 {
+		snap.SuspendUntilUnix = s.suspendUntil.Unix()
+	}
+	if err := writeOverrideSnapshotFn(snap); err != nil {
+		logger.Error("Failed to save overrides for the next start: %v", err)
+		return
+	}
+	logger.Default("Saved overrides for the next start.")
+}
+
+// restoreOverrides resumes the overrides saved at the last shutdown and then
+// clears the snapshot, so a later crash does not bring them back again. A
+// snapshot from another version or older than maxOverrideSnapshotAge is
+// ignored, as is each override that has expired since.
+func (s *Daemon) restoreOverrides() {
+	snap := readOverrideSnapshotFn()
+	if snap.Version == 0 {
+		return
+	}
+	if err := writeOverrideSnapshotFn(cfg.OverrideSnapshot{}); err != nil {
+		logger.Error("Failed to clear saved overrides: %v", err)
+	}
+	now := clock.Now()
+	if snap.Version != overrideSnapshotVersion {
+		logger.Default("Ignoring saved overrides from snapshot version %d.", snap.Version)
+		return
+	}
+	if saved := time.Unix(snap.SavedUnix, 0); now.Sub(saved) > maxOverrideSnapshotAge || saved.After(now) {
+		logger.Default("Ignoring saved overrides from %s.", saved.Format(time.RFC3339))
+		return
+	}
+
+	s.mu.Lock()
+	var restored []string
+	if until := time.Unix(snap.BoostUntilUnix, 0); snap.BoostUntilUnix != 0 && now.Before(until) {
+		s.boostUntil = until
+		restored = append(restored, "charge boost")
+	}
+	if snap.PinnedPID != 0 {
+		s.pinnedPID = snap.PinnedPID
+		s.pinnedStart = time.Unix(0, snap.PinnedStartUnixNano)
+		if s.pinActiveLocked() {
+			restored = append(restored, "full-charge pin")
+		}
+	}
+	if deadline := time.Unix(snap.FullByDeadlineUnix, 0); snap.FullByDeadlineUnix != 0 && now.Before(deadline) {
+		s.fullByDeadline = deadline
+		s.fullByTarget = snap.FullByTarget
+		if snap.FullByStartedUnix != 0 {
+			s.fullByStarted = time.Unix(snap.FullByStartedUnix, 0)
+		}
+		restored = append(restored, "full-by plan")
+	}
+	if until := time.Unix(snap.SuspendUntilUnix, 0); snap.SuspendUntilUnix != 0 && now.Before(until) {
+		s.suspendUntil = until
+		s.suspendTimer = clock.AfterFunc(until.Sub(now), s.resumeSuspendedManagement)
+		restored = append(restored, "management suspension")
+	}
+	discharge := snap.ForceDischarge && !s.batteryMissing && !s.managementDisabled
+	if discharge {
+		s.forceDischargeRequested = true
+		s.restoredForceDischarge = true
+		restored = append(restored, "force discharge")
+	}
+	s.mu.Unlock()
+
+	if len(restored) == 0 {
+		return
+	}
+	logger.Default("Restored overrides from before the restart: %s.", strings.Join(restored, ", "))
+	s.recordEvent("overrides_restored", map[string]any{"overrides": restored})
+	// The shutdown restore may have turned the adapter back on.
+	if discharge && !s.hardwareWritesBlocked("restored force discharge") {
+		if err := callWithTimeout(opTimeout, func() error {
+			return setAdapterStateFn(powerkit.AdapterActionOff)
+		}); err != nil {
+			logger.Error("Failed to re-apply restored force discharge: %v", err)
+		}
+	}
+}
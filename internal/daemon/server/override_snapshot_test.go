@@ -0,0 +1,159 @@
+package server
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/peterneutron/powerkit-go/pkg/powerkit"
+
+	cfg "powergrid/internal/config"
+	consoleuser "powergrid/internal/consoleuser"
+	"powergrid/internal/daemon/session"
+)
+
+func TestOverridesSurviveRestart(t *testing.T) {
+	resetServerTestGlobals(t)
+	fc := newFakeClock(time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC))
+	clock = fc
+
+	pinStart := time.Date(2026, 10, 16, 8, 0, 0, 123000, time.UTC)
+	processStartTimeFn = func(int) (time.Time, bool) { return pinStart, true }
+	var stored cfg.OverrideSnapshot
+	writeOverrideSnapshotFn = func(snap cfg.OverrideSnapshot) error {
+		stored = snap
+		return nil
+	}
+	readOverrideSnapshotFn = func() cfg.OverrideSnapshot { return stored }
+
+	before := &Daemon{
+		boostUntil:              fc.Now().Add(30 * time.Minute),
+		pinnedPID:               4242,
+		pinnedStart:             pinStart,
+		fullByDeadline:          fc.Now().Add(3 * time.Hour),
+		fullByTarget:            95,
+		suspendUntil:            fc.Now().Add(20 * time.Minute),
+		forceDischargeRequested: true,
+	}
+	before.saveOverridesLocked()
+
+	fc.Advance(2 * time.Minute)
+	var adapterActions []powerkit.AdapterAction
+	setAdapterStateFn = func(action powerkit.AdapterAction) error {
+		adapterActions = append(adapterActions, action)
+		return nil
+	}
+	after := &Daemon{}
+	after.restoreOverrides()
+
+	if !after.boostUntil.Equal(before.boostUntil.Truncate(time.Second)) || after.pinnedPID != 4242 || !after.pinnedStart.Equal(pinStart) {
+		t.Fatalf("boost or pin not restored: boost=%v pid=%d start=%v", after.boostUntil, after.pinnedPID, after.pinnedStart)
+	}
+	if !after.fullByDeadline.Equal(before.fullByDeadline) || after.fullByTarget != 95 {
+		t.Fatalf("full-by plan not restored: deadline=%v target=%d", after.fullByDeadline, after.fullByTarget)
+	}
+	if !after.suspendUntil.Equal(before.suspendUntil) || after.suspendTimer == nil {
+		t.Fatalf("suspension not restored: until=%v timer=%v", after.suspendUntil, after.suspendTimer)
+	}
+	if !after.forceDischargeRequested || len(adapterActions) != 1 || adapterActions[0] != powerkit.AdapterActionOff {
+		t.Fatalf("force discharge not restored: requested=%v actions=%v", after.forceDischargeRequested, adapterActions)
+	}
+	if stored.Version != 0 {
+		t.Fatalf("expected the snapshot cleared after restore, got %+v", stored)
+	}
+}
+
+func TestRestoreOverridesIgnoresStaleAndExpired(t *testing.T) {
+	resetServerTestGlobals(t)
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	clock = newFakeClock(now)
+	setAdapterStateFn = func(action powerkit.AdapterAction) error {
+		t.Fatalf("unexpected adapter action %v", action)
+		return nil
+	}
+
+	tests := []struct {
+		name string
+		snap cfg.OverrideSnapshot
+	}{
+		{
+			name: "old snapshot",
+			snap: cfg.OverrideSnapshot{Version: overrideSnapshotVersion, SavedUnix: now.Add(-time.Hour).Unix(), BoostUntilUnix: now.Add(time.Hour).Unix(), ForceDischarge: true},
+		},
+		{
+			name: "other version",
+			snap: cfg.OverrideSnapshot{Version: overrideSnapshotVersion + 1, SavedUnix: now.Unix(), BoostUntilUnix: now.Add(time.Hour).Unix()},
+		},
+		{
+			name: "expired overrides",
+			snap: cfg.OverrideSnapshot{Version: overrideSnapshotVersion, SavedUnix: now.Add(-5 * time.Minute).Unix(), BoostUntilUnix: now.Add(-time.Minute).Unix(), SuspendUntilUnix: now.Unix()},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			readOverrideSnapshotFn = func() cfg.OverrideSnapshot { return tc.snap }
+			d := &Daemon{}
+			d.restoreOverrides()
+			if !d.boostUntil.IsZero() || !d.suspendUntil.IsZero() || d.forceDischargeRequested {
+				t.Fatalf("expected nothing restored, got boost=%v suspend=%v discharge=%v", d.boostUntil, d.suspendUntil, d.forceDischargeRequested)
+			}
+		})
+	}
+}
+
+func TestRestoredForceDischargeSurvivesStartupUserEntry(t *testing.T) {
+	resetServerTestGlobals(t)
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	clock = newFakeClock(now)
+	allowAllSleepFn = func() {}
+	readOverrideSnapshotFn = func() cfg.OverrideSnapshot {
+		return cfg.OverrideSnapshot{Version: overrideSnapshotVersion, SavedUnix: now.Add(-time.Minute).Unix(), ForceDischarge: true}
+	}
+	writeOverrideSnapshotFn = func(cfg.OverrideSnapshot) error { return nil }
+	profileForUserFn = func(*consoleuser.ConsoleUser, int) session.Profile {
+		return session.Profile{Limit: 80}
+	}
+	ran := make(chan struct{}, 1)
+	getSystemInfoFn = func(...powerkit.FetchOptions) (*powerkit.SystemInfo, error) {
+		ran <- struct{}{}
+		return nil, errors.New("no hardware in tests")
+	}
+	var adapterActions []powerkit.AdapterAction
+	setAdapterStateFn = func(action powerkit.AdapterAction) error {
+		adapterActions = append(adapterActions, action)
+		return nil
+	}
+
+	d := &Daemon{}
+	d.restoreOverrides()
+	d.enterConsoleUser(&consoleuser.ConsoleUser{Username: "alice", UID: 501, HomeDir: t.TempDir()})
+	waitForLogicRun(t, ran)
+	d.mu.Lock()
+	kept := d.forceDischargeRequested
+	d.mu.Unlock()
+	if !kept {
+		t.Fatal("expected the restored force discharge to survive the startup user entry")
+	}
+	if len(adapterActions) != 1 || adapterActions[0] != powerkit.AdapterActionOff {
+		t.Fatalf("expected the adapter to stay off, got %v", adapterActions)
+	}
+
+	// A later user switch drops it as usual.
+	d.enterConsoleUser(&consoleuser.ConsoleUser{Username: "bob", UID: 502, HomeDir: t.TempDir()})
+	waitForLogicRun(t, ran)
+	d.mu.Lock()
+	kept = d.forceDischargeRequested
+	d.mu.Unlock()
+	if kept || adapterActions[len(adapterActions)-1] != powerkit.AdapterActionOn {
+		t.Fatalf("expected a later switch to end the discharge, got requested=%v actions=%v", kept, adapterActions)
+	}
+}
+
+func waitForLogicRun(t *testing.T, ran <-chan struct{}) {
+	t.Helper()
+	select {
+	case <-ran:
+	case <-time.After(time.Second):
+		t.Fatal("expected a charging-logic run after the switch")
+	}
+}
@@ -0,0 +1,44 @@
+package server
+
+import (
+	"time"
+
+	"github.com/peterneutron/powerkit-go/pkg/powerkit"
+
+	rpc "powergrid/internal/rpc"
+)
+
+// clearOverrides drops every temporary override and returns the daemon to
+// automatic management: the adapter is re-enabled, sleep assertions are
+// released, any post-connect grace window, charge boost, full-charge pin or
+// full-by plan ends, a management suspension is lifted, and a manual charging
+// override is released. Persisted preferences (limit, profiles, LED control)
+// are left alone.
+func (s *Daemon) clearOverrides() error {
+	if err := callWithTimeout(opTimeout, func() error {
+		return setAdapterStateFn(powerkit.AdapterActionOn)
+	}); err != nil {
+		logger.Error("Failed to re-enable adapter while clearing overrides: %v", err)
+		return smcError("re-enable adapter", err)
+	}
+	s.releaseAllAssertions()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.forceDischargeRequested = false
+	s.wantPreventDisplaySleep = false
+	s.autoPreventDisplaySleep = false
+	s.wantPreventSystemSleep = false
+	s.connectedSince = time.Time{}
+	s.boostUntil = time.Time{}
+	s.pinnedPID = 0
+	s.clearFullByLocked()
+	s.suspendUntil = time.Time{}
+	s.stopSuspendTimerLocked()
+	s.manualCharging = rpc.ManualCharging_MANUAL_CHARGING_NONE
+	logger.Default("Cleared overrides; returning to automatic management.")
+
+	s.runChargingLogicLocked(nil)
+	return nil
+}
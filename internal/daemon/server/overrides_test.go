@@ -0,0 +1,55 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/peterneutron/powerkit-go/pkg/powerkit"
+)
+
+func TestClearOverrides(t *testing.T) {
+	resetServerTestGlobals(t)
+
+	now := time.Date(2026, 4, 20, 10, 0, 0, 0, time.UTC)
+	clk := newFakeClock(now)
+	clock = clk
+
+	var adapterActions []powerkit.AdapterAction
+	setAdapterStateFn = func(action powerkit.AdapterAction) error {
+		adapterActions = append(adapterActions, action)
+		return nil
+	}
+	var chargingActions []powerkit.ChargingAction
+	setChargingStateFn = func(action powerkit.ChargingAction) error {
+		chargingActions = append(chargingActions, action)
+		return nil
+	}
+	allowedSleep := false
+	allowAllSleepFn = func() { allowedSleep = true }
+	getSystemInfoFn = func(...powerkit.FetchOptions) (*powerkit.SystemInfo, error) {
+		info := testSystemInfo(85, true)
+		info.IOKit.State.IsConnected = true
+		return info, nil
+	}
+
+	d := &Daemon{
+		currentLimit:            80,
+		forceDischargeRequested: true,
+		wantPreventSystemSleep:  true,
+		connectGrace:            2 * time.Minute,
+		connectedSince:          now,
+	}
+	if err := d.clearOverrides(); err != nil {
+		t.Fatalf("clearOverrides returned error: %v", err)
+	}
+
+	if len(adapterActions) != 1 || adapterActions[0] != powerkit.AdapterActionOn {
+		t.Fatalf("expected adapter re-enabled, got %v", adapterActions)
+	}
+	if !allowedSleep || d.wantPreventSystemSleep || d.forceDischargeRequested {
+		t.Fatalf("unexpected override state: allowedSleep=%v preventSystemSleep=%v forceDischarge=%v", allowedSleep, d.wantPreventSystemSleep, d.forceDischargeRequested)
+	}
+	if len(chargingActions) != 1 || chargingActions[0] != powerkit.ChargingActionOff {
+		t.Fatalf("expected the limit enforced once grace was cleared, got %v", chargingActions)
+	}
+}
@@ -0,0 +1,129 @@
+package server
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/peterneutron/powerkit-go/pkg/powerkit"
+
+	"powergrid/internal/daemon/engine"
+)
+
+func TestRunChargingLogicSetsAndClearsPauseReason(t *testing.T) {
+	resetServerTestGlobals(t)
+	setChargingStateFn = func(powerkit.ChargingAction) error { return nil }
+
+	d := &Daemon{currentLimit: 80}
+
+	atLimit := testSystemInfo(80, true)
+	atLimit.IOKit.State.IsConnected = true
+	atLimit.SMC.State.IsAdapterEnabled = true
+	d.runChargingLogicLocked(atLimit)
+	if d.pauseReason != engine.PauseAtLimit {
+		t.Fatalf("unexpected pause reason at limit: got=%v want=%v", d.pauseReason, engine.PauseAtLimit)
+	}
+	if !d.chargedAtLimit {
+		t.Fatal("expected charged_at_limit at the limit")
+	}
+
+	below := testSystemInfo(75, false)
+	below.IOKit.State.IsConnected = true
+	below.SMC.State.IsAdapterEnabled = true
+	d.runChargingLogicLocked(below)
+	if d.pauseReason != engine.PauseNone {
+		t.Fatalf("expected pause reason cleared after re-enable, got %v", d.pauseReason)
+	}
+	if d.chargedAtLimit {
+		t.Fatal("expected charged_at_limit cleared below the limit")
+	}
+}
+
+func TestRunChargingLogicKeepsPauseReasonOnWriteFailure(t *testing.T) {
+	resetServerTestGlobals(t)
+	setChargingStateFn = func(powerkit.ChargingAction) error { return errors.New("smc busy") }
+
+	d := &Daemon{currentLimit: 80, pauseReason: engine.PauseNone}
+
+	info := testSystemInfo(85, true)
+	info.IOKit.State.IsConnected = true
+	info.SMC.State.IsAdapterEnabled = true
+	d.runChargingLogicLocked(info)
+	if d.pauseReason != engine.PauseNone {
+		t.Fatalf("expected pause reason unchanged after failed disable, got %v", d.pauseReason)
+	}
+}
+
+func TestRunChargingLogicPausesOnWeakAdapter(t *testing.T) {
+	resetServerTestGlobals(t)
+
+	var actions []powerkit.ChargingAction
+	setChargingStateFn = func(action powerkit.ChargingAction) error {
+		actions = append(actions, action)
+		return nil
+	}
+
+	d := &Daemon{currentLimit: 80, minChargingAdapterWatts: 45}
+
+	info := testSystemInfo(50, true)
+	info.IOKit.State.IsConnected = true
+	info.IOKit.Adapter.MaxWatts = 30
+	info.SMC.State.IsAdapterEnabled = true
+	d.runChargingLogicLocked(info)
+	if len(actions) != 1 || actions[0] != powerkit.ChargingActionOff {
+		t.Fatalf("expected charging disabled on a weak adapter, got %v", actions)
+	}
+	if d.pauseReason != engine.PauseWeakAdapter {
+		t.Fatalf("unexpected pause reason: got=%v want=%v", d.pauseReason, engine.PauseWeakAdapter)
+	}
+
+	strong := testSystemInfo(50, false)
+	strong.IOKit.State.IsConnected = true
+	strong.IOKit.Adapter.MaxWatts = 96
+	strong.SMC.State.IsAdapterEnabled = true
+	d.runChargingLogicLocked(strong)
+	if len(actions) != 2 || actions[1] != powerkit.ChargingActionOn {
+		t.Fatalf("expected charging re-enabled on a strong adapter, got %v", actions)
+	}
+	if d.pauseReason != engine.PauseNone {
+		t.Fatalf("expected pause reason cleared, got %v", d.pauseReason)
+	}
+}
+
+func TestRunChargingLogicDefersEnableUnderLoad(t *testing.T) {
+	resetServerTestGlobals(t)
+
+	now := time.Date(2026, 4, 20, 10, 0, 0, 0, time.UTC)
+	clk := newFakeClock(now)
+	clock = clk
+	systemLoadFn = func() (float64, error) { return 2, nil }
+
+	var actions []powerkit.ChargingAction
+	setChargingStateFn = func(action powerkit.ChargingAction) error {
+		actions = append(actions, action)
+		return nil
+	}
+
+	d := &Daemon{currentLimit: 80, deferUnderLoad: true}
+	nearLimit := func() *powerkit.SystemInfo {
+		info := testSystemInfo(78, false)
+		info.IOKit.State.IsConnected = true
+		info.SMC.State.IsAdapterEnabled = true
+		return info
+	}
+
+	d.runChargingLogicLocked(nearLimit())
+	if len(actions) != 0 {
+		t.Fatalf("expected enable deferred under load, got %v", actions)
+	}
+
+	now = now.Add(recomputeInterval)
+	clk.Set(now)
+	d.runChargingLogicLocked(nearLimit())
+	if len(actions) != 1 || actions[0] != powerkit.ChargingActionOn {
+		t.Fatalf("expected enable applied after one interval, got %v", actions)
+	}
+	if !d.enableDeferredSince.IsZero() {
+		t.Fatal("expected deferral cleared after enabling")
+	}
+}
@@ -0,0 +1,69 @@
+package server
+
+import (
+	"math"
+	"time"
+
+	"golang.org/x/sys/unix"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// applyFullChargePin lets charging run to 100% for as long as the process
+// with the given PID keeps running. PID zero removes the pin.
+func (s *Daemon) applyFullChargePin(pid int32) error {
+	if pid < 0 {
+		return outOfRangeError("pid", int(pid), 0, math.MaxInt32)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if pid == 0 {
+		if s.pinnedPID != 0 {
+			logger.Default("Full-charge pin for PID %d removed.", s.pinnedPID)
+			s.pinnedPID = 0
+		}
+		s.runChargingLogicCachedLocked()
+		return nil
+	}
+	if s.batteryMissing {
+		return noBatteryError("full-charge pin")
+	}
+	start, ok := processStartTimeFn(int(pid))
+	if !ok {
+		return status.Errorf(codes.NotFound, "no running process with PID %d", pid)
+	}
+
+	s.pinnedPID = pid
+	s.pinnedStart = start
+	logger.Default("Charge limit pinned to 100%% while PID %d runs.", pid)
+
+	s.runChargingLogicCachedLocked()
+	return nil
+}
+
+// pinActiveLocked reports whether a full-charge pin is in effect, ending it
+// once the pinned process has exited. Every charging-logic run polls this.
+func (s *Daemon) pinActiveLocked() bool {
+	if s.pinnedPID == 0 {
+		return false
+	}
+	if start, ok := processStartTimeFn(int(s.pinnedPID)); ok && start.Equal(s.pinnedStart) {
+		return true
+	}
+	logger.Default("Full-charge pin ended because PID %d exited.", s.pinnedPID)
+	s.recordEvent("full_charge_pin_ended", map[string]any{"pid": s.pinnedPID})
+	s.pinnedPID = 0
+	return false
+}
+
+// processStartTime returns when pid started, or false when no such process
+// exists. Comparing start times keeps a reused PID from extending a pin.
+func processStartTime(pid int) (time.Time, bool) {
+	kp, err := unix.SysctlKinfoProc("kern.proc.pid", pid)
+	if err != nil || kp.Proc.P_pid != int32(pid) {
+		return time.Time{}, false
+	}
+	return time.Unix(kp.Proc.P_starttime.Unix()), true
+}
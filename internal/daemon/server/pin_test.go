@@ -0,0 +1,71 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/peterneutron/powerkit-go/pkg/powerkit"
+)
+
+func TestFullChargePinHoldsUntilProcessExits(t *testing.T) {
+	resetServerTestGlobals(t)
+
+	started := time.Date(2026, 4, 20, 9, 0, 0, 0, time.UTC)
+	alive := true
+	processStartTimeFn = func(pid int) (time.Time, bool) {
+		return started, alive && pid == 4242
+	}
+
+	var actions []powerkit.ChargingAction
+	setChargingStateFn = func(action powerkit.ChargingAction) error {
+		actions = append(actions, action)
+		return nil
+	}
+
+	d := &Daemon{currentLimit: 80, pinnedPID: 4242, pinnedStart: started}
+	d.runChargingLogicLocked(connectedInfo(85, false, "desk", 96))
+	if len(actions) != 1 || actions[0] != powerkit.ChargingActionOn {
+		t.Fatalf("expected charging enabled past the limit while pinned, got %v", actions)
+	}
+
+	alive = false
+	d.runChargingLogicLocked(connectedInfo(90, true, "desk", 96))
+	if len(actions) != 2 || actions[1] != powerkit.ChargingActionOff {
+		t.Fatalf("expected limit enforced after the process exited, got %v", actions)
+	}
+	if d.pinnedPID != 0 {
+		t.Fatalf("expected pin cleared, got PID %d", d.pinnedPID)
+	}
+}
+
+func TestFullChargePinIgnoresReusedPID(t *testing.T) {
+	resetServerTestGlobals(t)
+
+	started := time.Date(2026, 4, 20, 9, 0, 0, 0, time.UTC)
+	processStartTimeFn = func(int) (time.Time, bool) {
+		return started.Add(time.Hour), true
+	}
+
+	d := &Daemon{pinnedPID: 4242, pinnedStart: started}
+	if d.pinActiveLocked() {
+		t.Fatal("expected a reused PID to end the pin")
+	}
+}
+
+func TestFullChargePinRejectsMissingProcess(t *testing.T) {
+	resetServerTestGlobals(t)
+
+	processStartTimeFn = func(int) (time.Time, bool) { return time.Time{}, false }
+
+	d := &Daemon{currentLimit: 80}
+	err := d.applyFullChargePin(4242)
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("unexpected error code: got=%v want=%v", status.Code(err), codes.NotFound)
+	}
+	if d.pinnedPID != 0 {
+		t.Fatalf("expected no pin, got PID %d", d.pinnedPID)
+	}
+}
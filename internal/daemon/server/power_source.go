@@ -0,0 +1,28 @@
+package server
+
+import (
+	"powergrid/internal/powersource"
+	rpc "powergrid/internal/rpc"
+)
+
+// setPowerSourceStatus reports the attached power sources. A Mac mini on a
+// UPS has no internal battery, so charge control is already suspended
+// through battery_missing; this tells clients why power keeps flowing.
+func setPowerSourceStatus(resp *rpc.StatusResponse) {
+	info, err := powerSourceFn()
+	if err != nil {
+		logger.InfoLimited("Could not read power sources: %v", err)
+		return
+	}
+	resp.UpsAttached = info.UPS
+	switch info.Providing {
+	case powersource.ProvidingAC:
+		resp.PowerSource = rpc.PowerSource_POWER_SOURCE_AC
+	case powersource.ProvidingBattery:
+		resp.PowerSource = rpc.PowerSource_POWER_SOURCE_BATTERY
+	case powersource.ProvidingUPS:
+		resp.PowerSource = rpc.PowerSource_POWER_SOURCE_UPS
+	default:
+		resp.PowerSource = rpc.PowerSource_POWER_SOURCE_UNKNOWN
+	}
+}
@@ -0,0 +1,31 @@
+package server
+
+import (
+	"errors"
+	"testing"
+
+	"powergrid/internal/powersource"
+	rpc "powergrid/internal/rpc"
+)
+
+func TestSetPowerSourceStatusReportsUPS(t *testing.T) {
+	resetServerTestGlobals(t)
+
+	powerSourceFn = func() (powersource.Info, error) {
+		return powersource.Info{UPS: true, Providing: powersource.ProvidingUPS}, nil
+	}
+	resp := &rpc.StatusResponse{}
+	setPowerSourceStatus(resp)
+	if !resp.GetUpsAttached() || resp.GetPowerSource() != rpc.PowerSource_POWER_SOURCE_UPS {
+		t.Fatalf("unexpected power source: ups=%v source=%v", resp.GetUpsAttached(), resp.GetPowerSource())
+	}
+
+	powerSourceFn = func() (powersource.Info, error) {
+		return powersource.Info{}, errors.New("snapshot unavailable")
+	}
+	resp = &rpc.StatusResponse{}
+	setPowerSourceStatus(resp)
+	if resp.GetUpsAttached() || resp.GetPowerSource() != rpc.PowerSource_POWER_SOURCE_UNKNOWN {
+		t.Fatalf("expected unknown source on read failure, got ups=%v source=%v", resp.GetUpsAttached(), resp.GetPowerSource())
+	}
+}
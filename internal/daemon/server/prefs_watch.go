@@ -0,0 +1,105 @@
+package server
+
+import (
+	"context"
+	"maps"
+	"strings"
+	"time"
+
+	cfg "powergrid/internal/config"
+)
+
+// prefsWatchInterval is how often the console user's preferences are re-read
+// when WatchPreferenceChanges is on. With the 2-second plist cache, an
+// outside edit takes effect within about 7 seconds.
+const prefsWatchInterval = 5 * time.Second
+
+// startPrefsWatcher periodically reloads the console user's preferences so
+// edits made outside the daemon, such as with defaults write, are applied
+// without waiting for a user switch.
+func (s *Daemon) startPrefsWatcher(ctx context.Context) {
+	if !s.watchPrefs {
+		return
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		ticker := time.NewTicker(prefsWatchInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.reloadUserPrefs()
+			}
+		}
+	}()
+}
+
+// reloadUserPrefs applies preferences that changed on disk since they were
+// last read. Only values that differ from the previous read are applied, so
+// in-memory state set without persisting, such as a profile's limit, is not
+// reverted. Mutations that persist a preference update s.userPrefs as they
+// write, so the daemon's own writes never read back as outside edits. The
+// read happens under s.mu, like those mutations, so a concurrent RPC cannot
+// be undone by a stale read.
+func (s *Daemon) reloadUserPrefs() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	u := s.currentConsoleUser
+	if u == nil {
+		return
+	}
+	fresh := profileForUserFn(u, defaultChargeLimit)
+	prev := s.userPrefs
+	var changed []string
+
+	if fresh.Limit != prev.Limit {
+		s.currentLimit = int32(fresh.Limit)
+		s.rememberUserLimitLocked()
+		changed = append(changed, "charge limit")
+	}
+	if fresh.DischargeBandFloor != prev.DischargeBandFloor || fresh.DischargeBandCeiling != prev.DischargeBandCeiling {
+		s.dischargeBandFloor, s.dischargeBandCeiling = fresh.DischargeBandFloor, fresh.DischargeBandCeiling
+		changed = append(changed, "discharge band")
+	}
+	if fresh.WantDisableChargingBeforeSleep != prev.WantDisableChargingBeforeSleep {
+		s.wantDisableChargingBeforeSleep = fresh.WantDisableChargingBeforeSleep
+		changed = append(changed, "disable charging before sleep")
+	}
+	if fresh.WantPersistSleepPrevention != prev.WantPersistSleepPrevention {
+		s.persistSleepPrevention = fresh.WantPersistSleepPrevention
+		changed = append(changed, "persist sleep prevention")
+	}
+	if fresh.LEDGreenOnlyWhenFull != prev.LEDGreenOnlyWhenFull {
+		s.ledGreenOnlyWhenFull = fresh.LEDGreenOnlyWhenFull
+		changed = append(changed, "LED green only when full")
+	}
+	returnLED := false
+	if fresh.LEDMode != prev.LEDMode {
+		returnLED = fresh.LEDMode == cfg.LEDModeSystem && s.ledControlledLocked()
+		s.ledMode = fresh.LEDMode
+		changed = append(changed, "LED mode")
+	}
+	if limits := cfg.ReadUserAdapterLimits(u.HomeDir); !maps.Equal(limits, s.adapterLimits) {
+		s.adapterLimits = limits
+		changed = append(changed, "adapter limits")
+	}
+	s.userPrefs = fresh
+	if len(changed) == 0 {
+		return
+	}
+
+	logger.Default("Applied preference changes made outside PowerGrid for %s: %s.", u.Username, strings.Join(changed, ", "))
+	s.recordEvent("preferences_reloaded", map[string]any{"user": u.Username, "changed": changed})
+	if returnLED {
+		if err := s.returnLEDToSystemLocked(); err != nil {
+			logger.Error("Failed to return MagSafe LED to system control: %v", err)
+		}
+	}
+	s.reconcileSleepChargingStateLocked()
+	s.runChargingLogicLocked(nil)
+}
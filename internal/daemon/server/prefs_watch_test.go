@@ -0,0 +1,85 @@
+package server
+
+import (
+	"os"
+	"testing"
+
+	"github.com/peterneutron/powerkit-go/pkg/powerkit"
+
+	"powergrid/internal/consoleuser"
+	"powergrid/internal/daemon/session"
+	rpc "powergrid/internal/rpc"
+)
+
+func TestReloadUserPrefsAppliesOnlyChangedValues(t *testing.T) {
+	resetServerTestGlobals(t)
+	setChargingStateFn = func(powerkit.ChargingAction) error { return nil }
+	getSystemInfoFn = func(...powerkit.FetchOptions) (*powerkit.SystemInfo, error) {
+		return testSystemInfo(50, true), nil
+	}
+
+	onDisk := session.Profile{Limit: 80}
+	profileForUserFn = func(*consoleuser.ConsoleUser, int) session.Profile { return onDisk }
+
+	// A profile applied a 70% limit without persisting it.
+	d := &Daemon{
+		currentLimit:       70,
+		userPrefs:          onDisk,
+		currentConsoleUser: &consoleuser.ConsoleUser{Username: "alice", UID: 501, HomeDir: t.TempDir()},
+	}
+	d.reloadUserPrefs()
+	if d.currentLimit != 70 {
+		t.Fatalf("expected the unpersisted limit to stay, got %d", d.currentLimit)
+	}
+
+	onDisk = session.Profile{Limit: 90, WantDisableChargingBeforeSleep: true}
+	d.reloadUserPrefs()
+	if d.currentLimit != 90 || !d.wantDisableChargingBeforeSleep {
+		t.Fatalf("expected the outside edit applied, got limit=%d disableBeforeSleep=%t", d.currentLimit, d.wantDisableChargingBeforeSleep)
+	}
+	if d.userPrefs != onDisk {
+		t.Fatalf("expected the baseline updated to the new read, got %+v", d.userPrefs)
+	}
+}
+
+func TestReloadUserPrefsAfterRPCWrite(t *testing.T) {
+	resetServerTestGlobals(t)
+	setChargingStateFn = func(powerkit.ChargingAction) error { return nil }
+	getSystemInfoFn = func(...powerkit.FetchOptions) (*powerkit.SystemInfo, error) {
+		return testSystemInfo(50, true), nil
+	}
+
+	u := &consoleuser.ConsoleUser{Username: "alice", UID: uint32(os.Getuid()), GID: uint32(os.Getgid()), HomeDir: t.TempDir()}
+	d := &Daemon{
+		currentLimit:       80,
+		userPrefs:          session.ProfileForUser(u, defaultChargeLimit),
+		currentConsoleUser: u,
+	}
+	if err := d.applySetChargeLimit(70); err != nil {
+		t.Fatalf("applySetChargeLimit: %v", err)
+	}
+	if err := d.applyPowerFeature(rpc.PowerFeature_DISABLE_CHARGING_BEFORE_SLEEP, true); err != nil {
+		t.Fatalf("applyPowerFeature: %v", err)
+	}
+	if onDisk := session.ProfileForUser(u, defaultChargeLimit); d.userPrefs != onDisk {
+		t.Fatalf("expected the baseline to match what was written, got %+v want %+v", d.userPrefs, onDisk)
+	}
+
+	// A limit applied later without persisting must survive the reload.
+	d.currentLimit = 60
+	d.reloadUserPrefs()
+	if d.currentLimit != 60 {
+		t.Fatalf("expected the daemon's own write not to be reapplied, got limit %d", d.currentLimit)
+	}
+}
+
+func TestReloadUserPrefsWithoutConsoleUser(t *testing.T) {
+	resetServerTestGlobals(t)
+	profileForUserFn = func(*consoleuser.ConsoleUser, int) session.Profile {
+		t.Fatal("unexpected preference read without a console user")
+		return session.Profile{}
+	}
+
+	d := &Daemon{currentLimit: 80}
+	d.reloadUserPrefs()
+}
@@ -0,0 +1,174 @@
+package server
+
+import (
+	"context"
+	"sort"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	cfg "powergrid/internal/config"
+	rpc "powergrid/internal/rpc"
+)
+
+func profileToRPC(name string, p cfg.ChargeProfile) *rpc.ChargeProfile {
+	return &rpc.ChargeProfile{
+		Name:                       name,
+		ChargeLimit:                int32(p.ChargeLimit),
+		ControlMagsafeLed:          p.ControlMagsafeLED,
+		DisableChargingBeforeSleep: p.DisableChargingBeforeSleep,
+	}
+}
+
+func profileFromRPC(p *rpc.ChargeProfile) cfg.ChargeProfile {
+	return cfg.ChargeProfile{
+		ChargeLimit:                int(p.GetChargeLimit()),
+		ControlMagsafeLED:          p.GetControlMagsafeLed(),
+		DisableChargingBeforeSleep: p.GetDisableChargingBeforeSleep(),
+	}
+}
+
+func (s *Daemon) ListProfiles(_ context.Context, _ *rpc.Empty) (*rpc.ProfileListResponse, error) {
+	s.mu.RLock()
+	homeDir := ""
+	if s.currentConsoleUser != nil {
+		homeDir = s.currentConsoleUser.HomeDir
+	}
+	active := s.activeProfile
+	s.mu.RUnlock()
+
+	profiles := cfg.ReadUserProfiles(homeDir)
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	resp := &rpc.ProfileListResponse{ActiveProfile: active}
+	for _, name := range names {
+		resp.Profiles = append(resp.Profiles, profileToRPC(name, profiles[name]))
+	}
+	return resp, nil
+}
+
+// applyProfile persists every setting in the named profile for the console
+// user and applies it to the running state in one step.
+func (s *Daemon) applyProfile(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	u := s.currentConsoleUser
+	if u == nil {
+		return noConsoleUserError("profiles")
+	}
+	p, ok := cfg.ReadUserProfiles(u.HomeDir)[name]
+	if !ok {
+		return status.Errorf(codes.NotFound, "unknown profile %q", name)
+	}
+	// Built-in profiles are not validated when read, so a raised
+	// MinChargeLimit can leave one below the floor.
+	if floor := s.chargeLimitFloor(); p.ChargeLimit < floor || p.ChargeLimit > 100 {
+		return outOfRangeError("profile limit", p.ChargeLimit, floor, 100)
+	}
+
+	if err := cfg.WriteUserChargeLimit(u.HomeDir, u.UID, u.GID, p.ChargeLimit); err != nil {
+		logger.Error("Failed to persist charge limit for profile %s: %v", name, err)
+	} else {
+		s.userPrefs.Limit = p.ChargeLimit
+	}
+	// Profiles only switch between auto and system LED control; a user who
+	// turned the LED off keeps it off.
+	ledMode := s.ledMode
+	if ledMode != cfg.LEDModeOff {
+		ledMode = cfg.LEDModeFromControl(p.ControlMagsafeLED && s.ledSupported)
+		if err := cfg.WriteUserLEDMode(u.HomeDir, u.UID, u.GID, cfg.LEDModeFromControl(p.ControlMagsafeLED)); err != nil {
+			logger.Error("Failed to persist MagSafe LED setting for profile %s: %v", name, err)
+		} else {
+			s.userPrefs.LEDMode = cfg.LEDModeFromControl(p.ControlMagsafeLED)
+		}
+	}
+	if err := cfg.WriteUserDisableChargingBeforeSleep(u.HomeDir, u.UID, u.GID, p.DisableChargingBeforeSleep); err != nil {
+		logger.Error("Failed to persist sleep charging setting for profile %s: %v", name, err)
+	} else {
+		s.userPrefs.WantDisableChargingBeforeSleep = p.DisableChargingBeforeSleep
+	}
+	if err := cfg.WriteUserActiveProfile(u.HomeDir, u.UID, u.GID, name); err != nil {
+		logger.Error("Failed to persist active profile %s: %v", name, err)
+	}
+
+	if s.ledControlledLocked() && ledMode == cfg.LEDModeSystem {
+		if err := s.returnLEDToSystemLocked(); err != nil {
+			logger.Error("Failed to return MagSafe LED to system control for profile %s: %v", name, err)
+		}
+	}
+
+	s.currentLimit = int32(p.ChargeLimit)
+	s.rememberUserLimitLocked()
+	s.ledMode = ledMode
+	s.wantDisableChargingBeforeSleep = p.DisableChargingBeforeSleep
+	s.activeProfile = name
+	s.reconcileSleepChargingStateLocked()
+	logger.Default("Applied profile %s for %s (limit %d%%).", name, u.Username, p.ChargeLimit)
+
+	s.runChargingLogicLocked(nil)
+	return nil
+}
+
+// setProfile stores a profile definition; redefining the active profile
+// applies the new values immediately.
+func (s *Daemon) setProfile(name string, def *rpc.ChargeProfile) error {
+	if def == nil {
+		return status.Error(codes.InvalidArgument, "profile definition is required")
+	}
+	p := profileFromRPC(def)
+	if err := cfg.ValidateProfile(name, p); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	s.mu.RLock()
+	u := s.currentConsoleUser
+	active := s.activeProfile
+	s.mu.RUnlock()
+	if u == nil {
+		return noConsoleUserError("profiles")
+	}
+
+	if err := cfg.WriteUserProfile(u.HomeDir, u.UID, u.GID, name, p); err != nil {
+		logger.Error("Failed to persist profile %s for %s: %v", name, u.Username, err)
+		return status.Errorf(codes.Internal, "failed to persist profile: %v", err)
+	}
+	logger.Default("Saved profile %s for %s.", name, u.Username)
+
+	if name == active {
+		return s.applyProfile(name)
+	}
+	return nil
+}
+
+// clearActiveProfile drops the active profile after a setting it bundles is
+// changed on its own, so status never claims a profile that no longer holds.
+func (s *Daemon) clearActiveProfile() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.activeProfile == "" {
+		return
+	}
+	s.activeProfile = ""
+	if u := s.currentConsoleUser; u != nil {
+		if err := cfg.WriteUserActiveProfile(u.HomeDir, u.UID, u.GID, ""); err != nil {
+			logger.Error("Failed to clear active profile for %s: %v", u.Username, err)
+		}
+	}
+}
+
+// bundledInProfile reports whether a feature toggle changes a setting that
+// charge profiles manage.
+func bundledInProfile(feature rpc.PowerFeature) bool {
+	switch feature {
+	case rpc.PowerFeature_CONTROL_MAGSAFE_LED, rpc.PowerFeature_DISABLE_CHARGING_BEFORE_SLEEP:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,112 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/peterneutron/powerkit-go/pkg/powerkit"
+
+	cfg "powergrid/internal/config"
+	"powergrid/internal/consoleuser"
+	rpc "powergrid/internal/rpc"
+)
+
+func TestProfileMutationsRequireConsoleUser(t *testing.T) {
+	d := &Daemon{currentLimit: 80}
+
+	_, err := d.ApplyMutation(context.Background(), &rpc.MutationRequest{
+		Operation:   rpc.MutationOperation_APPLY_PROFILE,
+		ProfileName: "daily",
+	})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("unexpected apply error code: got=%v want=%v", status.Code(err), codes.FailedPrecondition)
+	}
+
+	_, err = d.ApplyMutation(context.Background(), &rpc.MutationRequest{
+		Operation:   rpc.MutationOperation_SET_PROFILE,
+		ProfileName: "daily",
+		Profile:     &rpc.ChargeProfile{ChargeLimit: 80},
+	})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("unexpected set error code: got=%v want=%v", status.Code(err), codes.FailedPrecondition)
+	}
+	if d.currentLimit != 80 {
+		t.Fatalf("unexpected limit change: got=%d want=%d", d.currentLimit, 80)
+	}
+}
+
+func TestSetProfileValidatesDefinition(t *testing.T) {
+	d := &Daemon{}
+
+	tests := []struct {
+		name    string
+		profile string
+		def     *rpc.ChargeProfile
+	}{
+		{name: "missing definition", profile: "daily"},
+		{name: "empty name", def: &rpc.ChargeProfile{ChargeLimit: 80}},
+		{name: "limit too low", profile: "daily", def: &rpc.ChargeProfile{ChargeLimit: 40}},
+		{name: "limit too high", profile: "daily", def: &rpc.ChargeProfile{ChargeLimit: 120}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := d.setProfile(tc.profile, tc.def); status.Code(err) != codes.InvalidArgument {
+				t.Fatalf("unexpected error code: got=%v want=%v", status.Code(err), codes.InvalidArgument)
+			}
+		})
+	}
+}
+
+func TestBundledInProfile(t *testing.T) {
+	if !bundledInProfile(rpc.PowerFeature_CONTROL_MAGSAFE_LED) || !bundledInProfile(rpc.PowerFeature_DISABLE_CHARGING_BEFORE_SLEEP) {
+		t.Fatal("expected LED and sleep charging toggles to clear the active profile")
+	}
+	if bundledInProfile(rpc.PowerFeature_FORCE_DISCHARGE) || bundledInProfile(rpc.PowerFeature_LOW_POWER_MODE) {
+		t.Fatal("expected transient features to leave the active profile alone")
+	}
+}
+
+func TestApplyProfileRejectsLimitBelowFloor(t *testing.T) {
+	d := &Daemon{
+		currentLimit:       80,
+		minChargeLimit:     70,
+		currentConsoleUser: &consoleuser.ConsoleUser{Username: "alice", UID: 501, HomeDir: t.TempDir()},
+	}
+
+	err := d.applyProfile("longevity")
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("unexpected error code: got=%v want=%v", status.Code(err), codes.InvalidArgument)
+	}
+	if d.currentLimit != 80 || d.activeProfile != "" {
+		t.Fatalf("expected state unchanged, got limit=%d profile=%q", d.currentLimit, d.activeProfile)
+	}
+}
+
+func TestApplyProfileKeepsLEDOff(t *testing.T) {
+	resetServerTestGlobals(t)
+	setChargingStateFn = func(powerkit.ChargingAction) error { return nil }
+	getSystemInfoFn = func(...powerkit.FetchOptions) (*powerkit.SystemInfo, error) {
+		return testSystemInfo(70, true), nil
+	}
+
+	d := &Daemon{
+		currentLimit:       80,
+		ledSupported:       true,
+		ledMode:            cfg.LEDModeOff,
+		currentConsoleUser: &consoleuser.ConsoleUser{Username: "alice", UID: 501, HomeDir: t.TempDir()},
+	}
+
+	if err := d.applyProfile("travel"); err != nil {
+		t.Fatalf("applyProfile: %v", err)
+	}
+	if d.ledMode != cfg.LEDModeOff {
+		t.Fatalf("unexpected LED mode: got=%q want=%q", d.ledMode, cfg.LEDModeOff)
+	}
+	if d.currentLimit != 100 {
+		t.Fatalf("unexpected limit: got=%d want=%d", d.currentLimit, 100)
+	}
+}
@@ -0,0 +1,79 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// safeModeWriteFailures is how many charging or adapter writes in a row must
+// be denied, before any has succeeded, for the daemon to stop writing to the
+// SMC.
+const safeModeWriteFailures = 3
+
+// IOKit return codes for a caller that may not write the SMC, as powerkit
+// prints them in its write errors.
+const (
+	kIOReturnNotPrivileged = -536870207 // 0xe00002c1
+	kIOReturnNotPermitted  = -536870174 // 0xe00002e2
+)
+
+// noteHardwareWriteLocked tracks charging and adapter write outcomes. One
+// success proves the daemon can write and rules safe mode out for the rest of
+// the run; until then, safeModeWriteFailures privilege failures in a row put
+// the daemon in read-only safe mode so a machine with broken SMC access does
+// not loop on writes that cannot succeed. Timeouts and other failures neither
+// count nor break the run, since a later write may still work.
+func (s *Daemon) noteHardwareWriteLocked(err error) {
+	if s.hardwareWriteOK || s.safeMode.Load() {
+		return
+	}
+	if err == nil {
+		s.hardwareWriteOK = true
+		s.hardwareWriteFailures = 0
+		return
+	}
+	if !privilegeError(err) {
+		return
+	}
+	s.hardwareWriteFailures++
+	if s.hardwareWriteFailures < safeModeWriteFailures {
+		return
+	}
+	s.safeMode.Store(true)
+	logger.Fault("SMC writes failed %d times in a row since start (last: %v); entering read-only safe mode. Charging is not managed until the daemon restarts with working SMC access.", s.hardwareWriteFailures, err)
+	s.recordEvent("safe_mode_entered", map[string]any{"failures": s.hardwareWriteFailures, "error": err.Error()})
+}
+
+// hardwareWritesBlocked reports whether safe mode forbids an SMC write, and
+// logs the skipped action when it does.
+func (s *Daemon) hardwareWritesBlocked(action string) bool {
+	if !s.safeMode.Load() {
+		return false
+	}
+	logger.InfoLimited("Read-only safe mode; skipping %s.", action)
+	return true
+}
+
+// privilegeError reports whether a write failed because the daemon lacks the
+// rights to write the SMC.
+func privilegeError(err error) bool {
+	if errors.Is(err, os.ErrPermission) {
+		return true
+	}
+	msg := err.Error()
+	for _, code := range []int{kIOReturnNotPrivileged, kIOReturnNotPermitted} {
+		if strings.HasSuffix(msg, fmt.Sprintf(": %d", code)) {
+			return true
+		}
+	}
+	return false
+}
+
+func safeModeError() error {
+	return status.Error(codes.FailedPrecondition, "daemon is in read-only safe mode because SMC writes are denied; fix SMC access and restart the daemon")
+}
@@ -0,0 +1,110 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/peterneutron/powerkit-go/pkg/powerkit"
+
+	rpc "powergrid/internal/rpc"
+)
+
+func TestRepeatedWriteFailuresEnterSafeMode(t *testing.T) {
+	resetServerTestGlobals(t)
+
+	clock = newFakeClock(time.Date(2026, 4, 20, 12, 0, 0, 0, time.UTC))
+	writes := 0
+	setChargingStateFn = func(powerkit.ChargingAction) error {
+		writes++
+		return fmt.Errorf("SMC write failed for key 'CHTE' with kern_return code: %d", kIOReturnNotPrivileged)
+	}
+
+	d := &Daemon{currentLimit: 80}
+	for i := 0; i < safeModeWriteFailures; i++ {
+		d.runChargingLogicLocked(testSystemInfo(85, true))
+	}
+	if !d.safeMode.Load() {
+		t.Fatalf("expected safe mode after %d failed writes", safeModeWriteFailures)
+	}
+
+	d.runChargingLogicLocked(testSystemInfo(85, true))
+	if writes != safeModeWriteFailures {
+		t.Fatalf("expected no writes in safe mode, got %d writes", writes)
+	}
+	resp, err := d.GetStatus(context.Background(), &rpc.Empty{})
+	if err != nil {
+		t.Fatalf("GetStatus returned error: %v", err)
+	}
+	if !resp.GetSafeMode() {
+		t.Fatal("expected status to report safe mode")
+	}
+	_, err = d.ApplyMutation(context.Background(), &rpc.MutationRequest{Operation: rpc.MutationOperation_SET_CHARGE_LIMIT, Limit: 70})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition in safe mode, got %v", err)
+	}
+}
+
+func TestTransientWriteFailuresDoNotEnterSafeMode(t *testing.T) {
+	resetServerTestGlobals(t)
+
+	clock = newFakeClock(time.Date(2026, 4, 20, 12, 0, 0, 0, time.UTC))
+	setChargingStateFn = func(powerkit.ChargingAction) error {
+		return errors.New("operation timed out after 2s")
+	}
+
+	d := &Daemon{currentLimit: 80}
+	for i := 0; i < safeModeWriteFailures+1; i++ {
+		d.runChargingLogicLocked(testSystemInfo(85, true))
+	}
+	if d.safeMode.Load() {
+		t.Fatal("expected timeouts to leave the daemon out of safe mode")
+	}
+}
+
+func TestPrivilegeError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{err: fmt.Errorf("failed to open SMC connection for writing: %d", kIOReturnNotPrivileged), want: true},
+		{err: fmt.Errorf("SMC write failed for key 'CHTE' with kern_return code: %d", kIOReturnNotPermitted), want: true},
+		{err: fmt.Errorf("open: %w", os.ErrPermission), want: true},
+		{err: errors.New("operation timed out after 2s"), want: false},
+		{err: fmt.Errorf("SMC write failed for key 'CHTE' with kern_return code: %d", -536870212), want: false},
+	}
+	for _, tc := range tests {
+		if got := privilegeError(tc.err); got != tc.want {
+			t.Errorf("privilegeError(%q) = %v, want %v", tc.err, got, tc.want)
+		}
+	}
+}
+
+func TestSuccessfulWriteRulesOutSafeMode(t *testing.T) {
+	resetServerTestGlobals(t)
+
+	clock = newFakeClock(time.Date(2026, 4, 20, 12, 0, 0, 0, time.UTC))
+	fail := false
+	setChargingStateFn = func(powerkit.ChargingAction) error {
+		if fail {
+			return fmt.Errorf("SMC write failed for key 'CHTE' with kern_return code: %d", kIOReturnNotPrivileged)
+		}
+		return nil
+	}
+
+	d := &Daemon{currentLimit: 80}
+	d.runChargingLogicLocked(testSystemInfo(85, true))
+	fail = true
+	for i := 0; i < safeModeWriteFailures+1; i++ {
+		d.runChargingLogicLocked(testSystemInfo(85, true))
+	}
+	if d.safeMode.Load() {
+		t.Fatal("expected a working SMC to keep the daemon out of safe mode")
+	}
+}
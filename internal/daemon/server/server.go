@@ -2,15 +2,21 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math/rand/v2"
 	"os"
 	"os/signal"
 	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
 	"google.golang.org/grpc/status"
 
 	"github.com/peterneutron/powerkit-go/pkg/powerkit"
@@ -20,36 +26,97 @@ import (
 	"powergrid/internal/daemon/engine"
 	"powergrid/internal/daemon/ipc"
 	"powergrid/internal/daemon/session"
+	"powergrid/internal/display"
+	"powergrid/internal/eventlog"
 	oslogger "powergrid/internal/oslogger"
+	"powergrid/internal/powersource"
 	rpc "powergrid/internal/rpc"
 )
 
+// DefaultChargeLimit is the built-in limit used when no user or system limit
+// is configured.
+const DefaultChargeLimit = 80
+
+// defaultChargeLimit is DefaultChargeLimit unless Run was given another
+// default, e.g. from POWERGRID_DEFAULT_LIMIT.
+var defaultChargeLimit = DefaultChargeLimit
+
+// socketPath is where Run serves gRPC. textSocketPath, beside it, serves a
+// line protocol for shell scripts when TextControlEnabled is set; it shares
+// the gRPC socket's ownership, mode and caller allowlist.
+var (
+	socketPath     = ipc.DefaultSocketPath
+	textSocketPath = ipc.TextSocketPath(ipc.DefaultSocketPath)
+)
+
 const (
-	socketPath         = "/var/run/powergrid.sock"
-	defaultChargeLimit = 80
-	logSubsystem       = "com.neutronstar.powergrid.daemon"
-	opTimeout          = 5 * time.Second
-	preSleepBudget     = 5 * time.Second
-	wakeHoldDuration   = 30 * time.Second
-	apiMajor           = uint32(1)
-	apiMinor           = uint32(0)
+	logSubsystem      = "com.neutronstar.powergrid.daemon"
+	opTimeout         = 5 * time.Second
+	preSleepBudget    = 5 * time.Second
+	wakeHoldDuration  = 30 * time.Second
+	recomputeInterval = 60 * time.Second
+	externalHoldGrace = 2 * time.Minute
+	maxStartupJitter  = time.Second
+	apiMajor          = uint32(1)
+	apiMinor          = uint32(13)
 )
 
 var logger = oslogger.NewLogger(logSubsystem, "Daemon")
 
 var (
-	streamSystemEventsFn = powerkit.StreamSystemEventsWithHooks
-	setChargingStateFn   = powerkit.SetChargingState
-	getSystemInfoFn      = powerkit.GetSystemInfo
-	nowFn                = time.Now
+	streamSystemEventsFn     = powerkit.StreamSystemEventsWithHooks
+	setChargingStateFn       = powerkit.SetChargingState
+	setMagsafeLEDStateFn     = powerkit.SetMagsafeLEDState
+	getMagsafeLEDStateFn     = powerkit.GetMagsafeLEDState
+	setAdapterStateFn        = powerkit.SetAdapterState
+	getSystemInfoFn          = powerkit.GetSystemInfo
+	allowAllSleepFn          = powerkit.AllowAllSleep
+	clock                    = Clock(realClock{})
+	systemLoadFn             = readLoadPerCPU
+	chargeManagerProcessesFn = findChargeManagers
+	processStartTimeFn       = processStartTime
+	writeCountersFn          = cfg.WriteSystemChargingCounters
+	systemLocationFn         = systemLocation
+	externalDisplayCountFn   = display.ExternalCount
+	powerSourceFn            = powersource.Read
+	createAssertionFn        = powerkit.CreateAssertion
+	releaseAssertionFn       = powerkit.ReleaseAssertion
+	writeLastUserLimitFn     = cfg.WriteSystemLastUserChargeLimit
+	writeEffectiveLimitFn    = cfg.WriteSystemEffectiveChargeLimit
+	profileForUserFn         = session.ProfileForUser
+	getRawSMCValuesFn        = powerkit.GetRawSMCValues
+	localUsersFn             = consoleuser.LocalUsers
+	sleepAssertionsFn        = readSleepAssertions
+	callerUIDFn              = ipc.CallerUID
+	setLowPowerModeFn        = powerkit.SetLowPowerModeContext
+	readOverrideSnapshotFn   = cfg.ReadSystemOverrideSnapshot
+	writeOverrideSnapshotFn  = cfg.WriteSystemOverrideSnapshot
+	writeHealthHistoryFn     = cfg.WriteSystemHealthHistory
 )
 
 type Daemon struct {
 	rpc.UnimplementedPowerGridServer
 
 	mu                             sync.RWMutex
+	userSwitchMu                   sync.Mutex
 	wg                             sync.WaitGroup
 	currentLimit                   int32
+	adapterLimits                  map[string]int
+	statusFetchedAt                time.Time
+	batteryMissing                 bool
+	boostUntil                     time.Time
+	suspendUntil                   time.Time
+	suspendTimer                   Timer
+	fullByDeadline                 time.Time
+	fullByTarget                   int
+	fullByStarted                  time.Time
+	pinnedPID                      int32
+	pinnedStart                    time.Time
+	counters                       cfg.ChargingCounters
+	forceDischargeRequested        bool
+	restoredForceDischarge         bool
+	forceDischargeFloorReached     bool
+	forceDischargeFloor            int
 	lastIOKitStatus                *powerkit.IOKitData
 	lastSMCStatus                  *powerkit.SMCData
 	lastBatteryWattage             float32
@@ -58,16 +125,99 @@ type Daemon struct {
 	currentConsoleUser             *consoleuser.ConsoleUser
 	wantPreventDisplaySleep        bool
 	wantPreventSystemSleep         bool
-	wantMagsafeLED                 bool
+	ledMode                        cfg.LEDMode
+	ledGreenOnlyWhenFull           bool
+	dischargeBandFloor             int
+	dischargeBandCeiling           int
+	bandDischarging                bool
+	assertions                     assertionTracker
+	manualCharging                 rpc.ManualCharging
+	criticalLevel                  int
+	criticalActions                cfg.CriticalActions
+	criticalCharge                 bool
 	wantDisableChargingBeforeSleep bool
+	persistSleepPrevention         bool
+	externalDisplaySleep           bool
+	watchPrefs                     bool
+	userPrefs                      session.Profile
+	autoPreventDisplaySleep        bool
+	limitRamp                      time.Duration
+	minChargeLimit                 int
+	rampFrom                       int
+	rampTo                         int
+	rampedLimit                    int
+	rampStart                      time.Time
+	rememberUserLimit              bool
+	rememberedLimit                int
+	managementDisabled             bool
+	mutationTokenRequired          bool
+	textControlEnabled             bool
+	adapterUnderperformPercent     int
+	minChargingAdapterWatts        int
+	minChargeBeforeSleep           int
+	preSleepDelay                  time.Duration
+	preSleepTimer                  Timer
+	chargedAtLimit                 bool
+	limitEnforced                  bool
+	hideChargedAtLimit             bool
+	deferUnderLoad                 bool
+	chargeDuringDischarge          bool
+	restoreOverridesOnStart        bool
+	enableDeferredSince            time.Time
+	chargingExpect                 atomic.Int32
+	chargeMismatches               int
+	conflictScanAt                 time.Time
+	conflictProcesses              []string
+	chargeConflict                 string
+	chargeHistory                  []engine.ChargeSample
+	chargeSession                  engine.ChargeSession
+	healthHistory                  []cfg.HealthSample
+	chargingStalled                bool
+	events                         *eventlog.Log
+	activeProfile                  string
+	connectGrace                   time.Duration
+	warmUpUntil                    time.Time
+	connectedSince                 time.Time
+	pauseReason                    engine.PauseReason
 	sleepTransitionActive          bool
 	wakeHoldUntil                  time.Time
+	externalHoldSince              time.Time
 	ledSupported                   bool
+	ledProbed                      bool
+	ledUnhonored                   map[powerkit.MagsafeLEDState]bool
+	ledStatesProbed                bool
+	ledProbing                     bool
+	ledProbeTried                  bool
+	ledProbeGen                    int
 	lastLEDState                   powerkit.MagsafeLEDState
-	buildID                        string
-	buildIDSource                  string
-	buildDirty                     bool
+	ledDirty                       bool
+	ledAppliedAt                   map[powerkit.MagsafeLEDState]time.Time
+	ledFailedAt                    time.Time
+	ledLastError                   string
+	ledRetries                     int
+	ledRetryLimit                  int
+	ledRetryTimer                  Timer
+	build                          BuildInfo
 	batteryUpdateCh                chan *powerkit.SystemInfo
+	lastLogicRunNanos              atomic.Int64
+	watchdogTripped                atomic.Bool
+	safeMode                       atomic.Bool
+	hardwareWriteOK                bool
+	hardwareWriteFailures          int
+	health                         *health.Server
+	lastHealth                     healthpb.HealthCheckResponse_ServingStatus
+	eventStreamUp                  bool
+	pollingOnly                    bool
+	logDecisions                   bool
+	pollInterval                   time.Duration
+	smcKeyReadsEnabled             bool
+	persistEffectiveLimit          bool
+	persistedEffectiveLimit        int
+	pendingEffectiveLimit          int
+	effectiveLimitTimer            Timer
+	smcKeyReadAt                   time.Time
+	systemInfoSeen                 bool
+	smcFailures                    int
 }
 
 // Low Power Mode is read via powerkit-go's cached helper; no extra cache needed here.
@@ -77,7 +227,7 @@ func (s *Daemon) GetStatus(_ context.Context, _ *rpc.Empty) (*rpc.StatusResponse
 	defer s.mu.RUnlock()
 
 	if s.lastIOKitStatus == nil {
-		return &rpc.StatusResponse{ChargeLimit: s.currentLimit, AdapterDescription: "Initializing..."}, nil
+		return &rpc.StatusResponse{ChargeLimit: s.currentLimit, AdapterDescription: "Initializing...", ManagementEnabled: !s.managementDisabled, SafeMode: s.safeMode.Load(), WarmingUp: s.warmingUpLocked(clock.Now())}, nil
 	}
 
 	resp := &rpc.StatusResponse{
@@ -97,7 +247,7 @@ func (s *Daemon) GetStatus(_ context.Context, _ *rpc.Empty) (*rpc.StatusResponse
 		AdapterInputAmperage:      float32(s.lastIOKitStatus.Adapter.InputAmperage),
 		TimeToFullMinutes:         int32(s.lastIOKitStatus.Battery.TimeToFull),
 		TimeToEmptyMinutes:        int32(s.lastIOKitStatus.Battery.TimeToEmpty),
-		PreventDisplaySleepActive: s.wantPreventDisplaySleep,
+		PreventDisplaySleepActive: s.wantPreventDisplaySleep || s.autoPreventDisplaySleep,
 		PreventSystemSleepActive:  s.wantPreventSystemSleep,
 		ForceDischargeActive: func() bool {
 			if s.lastSMCStatus != nil {
@@ -110,8 +260,15 @@ func (s *Daemon) GetStatus(_ context.Context, _ *rpc.Empty) (*rpc.StatusResponse
 		resp.SmcChargingEnabled = s.lastSMCStatus.State.IsChargingEnabled
 		resp.SmcAdapterEnabled = s.lastSMCStatus.State.IsAdapterEnabled
 	}
-	resp.MagsafeLedControlActive = s.wantMagsafeLED
+	resp.MagsafeLedControlActive = s.ledControlledLocked()
+	resp.MagsafeLedMode = ledModeToProto(s.ledMode)
+	resp.MagsafeLedGreenOnlyWhenFull = s.ledGreenOnlyWhenFull
 	resp.MagsafeLedSupported = s.ledSupported
+	resp.MagsafeLedSupport = s.magsafeLEDSupportLocked()
+	resp.MagsafeLedStates = s.honoredLEDStateNamesLocked()
+	s.setLEDHistoryStatusLocked(resp)
+	resp.MinChargeLimit = int32(s.chargeLimitFloor())
+	resp.SuspendRemainingSeconds = int32(s.suspendRemainingLocked(clock.Now()).Seconds())
 	// Low Power Mode via powerkit-go (cached internally by the library)
 	if enabled, available, err := powerkit.GetLowPowerModeEnabled(); err == nil {
 		resp.LowPowerModeAvailable = available
@@ -120,6 +277,54 @@ func (s *Daemon) GetStatus(_ context.Context, _ *rpc.Empty) (*rpc.StatusResponse
 		}
 	}
 	resp.DisableChargingBeforeSleepActive = s.wantDisableChargingBeforeSleep
+	resp.PersistSleepPreventionActive = s.persistSleepPrevention
+	resp.PreventDisplaySleepAuto = s.autoPreventDisplaySleep
+	resp.RampedChargeLimit = int32(s.limitRampingLocked())
+	resp.ManagementEnabled = !s.managementDisabled
+	resp.ExternalChargeHold = s.externalHoldReportedLocked(clock.Now())
+	effectiveLimit, matchedKey := s.effectiveLimitLocked()
+	resp.AdapterKey = s.currentAdapterKeyLocked()
+	resp.MatchedAdapterKey = matchedKey
+	resp.EffectiveChargeLimit = int32(effectiveLimit)
+	resp.ForceDischargeFloor = int32(s.forceDischargeFloor)
+	resp.ForceDischargeFloorReached = s.forceDischargeFloorReached
+	resp.BatteryMissing = s.batteryMissing
+	setPowerSourceStatus(resp)
+	setSleepAssertionStatus(resp)
+	resp.ChargedAtLimit = s.chargedAtLimit
+	resp.LimitCurrentlyEnforced = s.limitEnforced
+	if rate, ok := s.wearRateLocked(); ok {
+		resp.WearPerHundredCycles = float32(rate)
+	}
+	resp.ChargeManagerConflict = s.chargeConflict != ""
+	resp.ChargeManagerConflictDetail = s.chargeConflict
+	resp.ChargingStalled = s.chargingStalled
+	resp.SafeMode = s.safeMode.Load()
+	resp.WarmingUp = s.warmingUpLocked(clock.Now())
+	resp.DischargeBandFloor = int32(s.dischargeBandFloor)
+	resp.DischargeBandCeiling = int32(s.dischargeBandCeiling)
+	resp.DischargeBandActive = s.bandDischarging
+	resp.ManualCharging = s.manualCharging
+	resp.CriticalCharge = s.criticalCharge && s.criticalActions.Notify
+	resp.PinnedPid = s.pinnedPID
+	smcCharging := s.lastSMCStatus == nil || s.lastSMCStatus.State.IsChargingEnabled
+	resp.BatteryState = batteryFlowToRPC(engine.DecideBatteryFlow(float64(s.lastBatteryWattage), smcCharging))
+	resp.BoostRemainingSeconds = int32(s.boostRemainingLocked(clock.Now()).Seconds())
+	if !s.fullByDeadline.IsZero() {
+		resp.FullByUnix = s.fullByDeadline.Unix()
+		resp.FullByTarget = int32(s.fullByTarget)
+		resp.FullByStartUnix = s.fullByStartLocked().Unix()
+	}
+	resp.ChargingPauseReason = pauseReasonToRPC(s.pauseReason)
+	resp.ActiveProfile = s.activeProfile
+	resp.AdapterUnderperforming = engine.IsAdapterUnderperforming(engine.AdapterPerformanceInput{
+		IsConnected:        s.lastIOKitStatus.State.IsConnected,
+		IsCharging:         s.lastIOKitStatus.State.IsCharging,
+		TelemetryAvailable: s.lastIOKitStatus.Adapter.TelemetryAvailable,
+		RatedWatts:         s.lastIOKitStatus.Adapter.MaxWatts,
+		MeasuredWatts:      s.lastIOKitStatus.Calculations.AdapterPower,
+		MinPercent:         s.adapterUnderperformPercent,
+	})
 	// Battery details (best-effort; fields may not be available on all hardware)
 	if s.lastIOKitStatus != nil {
 		b := s.lastIOKitStatus.Battery
@@ -129,6 +334,7 @@ func (s *Daemon) GetStatus(_ context.Context, _ *rpc.Empty) (*rpc.StatusResponse
 		resp.BatteryNominalCapacity = int32(b.NominalCapacity)
 		resp.BatteryVoltage = float32(b.Voltage)
 		resp.BatteryAmperage = float32(b.Amperage)
+		resp.ActuallyCharging = engine.CurrentIntoBattery(float64(b.Amperage))
 		resp.BatteryVoltageDriftMv = int32(s.lastIOKitStatus.Calculations.VoltageDriftMV)
 		resp.BatteryBalanceState = string(s.lastIOKitStatus.Calculations.BalanceState)
 		// Temperature (°C) if available
@@ -145,130 +351,253 @@ func (s *Daemon) GetStatus(_ context.Context, _ *rpc.Empty) (*rpc.StatusResponse
 }
 
 func (s *Daemon) GetVersion(_ context.Context, _ *rpc.Empty) (*rpc.VersionResponse, error) {
-	return &rpc.VersionResponse{BuildId: s.buildID}, nil
+	return &rpc.VersionResponse{
+		BuildId:   s.build.ID,
+		GitCommit: s.build.GitCommit,
+		BuildTime: s.build.Time,
+		Version:   s.build.Version,
+	}, nil
 }
 
 func (s *Daemon) GetDaemonInfo(_ context.Context, _ *rpc.Empty) (*rpc.DaemonInfoResponse, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
+	authMode := ipc.AuthMode
+	if s.mutationTokenRequired {
+		authMode = ipc.AuthModeToken
+	}
 	return &rpc.DaemonInfoResponse{
-		BuildId:             s.buildID,
-		AuthMode:            ipc.AuthMode,
+		BuildId:             s.build.ID,
+		AuthMode:            authMode,
 		MagsafeLedSupported: s.ledSupported,
-		BuildIdSource:       s.buildIDSource,
-		BuildDirty:          s.buildDirty,
+		BuildIdSource:       s.build.IDSource,
+		BuildDirty:          s.build.Dirty,
 		ApiMajor:            apiMajor,
 		ApiMinor:            apiMinor,
 		Capabilities: []string{
 			"apply-mutation",
 			"daemon-info",
+			"charge-management",
+			"adapter-details",
+			"charge-profiles",
+			"effective-settings",
+			"daemon-logs",
+			"refresh",
+			"support-bundle",
+			"charging-counters",
+			"smc-key-reads",
+			"user-settings",
+			"time-estimates",
+			"session-stats",
+			"wear-trend",
 		},
 	}, nil
 }
 
+// GetEffectiveSettings reports each preference the daemon resolves together
+// with the layer (user, system or default) that supplied it.
+func (s *Daemon) GetEffectiveSettings(_ context.Context, _ *rpc.Empty) (*rpc.EffectiveSettingsResponse, error) {
+	s.mu.RLock()
+	homeDir := ""
+	if s.currentConsoleUser != nil {
+		homeDir = s.currentConsoleUser.HomeDir
+	}
+	s.mu.RUnlock()
+
+	resp := &rpc.EffectiveSettingsResponse{}
+	for _, setting := range cfg.ResolveSettings(homeDir, defaultChargeLimit) {
+		resp.Settings = append(resp.Settings, &rpc.EffectiveSetting{
+			Key:    setting.Key,
+			Value:  setting.Value,
+			Source: string(setting.Source),
+		})
+	}
+	return resp, nil
+}
+
+// Refresh reads the hardware now, runs charging logic on it and returns the
+// resulting status, so clients need not wait for the next event or tick.
+func (s *Daemon) Refresh(ctx context.Context, _ *rpc.Empty) (*rpc.StatusResponse, error) {
+	s.mu.Lock()
+	s.runChargingLogicLocked(nil)
+	s.mu.Unlock()
+	return s.GetStatus(ctx, &rpc.Empty{})
+}
+
+// GetLogs returns the newest daemon log lines from the in-memory copy the
+// logger keeps, so clients need not query os_log.
+func (s *Daemon) GetLogs(_ context.Context, req *rpc.LogsRequest) (*rpc.LogsResponse, error) {
+	resp := &rpc.LogsResponse{}
+	for _, e := range logger.Recent(int(req.GetLines())) {
+		resp.Entries = append(resp.Entries, &rpc.LogEntry{
+			TimestampUnixMs: e.Time.UnixMilli(),
+			Level:           e.Level,
+			Message:         e.Message,
+		})
+	}
+	return resp, nil
+}
+
+func (s *Daemon) GetAdapterDetails(_ context.Context, _ *rpc.Empty) (*rpc.AdapterDetailsResponse, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.lastIOKitStatus == nil {
+		return nil, status.Error(codes.Unavailable, "adapter status not yet available")
+	}
+	if !s.lastIOKitStatus.State.IsConnected {
+		return &rpc.AdapterDetailsResponse{Connected: false}, nil
+	}
+
+	a := s.lastIOKitStatus.Adapter
+	return &rpc.AdapterDetailsResponse{
+		Connected:          true,
+		Description:        a.Description,
+		MaxWatts:           int32(a.MaxWatts),
+		MaxVoltage:         float32(a.MaxVoltage),
+		MaxAmperage:        float32(a.MaxAmperage),
+		InputVoltage:       float32(a.InputVoltage),
+		InputAmperage:      float32(a.InputAmperage),
+		InputWatts:         s.lastAdapterWattage,
+		TelemetryAvailable: a.TelemetryAvailable,
+	}, nil
+}
+
+// chargeLimitFloor is the lowest limit clients may set: MinChargeLimit as
+// read at start, or the default floor when unset.
+func (s *Daemon) chargeLimitFloor() int {
+	if s.minChargeLimit == 0 {
+		return cfg.DefaultMinChargeLimit
+	}
+	return s.minChargeLimit
+}
+
 func (s *Daemon) applySetChargeLimit(newLimit int32) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	if newLimit < 60 || newLimit > 100 {
-		return status.Errorf(codes.InvalidArgument, "charge limit out of range: %d", newLimit)
+	if floor := s.chargeLimitFloor(); int(newLimit) < floor || newLimit > 100 {
+		return outOfRangeError("limit", int(newLimit), floor, 100)
 	}
 
 	if s.currentConsoleUser == nil {
 		logger.Default("SetChargeLimit requested with no console user; using daemon default %d%%", defaultChargeLimit)
-		s.currentLimit = defaultChargeLimit
+		s.currentLimit = int32(defaultChargeLimit)
 	} else {
 		u := s.currentConsoleUser
 		if err := cfg.WriteUserChargeLimit(u.HomeDir, u.UID, u.GID, int(newLimit)); err != nil {
 			logger.Error("Failed to persist user charge limit for %s: %v", u.Username, err)
 		} else {
+			s.userPrefs.Limit = int(newLimit)
 			logger.Default("Persisted user charge limit %d%% for %s", newLimit, u.Username)
 		}
 		s.currentLimit = newLimit
+		s.rememberUserLimitLocked()
 	}
 	s.reconcileSleepChargingStateLocked()
 
-	s.runChargingLogicLocked(nil)
+	s.runChargingLogicCachedLocked()
 	return nil
 }
 
 func (s *Daemon) applyPowerFeature(feature rpc.PowerFeature, enable bool) error {
+	if enable && requiresManagement(feature) {
+		s.mu.RLock()
+		disabled := s.managementDisabled
+		s.mu.RUnlock()
+		if disabled {
+			return status.Errorf(codes.FailedPrecondition, "%v requires charge management to be enabled", feature)
+		}
+	}
+
 	switch feature {
 	case rpc.PowerFeature_PREVENT_DISPLAY_SLEEP:
 		s.mu.Lock()
 		s.wantPreventDisplaySleep = enable
+		err := s.reconcileDisplayAssertionLocked()
 		s.mu.Unlock()
-		if enable {
-			if _, err := powerkit.CreateAssertion(powerkit.AssertionTypePreventDisplaySleep, "PowerGrid: Prevent Display Sleep"); err != nil {
-				logger.Error("Failed to create display sleep assertion: %v", err)
-				return status.Errorf(codes.Internal, "failed to create display sleep assertion: %v", err)
-			}
-		} else {
-			powerkit.ReleaseAssertion(powerkit.AssertionTypePreventDisplaySleep)
+		if err != nil {
+			logger.Error("Failed to create display sleep assertion: %v", err)
+			return status.Errorf(codes.Internal, "failed to create display sleep assertion: %v", err)
 		}
 	case rpc.PowerFeature_PREVENT_SYSTEM_SLEEP:
 		s.mu.Lock()
 		s.wantPreventSystemSleep = enable
 		s.mu.Unlock()
 		if enable {
-			if _, err := powerkit.CreateAssertion(powerkit.AssertionTypePreventSystemSleep, "PowerGrid: Prevent System Sleep"); err != nil {
+			if err := s.holdAssertion(powerkit.AssertionTypePreventSystemSleep, "PowerGrid: Prevent System Sleep"); err != nil {
 				logger.Error("Failed to create system sleep assertion: %v", err)
 				return status.Errorf(codes.Internal, "failed to create system sleep assertion: %v", err)
 			}
 		} else {
-			powerkit.ReleaseAssertion(powerkit.AssertionTypePreventSystemSleep)
+			s.releaseAssertion(powerkit.AssertionTypePreventSystemSleep)
 		}
 	case rpc.PowerFeature_FORCE_DISCHARGE:
-		if enable {
-			if err := callWithTimeout(opTimeout, func() error {
-				return powerkit.SetAdapterState(powerkit.AdapterActionOff)
-			}); err != nil {
-				logger.Error("Failed to force discharge (adapter off): %v", err)
-				return status.Errorf(codes.Internal, "failed to set force discharge: %v", err)
-			}
-		} else {
-			if err := callWithTimeout(opTimeout, func() error {
-				return powerkit.SetAdapterState(powerkit.AdapterActionOn)
-			}); err != nil {
-				logger.Error("Failed to re-enable adapter: %v", err)
-				return status.Errorf(codes.Internal, "failed to re-enable adapter: %v", err)
-			}
+		if err := s.applyForceDischarge(enable); err != nil {
+			return err
 		}
 	case rpc.PowerFeature_CONTROL_MAGSAFE_LED:
 		s.mu.Lock()
 		if !s.ledSupported && enable {
+			s.mu.Unlock()
 			logger.Default("MagSafe LED control not supported on this hardware.")
-		} else {
-			s.wantMagsafeLED = enable
-			if s.currentConsoleUser != nil {
-				_ = cfg.WriteUserMagsafeLED(s.currentConsoleUser.HomeDir, s.currentConsoleUser.UID, s.currentConsoleUser.GID, enable)
+			return unsupportedHardwareError("magsafe_led")
+		}
+		s.ledMode = cfg.LEDModeFromControl(enable)
+		if s.currentConsoleUser != nil {
+			if cfg.WriteUserLEDMode(s.currentConsoleUser.HomeDir, s.currentConsoleUser.UID, s.currentConsoleUser.GID, s.ledMode) == nil {
+				s.userPrefs.LEDMode = s.ledMode
 			}
 		}
-		s.mu.Unlock()
 		// On disable, hand control back to system immediately
-		if !enable && s.ledSupported {
-			if err := callWithTimeout(opTimeout, func() error {
-				return powerkit.SetMagsafeLEDState(powerkit.LEDSystem)
-			}); err != nil {
-				logger.Error("Failed to return MagSafe LED to system control: %v", err)
-				return status.Errorf(codes.Internal, "failed to set magsafe LED system mode: %v", err)
-			} else {
-				s.lastLEDState = powerkit.LEDSystem
-			}
+		var err error
+		if !enable {
+			err = s.returnLEDToSystemLocked()
+		}
+		s.mu.Unlock()
+		if err != nil {
+			logger.Error("Failed to return MagSafe LED to system control: %v", err)
+			return smcError("set magsafe LED system mode", err)
 		}
 	case rpc.PowerFeature_DISABLE_CHARGING_BEFORE_SLEEP:
 		s.mu.Lock()
 		s.wantDisableChargingBeforeSleep = enable
 		if s.currentConsoleUser != nil {
-			_ = cfg.WriteUserDisableChargingBeforeSleep(s.currentConsoleUser.HomeDir, s.currentConsoleUser.UID, s.currentConsoleUser.GID, enable)
+			if cfg.WriteUserDisableChargingBeforeSleep(s.currentConsoleUser.HomeDir, s.currentConsoleUser.UID, s.currentConsoleUser.GID, enable) == nil {
+				s.userPrefs.WantDisableChargingBeforeSleep = enable
+			}
 		}
 		s.reconcileSleepChargingStateLocked()
 		s.mu.Unlock()
+	case rpc.PowerFeature_PERSIST_SLEEP_PREVENTION:
+		s.mu.Lock()
+		s.persistSleepPrevention = enable
+		if s.currentConsoleUser != nil {
+			if cfg.WriteUserPersistSleepPrevention(s.currentConsoleUser.HomeDir, s.currentConsoleUser.UID, s.currentConsoleUser.GID, enable) == nil {
+				s.userPrefs.WantPersistSleepPrevention = enable
+			}
+		}
+		s.mu.Unlock()
+	case rpc.PowerFeature_MAGSAFE_LED_GREEN_ONLY_WHEN_FULL:
+		s.mu.Lock()
+		s.ledGreenOnlyWhenFull = enable
+		if s.currentConsoleUser != nil {
+			if cfg.WriteUserLEDGreenOnlyWhenFull(s.currentConsoleUser.HomeDir, s.currentConsoleUser.UID, s.currentConsoleUser.GID, enable) == nil {
+				s.userPrefs.LEDGreenOnlyWhenFull = enable
+			}
+		}
+		s.runChargingLogicLocked(nil)
+		s.mu.Unlock()
+	case rpc.PowerFeature_CHARGE_MANAGEMENT:
+		if err := s.applyChargeManagement(enable); err != nil {
+			return err
+		}
 	case rpc.PowerFeature_LOW_POWER_MODE:
-		// Use powerkit-go to set Low Power Mode (requires root; daemon runs as root)
-		if err := callWithTimeout(opTimeout, func() error {
-			return powerkit.SetLowPowerMode(enable)
+		// Use powerkit-go to set Low Power Mode (requires root; daemon runs as root).
+		// The context variant kills pmset on timeout instead of leaving it running.
+		if err := callWithContextTimeout(opTimeout, func(ctx context.Context) error {
+			return setLowPowerModeFn(ctx, enable)
 		}); err != nil {
 			logger.Error("Failed to set Low Power Mode: %v", err)
 			return status.Errorf(codes.Internal, "failed to set low power mode: %v", err)
@@ -285,16 +614,85 @@ func (s *Daemon) applyPowerFeature(feature rpc.PowerFeature, enable bool) error
 	return nil
 }
 
-func (s *Daemon) ApplyMutation(_ context.Context, req *rpc.MutationRequest) (*rpc.Empty, error) {
+func (s *Daemon) ApplyMutation(ctx context.Context, req *rpc.MutationRequest) (*rpc.Empty, error) {
+	if s.safeMode.Load() {
+		return nil, safeModeError()
+	}
 	switch req.GetOperation() {
 	case rpc.MutationOperation_SET_CHARGE_LIMIT:
 		if err := s.applySetChargeLimit(req.GetLimit()); err != nil {
 			return nil, err
 		}
+		s.clearActiveProfile()
+		s.recordEvent("charge_limit_set", map[string]any{"limit": req.GetLimit()})
 	case rpc.MutationOperation_SET_POWER_FEATURE:
 		if err := s.applyPowerFeature(req.GetFeature(), req.GetEnable()); err != nil {
 			return nil, err
 		}
+		if bundledInProfile(req.GetFeature()) {
+			s.clearActiveProfile()
+		}
+		s.recordEvent("feature_toggled", map[string]any{"feature": req.GetFeature().String(), "enable": req.GetEnable()})
+	case rpc.MutationOperation_APPLY_PROFILE:
+		if err := s.applyProfile(req.GetProfileName()); err != nil {
+			return nil, err
+		}
+		s.recordEvent("profile_applied", map[string]any{"profile": req.GetProfileName()})
+	case rpc.MutationOperation_SET_PROFILE:
+		if err := s.setProfile(req.GetProfileName(), req.GetProfile()); err != nil {
+			return nil, err
+		}
+		s.recordEvent("profile_saved", map[string]any{"profile": req.GetProfileName()})
+	case rpc.MutationOperation_CLEAR_OVERRIDES:
+		if err := s.clearOverrides(); err != nil {
+			return nil, err
+		}
+		s.recordEvent("overrides_cleared", nil)
+	case rpc.MutationOperation_CHARGE_BOOST:
+		if err := s.applyChargeBoost(req.GetBoostMinutes()); err != nil {
+			return nil, err
+		}
+		s.recordEvent("charge_boost_set", map[string]any{"minutes": req.GetBoostMinutes()})
+	case rpc.MutationOperation_RESET_COUNTERS:
+		if err := s.resetCounters(); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to reset counters: %v", err)
+		}
+		s.recordEvent("counters_reset", nil)
+	case rpc.MutationOperation_PIN_FULL_CHARGE:
+		if err := s.applyFullChargePin(req.GetPid()); err != nil {
+			return nil, err
+		}
+		s.recordEvent("full_charge_pin_set", map[string]any{"pid": req.GetPid()})
+	case rpc.MutationOperation_SUSPEND_MANAGEMENT:
+		if err := s.applySuspendManagement(req.GetSuspendMinutes()); err != nil {
+			return nil, err
+		}
+		s.recordEvent("management_suspended", map[string]any{"minutes": req.GetSuspendMinutes()})
+	case rpc.MutationOperation_SET_MAGSAFE_LED_MODE:
+		if err := s.applySetMagsafeLEDMode(req.GetMagsafeLedMode()); err != nil {
+			return nil, err
+		}
+		s.recordEvent("magsafe_led_mode_set", map[string]any{"mode": req.GetMagsafeLedMode().String()})
+	case rpc.MutationOperation_MANUAL_CHARGING:
+		if err := s.applyManualCharging(ctx, req.GetManualCharging()); err != nil {
+			return nil, err
+		}
+		s.recordEvent("manual_charging_set", map[string]any{"mode": req.GetManualCharging().String()})
+	case rpc.MutationOperation_SET_DISCHARGE_BAND:
+		if err := s.applySetDischargeBand(req.GetDischargeBandFloor(), req.GetDischargeBandCeiling()); err != nil {
+			return nil, err
+		}
+		s.recordEvent("discharge_band_set", map[string]any{"floor": req.GetDischargeBandFloor(), "ceiling": req.GetDischargeBandCeiling()})
+	case rpc.MutationOperation_FULL_BY:
+		if err := s.applyFullBy(req.GetFullByUnix(), req.GetLimit()); err != nil {
+			return nil, err
+		}
+		s.recordEvent("full_by_set", map[string]any{"deadline": req.GetFullByUnix(), "target": req.GetLimit()})
+	case rpc.MutationOperation_SET_ADAPTER_LIMIT:
+		if err := s.applySetAdapterLimit(req.GetAdapterKey(), req.GetLimit()); err != nil {
+			return nil, err
+		}
+		s.recordEvent("adapter_limit_set", map[string]any{"adapter": req.GetAdapterKey(), "limit": req.GetLimit()})
 	default:
 		return nil, status.Errorf(codes.InvalidArgument, "unsupported mutation operation: %v", req.GetOperation())
 	}
@@ -331,9 +729,9 @@ func (s *Daemon) startBatteryCoalescer(ctx context.Context) {
 		const debounce = 350 * time.Millisecond
 
 		var latest *powerkit.SystemInfo
-		timer := time.NewTimer(debounce)
+		timer := clock.NewTimer(debounce)
 		if !timer.Stop() {
-			<-timer.C
+			<-timer.C()
 		}
 		timerActive := false
 
@@ -341,17 +739,17 @@ func (s *Daemon) startBatteryCoalescer(ctx context.Context) {
 			select {
 			case <-ctx.Done():
 				if timerActive && !timer.Stop() {
-					<-timer.C
+					<-timer.C()
 				}
 				return
 			case info := <-s.batteryUpdateCh:
 				latest = info
 				if timerActive && !timer.Stop() {
-					<-timer.C
+					<-timer.C()
 				}
 				timer.Reset(debounce)
 				timerActive = true
-			case <-timer.C:
+			case <-timer.C():
 				timerActive = false
 				if latest != nil {
 					s.runChargingLogic(latest)
@@ -365,7 +763,7 @@ func (s *Daemon) startBatteryCoalescer(ctx context.Context) {
 }
 
 func (s *Daemon) reconcileSleepChargingStateLocked() {
-	if s.wantDisableChargingBeforeSleep && s.currentLimit < 100 {
+	if limit, _ := s.effectiveLimitLocked(); s.wantDisableChargingBeforeSleep && limit < 100 {
 		return
 	}
 	if s.sleepTransitionActive || !s.wakeHoldUntil.IsZero() {
@@ -379,6 +777,10 @@ func (s *Daemon) updateCachedStatusLocked(info *powerkit.SystemInfo) {
 	if info == nil {
 		return
 	}
+	s.recordAdapterChangeLocked(s.lastIOKitStatus, info.IOKit)
+	s.trackConnectLocked(s.lastIOKitStatus, info.IOKit)
+	s.noteChargeSessionLocked(info.IOKit)
+	s.noteHealthSampleLocked(info.IOKit)
 	s.lastIOKitStatus = info.IOKit
 	s.lastSMCStatus = info.SMC
 
@@ -389,6 +791,25 @@ func (s *Daemon) updateCachedStatusLocked(info *powerkit.SystemInfo) {
 	}
 }
 
+// trackConnectLocked stamps the moment the adapter is plugged in. The first
+// snapshot after startup is not a plug-in and does not start a grace period.
+func (s *Daemon) trackConnectLocked(prev, next *powerkit.IOKitData) {
+	if next == nil || !next.State.IsConnected {
+		s.connectedSince = time.Time{}
+		return
+	}
+	if prev != nil && !prev.State.IsConnected {
+		s.connectedSince = clock.Now()
+	}
+}
+
+func (s *Daemon) inConnectGraceLocked(now time.Time) bool {
+	if s.connectGrace <= 0 || s.connectedSince.IsZero() {
+		return false
+	}
+	return now.Before(s.connectedSince.Add(s.connectGrace))
+}
+
 func (s *Daemon) clearExpiredWakeHoldLocked(now time.Time) {
 	if s.wakeHoldUntil.IsZero() || now.Before(s.wakeHoldUntil) {
 		return
@@ -418,14 +839,99 @@ func (s *Daemon) shouldSuppressChargingEnableLocked(charge, limit int, now time.
 	return false
 }
 
+// updateExternalHoldLocked tracks how long charging has been allowed but not
+// happening below the limit; GetStatus reports it once it outlasts
+// externalHoldGrace.
+func (s *Daemon) updateExternalHoldLocked(info *powerkit.SystemInfo, limit int, now time.Time) {
+	held := engine.IsExternalChargeHold(engine.ExternalHoldInput{
+		IsConnected:        info.IOKit.State.IsConnected,
+		IsCharging:         info.IOKit.State.IsCharging,
+		FullyCharged:       info.IOKit.State.FullyCharged,
+		Charge:             info.IOKit.Battery.CurrentCharge,
+		Limit:              limit,
+		SMCChargingEnabled: info.SMC.State.IsChargingEnabled,
+		SMCAdapterEnabled:  info.SMC.State.IsAdapterEnabled,
+	})
+	switch {
+	case !held:
+		if !s.externalHoldSince.IsZero() {
+			logger.Info("External charge hold cleared.")
+		}
+		s.externalHoldSince = time.Time{}
+	case s.externalHoldSince.IsZero():
+		logger.Default("Not charging below the limit although charging is allowed (charge %d%%, limit %d%%); possibly Optimized Battery Charging, a thermal limit or heavy load.", info.IOKit.Battery.CurrentCharge, limit)
+		s.externalHoldSince = now
+	}
+}
+
+func (s *Daemon) externalHoldReportedLocked(now time.Time) bool {
+	return !s.externalHoldSince.IsZero() && now.Sub(s.externalHoldSince) >= externalHoldGrace
+}
+
+// setPauseReasonLocked is the only writer of pauseReason so every change is
+// logged once, whichever path made it.
+func (s *Daemon) setPauseReasonLocked(r engine.PauseReason) {
+	if r == s.pauseReason {
+		return
+	}
+	logger.Default("Charging pause reason: %s -> %s.", s.pauseReason, r)
+	s.recordEvent("charging_pause_changed", map[string]any{"from": s.pauseReason.String(), "to": r.String()})
+	s.pauseReason = r
+}
+
+func pauseReasonToRPC(r engine.PauseReason) rpc.ChargingPauseReason {
+	switch r {
+	case engine.PauseAtLimit:
+		return rpc.ChargingPauseReason_PAUSE_AT_LIMIT
+	case engine.PauseForceDischarge:
+		return rpc.ChargingPauseReason_PAUSE_FORCE_DISCHARGE
+	case engine.PauseBeforeSleep:
+		return rpc.ChargingPauseReason_PAUSE_BEFORE_SLEEP
+	case engine.PauseExternalHold:
+		return rpc.ChargingPauseReason_PAUSE_EXTERNAL_HOLD
+	case engine.PauseWeakAdapter:
+		return rpc.ChargingPauseReason_PAUSE_WEAK_ADAPTER
+	case engine.PauseManual:
+		return rpc.ChargingPauseReason_PAUSE_MANUAL
+	default:
+		return rpc.ChargingPauseReason_CHARGING_PAUSE_REASON_NONE
+	}
+}
+
+func batteryFlowToRPC(f engine.BatteryFlow) rpc.BatteryState {
+	switch f {
+	case engine.BatteryCharging:
+		return rpc.BatteryState_BATTERY_CHARGING
+	case engine.BatteryDischarging:
+		return rpc.BatteryState_BATTERY_DISCHARGING
+	default:
+		return rpc.BatteryState_BATTERY_IDLE
+	}
+}
+
 func (s *Daemon) runChargingLogicLocked(info *powerkit.SystemInfo) {
+	s.runChargingLogicFromLocked(info, info != nil && info.SMC != nil)
+}
+
+// runChargingLogicFromLocked runs the charging logic on info, reading the
+// hardware when it is nil. freshSMC says whether info.SMC was read directly
+// for this run; only such reads feed conflict detection, since a cached
+// snapshot can predate the daemon's own last charging write.
+func (s *Daemon) runChargingLogicFromLocked(info *powerkit.SystemInfo, freshSMC bool) {
 	var err error
 	if info == nil {
 		info, err = getSystemInfoWithTimeout(opTimeout)
 		if err != nil {
 			logger.Error("Failed to get system info: %v", err)
+			s.noteSystemInfoLocked(false)
 			return
 		}
+		// Stream events never carry SMC data, so only direct reads count.
+		s.noteSystemInfoLocked(info.SMC != nil)
+		freshSMC = info.SMC != nil
+		if info.SMC != nil {
+			s.statusFetchedAt = clock.Now()
+		}
 	}
 
 	if info.SMC == nil && s.lastSMCStatus != nil {
@@ -438,39 +944,192 @@ func (s *Daemon) runChargingLogicLocked(info *powerkit.SystemInfo) {
 		logger.Default("Skipping logic run due to incomplete data.")
 		return
 	}
+	if s.hardwareWritesBlocked("charging logic") {
+		s.markChargingLogicRun()
+		return
+	}
+	if s.warmingUpLocked(clock.Now()) {
+		logger.InfoLimited("Startup grace period; holding charging changes until readings settle.")
+		s.markChargingLogicRun()
+		return
+	}
+
+	s.chargedAtLimit = false
+	s.limitEnforced = false
+	if s.managementDisabled || s.managementSuspendedLocked(clock.Now()) {
+		s.externalHoldSince = time.Time{}
+		s.resetChargeHistoryLocked()
+		s.setPauseReasonLocked(engine.PauseNone)
+		s.applyUnmanagedLocked(info)
+		s.markChargingLogicRun()
+		return
+	}
+
+	if !engine.BatteryPresent(info.IOKit.Battery) {
+		s.resetChargeHistoryLocked()
+		s.applyNoBatteryLocked(info)
+		return
+	}
+	s.noteBatteryPresentLocked()
 
 	charge := info.IOKit.Battery.CurrentCharge
-	limit := int(s.currentLimit)
+	s.enforceDischargeFloorLocked(info, charge)
+	s.applyDischargeBandLocked(info, charge)
+	if s.manualChargingActiveLocked() {
+		s.setPauseReasonLocked(engine.DecidePauseReason(engine.PauseInput{
+			IsConnected:    info.IOKit.State.IsConnected,
+			ForceDischarge: !info.SMC.State.IsAdapterEnabled,
+			ManualOff:      s.manualCharging == rpc.ManualCharging_MANUAL_CHARGING_OFF,
+		}))
+		logger.InfoLimited("Manual charging override holds charging %s; skipping limit enforcement.", manualChargingState(s.manualCharging))
+		s.markChargingLogicRun()
+		return
+	}
+	limit, _ := s.effectiveLimitLocked()
 	isSMCChargingEnabled := info.SMC.State.IsChargingEnabled
-	now := nowFn()
+	now := clock.Now()
+	limit = s.rampedLimitLocked(limit, now)
+	s.noteEffectiveLimitLocked(limit)
 	s.clearExpiredWakeHoldLocked(now)
+	s.handleCriticalChargeLocked(info, charge)
+	s.updateExternalHoldLocked(info, limit, now)
+	if freshSMC {
+		s.checkChargeConflictLocked(info, now)
+	}
+	healthy := true
 
-	switch engine.DecideCharging(charge, limit, isSMCChargingEnabled) {
+	decisionLimit := limit
+	if s.inConnectGraceLocked(now) {
+		logger.InfoLimited("Within post-connect grace period; allowing charging past the %d%% limit.", limit)
+		decisionLimit = 100
+	}
+	// A weak adapter can carry the system but not the system plus charging,
+	// so it stays on adapter power alone. Only an explicit boost overrides it.
+	weakAdapter := engine.IsAdapterTooWeak(info.IOKit.State.IsConnected, info.IOKit.Adapter.MaxWatts, s.minChargingAdapterWatts)
+	if weakAdapter {
+		logger.InfoLimited("Adapter rated %dW is below the %dW charging minimum; running from the adapter only.", info.IOKit.Adapter.MaxWatts, s.minChargingAdapterWatts)
+		decisionLimit = 0
+	}
+	if s.boostActiveLocked(now, info.IOKit.State.IsConnected) {
+		weakAdapter = false
+		logger.InfoLimited("Charge boost active; allowing charging past the %d%% limit.", limit)
+		decisionLimit = 100
+	}
+	if s.pinActiveLocked() {
+		weakAdapter = false
+		logger.InfoLimited("PID %d holds a full-charge pin; allowing charging past the %d%% limit.", s.pinnedPID, limit)
+		decisionLimit = 100
+	}
+	if s.fullByActiveLocked(now) && !weakAdapter && s.fullByTarget > decisionLimit {
+		logger.InfoLimited("Full-by charge plan active; charging toward %d%% past the %d%% limit.", s.fullByTarget, limit)
+		decisionLimit = s.fullByTarget
+	}
+
+	decision := engine.DecideCharging(charge, decisionLimit, isSMCChargingEnabled)
+	chargingEnabled := isSMCChargingEnabled
+	if s.logDecisions {
+		s.logDecisionLocked(info, decision, limit, decisionLimit, weakAdapter, now)
+	}
+	if decision != engine.ChargingEnable {
+		s.enableDeferredSince = time.Time{}
+	}
+	switch decision {
 	case engine.ChargingDisable:
 		logger.Default("Charge %d%% >= Limit %d%%. Disabling charging.", charge, limit)
 		if err := callWithTimeout(opTimeout, func() error {
 			return setChargingStateFn(powerkit.ChargingActionOff)
 		}); err != nil {
 			logger.Error("Failed to disable charging: %v", err)
+			s.noteHardwareWriteLocked(err)
+			s.recordEvent("charging_disable_failed", map[string]any{"charge": charge, "limit": limit, "error": err.Error()})
+			healthy = false
 		} else {
+			s.noteHardwareWriteLocked(nil)
+			s.noteChargingWrite(powerkit.ChargingActionOff)
+			s.countChargingWriteLocked(false)
+			chargingEnabled = false
 			logger.Default("Successfully disabled charging.")
+			s.recordEvent("charging_disabled", map[string]any{"charge": charge, "limit": limit})
 		}
 	case engine.ChargingEnable:
+		if !s.chargeDuringDischarge && engine.DischargeHoldsCharging(info.SMC.State.IsAdapterEnabled, s.forceDischargeRequested, s.bandDischarging) {
+			logger.InfoLimited("Adapter is off for a discharge; not re-enabling charging at %d%%.", charge)
+			break
+		}
 		if s.shouldSuppressChargingEnableLocked(charge, limit, now) {
 			break
 		}
+		if s.deferEnableLocked(charge, decisionLimit, now) {
+			break
+		}
+		s.enableDeferredSince = time.Time{}
 		logger.Default("Charge %d%% < Limit %d%%. Re-enabling charging.", charge, limit)
 		if err := callWithTimeout(opTimeout, func() error {
 			return setChargingStateFn(powerkit.ChargingActionOn)
 		}); err != nil {
 			logger.Error("Failed to enable charging: %v", err)
+			s.noteHardwareWriteLocked(err)
+			s.recordEvent("charging_enable_failed", map[string]any{"charge": charge, "limit": limit, "error": err.Error()})
+			healthy = false
 		} else {
+			s.noteHardwareWriteLocked(nil)
+			s.noteChargingWrite(powerkit.ChargingActionOn)
+			s.countChargingWriteLocked(true)
+			chargingEnabled = true
 			logger.Default("Successfully enabled charging.")
+			s.recordEvent("charging_enabled", map[string]any{"charge": charge, "limit": limit})
 		}
 	}
 
+	// A weak adapter holds charging off below any limit, so only a real
+	// limit counts as enforced.
+	s.limitEnforced = !weakAdapter && engine.LimitEnforced(charge, decisionLimit, info.IOKit.State.IsConnected, chargingEnabled)
+
+	// A failed write leaves the hardware where it was, so the previous
+	// reason still describes it.
+	if healthy {
+		s.setPauseReasonLocked(engine.DecidePauseReason(engine.PauseInput{
+			IsConnected:     info.IOKit.State.IsConnected,
+			IsCharging:      info.IOKit.State.IsCharging,
+			FullyCharged:    info.IOKit.State.FullyCharged,
+			ForceDischarge:  !info.SMC.State.IsAdapterEnabled,
+			WeakAdapter:     weakAdapter,
+			SleepTransition: s.sleepTransitionActive,
+			ExternalHold:    s.externalHoldReportedLocked(now),
+			Charge:          charge,
+			Limit:           decisionLimit,
+		}))
+		s.noteChargeSampleLocked(engine.ChargeSample{
+			At:     now,
+			Charge: charge,
+			Expecting: info.IOKit.State.IsConnected && !info.IOKit.State.FullyCharged &&
+				isSMCChargingEnabled && info.SMC.State.IsAdapterEnabled && !weakAdapter &&
+				charge < decisionLimit && !s.sleepTransitionActive && !s.externalHoldReportedLocked(now),
+		})
+	}
+
+	// Grace and boost raise the target, so "charged" waits for it. A weak
+	// adapter lowers only the decision limit, not what counts as charged.
+	chargedAt := limit
+	if decisionLimit > limit {
+		chargedAt = decisionLimit
+	}
+	s.chargedAtLimit = !s.hideChargedAtLimit && engine.ChargedAtLimit(info.IOKit.State.IsConnected, info.IOKit.State.FullyCharged, charge, chargedAt)
+
 	// Apply MagSafe LED if requested and supported
 	s.applyMagsafeLED(info)
+	if healthy {
+		s.markChargingLogicRun()
+	}
+}
+
+// tickInterval is how often the periodic ticker re-reads the hardware. In
+// polling-only mode it is the only source of updates, so it runs faster.
+func (s *Daemon) tickInterval() time.Duration {
+	if s.pollingOnly && s.pollInterval > 0 {
+		return s.pollInterval
+	}
+	return recomputeInterval
 }
 
 func (s *Daemon) startEventStream(ctx context.Context) {
@@ -481,6 +1140,7 @@ func (s *Daemon) startEventStream(ctx context.Context) {
 	}
 
 	logger.Default("Daemon event stream started. Watching for all power events.")
+	s.markEventStreamUp()
 	s.wg.Add(1)
 	go func() {
 		defer s.wg.Done()
@@ -507,32 +1167,33 @@ func (s *Daemon) startEventStream(ctx context.Context) {
 							select {
 							case <-ctx.Done():
 								return
-							case <-time.After(d):
+							case <-clock.After(d):
 							}
 
 							s.mu.RLock()
-							shouldPreventDisplaySleep := s.wantPreventDisplaySleep
+							shouldPreventDisplaySleep := s.wantPreventDisplaySleep || s.autoPreventDisplaySleep
 							shouldPreventSystemSleep := s.wantPreventSystemSleep
 							s.mu.RUnlock()
 
 							if shouldPreventDisplaySleep {
 								logger.Default("Re-applying 'Prevent Display Sleep' after wake (attempt %d).", i+1)
-								if _, err := powerkit.CreateAssertion(powerkit.AssertionTypePreventDisplaySleep, "PowerGrid: Prevent Display Sleep"); err != nil {
+								if err := s.holdAssertion(powerkit.AssertionTypePreventDisplaySleep, "PowerGrid: Prevent Display Sleep"); err != nil {
 									logger.Error("Failed to re-create display sleep assertion after wake: %v", err)
 								}
 							}
 							if shouldPreventSystemSleep {
 								logger.Default("Re-applying 'Prevent System Sleep' after wake (attempt %d).", i+1)
-								if _, err := powerkit.CreateAssertion(powerkit.AssertionTypePreventSystemSleep, "PowerGrid: Prevent System Sleep"); err != nil {
+								if err := s.holdAssertion(powerkit.AssertionTypePreventSystemSleep, "PowerGrid: Prevent System Sleep"); err != nil {
 									logger.Error("Failed to re-create system sleep assertion after wake: %v", err)
 								}
 							}
+							s.reapplyForceDischarge(i + 1)
 
 							s.runChargingLogic(nil)
 						}
 					}()
 				case powerkit.EventTypeBatteryUpdate:
-					logger.Info("Received a battery status update, running charging logic.")
+					logger.InfoLimited("Received a battery status update, running charging logic.")
 					s.enqueueBatteryUpdate(event.Info)
 				default:
 					if event.Info != nil {
@@ -546,10 +1207,17 @@ func (s *Daemon) startEventStream(ctx context.Context) {
 	}()
 }
 
+// consoleWatchRestartDelay paces re-subscribing after the console user watcher
+// stops, so a watcher that dies at once cannot spin.
+const consoleWatchRestartDelay = 5 * time.Second
+
 func (s *Daemon) startConsoleUserEventHandler(ctx context.Context) {
 	userEvents := consoleuser.Watch()
 
 	s.handleConsoleUserChange(nil)
+	s.mu.Lock()
+	s.restoredForceDischarge = false
+	s.mu.Unlock()
 
 	s.wg.Add(1)
 	go func() {
@@ -560,13 +1228,22 @@ func (s *Daemon) startConsoleUserEventHandler(ctx context.Context) {
 				return
 			case _, ok := <-userEvents:
 				if !ok {
-					return
+					logger.Error("Console user watcher stopped; re-subscribing in %s.", consoleWatchRestartDelay)
+					select {
+					case <-ctx.Done():
+						return
+					case <-clock.After(consoleWatchRestartDelay):
+					}
+					userEvents = consoleuser.Watch()
+					// Changes while the watcher was down were not reported.
+					s.handleConsoleUserChange(nil)
+					continue
 				}
 				logger.Default("Received console user change event. Re-evaluating in 1 second...")
 				select {
 				case <-ctx.Done():
 					return
-				case <-time.After(1 * time.Second):
+				case <-clock.After(1 * time.Second):
 				}
 				s.handleConsoleUserChange(nil)
 			}
@@ -577,6 +1254,11 @@ func (s *Daemon) startConsoleUserEventHandler(ctx context.Context) {
 // startConsoleUserWatcher removed (unused). Event-based handler is used instead.
 
 func (s *Daemon) handleConsoleUserChange(_ interface{}) {
+	// The watcher and its restart path can both re-check the console user;
+	// one switch at a time keeps a second entry from replaying stale state.
+	s.userSwitchMu.Lock()
+	defer s.userSwitchMu.Unlock()
+
 	userNow, err := consoleuser.Current()
 	if err != nil {
 		logger.Error("Console user check failed: %v", err)
@@ -604,36 +1286,48 @@ func (s *Daemon) enterNoUser() {
 
 	s.mu.Lock()
 	s.currentConsoleUser = nil
+	s.activeProfile = ""
+	s.adapterLimits = nil
+	s.forceDischargeRequested = false
+	s.restoredForceDischarge = false
+	s.dischargeBandFloor, s.dischargeBandCeiling = 0, 0
+	s.bandDischarging = false
 	s.wantPreventDisplaySleep = false
+	s.autoPreventDisplaySleep = false
 	s.wantPreventSystemSleep = false
-	s.wantMagsafeLED = profile.WantMagsafeLED
+	s.ledMode = profile.LEDMode
+	s.ledGreenOnlyWhenFull = profile.LEDGreenOnlyWhenFull
 	s.wantDisableChargingBeforeSleep = profile.WantDisableChargingBeforeSleep
+	s.persistSleepPrevention = profile.WantPersistSleepPrevention
 	s.currentLimit = int32(profile.Limit)
 	s.reconcileSleepChargingStateLocked()
 	s.mu.Unlock()
 
 	logger.Default("Entering NoUser state: clearing assertions, enabling adapter, applying system/effective limit")
-	if err := ipc.SetSocketGroupAccess(socketPath, 0); err != nil {
-		logger.Error("Failed to reset socket group access in NoUser state: %v", err)
+	for _, path := range s.controlSockets() {
+		if err := ipc.SetSocketGroupAccess(path, 0); err != nil {
+			logger.Error("Failed to reset socket group access for %s in NoUser state: %v", path, err)
+		}
 	}
 	// Safety actions
-	powerkit.AllowAllSleep()
-	if err := callWithTimeout(opTimeout, func() error {
-		return powerkit.SetAdapterState(powerkit.AdapterActionOn)
-	}); err != nil {
-		logger.Error("Failed to ensure adapter ON in NoUser: %v", err)
-	}
-	if s.ledSupported {
-		if err := callWithTimeout(opTimeout, func() error {
-			return powerkit.SetMagsafeLEDState(powerkit.LEDSystem)
-		}); err != nil {
+	s.releaseAllAssertions()
+	if !s.hardwareWritesBlocked("adapter and LED reset") {
+		err := callWithTimeout(opTimeout, func() error {
+			return setAdapterStateFn(powerkit.AdapterActionOn)
+		})
+		if err != nil {
+			logger.Error("Failed to ensure adapter ON in NoUser: %v", err)
+		}
+		s.mu.Lock()
+		s.noteHardwareWriteLocked(err)
+		if err := s.returnLEDToSystemLocked(); err != nil {
 			logger.Info("Could not set MagSafe LED to system in NoUser: %v", err)
-		} else {
-			s.lastLEDState = powerkit.LEDSystem
 		}
+		s.mu.Unlock()
 	}
 
 	logger.Default("Applied effective limit (no user): %d%%", profile.Limit)
+	s.recordEvent("console_user_changed", map[string]any{"user": "", "limit": profile.Limit})
 
 	go s.runChargingLogic(nil)
 }
@@ -642,47 +1336,82 @@ func (s *Daemon) enterConsoleUser(u *consoleuser.ConsoleUser) {
 	if err := cfg.EnsureUserConfigOwnership(u.HomeDir, u.UID, u.GID); err != nil {
 		logger.Error("Failed to repair user config ownership for %s: %v", u.Username, err)
 	}
-	profile := session.ProfileForUser(u, defaultChargeLimit)
 
+	// Read the profile under the same lock applySetChargeLimit persists
+	// under, so a limit written just before the switch is what gets applied.
 	s.mu.Lock()
+	if prev := s.currentConsoleUser; prev != nil && prev.UID == u.UID {
+		s.mu.Unlock()
+		logger.Info("Console user %s is already active; keeping the running state.", u.Username)
+		return
+	}
+	profile := profileForUserFn(u, defaultChargeLimit)
+	// The first user entered after a start keeps a force discharge restored
+	// from before the restart; any later switch drops it.
+	keepDischarge := s.currentConsoleUser == nil && s.restoredForceDischarge && s.forceDischargeRequested
+	s.restoredForceDischarge = false
 	s.currentConsoleUser = u
+	s.userPrefs = profile
+	s.activeProfile = cfg.ReadUserActiveProfile(u.HomeDir)
+	s.adapterLimits = cfg.ReadUserAdapterLimits(u.HomeDir)
+	s.forceDischargeRequested = keepDischarge
+	s.dischargeBandFloor, s.dischargeBandCeiling = profile.DischargeBandFloor, profile.DischargeBandCeiling
+	s.bandDischarging = false
 	s.wantPreventDisplaySleep = false
+	s.autoPreventDisplaySleep = false
 	s.wantPreventSystemSleep = false
-	s.wantMagsafeLED = profile.WantMagsafeLED
+	s.ledMode = profile.LEDMode
+	s.ledGreenOnlyWhenFull = profile.LEDGreenOnlyWhenFull
 	s.wantDisableChargingBeforeSleep = profile.WantDisableChargingBeforeSleep
+	s.persistSleepPrevention = profile.WantPersistSleepPrevention
 	s.currentLimit = int32(profile.Limit)
+	s.rememberUserLimitLocked()
 	s.reconcileSleepChargingStateLocked()
 	s.mu.Unlock()
 
 	logger.Default("Entering ConsoleUser state (%s): clearing assertions, enabling adapter, applying effective limit", u.Username)
 	if u.GID != 0 {
-		if err := ipc.SetSocketGroupAccess(socketPath, u.GID); err != nil {
-			logger.Error("Failed to grant socket group access to %s (gid=%d): %v", u.Username, u.GID, err)
+		for _, path := range s.controlSockets() {
+			if err := ipc.SetSocketGroupAccess(path, u.GID); err != nil {
+				logger.Error("Failed to grant %s group access to %s (gid=%d): %v", path, u.Username, u.GID, err)
+			}
 		}
 	} else {
 		logger.Info("Console user gid unavailable; socket group left unchanged.")
 	}
-	powerkit.AllowAllSleep()
-	if err := callWithTimeout(opTimeout, func() error {
-		return powerkit.SetAdapterState(powerkit.AdapterActionOn)
-	}); err != nil {
-		logger.Error("Failed to ensure adapter ON on user switch: %v", err)
+	s.releaseAllAssertions()
+	if keepDischarge {
+		logger.Default("Keeping the force discharge restored from before the restart.")
+	} else if !s.hardwareWritesBlocked("adapter reset") {
+		err := callWithTimeout(opTimeout, func() error {
+			return setAdapterStateFn(powerkit.AdapterActionOn)
+		})
+		if err != nil {
+			logger.Error("Failed to ensure adapter ON on user switch: %v", err)
+		}
+		s.mu.Lock()
+		s.noteHardwareWriteLocked(err)
+		s.mu.Unlock()
 	}
 
 	logger.Default("Applied effective limit for %s: %d%%", u.Username, profile.Limit)
+	s.recordEvent("console_user_changed", map[string]any{"user": u.Username, "limit": profile.Limit})
 
 	go s.runChargingLogic(nil)
 }
 
 func (s *Daemon) handleBeforeSleep() {
+	if s.hardwareWritesBlocked("pre-sleep charging hook") {
+		return
+	}
 	s.mu.Lock()
-	enforce := s.wantDisableChargingBeforeSleep
-	limit := int(s.currentLimit)
+	enforce := s.wantDisableChargingBeforeSleep && !s.managementDisabled && !s.managementSuspendedLocked(clock.Now()) && !s.batteryMissing && !s.manualChargingActiveLocked()
+	limit, _ := s.effectiveLimitLocked()
 	if !enforce {
 		s.sleepTransitionActive = false
 		s.wakeHoldUntil = time.Time{}
 		s.mu.Unlock()
-		logger.Default("Pre-sleep charging hook skipped because Disable Charging before Sleep is off.")
+		logger.Default("Pre-sleep charging hook skipped because Disable Charging before Sleep is off, management is disabled, no battery is present, or manual charging is in effect.")
 		return
 	}
 	if limit >= 100 {
@@ -692,16 +1421,67 @@ func (s *Daemon) handleBeforeSleep() {
 		logger.Default("Pre-sleep charging hook skipped because effective charge limit is 100%%.")
 		return
 	}
+	charge := -1
+	if s.lastIOKitStatus != nil {
+		charge = s.lastIOKitStatus.Battery.CurrentCharge
+	}
+	if floor := s.minChargeBeforeSleep; engine.BelowSleepFloor(charge, floor) {
+		s.sleepTransitionActive = false
+		s.wakeHoldUntil = time.Time{}
+		s.mu.Unlock()
+		logger.Default("Pre-sleep charging hook skipped because charge %d%% is below the %d%% pre-sleep floor; leaving charging on.", charge, floor)
+		s.recordEvent("pre_sleep_skipped", map[string]any{"charge": charge, "floor": floor})
+		return
+	}
 	s.sleepTransitionActive = false
 	s.wakeHoldUntil = time.Time{}
+	if s.preSleepDelay > 0 {
+		s.stopPreSleepTimerLocked()
+		s.preSleepTimer = clock.AfterFunc(s.preSleepDelay, s.firePreSleepTimer)
+		s.mu.Unlock()
+		logger.Default("Pre-sleep charging disable deferred by %s; a wake before then cancels it.", s.preSleepDelay)
+		return
+	}
+	s.mu.Unlock()
+
+	s.disableChargingForSleep(limit)
+}
+
+// firePreSleepTimer runs the deferred pre-sleep disable unless a wake
+// cancelled it first.
+func (s *Daemon) firePreSleepTimer() {
+	s.mu.Lock()
+	if s.preSleepTimer == nil {
+		s.mu.Unlock()
+		return
+	}
+	s.preSleepTimer = nil
+	limit, _ := s.effectiveLimitLocked()
 	s.mu.Unlock()
 
+	s.disableChargingForSleep(limit)
+}
+
+// stopPreSleepTimerLocked cancels a deferred pre-sleep disable and reports
+// whether one was pending.
+func (s *Daemon) stopPreSleepTimerLocked() bool {
+	if s.preSleepTimer == nil {
+		return false
+	}
+	s.preSleepTimer.Stop()
+	s.preSleepTimer = nil
+	return true
+}
+
+// disableChargingForSleep turns charging off and verifies it within the
+// pre-sleep budget.
+func (s *Daemon) disableChargingForSleep(limit int) {
 	logger.Default("Pre-sleep charging hook started (limit %d%%).", limit)
-	deadline := nowFn().Add(preSleepBudget)
+	deadline := clock.Now().Add(preSleepBudget)
 	var lastErr error
 
 	for attempt := 1; attempt <= 2; attempt++ {
-		remaining := time.Until(deadline)
+		remaining := deadline.Sub(clock.Now())
 		if remaining <= 0 {
 			lastErr = fmt.Errorf("pre-sleep budget exhausted")
 			break
@@ -715,6 +1495,7 @@ func (s *Daemon) handleBeforeSleep() {
 			logger.Error("Pre-sleep charging disable attempt %d failed: %v", attempt, err)
 			continue
 		}
+		s.noteChargingWrite(powerkit.ChargingActionOff)
 
 		verified, err := s.verifyChargingDisabled(deadline)
 		if err != nil {
@@ -725,6 +1506,7 @@ func (s *Daemon) handleBeforeSleep() {
 		if verified {
 			s.mu.Lock()
 			s.sleepTransitionActive = true
+			s.setPauseReasonLocked(engine.PauseBeforeSleep)
 			s.mu.Unlock()
 			logger.Default("Pre-sleep charging verification succeeded on attempt %d.", attempt)
 			logger.Default("Pre-sleep charging enforcement active; allowing sleep to proceed.")
@@ -742,7 +1524,7 @@ func (s *Daemon) handleBeforeSleep() {
 }
 
 func (s *Daemon) verifyChargingDisabled(deadline time.Time) (bool, error) {
-	remaining := time.Until(deadline)
+	remaining := deadline.Sub(clock.Now())
 	if remaining <= 0 {
 		return false, fmt.Errorf("pre-sleep verification budget exhausted")
 	}
@@ -766,11 +1548,24 @@ func (s *Daemon) verifyChargingDisabled(deadline time.Time) (bool, error) {
 }
 
 func (s *Daemon) handleWake() {
-	now := nowFn()
+	now := clock.Now()
+	// Sleep time must not count against the stall watchdog.
+	s.markChargingLogicRun()
 
 	s.mu.Lock()
+	if s.stopPreSleepTimerLocked() {
+		logger.Default("Woke before the deferred pre-sleep charging disable; cancelled it.")
+	}
+	s.resetChargeHistoryLocked()
+	if !s.persistSleepPrevention && (s.wantPreventDisplaySleep || s.wantPreventSystemSleep) {
+		s.wantPreventDisplaySleep = false
+		s.autoPreventDisplaySleep = false
+		s.wantPreventSystemSleep = false
+		s.releaseAllAssertions()
+		logger.Default("Cleared sleep prevention after wake because persisting it across sleep is off.")
+	}
 	s.sleepTransitionActive = false
-	if s.wantDisableChargingBeforeSleep && s.currentLimit < 100 {
+	if limit, _ := s.effectiveLimitLocked(); s.wantDisableChargingBeforeSleep && limit < 100 {
 		s.wakeHoldUntil = now.Add(wakeHoldDuration)
 		until := s.wakeHoldUntil
 		s.mu.Unlock()
@@ -786,53 +1581,210 @@ func (s *Daemon) handleWake() {
 	logger.Default("Wake hold not enabled because sleep-charging enforcement is inactive or limit is 100%%.")
 }
 
-func Run(buildID string, buildIDSource string, buildDirty bool) error {
+// probeMagsafeLED detects MagSafe LED control and hands the LED to the system.
+// It blocks for at most opTimeout; a slower probe finishes in the background
+// while status reports support as unknown.
+func (s *Daemon) probeMagsafeLED() {
+	probe := make(chan bool, 1)
+	go func() {
+		probe <- powerkit.IsMagsafeAvailable()
+	}()
+	select {
+	case available := <-probe:
+		s.finishMagsafeProbe(available)
+	case <-time.After(opTimeout):
+		logger.Error("MagSafe LED capability probe timed out after %s; support unknown until it finishes.", opTimeout)
+		go func() {
+			s.finishMagsafeProbe(<-probe)
+		}()
+	}
+}
+
+func (s *Daemon) finishMagsafeProbe(available bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ledProbed = true
+	if !available {
+		logger.Default("MagSafe LED not supported or not present.")
+		return
+	}
+	s.ledSupported = true
+	logger.Default("MagSafe LED control supported on this hardware.")
+	// Ensure safe default on boot
+	if err := s.returnLEDToSystemLocked(); err != nil {
+		logger.Info("Could not set MagSafe LED to system on startup: %v", err)
+	}
+}
+
+// BuildInfo describes the running daemon binary. Fields are stamped at build
+// time through -ldflags and may be empty for ad hoc builds.
+type BuildInfo struct {
+	ID        string
+	IDSource  string // git, override, fallback, unknown
+	Dirty     bool
+	GitCommit string
+	Time      string // RFC 3339
+	Version   string // Semantic version, independent of ID
+}
+
+// Run serves on socket until SIGINT or SIGTERM. Only a daemon on the
+// default socket must run as root; another path allows unprivileged runs for
+// tests and sandboxes, where SMC writes fail and safe mode takes over.
+func Run(build BuildInfo, defaultLimit int, socket string) error {
 	logger.Default("Starting PowerGrid Daemon...")
-	if os.Geteuid() != 0 {
+	if os.Geteuid() != 0 && socket == ipc.DefaultSocketPath {
 		return fmt.Errorf("powergrid daemon must be run as root")
 	}
+	socketPath = socket
+	textSocketPath = ipc.TextSocketPath(socket)
+	if socket != ipc.DefaultSocketPath {
+		logger.Default("Serving on %s instead of %s.", socket, ipc.DefaultSocketPath)
+	}
+	if defaultLimit != DefaultChargeLimit {
+		logger.Default("Using default charge limit %d%% instead of the built-in %d%%.", defaultLimit, DefaultChargeLimit)
+	}
+	defaultChargeLimit = defaultLimit
+	cfg.ReportAdminConfigError = func(err error) {
+		logger.Error("Ignoring the admin config: %v", err)
+	}
 	if err := cfg.EnsureSystemConfig(defaultChargeLimit); err != nil {
 		logger.Error("Failed to ensure system config: %v", err)
 	}
 
+	// Spread out restarts so a launchd KeepAlive loop cannot hammer the
+	// socket and flood the log.
+	time.Sleep(rand.N(maxStartupJitter))
 	lis, err := ipc.Listen(socketPath)
+	if errors.Is(err, ipc.ErrSocketInUse) {
+		logger.Fault("Another process is serving %s; exiting instead of taking over its socket.", socketPath)
+		return nil
+	}
 	if err != nil {
+		logger.Fault("Failed to listen on %s: %v. %s", socketPath, err, ipc.ListenHint(socketPath, err))
 		return fmt.Errorf("failed to listen on socket: %w", err)
 	}
 
-	if buildIDSource == "" {
-		buildIDSource = "unknown"
+	if build.IDSource == "" {
+		build.IDSource = "unknown"
 	}
+	if build.Time != "" {
+		if _, err := time.Parse(time.RFC3339, build.Time); err != nil {
+			logger.Error("Ignoring build time %q that is not RFC 3339.", build.Time)
+			build.Time = ""
+		}
+	}
+	logger.Default("Build %s (version %q, commit %q, built %q).", build.ID, build.Version, build.GitCommit, build.Time)
 	server := &Daemon{
-		currentLimit:    defaultChargeLimit,
-		buildID:         buildID,
-		buildIDSource:   buildIDSource,
-		buildDirty:      buildDirty,
-		batteryUpdateCh: make(chan *powerkit.SystemInfo, 64),
+		currentLimit:               int32(defaultChargeLimit),
+		managementDisabled:         !cfg.ReadSystemManagementEnabled(),
+		adapterUnderperformPercent: cfg.ReadSystemAdapterUnderperformPercent(),
+		connectGrace:               time.Duration(cfg.ReadSystemConnectGraceSeconds()) * time.Second,
+		limitRamp:                  time.Duration(cfg.ReadSystemChargeLimitRampMinutes()) * time.Minute,
+		minChargeLimit:             cfg.ReadSystemMinChargeLimit(),
+		rememberUserLimit:          cfg.ReadSystemRememberLastUserChargeLimit(),
+		rememberedLimit:            cfg.ReadSystemLastUserChargeLimit(),
+		forceDischargeFloor:        cfg.ReadSystemForceDischargeFloorPercent(),
+		minChargingAdapterWatts:    cfg.ReadSystemMinChargingAdapterWatts(),
+		minChargeBeforeSleep:       cfg.ReadSystemMinChargeBeforeSleepPercent(),
+		criticalLevel:              cfg.ReadSystemCriticalChargePercent(),
+		criticalActions:            cfg.ReadSystemCriticalChargeActions(),
+		preSleepDelay:              time.Duration(cfg.ReadSystemPreSleepDisableDelaySeconds()) * time.Second,
+		hideChargedAtLimit:         !cfg.ReadSystemReportChargedAtLimit(),
+		deferUnderLoad:             cfg.ReadSystemDeferChargingUnderLoad(),
+		chargeDuringDischarge:      cfg.ReadSystemEnableChargingDuringDischarge(),
+		restoreOverridesOnStart:    cfg.ReadSystemRestoreOverridesAcrossRestart(),
+		externalDisplaySleep:       cfg.ReadSystemPreventDisplaySleepWithExternalDisplay(),
+		watchPrefs:                 cfg.ReadSystemWatchPreferenceChanges(),
+		pollingOnly:                cfg.ReadSystemPollingOnly(),
+		logDecisions:               cfg.ReadSystemLogChargingDecisions(),
+		pollInterval:               time.Duration(cfg.ReadSystemPollIntervalSeconds()) * time.Second,
+		smcKeyReadsEnabled:         cfg.ReadSystemSMCKeyReadsEnabled(),
+		persistEffectiveLimit:      cfg.ReadSystemPersistEffectiveChargeLimit(),
+		ledRetryLimit:              cfg.ReadSystemMagsafeLEDRetries(),
+		counters:                   cfg.ReadSystemChargingCounters(),
+		healthHistory:              cfg.ReadSystemHealthHistory(),
+		build:                      build,
+		batteryUpdateCh:            make(chan *powerkit.SystemInfo, 64),
+		events:                     openEventLog(cfg.ReadSystemEventLogSettings()),
+		health:                     newHealthServer(),
+	}
+	defer func() {
+		if err := server.events.Close(); err != nil {
+			logger.Error("Failed to close event log: %v", err)
+		}
+	}()
+	server.recordEvent("daemon_started", map[string]any{"build_id": build.ID, "version": build.Version})
+	if server.managementDisabled {
+		logger.Default("Charge management is disabled; daemon starting in passthrough mode.")
 	}
+	// Probe before anything can read ledSupported so clients never see the
+	// LED toggle flip after launch.
+	server.probeMagsafeLED()
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
+	activeUID := func() (uint32, bool) {
+		server.mu.RLock()
+		defer server.mu.RUnlock()
+		if server.currentConsoleUser == nil {
+			return 0, false
+		}
+		return server.currentConsoleUser.UID, true
+	}
+	mutationToken, err := ipc.LoadMutationToken(ipc.MutationTokenPath)
+	if err != nil {
+		logger.Error("Failed to load mutation token; rejecting all mutations until it is fixed: %v", err)
+	}
+	server.mutationTokenRequired = err != nil || mutationToken != ""
+	if mutationToken != "" {
+		logger.Default("Mutation token loaded from %s; mutating RPCs require it.", ipc.MutationTokenPath)
+	}
 	grpcServer := grpc.NewServer(
-		grpc.UnaryInterceptor(ipc.AuthUnaryInterceptor(func() (uint32, bool) {
-			server.mu.RLock()
-			defer server.mu.RUnlock()
-			if server.currentConsoleUser == nil {
-				return 0, false
-			}
-			return server.currentConsoleUser.UID, true
-		})),
+		grpc.ChainUnaryInterceptor(
+			ipc.AuthUnaryInterceptor(activeUID),
+			ipc.MutationTokenUnaryInterceptor(mutationToken, server.mutationTokenRequired),
+		),
+		grpc.StreamInterceptor(ipc.AuthStreamInterceptor(activeUID)),
 	)
 	rpc.RegisterPowerGridServer(grpcServer, server)
+	healthpb.RegisterHealthServer(grpcServer, server.health)
+	if cfg.ReadSystemReflectionEnabled() {
+		reflection.Register(grpcServer)
+		logger.Default("gRPC server reflection enabled.")
+	}
+
+	if cfg.ReadSystemTextControlEnabled() {
+		textLis, err := ipc.Listen(textSocketPath)
+		if err != nil {
+			logger.Error("Failed to listen on text control socket: %v. %s", err, ipc.ListenHint(textSocketPath, err))
+		} else {
+			server.textControlEnabled = true
+			text := &textControl{d: server, activeUID: activeUID, token: mutationToken, tokenRequired: server.mutationTokenRequired}
+			go text.serve(ctx, textLis)
+			logger.Default("Text control socket enabled at %s.", textSocketPath)
+		}
+	}
 
+	if server.restoreOverridesOnStart {
+		server.restoreOverrides()
+	}
+	server.startStartupGrace(time.Duration(cfg.ReadSystemStartupGraceSeconds()) * time.Second)
 	server.startConsoleUserEventHandler(ctx)
 	server.startBatteryCoalescer(ctx)
 
-	server.startEventStream(ctx)
+	if server.pollingOnly {
+		logger.Default("Polling-only mode: skipping the powerkit event stream and reading every %s.", server.pollInterval)
+	} else {
+		server.startEventStream(ctx)
+	}
+	server.startWatchdog(ctx)
+	server.startExternalDisplayWatcher(ctx)
+	server.startPrefsWatcher(ctx)
+	server.startSleepAssertionWatcher(ctx)
 
 	server.wg.Add(1)
 	go func() {
 		defer server.wg.Done()
-		ticker := time.NewTicker(60 * time.Second)
+		ticker := time.NewTicker(server.tickInterval())
 		defer ticker.Stop()
 		for {
 			select {
@@ -852,31 +1804,12 @@ func Run(buildID string, buildIDSource string, buildDirty bool) error {
 
 	logger.Default("PowerGrid Daemon is running.")
 
-	// Probe MagSafe LED capability once after start
-	go func() {
-		if powerkit.IsMagsafeAvailable() {
-			server.mu.Lock()
-			server.ledSupported = true
-			server.mu.Unlock()
-			logger.Default("MagSafe LED control supported on this hardware.")
-			// Ensure safe default on boot
-			if err := callWithTimeout(opTimeout, func() error {
-				return powerkit.SetMagsafeLEDState(powerkit.LEDSystem)
-			}); err != nil {
-				logger.Info("Could not set MagSafe LED to system on startup: %v", err)
-			} else {
-				server.lastLEDState = powerkit.LEDSystem
-			}
-		} else {
-			logger.Default("MagSafe LED not supported or not present.")
-		}
-	}()
-
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 
 	logger.Default("Shutting down PowerGrid Daemon...")
+	server.health.Shutdown()
 	cancel()
 	grpcServer.GracefulStop()
 	done := make(chan struct{})
@@ -889,39 +1822,108 @@ func Run(buildID string, buildIDSource string, buildDirty bool) error {
 	case <-time.After(3 * time.Second):
 		logger.Info("Timed out waiting for background goroutines to stop.")
 	}
-	if err := os.Remove(socketPath); err != nil && !os.IsNotExist(err) {
-		logger.Error("Failed to remove socket on shutdown: %v", err)
+	server.handleShutdown(cfg.ReadSystemRestoreOnShutdown())
+	server.recordEvent("daemon_stopped", nil)
+	for _, path := range server.controlSockets() {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			logger.Error("Failed to remove socket %s on shutdown: %v", path, err)
+		}
 	}
 	return nil
 }
 
+// handleShutdown leaves the hardware in a safe state before exit so a stopped
+// or uninstalled daemon cannot strand charging disabled at the limit.
+func (s *Daemon) handleShutdown(restore bool) {
+	s.mu.Lock()
+	if s.restoreOverridesOnStart {
+		s.saveOverridesLocked()
+	}
+	s.stopLEDRetryLocked()
+	s.stopPreSleepTimerLocked()
+	s.stopSuspendTimerLocked()
+	s.flushEffectiveLimitLocked()
+	s.mu.Unlock()
+	// Sleep assertions are released whatever the restore policy so a
+	// restarted or removed daemon never leaves the Mac unable to sleep.
+	if n := s.releaseAllAssertions(); n > 0 {
+		logger.Default("Released %d sleep assertion(s) before shutdown.", n)
+	}
+	if !restore {
+		logger.Default("Shutdown policy is leave-as-is; not touching charging state.")
+		return
+	}
+	if s.hardwareWritesBlocked("shutdown restore") {
+		return
+	}
+
+	logger.Default("Restoring charging and adapter before shutdown.")
+	if err := callWithTimeout(opTimeout, func() error {
+		return setChargingStateFn(powerkit.ChargingActionOn)
+	}); err != nil {
+		logger.Error("Failed to re-enable charging on shutdown: %v", err)
+	} else {
+		s.noteChargingWrite(powerkit.ChargingActionOn)
+	}
+	if err := callWithTimeout(opTimeout, func() error {
+		return setAdapterStateFn(powerkit.AdapterActionOn)
+	}); err != nil {
+		logger.Error("Failed to re-enable adapter on shutdown: %v", err)
+	}
+
+	s.mu.RLock()
+	ledSupported := s.ledSupported
+	s.mu.RUnlock()
+	if ledSupported {
+		if err := callWithTimeout(opTimeout, func() error {
+			return setMagsafeLEDStateFn(powerkit.LEDSystem)
+		}); err != nil {
+			logger.Info("Could not return MagSafe LED to system on shutdown: %v", err)
+		}
+	}
+}
+
 func (s *Daemon) applyMagsafeLED(info *powerkit.SystemInfo) {
-	if !s.wantMagsafeLED || !s.ledSupported {
+	adapterKnown := info != nil && info.IOKit != nil
+	if s.ledSupported && adapterKnown && info.IOKit.Adapter.MaxWatts == 0 {
+		s.noteLEDAdapterRemovedLocked()
+	}
+	if !s.ledControlledLocked() || !s.ledSupported || s.safeMode.Load() {
 		return
 	}
-	target, ok := engine.DecideMagsafeLED(engine.LEDInput{
-		AdapterPresent:     info.IOKit != nil && info.IOKit.Adapter.MaxWatts > 0,
-		Charge:             info.IOKit.Battery.CurrentCharge,
-		Limit:              int(s.currentLimit),
-		IsCharging:         info.IOKit.State.IsCharging,
-		IsConnected:        info.IOKit.State.IsConnected,
-		SMCChargingEnabled: info.SMC.State.IsChargingEnabled,
-		ForceDischarge:     !info.SMC.State.IsAdapterEnabled,
-	})
+	if adapterKnown && info.IOKit.Adapter.MaxWatts > 0 {
+		s.startLEDProbeLocked()
+	}
+	if s.ledProbing {
+		return
+	}
+	target, ok := powerkit.LEDOff, true
+	if s.ledMode != cfg.LEDModeOff {
+		if info == nil || info.IOKit == nil || info.SMC == nil {
+			logger.InfoLimited("Skipping MagSafe LED update due to incomplete data.")
+			return
+		}
+		target, ok = s.decideAutoLEDLocked(info)
+	}
 	if !ok {
 		return
 	}
+	if honored := engine.MagsafeLEDFallback(target, s.ledStateHonoredLocked); honored != target {
+		logger.InfoLimited("MagSafe LED state %s is not honored on this Mac; using %s instead.", ledStateName(target), ledStateName(honored))
+		target = honored
+	}
 
-	if target == s.lastLEDState {
+	if target == s.lastLEDState && !s.ledDirty {
 		return
 	}
 	if err := callWithTimeout(opTimeout, func() error {
-		return powerkit.SetMagsafeLEDState(target)
+		return setMagsafeLEDStateFn(target)
 	}); err != nil {
 		logger.Error("Failed to set MagSafe LED: %v", err)
+		s.markLEDFailedLocked(err)
 		return
 	}
-	s.lastLEDState = target
+	s.markLEDWrittenLocked(target)
 	switch target {
 	case powerkit.LEDAmber:
 		logger.Info("MagSafe LED -> Amber")
@@ -950,6 +1952,25 @@ func callWithTimeout(timeout time.Duration, fn func() error) error {
 	}
 }
 
+// callWithContextTimeout is callWithTimeout for operations that accept a
+// context, so subprocess-backed calls are cancelled rather than abandoned.
+func callWithContextTimeout(timeout time.Duration, fn func(ctx context.Context) error) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- fn(ctx)
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		return fmt.Errorf("operation timed out after %s", timeout)
+	}
+}
+
 func minDuration(a, b time.Duration) time.Duration {
 	if a < b {
 		return a
@@ -0,0 +1,161 @@
+package server
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/peterneutron/powerkit-go/pkg/powerkit"
+
+	cfg "powergrid/internal/config"
+	rpc "powergrid/internal/rpc"
+)
+
+func TestApplyMagsafeLEDSkipsWhenSMCMissing(t *testing.T) {
+	resetServerTestGlobals(t)
+
+	calls := 0
+	setMagsafeLEDStateFn = func(powerkit.MagsafeLEDState) error {
+		calls++
+		return nil
+	}
+
+	info := testSystemInfo(50, true)
+	info.IOKit.Adapter.MaxWatts = 96
+	info.SMC = nil
+
+	d := &Daemon{
+		currentLimit: 80,
+		ledMode:      cfg.LEDModeAuto,
+		ledSupported: true,
+	}
+	d.applyMagsafeLED(info)
+
+	if calls != 0 {
+		t.Fatalf("expected no LED writes without SMC data, got %d", calls)
+	}
+}
+
+func TestApplyMagsafeLEDSkipsWhenIOKitMissing(t *testing.T) {
+	resetServerTestGlobals(t)
+
+	calls := 0
+	setMagsafeLEDStateFn = func(powerkit.MagsafeLEDState) error {
+		calls++
+		return nil
+	}
+
+	info := testSystemInfo(50, true)
+	info.IOKit = nil
+
+	d := &Daemon{
+		currentLimit: 80,
+		ledMode:      cfg.LEDModeAuto,
+		ledSupported: true,
+	}
+	d.applyMagsafeLED(info)
+	d.applyMagsafeLED(nil)
+
+	if calls != 0 {
+		t.Fatalf("expected no LED writes without IOKit data, got %d", calls)
+	}
+}
+
+func TestApplyMagsafeLEDWritesWhenDataComplete(t *testing.T) {
+	resetServerTestGlobals(t)
+
+	var states []powerkit.MagsafeLEDState
+	setMagsafeLEDStateFn = func(state powerkit.MagsafeLEDState) error {
+		states = append(states, state)
+		return nil
+	}
+
+	info := testSystemInfo(50, true)
+	info.IOKit.Adapter.MaxWatts = 96
+	info.IOKit.State.IsCharging = true
+
+	d := &Daemon{
+		currentLimit: 80,
+		ledMode:      cfg.LEDModeAuto,
+		ledSupported: true,
+	}
+	d.applyMagsafeLED(info)
+
+	if len(states) != 1 || states[0] != powerkit.LEDAmber {
+		t.Fatalf("expected a single amber LED write, got %v", states)
+	}
+}
+
+func TestApplyMagsafeLEDRewritesAfterFailure(t *testing.T) {
+	resetServerTestGlobals(t)
+
+	var states []powerkit.MagsafeLEDState
+	fail := true
+	setMagsafeLEDStateFn = func(state powerkit.MagsafeLEDState) error {
+		states = append(states, state)
+		if fail {
+			return errors.New("controller busy")
+		}
+		return nil
+	}
+
+	charging := testSystemInfo(50, true)
+	charging.IOKit.Adapter.MaxWatts = 96
+	charging.IOKit.State.IsCharging = true
+
+	d := &Daemon{
+		currentLimit: 80,
+		ledMode:      cfg.LEDModeAuto,
+		ledSupported: true,
+		lastLEDState: powerkit.LEDGreen,
+	}
+	d.applyMagsafeLED(charging)
+	if !d.ledDirty {
+		t.Fatal("expected a failed write to mark the LED dirty")
+	}
+
+	// The target is back to the last successful state, but the failed write
+	// left the hardware unknown, so it must be written again.
+	fail = false
+	atLimit := testSystemInfo(80, false)
+	atLimit.IOKit.Adapter.MaxWatts = 96
+	d.applyMagsafeLED(atLimit)
+	if len(states) != 2 || states[1] != powerkit.LEDGreen {
+		t.Fatalf("expected green rewritten after failure, got %v", states)
+	}
+	if d.ledDirty {
+		t.Fatal("expected dirty flag cleared after a successful write")
+	}
+}
+
+func TestMarkLEDFailedSchedulesBoundedRetry(t *testing.T) {
+	resetServerTestGlobals(t)
+
+	d := &Daemon{ledRetryLimit: 1}
+	d.markLEDFailedLocked(errors.New("smc busy"))
+	if d.ledRetryTimer == nil || d.ledRetries != 1 {
+		t.Fatalf("expected one retry scheduled, got timer=%v retries=%d", d.ledRetryTimer != nil, d.ledRetries)
+	}
+	d.stopLEDRetryLocked()
+
+	d.markLEDFailedLocked(errors.New("smc busy"))
+	if d.ledRetryTimer != nil {
+		t.Fatal("expected no retry past the limit")
+	}
+}
+
+func TestMagsafeLEDSupportTriState(t *testing.T) {
+	d := &Daemon{}
+	if got := d.magsafeLEDSupportLocked(); got != rpc.MagsafeLedSupport_MAGSAFE_LED_SUPPORT_UNKNOWN {
+		t.Fatalf("unexpected support before probe: got=%v want=%v", got, rpc.MagsafeLedSupport_MAGSAFE_LED_SUPPORT_UNKNOWN)
+	}
+
+	d.finishMagsafeProbe(false)
+	if got := d.magsafeLEDSupportLocked(); got != rpc.MagsafeLedSupport_MAGSAFE_LED_UNSUPPORTED {
+		t.Fatalf("unexpected support after failed probe: got=%v want=%v", got, rpc.MagsafeLedSupport_MAGSAFE_LED_UNSUPPORTED)
+	}
+
+	d.ledSupported = true
+	if got := d.magsafeLEDSupportLocked(); got != rpc.MagsafeLedSupport_MAGSAFE_LED_SUPPORTED {
+		t.Fatalf("unexpected support when supported: got=%v want=%v", got, rpc.MagsafeLedSupport_MAGSAFE_LED_SUPPORTED)
+	}
+}
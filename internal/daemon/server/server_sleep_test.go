@@ -1,17 +1,22 @@
 package server
 
 import (
+	"context"
 	"testing"
 	"time"
 
 	"github.com/peterneutron/powerkit-go/pkg/powerkit"
+
+	cfg "powergrid/internal/config"
+	"powergrid/internal/daemon/engine"
 )
 
 func testSystemInfo(charge int, smcChargingEnabled bool) *powerkit.SystemInfo {
 	return &powerkit.SystemInfo{
 		IOKit: &powerkit.IOKitData{
 			Battery: powerkit.IOKitBattery{
-				CurrentCharge: charge,
+				CurrentCharge:  charge,
+				DesignCapacity: 5000,
 			},
 		},
 		SMC: &powerkit.SMCData{
@@ -25,12 +30,70 @@ func testSystemInfo(charge int, smcChargingEnabled bool) *powerkit.SystemInfo {
 func resetServerTestGlobals(t *testing.T) {
 	t.Helper()
 	oldSetChargingStateFn := setChargingStateFn
+	oldSetMagsafeLEDStateFn := setMagsafeLEDStateFn
+	oldGetMagsafeLEDStateFn := getMagsafeLEDStateFn
+	oldSetAdapterStateFn := setAdapterStateFn
 	oldGetSystemInfoFn := getSystemInfoFn
-	oldNowFn := nowFn
+	oldClock := clock
+	oldAllowAllSleepFn := allowAllSleepFn
+	oldSystemLoadFn := systemLoadFn
+	oldChargeManagerProcessesFn := chargeManagerProcessesFn
+	oldProcessStartTimeFn := processStartTimeFn
+	oldWriteCountersFn := writeCountersFn
+	oldSystemLocationFn := systemLocationFn
+	oldExternalDisplayCountFn := externalDisplayCountFn
+	oldPowerSourceFn := powerSourceFn
+	oldCreateAssertionFn := createAssertionFn
+	oldReleaseAssertionFn := releaseAssertionFn
+	oldWriteLastUserLimitFn := writeLastUserLimitFn
+	oldGetRawSMCValuesFn := getRawSMCValuesFn
+	oldWriteEffectiveLimitFn := writeEffectiveLimitFn
+	oldProfileForUserFn := profileForUserFn
+	oldLocalUsersFn := localUsersFn
+	oldSleepAssertionsFn := sleepAssertionsFn
+	oldCallerUIDFn := callerUIDFn
+	oldSetLowPowerModeFn := setLowPowerModeFn
+	oldReadOverrideSnapshotFn := readOverrideSnapshotFn
+	oldWriteOverrideSnapshotFn := writeOverrideSnapshotFn
+	oldWriteHealthHistoryFn := writeHealthHistoryFn
+	writeCountersFn = func(cfg.ChargingCounters) error { return nil }
+	writeLastUserLimitFn = func(int) error { return nil }
+	writeEffectiveLimitFn = func(int) error { return nil }
+	readOverrideSnapshotFn = func() cfg.OverrideSnapshot { return cfg.OverrideSnapshot{} }
+	writeOverrideSnapshotFn = func(cfg.OverrideSnapshot) error { return nil }
+	writeHealthHistoryFn = func([]cfg.HealthSample) error { return nil }
+	chargeManagerProcessesFn = func() []string { return nil }
+	sleepAssertionsFn = func(context.Context) ([]sleepAssertion, error) { return nil, nil }
+	resetSleepAssertionCache()
 	t.Cleanup(func() {
 		setChargingStateFn = oldSetChargingStateFn
+		setMagsafeLEDStateFn = oldSetMagsafeLEDStateFn
+		getMagsafeLEDStateFn = oldGetMagsafeLEDStateFn
+		setAdapterStateFn = oldSetAdapterStateFn
 		getSystemInfoFn = oldGetSystemInfoFn
-		nowFn = oldNowFn
+		clock = oldClock
+		allowAllSleepFn = oldAllowAllSleepFn
+		systemLoadFn = oldSystemLoadFn
+		chargeManagerProcessesFn = oldChargeManagerProcessesFn
+		processStartTimeFn = oldProcessStartTimeFn
+		writeCountersFn = oldWriteCountersFn
+		systemLocationFn = oldSystemLocationFn
+		externalDisplayCountFn = oldExternalDisplayCountFn
+		powerSourceFn = oldPowerSourceFn
+		createAssertionFn = oldCreateAssertionFn
+		releaseAssertionFn = oldReleaseAssertionFn
+		writeLastUserLimitFn = oldWriteLastUserLimitFn
+		getRawSMCValuesFn = oldGetRawSMCValuesFn
+		writeEffectiveLimitFn = oldWriteEffectiveLimitFn
+		profileForUserFn = oldProfileForUserFn
+		localUsersFn = oldLocalUsersFn
+		sleepAssertionsFn = oldSleepAssertionsFn
+		callerUIDFn = oldCallerUIDFn
+		setLowPowerModeFn = oldSetLowPowerModeFn
+		readOverrideSnapshotFn = oldReadOverrideSnapshotFn
+		writeOverrideSnapshotFn = oldWriteOverrideSnapshotFn
+		writeHealthHistoryFn = oldWriteHealthHistoryFn
+		resetSleepAssertionCache()
 	})
 }
 
@@ -85,6 +148,31 @@ func TestHandleBeforeSleepNoopWhenLimitIsHundred(t *testing.T) {
 	}
 }
 
+func TestHandleBeforeSleepSkipsBelowChargeFloor(t *testing.T) {
+	resetServerTestGlobals(t)
+
+	calls := 0
+	setChargingStateFn = func(powerkit.ChargingAction) error {
+		calls++
+		return nil
+	}
+
+	d := &Daemon{
+		currentLimit:                   80,
+		wantDisableChargingBeforeSleep: true,
+		minChargeBeforeSleep:           30,
+	}
+	d.updateCachedStatusLocked(testSystemInfo(12, true))
+	d.handleBeforeSleep()
+
+	if calls != 0 {
+		t.Fatalf("expected no charging writes below the pre-sleep floor, got %d", calls)
+	}
+	if d.sleepTransitionActive {
+		t.Fatalf("expected sleep transition to stay inactive")
+	}
+}
+
 func TestHandleBeforeSleepSuccessSetsTransitionActive(t *testing.T) {
 	resetServerTestGlobals(t)
 
@@ -118,6 +206,73 @@ func TestHandleBeforeSleepSuccessSetsTransitionActive(t *testing.T) {
 	if !d.sleepTransitionActive {
 		t.Fatalf("expected sleep transition to be active after successful verification")
 	}
+	if d.pauseReason != engine.PauseBeforeSleep {
+		t.Fatalf("unexpected pause reason: got=%v want=%v", d.pauseReason, engine.PauseBeforeSleep)
+	}
+}
+
+func TestHandleBeforeSleepDelayCancelledByWake(t *testing.T) {
+	resetServerTestGlobals(t)
+
+	clk := newFakeClock(time.Date(2026, 4, 20, 10, 0, 0, 0, time.UTC))
+	clock = clk
+	var setCalls int
+	setChargingStateFn = func(powerkit.ChargingAction) error {
+		setCalls++
+		return nil
+	}
+	getSystemInfoFn = func(opts ...powerkit.FetchOptions) (*powerkit.SystemInfo, error) {
+		return testSystemInfo(80, false), nil
+	}
+
+	d := &Daemon{
+		currentLimit:                   80,
+		wantDisableChargingBeforeSleep: true,
+		preSleepDelay:                  time.Hour,
+	}
+	d.handleBeforeSleep()
+	if setCalls != 0 || d.preSleepTimer == nil {
+		t.Fatalf("expected a deferred disable: writes=%d timer=%v", setCalls, d.preSleepTimer != nil)
+	}
+
+	d.handleWake()
+	if d.preSleepTimer != nil {
+		t.Fatal("expected wake to cancel the deferred disable")
+	}
+	clk.Advance(time.Hour)
+	if setCalls != 0 {
+		t.Fatalf("expected no charging writes after a brief sleep, got %d", setCalls)
+	}
+
+	d.handleBeforeSleep()
+	clk.Advance(time.Hour - time.Second)
+	if setCalls != 0 {
+		t.Fatalf("expected no write before the delay elapsed, got %d", setCalls)
+	}
+	clk.Advance(time.Second)
+	if setCalls != 1 || !d.sleepTransitionActive {
+		t.Fatalf("expected the deferred disable to run once: writes=%d transition=%t", setCalls, d.sleepTransitionActive)
+	}
+}
+
+func TestHandleWakeClearsSleepPreventionWhenNotPersisted(t *testing.T) {
+	resetServerTestGlobals(t)
+
+	var released int
+	allowAllSleepFn = func() { released++ }
+
+	d := &Daemon{persistSleepPrevention: true, wantPreventSystemSleep: true}
+	d.handleWake()
+	if !d.wantPreventSystemSleep || released != 0 {
+		t.Fatalf("expected sleep prevention kept across wake: want=%t releases=%d", d.wantPreventSystemSleep, released)
+	}
+
+	d.persistSleepPrevention = false
+	d.wantPreventDisplaySleep = true
+	d.handleWake()
+	if d.wantPreventSystemSleep || d.wantPreventDisplaySleep || released != 1 {
+		t.Fatalf("expected wake to clear sleep prevention: system=%t display=%t releases=%d", d.wantPreventSystemSleep, d.wantPreventDisplaySleep, released)
+	}
 }
 
 func TestHandleBeforeSleepRetriesAndClearsTransitionOnFailure(t *testing.T) {
@@ -193,7 +348,8 @@ func TestRunChargingLogicAllowsImmediateEnableBelowLimitDuringWakeHold(t *testin
 	resetServerTestGlobals(t)
 
 	now := time.Date(2026, 4, 20, 10, 0, 0, 0, time.UTC)
-	nowFn = func() time.Time { return now }
+	clk := newFakeClock(now)
+	clock = clk
 
 	var actions []powerkit.ChargingAction
 	setChargingStateFn = func(action powerkit.ChargingAction) error {
@@ -0,0 +1,85 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/peterneutron/powerkit-go/pkg/powerkit"
+)
+
+func TestHandleShutdownRestoresChargingAndAdapter(t *testing.T) {
+	resetServerTestGlobals(t)
+
+	var chargingActions []powerkit.ChargingAction
+	setChargingStateFn = func(action powerkit.ChargingAction) error {
+		chargingActions = append(chargingActions, action)
+		return nil
+	}
+	var adapterActions []powerkit.AdapterAction
+	setAdapterStateFn = func(action powerkit.AdapterAction) error {
+		adapterActions = append(adapterActions, action)
+		return nil
+	}
+
+	d := &Daemon{currentLimit: 80}
+	d.handleShutdown(true)
+
+	if len(chargingActions) != 1 || chargingActions[0] != powerkit.ChargingActionOn {
+		t.Fatalf("expected charging to be restored on shutdown, got %v", chargingActions)
+	}
+	if len(adapterActions) != 1 || adapterActions[0] != powerkit.AdapterActionOn {
+		t.Fatalf("expected adapter to be restored on shutdown, got %v", adapterActions)
+	}
+}
+
+func TestHandleShutdownLeaveAsIs(t *testing.T) {
+	resetServerTestGlobals(t)
+
+	writes := 0
+	setChargingStateFn = func(powerkit.ChargingAction) error {
+		writes++
+		return nil
+	}
+	setAdapterStateFn = func(powerkit.AdapterAction) error {
+		writes++
+		return nil
+	}
+
+	d := &Daemon{currentLimit: 80}
+	d.handleShutdown(false)
+
+	if writes != 0 {
+		t.Fatalf("expected no hardware writes with leave-as-is policy, got %d", writes)
+	}
+}
+
+func TestHandleShutdownReleasesAssertions(t *testing.T) {
+	resetServerTestGlobals(t)
+
+	createAssertionFn = func(powerkit.AssertionType, string) (powerkit.AssertionID, error) { return 1, nil }
+	var released []powerkit.AssertionType
+	releaseAssertionFn = func(assertionType powerkit.AssertionType) {
+		released = append(released, assertionType)
+	}
+	allowAllCalls := 0
+	allowAllSleepFn = func() { allowAllCalls++ }
+
+	d := &Daemon{currentLimit: 80}
+	if err := d.holdAssertion(powerkit.AssertionTypePreventSystemSleep, "test"); err != nil {
+		t.Fatalf("holdAssertion: %v", err)
+	}
+	if err := d.holdAssertion(powerkit.AssertionTypePreventDisplaySleep, "test"); err != nil {
+		t.Fatalf("holdAssertion: %v", err)
+	}
+	// Leave-as-is only covers charging state; assertions are always released.
+	d.handleShutdown(false)
+
+	if len(released) != 2 || released[0] == released[1] || released[0] > released[1] {
+		t.Fatalf("expected both assertions released in type order, got %v", released)
+	}
+	if allowAllCalls != 1 {
+		t.Fatalf("expected one AllowAllSleep backstop call, got %d", allowAllCalls)
+	}
+	if n := d.releaseAllAssertions(); n != 0 {
+		t.Fatalf("expected no tracked assertions after shutdown, got %d", n)
+	}
+}
@@ -0,0 +1,159 @@
+package server
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	rpc "powergrid/internal/rpc"
+)
+
+// sleepAssertionInterval is how often pmset is run in the background for
+// the assertions GetStatus reports; clients poll status far more often than
+// other apps take or drop assertions.
+const sleepAssertionInterval = 5 * time.Second
+
+// Assertion types pmset lists per process, grouped by what they keep awake.
+// The legacy names are still used by some older apps.
+var (
+	displaySleepAssertionTypes = map[string]bool{
+		"PreventUserIdleDisplaySleep": true,
+		"NoDisplaySleepAssertion":     true,
+	}
+	systemSleepAssertionTypes = map[string]bool{
+		"PreventUserIdleSystemSleep": true,
+		"PreventSystemSleep":         true,
+		"NoIdleSleepAssertion":       true,
+	}
+)
+
+// sleepAssertionLine matches an entry under "Listed by owning process", e.g.
+//
+//	pid 412(caffeinate): [0x0000a1b200019b5c] 00:00:05 PreventUserIdleSystemSleep named: "caffeinate command-line tool"
+var sleepAssertionLine = regexp.MustCompile(`^\s*pid (\d+)\((.*)\): \[0x[0-9a-fA-F]+\] [0-9:]+ (\S+) named: `)
+
+// sleepAssertion is one display or system sleep assertion held by a process.
+type sleepAssertion struct {
+	PID     int
+	Process string
+	Display bool
+}
+
+var sleepAssertionCache struct {
+	mu   sync.Mutex
+	list []sleepAssertion
+}
+
+// readSleepAssertions lists the sleep assertions currently held system-wide,
+// by any process including this one. pmset is killed if ctx ends first.
+func readSleepAssertions(ctx context.Context) ([]sleepAssertion, error) {
+	out, err := exec.CommandContext(ctx, "/usr/bin/pmset", "-g", "assertions").Output()
+	if err != nil {
+		return nil, err
+	}
+	return parseSleepAssertions(string(out)), nil
+}
+
+// parseSleepAssertions extracts display and system sleep assertions from
+// `pmset -g assertions` output. Other assertion types, such as
+// UserIsActive or BackgroundTask, do not keep the Mac awake and are skipped.
+func parseSleepAssertions(out string) []sleepAssertion {
+	var list []sleepAssertion
+	for _, line := range strings.Split(out, "\n") {
+		m := sleepAssertionLine.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		display := displaySleepAssertionTypes[m[3]]
+		if !display && !systemSleepAssertionTypes[m[3]] {
+			continue
+		}
+		pid, err := strconv.Atoi(m[1])
+		if err != nil {
+			continue
+		}
+		list = append(list, sleepAssertion{PID: pid, Process: m[2], Display: display})
+	}
+	return list
+}
+
+// startSleepAssertionWatcher keeps the sleep assertion cache current, so
+// GetStatus never waits on pmset while holding s.mu.
+func (s *Daemon) startSleepAssertionWatcher(ctx context.Context) {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		refreshSleepAssertions()
+		ticker := time.NewTicker(sleepAssertionInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				refreshSleepAssertions()
+			}
+		}
+	}()
+}
+
+// refreshSleepAssertions re-reads the system's sleep assertions, bounded by
+// opTimeout, and stores them for cachedSleepAssertions. pmset runs without
+// the cache lock held. A failed read clears the cache rather than keep
+// reporting assertions that may be gone.
+func refreshSleepAssertions() {
+	ctx, cancel := context.WithTimeout(context.Background(), opTimeout)
+	defer cancel()
+	list, err := sleepAssertionsFn(ctx)
+	if err != nil {
+		logger.InfoLimited("Could not read sleep assertions: %v", err)
+	}
+	sleepAssertionCache.mu.Lock()
+	defer sleepAssertionCache.mu.Unlock()
+	sleepAssertionCache.list = list
+}
+
+// cachedSleepAssertions returns the sleep assertions from the latest
+// background read.
+func cachedSleepAssertions() []sleepAssertion {
+	sleepAssertionCache.mu.Lock()
+	defer sleepAssertionCache.mu.Unlock()
+	return sleepAssertionCache.list
+}
+
+// setSleepAssertionStatus reports sleep assertions held by any process, and
+// names the ones held outside PowerGrid, so clients can explain why the Mac
+// stays awake while PowerGrid's own sleep prevention is off.
+func setSleepAssertionStatus(resp *rpc.StatusResponse) {
+	self := os.Getpid()
+	seen := map[string]bool{}
+	for _, a := range cachedSleepAssertions() {
+		if a.Display {
+			resp.SystemDisplaySleepPrevented = true
+		} else {
+			resp.SystemSleepPrevented = true
+		}
+		if a.PID == self {
+			continue
+		}
+		holder := a.Process + " (system)"
+		if a.Display {
+			holder = a.Process + " (display)"
+		}
+		if !seen[holder] {
+			seen[holder] = true
+			resp.ExternalSleepAssertions = append(resp.ExternalSleepAssertions, holder)
+		}
+	}
+}
+
+func resetSleepAssertionCache() {
+	sleepAssertionCache.mu.Lock()
+	defer sleepAssertionCache.mu.Unlock()
+	sleepAssertionCache.list = nil
+}
@@ -0,0 +1,94 @@
+package server
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"testing"
+
+	rpc "powergrid/internal/rpc"
+)
+
+const pmsetAssertionsSample = `2026-10-16 09:12:03 +0200
+Assertion status system-wide:
+   BackgroundTask                 0
+   PreventUserIdleDisplaySleep    1
+   PreventSystemSleep             0
+   PreventUserIdleSystemSleep     1
+   UserIsActive                   1
+Listed by owning process:
+   pid 412(caffeinate): [0x0000a1b200019b5c] 00:00:05 PreventUserIdleSystemSleep named: "caffeinate command-line tool"
+	Details: caffeinate asserting on behalf of '/bin/sleep' (pid 411)
+   pid 98(WindowServer): [0x0000a1b200089b41] 00:01:10 UserIsActive named: "com.apple.iohideventsystem.queue.tickle"
+   pid 733(Google Chrome Helper (Renderer)): [0x0000a1b2000a9b77] 00:12:44 PreventUserIdleDisplaySleep named: "Video Wake Lock"
+Kernel Assertions: 0x4=USB
+   id=500  level=255 0x4=USB mod=10/16/26, 9:00 AM description=com.apple.usb.externaldevice.14100000 owner=Keyboard
+`
+
+func TestParseSleepAssertions(t *testing.T) {
+	got := parseSleepAssertions(pmsetAssertionsSample)
+	want := []sleepAssertion{
+		{PID: 412, Process: "caffeinate"},
+		{PID: 733, Process: "Google Chrome Helper (Renderer)", Display: true},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("parseSleepAssertions() = %+v, want %+v", got, want)
+	}
+}
+
+func TestSetSleepAssertionStatusSkipsOwnAssertions(t *testing.T) {
+	resetServerTestGlobals(t)
+
+	sleepAssertionsFn = func(context.Context) ([]sleepAssertion, error) {
+		return []sleepAssertion{
+			{PID: os.Getpid(), Process: "powergrid-daemon", Display: true},
+			{PID: 412, Process: "caffeinate"},
+			{PID: 413, Process: "caffeinate"},
+		}, nil
+	}
+	refreshSleepAssertions()
+
+	resp := &rpc.StatusResponse{}
+	setSleepAssertionStatus(resp)
+
+	if !resp.SystemDisplaySleepPrevented || !resp.SystemSleepPrevented {
+		t.Fatalf("expected system-wide display and system sleep prevention, got %+v", resp)
+	}
+	if want := []string{"caffeinate (system)"}; !reflect.DeepEqual(resp.ExternalSleepAssertions, want) {
+		t.Fatalf("ExternalSleepAssertions = %v, want %v", resp.ExternalSleepAssertions, want)
+	}
+}
+
+func TestSleepAssertionStatusReadsOnlyTheCache(t *testing.T) {
+	resetServerTestGlobals(t)
+
+	reads := 0
+	sleepAssertionsFn = func(ctx context.Context) ([]sleepAssertion, error) {
+		reads++
+		if _, ok := ctx.Deadline(); !ok {
+			t.Fatal("expected pmset to run with a deadline")
+		}
+		return []sleepAssertion{{PID: 412, Process: "caffeinate"}}, nil
+	}
+
+	setSleepAssertionStatus(&rpc.StatusResponse{})
+	if reads != 0 {
+		t.Fatalf("expected status to leave pmset to the background refresh, got %d reads", reads)
+	}
+	refreshSleepAssertions()
+	resp := &rpc.StatusResponse{}
+	setSleepAssertionStatus(resp)
+	if reads != 1 || !resp.SystemSleepPrevented {
+		t.Fatalf("expected the refreshed assertions in status, got %d reads and %+v", reads, resp)
+	}
+
+	sleepAssertionsFn = func(context.Context) ([]sleepAssertion, error) {
+		return nil, context.DeadlineExceeded
+	}
+	refreshSleepAssertions()
+	resp = &rpc.StatusResponse{}
+	setSleepAssertionStatus(resp)
+	if resp.SystemSleepPrevented {
+		t.Fatal("expected a failed read to clear the cached assertions")
+	}
+}
@@ -0,0 +1,85 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/peterneutron/powerkit-go/pkg/powerkit"
+
+	rpc "powergrid/internal/rpc"
+)
+
+const (
+	// maxSMCKeysPerRead bounds a single ReadSMCKeys call.
+	maxSMCKeysPerRead = 16
+	// smcKeyReadInterval spaces ReadSMCKeys calls so a client cannot keep
+	// the SMC busy between charging-logic reads.
+	smcKeyReadInterval = time.Second
+)
+
+// ReadSMCKeys returns raw values for the requested SMC keys, for diagnosing
+// model-specific keys the daemon does not model. It only reads, is limited
+// to root by the RPC allowlist, and stays off unless SMCKeyReadsEnabled is set.
+func (s *Daemon) ReadSMCKeys(_ context.Context, req *rpc.SMCKeysRequest) (*rpc.SMCKeysResponse, error) {
+	if !s.smcKeyReadsEnabled {
+		return nil, status.Error(codes.FailedPrecondition, "raw SMC reads are disabled; set SMCKeyReadsEnabled in the system preferences")
+	}
+	keys := req.GetKeys()
+	if len(keys) == 0 || len(keys) > maxSMCKeysPerRead {
+		return nil, outOfRangeError("keys", len(keys), 1, maxSMCKeysPerRead)
+	}
+	for _, key := range keys {
+		if !validSMCKey(key) {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid SMC key %q: want four printable ASCII characters", key)
+		}
+	}
+
+	s.mu.Lock()
+	now := clock.Now()
+	if !s.smcKeyReadAt.IsZero() && now.Sub(s.smcKeyReadAt) < smcKeyReadInterval {
+		s.mu.Unlock()
+		return nil, status.Errorf(codes.ResourceExhausted, "raw SMC reads are limited to one per %s", smcKeyReadInterval)
+	}
+	s.smcKeyReadAt = now
+	s.mu.Unlock()
+
+	var values map[string]powerkit.RawSMCValue
+	if err := callWithTimeout(opTimeout, func() error {
+		var err error
+		values, err = getRawSMCValuesFn(keys)
+		return err
+	}); err != nil {
+		return nil, smcError("read SMC keys", err)
+	}
+
+	resp := &rpc.SMCKeysResponse{}
+	for _, key := range keys {
+		v, ok := values[key]
+		if !ok {
+			continue
+		}
+		resp.Values = append(resp.Values, &rpc.SMCKeyValue{
+			Key:      key,
+			DataType: v.DataType,
+			DataSize: int32(v.DataSize),
+			Data:     v.Data,
+		})
+	}
+	return resp, nil
+}
+
+// validSMCKey accepts the four printable ASCII characters SMC keys are made of.
+func validSMCKey(key string) bool {
+	if len(key) != 4 {
+		return false
+	}
+	for i := 0; i < len(key); i++ {
+		if key[i] < 0x20 || key[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
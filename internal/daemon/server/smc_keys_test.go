@@ -0,0 +1,108 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/peterneutron/powerkit-go/pkg/powerkit"
+
+	rpc "powergrid/internal/rpc"
+)
+
+func TestReadSMCKeysReturnsValuesInRequestOrder(t *testing.T) {
+	resetServerTestGlobals(t)
+
+	clock = newFakeClock(time.Date(2026, 4, 20, 12, 0, 0, 0, time.UTC))
+	var asked []string
+	getRawSMCValuesFn = func(keys []string) (map[string]powerkit.RawSMCValue, error) {
+		asked = keys
+		return map[string]powerkit.RawSMCValue{
+			"CHTE": {DataType: "ui32", DataSize: 4, Data: []byte{0, 0, 0, 1}},
+			"AC-W": {DataType: "si8 ", DataSize: 1, Data: []byte{2}},
+		}, nil
+	}
+
+	d := &Daemon{smcKeyReadsEnabled: true}
+	resp, err := d.ReadSMCKeys(context.Background(), &rpc.SMCKeysRequest{Keys: []string{"AC-W", "ZZZZ", "CHTE"}})
+	if err != nil {
+		t.Fatalf("ReadSMCKeys returned error: %v", err)
+	}
+	if len(asked) != 3 {
+		t.Fatalf("expected all keys passed to the SMC, got %v", asked)
+	}
+	values := resp.GetValues()
+	if len(values) != 2 || values[0].GetKey() != "AC-W" || values[1].GetKey() != "CHTE" {
+		t.Fatalf("unexpected values: %+v", values)
+	}
+	if values[1].GetDataType() != "ui32" || values[1].GetDataSize() != 4 || len(values[1].GetData()) != 4 {
+		t.Fatalf("unexpected CHTE value: %+v", values[1])
+	}
+}
+
+func TestReadSMCKeysRejectsBadRequests(t *testing.T) {
+	resetServerTestGlobals(t)
+
+	clock = newFakeClock(time.Date(2026, 4, 20, 12, 0, 0, 0, time.UTC))
+	calls := 0
+	getRawSMCValuesFn = func([]string) (map[string]powerkit.RawSMCValue, error) {
+		calls++
+		return nil, nil
+	}
+
+	tooMany := make([]string, maxSMCKeysPerRead+1)
+	for i := range tooMany {
+		tooMany[i] = "CHTE"
+	}
+	cases := []struct {
+		name    string
+		enabled bool
+		keys    []string
+		code    codes.Code
+	}{
+		{"disabled", false, []string{"CHTE"}, codes.FailedPrecondition},
+		{"no keys", true, nil, codes.InvalidArgument},
+		{"too many keys", true, tooMany, codes.InvalidArgument},
+		{"short key", true, []string{"CHT"}, codes.InvalidArgument},
+		{"control character", true, []string{"CH\x00E"}, codes.InvalidArgument},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := &Daemon{smcKeyReadsEnabled: tc.enabled}
+			_, err := d.ReadSMCKeys(context.Background(), &rpc.SMCKeysRequest{Keys: tc.keys})
+			if status.Code(err) != tc.code {
+				t.Fatalf("expected %v, got %v", tc.code, err)
+			}
+		})
+	}
+	if calls != 0 {
+		t.Fatalf("rejected requests must not reach the SMC, got %d reads", calls)
+	}
+}
+
+func TestReadSMCKeysIsRateLimited(t *testing.T) {
+	resetServerTestGlobals(t)
+
+	now := time.Date(2026, 4, 20, 12, 0, 0, 0, time.UTC)
+	clk := newFakeClock(now)
+	clock = clk
+	getRawSMCValuesFn = func([]string) (map[string]powerkit.RawSMCValue, error) {
+		return map[string]powerkit.RawSMCValue{}, nil
+	}
+
+	d := &Daemon{smcKeyReadsEnabled: true}
+	req := &rpc.SMCKeysRequest{Keys: []string{"CHTE"}}
+	if _, err := d.ReadSMCKeys(context.Background(), req); err != nil {
+		t.Fatalf("first read failed: %v", err)
+	}
+	if _, err := d.ReadSMCKeys(context.Background(), req); status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("expected ResourceExhausted for an immediate second read, got %v", err)
+	}
+	clk.Set(now.Add(smcKeyReadInterval))
+	if _, err := d.ReadSMCKeys(context.Background(), req); err != nil {
+		t.Fatalf("read after the interval failed: %v", err)
+	}
+}
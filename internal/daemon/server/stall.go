@@ -0,0 +1,45 @@
+package server
+
+import (
+	"powergrid/internal/daemon/engine"
+)
+
+// chargeHistoryCap bounds the charge samples kept for stall detection in case
+// battery events arrive far faster than expected.
+const chargeHistoryCap = 256
+
+// noteChargeSampleLocked adds a reading to the charge history, keeps only
+// what the stall window needs, and flags a stall when charge has not risen
+// although charging was expected throughout the window.
+func (s *Daemon) noteChargeSampleLocked(sample engine.ChargeSample) {
+	s.chargeHistory = append(s.chargeHistory, sample)
+
+	// Keep the newest sample at or before the window start so the window is
+	// always covered once enough time has passed.
+	cutoff := sample.At.Add(-engine.StallWindow)
+	drop := 0
+	for drop+1 < len(s.chargeHistory) && !s.chargeHistory[drop+1].At.After(cutoff) {
+		drop++
+	}
+	if n := len(s.chargeHistory) - drop; n > chargeHistoryCap {
+		drop += n - chargeHistoryCap
+	}
+	s.chargeHistory = s.chargeHistory[drop:]
+
+	stalled := engine.IsChargingStalled(s.chargeHistory, engine.StallWindow)
+	if stalled && !s.chargingStalled {
+		logger.Error("Charging is enabled but charge has stayed at %d%% for %s; check the adapter, cable and port.", sample.Charge, engine.StallWindow)
+		s.recordEvent("charging_stalled", map[string]any{"charge": sample.Charge})
+	}
+	if !stalled && s.chargingStalled {
+		logger.Default("Charging stall cleared.")
+	}
+	s.chargingStalled = stalled
+}
+
+// resetChargeHistoryLocked forgets the charge trend when readings stop being
+// comparable, e.g. across sleep or while the daemon is not managing charging.
+func (s *Daemon) resetChargeHistoryLocked() {
+	s.chargeHistory = nil
+	s.chargingStalled = false
+}
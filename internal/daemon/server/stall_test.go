@@ -0,0 +1,31 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"powergrid/internal/daemon/engine"
+)
+
+func TestNoteChargeSampleFlagsStallAndPrunes(t *testing.T) {
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	d := &Daemon{}
+	for minute := 0; minute <= 15; minute++ {
+		d.noteChargeSampleLocked(engine.ChargeSample{At: start.Add(time.Duration(minute) * time.Minute), Charge: 50, Expecting: true})
+		if want := minute >= 10; d.chargingStalled != want {
+			t.Fatalf("unexpected stall at minute %d: got=%t want=%t", minute, d.chargingStalled, want)
+		}
+	}
+	if got, want := len(d.chargeHistory), 11; got != want {
+		t.Fatalf("unexpected history length: got=%d want=%d", got, want)
+	}
+
+	d.noteChargeSampleLocked(engine.ChargeSample{At: start.Add(16 * time.Minute), Charge: 51, Expecting: true})
+	if d.chargingStalled {
+		t.Fatal("expected rising charge to clear the stall")
+	}
+	d.resetChargeHistoryLocked()
+	if d.chargeHistory != nil || d.chargingStalled {
+		t.Fatal("expected reset to clear history and stall")
+	}
+}
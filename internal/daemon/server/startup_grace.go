@@ -0,0 +1,25 @@
+package server
+
+import "time"
+
+// startStartupGrace holds charging changes for grace after launch, since SMC
+// and IOKit readings can be unstable right after boot, then runs the charging
+// logic so the first decision uses settled readings.
+func (s *Daemon) startStartupGrace(grace time.Duration) {
+	if grace <= 0 {
+		return
+	}
+	s.mu.Lock()
+	s.warmUpUntil = clock.Now().Add(grace)
+	s.mu.Unlock()
+	logger.Default("Holding charging changes for %s while readings settle after start.", grace)
+	clock.AfterFunc(grace, func() {
+		logger.Default("Startup grace period over; applying charging changes.")
+		s.runChargingLogic(nil)
+	})
+}
+
+// warmingUpLocked reports whether now falls in the startup grace period.
+func (s *Daemon) warmingUpLocked(now time.Time) bool {
+	return !s.warmUpUntil.IsZero() && now.Before(s.warmUpUntil)
+}
@@ -0,0 +1,49 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/peterneutron/powerkit-go/pkg/powerkit"
+
+	rpc "powergrid/internal/rpc"
+)
+
+func TestStartupGraceHoldsChargingChanges(t *testing.T) {
+	resetServerTestGlobals(t)
+
+	fc := newFakeClock(time.Date(2026, 4, 20, 8, 0, 0, 0, time.UTC))
+	clock = fc
+	var actions []powerkit.ChargingAction
+	setChargingStateFn = func(action powerkit.ChargingAction) error {
+		actions = append(actions, action)
+		return nil
+	}
+	getSystemInfoFn = func(...powerkit.FetchOptions) (*powerkit.SystemInfo, error) {
+		return testSystemInfo(85, true), nil
+	}
+
+	d := &Daemon{currentLimit: 80}
+	d.startStartupGrace(10 * time.Second)
+	d.runChargingLogicLocked(testSystemInfo(85, true))
+	if len(actions) != 0 {
+		t.Fatalf("expected no charging writes during the grace period, got %v", actions)
+	}
+	resp, err := d.GetStatus(context.Background(), &rpc.Empty{})
+	if err != nil {
+		t.Fatalf("GetStatus returned error: %v", err)
+	}
+	if !resp.GetWarmingUp() {
+		t.Fatal("expected status to report warming up")
+	}
+
+	fc.Advance(10 * time.Second)
+	if len(actions) != 1 || actions[0] != powerkit.ChargingActionOff {
+		t.Fatalf("expected the limit enforced once the grace period ended, got %v", actions)
+	}
+	resp, _ = d.GetStatus(context.Background(), &rpc.Empty{})
+	if resp.GetWarmingUp() {
+		t.Fatal("expected warming up to clear after the grace period")
+	}
+}
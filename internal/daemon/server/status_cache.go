@@ -0,0 +1,40 @@
+package server
+
+import (
+	"time"
+
+	"github.com/peterneutron/powerkit-go/pkg/powerkit"
+)
+
+// statusCacheMaxAge bounds how old a full status read may be for interactive
+// changes to be evaluated against it instead of reading the SMC again.
+const statusCacheMaxAge = 2 * time.Second
+
+// cachedSystemInfoLocked returns a copy of the last full status read, or nil
+// when there is none or it is older than statusCacheMaxAge. The copy keeps
+// the logic run from mutating the cached snapshot.
+func (s *Daemon) cachedSystemInfoLocked(now time.Time) *powerkit.SystemInfo {
+	if s.lastIOKitStatus == nil || s.lastSMCStatus == nil || s.statusFetchedAt.IsZero() {
+		return nil
+	}
+	if now.Sub(s.statusFetchedAt) >= statusCacheMaxAge {
+		return nil
+	}
+	iokit := *s.lastIOKitStatus
+	smc := *s.lastSMCStatus
+	return &powerkit.SystemInfo{IOKit: &iokit, SMC: &smc}
+}
+
+// runChargingLogicCachedLocked applies a settings change right away using a
+// fresh-enough cached status, then queues a full read so anything the cache
+// missed (including our own SMC writes since it was taken) is corrected on
+// the next run. Without a usable cache it falls back to a full read.
+func (s *Daemon) runChargingLogicCachedLocked() {
+	info := s.cachedSystemInfoLocked(clock.Now())
+	if info == nil {
+		s.runChargingLogicLocked(nil)
+		return
+	}
+	s.runChargingLogicFromLocked(info, false)
+	go s.runChargingLogic(nil)
+}
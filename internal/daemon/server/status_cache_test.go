@@ -0,0 +1,107 @@
+package server
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/peterneutron/powerkit-go/pkg/powerkit"
+)
+
+func TestRunChargingLogicCachedUsesFreshStatus(t *testing.T) {
+	resetServerTestGlobals(t)
+
+	now := time.Date(2026, 4, 20, 10, 0, 0, 0, time.UTC)
+	clk := newFakeClock(now)
+	clock = clk
+
+	// The background refresh sees the write made from the cached run.
+	fetched := make(chan struct{}, 4)
+	var fetches atomic.Int32
+	getSystemInfoFn = func(...powerkit.FetchOptions) (*powerkit.SystemInfo, error) {
+		defer func() { fetched <- struct{}{} }()
+		return testSystemInfo(75, fetches.Add(1) == 1), nil
+	}
+	var actions []powerkit.ChargingAction
+	setChargingStateFn = func(action powerkit.ChargingAction) error {
+		actions = append(actions, action)
+		return nil
+	}
+
+	d := &Daemon{currentLimit: 80}
+	d.mu.Lock()
+	d.runChargingLogicLocked(nil)
+	d.mu.Unlock()
+	<-fetched
+
+	now = now.Add(time.Second)
+	clk.Set(now)
+	d.mu.Lock()
+	d.currentLimit = 70
+	d.runChargingLogicCachedLocked()
+	d.mu.Unlock()
+	if len(actions) != 1 || actions[0] != powerkit.ChargingActionOff {
+		t.Fatalf("expected limit applied from cache, got %v", actions)
+	}
+
+	select {
+	case <-fetched:
+	case <-time.After(time.Second):
+		t.Fatal("expected a background refresh after the cached run")
+	}
+	// The refresh holds the lock for its whole run.
+	d.mu.Lock()
+	d.mu.Unlock()
+}
+
+func TestCachedSystemInfoExpires(t *testing.T) {
+	now := time.Date(2026, 4, 20, 10, 0, 0, 0, time.UTC)
+	info := testSystemInfo(75, true)
+	d := &Daemon{lastIOKitStatus: info.IOKit, lastSMCStatus: info.SMC, statusFetchedAt: now}
+
+	cached := d.cachedSystemInfoLocked(now.Add(time.Second))
+	if cached == nil {
+		t.Fatal("expected fresh cache to be used")
+	}
+	cached.SMC.State.IsChargingEnabled = false
+	if !d.lastSMCStatus.State.IsChargingEnabled {
+		t.Fatal("cached copy must not alias the stored snapshot")
+	}
+	if d.cachedSystemInfoLocked(now.Add(statusCacheMaxAge)) != nil {
+		t.Fatal("expected stale cache to be ignored")
+	}
+}
+
+func TestRunChargingLogicCachedSkipsConflictCheck(t *testing.T) {
+	resetServerTestGlobals(t)
+
+	now := time.Date(2026, 4, 20, 10, 0, 0, 0, time.UTC)
+	clock = newFakeClock(now)
+	fetched := make(chan struct{}, 4)
+	getSystemInfoFn = func(...powerkit.FetchOptions) (*powerkit.SystemInfo, error) {
+		defer func() { fetched <- struct{}{} }()
+		return testSystemInfo(85, false), nil
+	}
+	setChargingStateFn = func(powerkit.ChargingAction) error { return nil }
+
+	// The snapshot predates the daemon's own disable.
+	stale := testSystemInfo(85, true)
+	d := &Daemon{currentLimit: 80, lastIOKitStatus: stale.IOKit, lastSMCStatus: stale.SMC, statusFetchedAt: now}
+	d.noteChargingWrite(powerkit.ChargingActionOff)
+
+	d.mu.Lock()
+	d.runChargingLogicCachedLocked()
+	mismatches := d.chargeMismatches
+	d.mu.Unlock()
+	if mismatches != 0 {
+		t.Fatalf("expected a cached snapshot not to count as a mismatch, got %d", mismatches)
+	}
+
+	select {
+	case <-fetched:
+	case <-time.After(time.Second):
+		t.Fatal("expected a background refresh after the cached run")
+	}
+	d.mu.Lock()
+	d.mu.Unlock()
+}
@@ -0,0 +1,80 @@
+package server
+
+import (
+	"time"
+)
+
+// maxSuspendMinutes caps a single management suspension.
+const maxSuspendMinutes = 24 * 60
+
+// applySuspendManagement hands charging, the adapter and the LED back to
+// macOS for the given number of minutes, e.g. during a battery benchmark, and
+// resumes on its own when the time is up even if no client is connected.
+// Zero minutes resumes at once.
+func (s *Daemon) applySuspendManagement(minutes int32) error {
+	if minutes < 0 || minutes > maxSuspendMinutes {
+		return outOfRangeError("suspend_minutes", int(minutes), 0, maxSuspendMinutes)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.stopSuspendTimerLocked()
+	if minutes == 0 {
+		if !s.suspendUntil.IsZero() {
+			s.suspendUntil = time.Time{}
+			logger.Default("Management suspension ended early; resuming limit enforcement.")
+		}
+		s.runChargingLogicLocked(nil)
+		return nil
+	}
+
+	d := time.Duration(minutes) * time.Minute
+	s.suspendUntil = clock.Now().Add(d)
+	s.suspendTimer = clock.AfterFunc(d, s.resumeSuspendedManagement)
+	logger.Default("Management suspended for %d minutes (until %s); charging, adapter and LED are left to macOS.", minutes, s.suspendUntil.Format(time.RFC3339))
+
+	s.runChargingLogicLocked(nil)
+	return nil
+}
+
+// resumeSuspendedManagement re-evaluates charging when a suspension's timer
+// fires; managementSuspendedLocked ends the suspension itself.
+func (s *Daemon) resumeSuspendedManagement() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.suspendTimer = nil
+	s.runChargingLogicLocked(nil)
+}
+
+// managementSuspendedLocked reports whether a timed suspension is in effect,
+// ending it once it expires.
+func (s *Daemon) managementSuspendedLocked(now time.Time) bool {
+	if s.suspendUntil.IsZero() {
+		return false
+	}
+	if now.Before(s.suspendUntil) {
+		return true
+	}
+	logger.Default("Management suspension expired; resuming limit enforcement.")
+	s.recordEvent("management_resumed", nil)
+	s.suspendUntil = time.Time{}
+	s.stopSuspendTimerLocked()
+	return false
+}
+
+func (s *Daemon) suspendRemainingLocked(now time.Time) time.Duration {
+	if s.suspendUntil.IsZero() || !now.Before(s.suspendUntil) {
+		return 0
+	}
+	return s.suspendUntil.Sub(now)
+}
+
+// stopSuspendTimerLocked cancels a pending automatic resume.
+func (s *Daemon) stopSuspendTimerLocked() {
+	if s.suspendTimer != nil {
+		s.suspendTimer.Stop()
+		s.suspendTimer = nil
+	}
+}
@@ -0,0 +1,58 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/peterneutron/powerkit-go/pkg/powerkit"
+)
+
+func TestSuspendManagementResumesOnItsOwn(t *testing.T) {
+	resetServerTestGlobals(t)
+
+	now := time.Date(2026, 5, 6, 14, 0, 0, 0, time.UTC)
+	clk := newFakeClock(now)
+	clock = clk
+
+	var actions []powerkit.ChargingAction
+	setChargingStateFn = func(action powerkit.ChargingAction) error {
+		actions = append(actions, action)
+		return nil
+	}
+	current := connectedInfo(85, false, "desk", 96)
+	getSystemInfoFn = func(...powerkit.FetchOptions) (*powerkit.SystemInfo, error) {
+		return current, nil
+	}
+
+	d := &Daemon{currentLimit: 80}
+	if err := d.applySuspendManagement(60); err != nil {
+		t.Fatalf("applySuspendManagement returned error: %v", err)
+	}
+	if len(actions) != 1 || actions[0] != powerkit.ChargingActionOn {
+		t.Fatalf("expected charging handed back to macOS, got %v", actions)
+	}
+	d.mu.Lock()
+	remaining := d.suspendRemainingLocked(now)
+	d.mu.Unlock()
+	if remaining != time.Hour {
+		t.Fatalf("unexpected suspension remaining: got=%v want=%v", remaining, time.Hour)
+	}
+
+	current = connectedInfo(90, true, "desk", 96)
+	clk.Advance(time.Hour)
+	if len(actions) != 2 || actions[1] != powerkit.ChargingActionOff {
+		t.Fatalf("expected the limit enforced once the suspension expired, got %v", actions)
+	}
+	if !d.suspendUntil.IsZero() || d.suspendTimer != nil {
+		t.Fatalf("expected suspension cleared, got until=%v timer=%v", d.suspendUntil, d.suspendTimer)
+	}
+}
+
+func TestSuspendManagementRejectsOutOfRange(t *testing.T) {
+	resetServerTestGlobals(t)
+
+	d := &Daemon{currentLimit: 80}
+	if err := d.applySuspendManagement(maxSuspendMinutes + 1); err == nil {
+		t.Fatal("expected an out-of-range suspension to be rejected")
+	}
+}
@@ -0,0 +1,156 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/status"
+
+	"powergrid/internal/daemon/ipc"
+	rpc "powergrid/internal/rpc"
+)
+
+const (
+	textIdleTimeout = time.Minute
+	textHelp        = "ok commands: get-status | set-limit <min-100> | auth <token> | help | quit"
+)
+
+// textControl answers one command per line with a single "ok ..." or
+// "error ..." line. Commands go through the same handlers, allowlist and
+// mutation token as their gRPC counterparts.
+type textControl struct {
+	d             *Daemon
+	activeUID     ipc.ActiveUIDProvider
+	token         string
+	tokenRequired bool
+}
+
+// textSession is the per-connection state: the peer uid and any token the
+// caller presented with auth.
+type textSession struct {
+	uid   uint32
+	token string
+}
+
+func (t *textControl) serve(ctx context.Context, lis net.Listener) {
+	go func() {
+		<-ctx.Done()
+		_ = lis.Close()
+	}()
+	for {
+		conn, err := lis.Accept()
+		if err != nil {
+			if ctx.Err() == nil && !errors.Is(err, net.ErrClosed) {
+				logger.Error("Text control accept failed: %v", err)
+				continue
+			}
+			return
+		}
+		go t.handleConn(conn)
+	}
+}
+
+func (t *textControl) handleConn(conn net.Conn) {
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	addr, ok := conn.RemoteAddr().(ipc.UIDAddr)
+	if !ok {
+		_, _ = fmt.Fprintln(conn, "error peer credentials unavailable")
+		return
+	}
+	sess := &textSession{uid: addr.UID()}
+
+	scanner := bufio.NewScanner(conn)
+	for {
+		_ = conn.SetReadDeadline(time.Now().Add(textIdleTimeout))
+		if !scanner.Scan() {
+			return
+		}
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if line == "quit" {
+			return
+		}
+		if _, err := fmt.Fprintln(conn, t.handleLine(sess, line)); err != nil {
+			return
+		}
+	}
+}
+
+func (t *textControl) handleLine(sess *textSession, line string) string {
+	fields := strings.Fields(line)
+	switch {
+	case fields[0] == "help":
+		return textHelp
+	case fields[0] == "auth" && len(fields) == 2:
+		sess.token = fields[1]
+		return "ok"
+	case fields[0] == "get-status" && len(fields) == 1:
+		if !ipc.Authorized(sess.uid, "/rpc.PowerGrid/GetStatus", t.activeUID) {
+			return "error permission denied"
+		}
+		resp, err := t.d.GetStatus(context.Background(), &rpc.Empty{})
+		if err != nil {
+			return textError(err)
+		}
+		return formatTextStatus(resp)
+	case fields[0] == "set-limit" && len(fields) == 2:
+		if !ipc.Authorized(sess.uid, "/rpc.PowerGrid/ApplyMutation", t.activeUID) {
+			return "error permission denied"
+		}
+		if t.tokenRequired && !ipc.ValidToken(sess.token, t.token) {
+			return "error mutation token required; send auth <token> first"
+		}
+		limit, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return fmt.Sprintf("error invalid limit %q", fields[1])
+		}
+		if _, err := t.d.ApplyMutation(context.Background(), &rpc.MutationRequest{
+			Operation: rpc.MutationOperation_SET_CHARGE_LIMIT,
+			Limit:     int32(limit),
+		}); err != nil {
+			return textError(err)
+		}
+		return "ok"
+	default:
+		return "error unknown command; try help"
+	}
+}
+
+func textError(err error) string {
+	return "error " + status.Convert(err).Message()
+}
+
+func formatTextStatus(resp *rpc.StatusResponse) string {
+	paused := "none"
+	if r := resp.GetChargingPauseReason(); r != rpc.ChargingPauseReason_CHARGING_PAUSE_REASON_NONE {
+		paused = strings.ToLower(strings.TrimPrefix(r.String(), "PAUSE_"))
+	}
+	return fmt.Sprintf("ok charge=%d limit=%d effective_limit=%d charging=%t connected=%t paused=%s management=%t",
+		resp.GetCurrentCharge(),
+		resp.GetChargeLimit(),
+		resp.GetEffectiveChargeLimit(),
+		resp.GetIsCharging(),
+		resp.GetIsConnected(),
+		paused,
+		resp.GetManagementEnabled(),
+	)
+}
+
+// controlSockets lists the sockets whose group follows the console user.
+func (s *Daemon) controlSockets() []string {
+	if s.textControlEnabled {
+		return []string{socketPath, textSocketPath}
+	}
+	return []string{socketPath}
+}
@@ -0,0 +1,48 @@
+package server
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/peterneutron/powerkit-go/pkg/powerkit"
+)
+
+func TestTextControlHandleLine(t *testing.T) {
+	resetServerTestGlobals(t)
+	setChargingStateFn = func(powerkit.ChargingAction) error { return nil }
+	getSystemInfoFn = func(...powerkit.FetchOptions) (*powerkit.SystemInfo, error) {
+		return testSystemInfo(70, true), nil
+	}
+
+	d := &Daemon{currentLimit: 80}
+	d.updateCachedStatusLocked(testSystemInfo(70, true))
+	noUser := func() (uint32, bool) { return 0, false }
+
+	tests := []struct {
+		name    string
+		control *textControl
+		uid     uint32
+		lines   []string
+		want    string
+	}{
+		{name: "status as root", control: &textControl{d: d, activeUID: noUser}, lines: []string{"get-status"}, want: "ok charge=70 limit=80"},
+		{name: "other user denied", control: &textControl{d: d, activeUID: noUser}, uid: 501, lines: []string{"get-status"}, want: "error permission denied"},
+		{name: "limit out of range", control: &textControl{d: d, activeUID: noUser}, lines: []string{"set-limit 20"}, want: "error limit out of range"},
+		{name: "token required", control: &textControl{d: d, activeUID: noUser, token: "s3cret", tokenRequired: true}, lines: []string{"set-limit 90"}, want: "error mutation token required"},
+		{name: "token presented", control: &textControl{d: d, activeUID: noUser, token: "s3cret", tokenRequired: true}, lines: []string{"auth s3cret", "set-limit 20"}, want: "error limit out of range"},
+		{name: "unknown command", control: &textControl{d: d, activeUID: noUser}, lines: []string{"reboot"}, want: "error unknown command"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			sess := &textSession{uid: tc.uid}
+			var got string
+			for _, line := range tc.lines {
+				got = tc.control.handleLine(sess, line)
+			}
+			if !strings.HasPrefix(got, tc.want) {
+				t.Fatalf("unexpected reply: got=%q want prefix %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCallWithContextTimeoutCancelsOperation(t *testing.T) {
+	cancelled := make(chan struct{})
+	err := callWithContextTimeout(10*time.Millisecond, func(ctx context.Context) error {
+		<-ctx.Done()
+		close(cancelled)
+		return ctx.Err()
+	})
+	if err == nil {
+		t.Fatal("expected timeout error")
+	}
+
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Fatal("expected operation context to be cancelled")
+	}
+}
+
+func TestCallWithContextTimeoutReturnsResult(t *testing.T) {
+	want := errors.New("pmset failed")
+	if err := callWithContextTimeout(time.Second, func(context.Context) error { return want }); !errors.Is(err, want) {
+		t.Fatalf("unexpected error: got=%v want=%v", err, want)
+	}
+}
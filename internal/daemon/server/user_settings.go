@@ -0,0 +1,40 @@
+package server
+
+import (
+	"context"
+	"os"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	cfg "powergrid/internal/config"
+	rpc "powergrid/internal/rpc"
+)
+
+// ListUserSettings reports the saved preferences of every local account that
+// has a PowerGrid preferences file, so an administrator can audit them
+// without switching users. It reads each user's plist directly and is limited
+// to root by the RPC allowlist.
+func (s *Daemon) ListUserSettings(_ context.Context, _ *rpc.Empty) (*rpc.UserSettingsResponse, error) {
+	users, err := localUsersFn()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "list local users: %v", err)
+	}
+	systemLimit := cfg.ReadSystemChargeLimit()
+	resp := &rpc.UserSettingsResponse{}
+	for _, u := range users {
+		if _, err := os.Stat(cfg.UserPlistPath(u.HomeDir)); err != nil {
+			continue
+		}
+		limit := cfg.ReadUserChargeLimit(u.HomeDir)
+		resp.Users = append(resp.Users, &rpc.UserSettings{
+			Username:                   u.Username,
+			Uid:                        u.UID,
+			ChargeLimit:                int32(limit),
+			EffectiveChargeLimit:       int32(cfg.EffectiveChargeLimit(limit, systemLimit, defaultChargeLimit)),
+			MagsafeLedMode:             ledModeToProto(cfg.ReadUserLEDMode(u.HomeDir)),
+			DisableChargingBeforeSleep: cfg.ReadUserDisableChargingBeforeSleep(u.HomeDir),
+		})
+	}
+	return resp, nil
+}
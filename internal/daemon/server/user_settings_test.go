@@ -0,0 +1,47 @@
+package server
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	cfg "powergrid/internal/config"
+	consoleuser "powergrid/internal/consoleuser"
+	rpc "powergrid/internal/rpc"
+)
+
+func TestListUserSettingsReportsUsersWithPreferences(t *testing.T) {
+	resetServerTestGlobals(t)
+
+	uid, gid := uint32(os.Getuid()), uint32(os.Getgid())
+	alice := consoleuser.ConsoleUser{Username: "alice", UID: 501, GID: gid, HomeDir: t.TempDir()}
+	bob := consoleuser.ConsoleUser{Username: "bob", UID: 502, GID: gid, HomeDir: t.TempDir()}
+	if err := cfg.WriteUserChargeLimit(alice.HomeDir, uid, gid, 70); err != nil {
+		t.Fatalf("WriteUserChargeLimit: %v", err)
+	}
+	if err := cfg.WriteUserLEDMode(alice.HomeDir, uid, gid, cfg.LEDModeOff); err != nil {
+		t.Fatalf("WriteUserLEDMode: %v", err)
+	}
+	localUsersFn = func() ([]consoleuser.ConsoleUser, error) {
+		return []consoleuser.ConsoleUser{alice, bob}, nil
+	}
+
+	d := &Daemon{}
+	resp, err := d.ListUserSettings(context.Background(), &rpc.Empty{})
+	if err != nil {
+		t.Fatalf("ListUserSettings returned error: %v", err)
+	}
+	if len(resp.GetUsers()) != 1 {
+		t.Fatalf("expected only the user with a preferences file, got %v", resp.GetUsers())
+	}
+	got := resp.GetUsers()[0]
+	if got.GetUsername() != "alice" || got.GetUid() != 501 {
+		t.Fatalf("unexpected user %s/%d", got.GetUsername(), got.GetUid())
+	}
+	if got.GetChargeLimit() != 70 || got.GetEffectiveChargeLimit() != 70 {
+		t.Fatalf("expected limit 70, got %d (effective %d)", got.GetChargeLimit(), got.GetEffectiveChargeLimit())
+	}
+	if got.GetMagsafeLedMode() != rpc.MagsafeLedMode_MAGSAFE_LED_MODE_OFF {
+		t.Fatalf("expected LED mode off, got %v", got.GetMagsafeLedMode())
+	}
+}
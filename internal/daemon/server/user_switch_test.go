@@ -0,0 +1,68 @@
+package server
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/peterneutron/powerkit-go/pkg/powerkit"
+
+	consoleuser "powergrid/internal/consoleuser"
+	"powergrid/internal/daemon/session"
+)
+
+// A SetChargeLimit that lands while a switch is in flight must either be
+// read back by the switch or be serialized before it; reading the profile
+// under s.mu guarantees the former.
+func TestEnterConsoleUserReadsProfileUnderLock(t *testing.T) {
+	resetServerTestGlobals(t)
+
+	allowAllSleepFn = func() {}
+	setAdapterStateFn = func(powerkit.AdapterAction) error { return nil }
+	ran := make(chan struct{}, 1)
+	getSystemInfoFn = func(...powerkit.FetchOptions) (*powerkit.SystemInfo, error) {
+		ran <- struct{}{}
+		return nil, errors.New("no hardware in tests")
+	}
+
+	d := &Daemon{currentLimit: 80}
+	profileForUserFn = func(*consoleuser.ConsoleUser, int) session.Profile {
+		if d.mu.TryLock() {
+			d.mu.Unlock()
+			t.Error("profile read without holding s.mu")
+		}
+		return session.Profile{Limit: 70}
+	}
+
+	d.enterConsoleUser(&consoleuser.ConsoleUser{Username: "alice", UID: 501, HomeDir: t.TempDir()})
+	select {
+	case <-ran:
+	case <-time.After(time.Second):
+		t.Fatal("expected a charging-logic run after the switch")
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.currentLimit != 70 {
+		t.Fatalf("unexpected limit after switch: got=%d want=70", d.currentLimit)
+	}
+}
+
+func TestEnterConsoleUserKeepsStateForActiveUser(t *testing.T) {
+	resetServerTestGlobals(t)
+
+	profileForUserFn = func(*consoleuser.ConsoleUser, int) session.Profile {
+		t.Fatal("re-entering the active user must not reload its profile")
+		return session.Profile{}
+	}
+
+	home := t.TempDir()
+	d := &Daemon{
+		currentConsoleUser: &consoleuser.ConsoleUser{Username: "alice", UID: 501, HomeDir: home},
+		currentLimit:       65, // as just persisted by SetChargeLimit
+	}
+	d.enterConsoleUser(&consoleuser.ConsoleUser{Username: "alice", UID: 501, HomeDir: home})
+
+	if d.currentLimit != 65 {
+		t.Fatalf("just-set limit was clobbered: got=%d want=65", d.currentLimit)
+	}
+}
@@ -0,0 +1,71 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/peterneutron/powerkit-go/pkg/powerkit"
+)
+
+const (
+	watchdogInterval = 15 * time.Second
+	// watchdogTimeout must comfortably exceed recomputeInterval so a quiet
+	// system with no battery events is not mistaken for a stalled daemon.
+	watchdogTimeout = 3 * recomputeInterval
+)
+
+// markChargingLogicRun records a completed charging-logic pass. It is safe to
+// call without holding s.mu so the watchdog never contends with a stuck holder.
+func (s *Daemon) markChargingLogicRun() {
+	s.lastLogicRunNanos.Store(clock.Now().UnixNano())
+	if s.watchdogTripped.Swap(false) {
+		logger.Default("Charging logic recovered; watchdog re-armed.")
+	}
+}
+
+func (s *Daemon) startWatchdog(ctx context.Context) {
+	s.markChargingLogicRun()
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		ticker := time.NewTicker(watchdogInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.checkWatchdog(clock.Now())
+			}
+		}
+	}()
+}
+
+// checkWatchdog force-enables charging and the adapter once if the charging
+// logic has not completed within watchdogTimeout. It returns true when it fired.
+func (s *Daemon) checkWatchdog(now time.Time) bool {
+	last := time.Unix(0, s.lastLogicRunNanos.Load())
+	if now.Sub(last) < watchdogTimeout {
+		return false
+	}
+	if s.safeMode.Load() || s.watchdogTripped.Swap(true) {
+		return false
+	}
+
+	logger.Fault("Charging logic has not completed since %s; forcing charging and adapter on as a safety net.", last.Format(time.RFC3339))
+	s.recordEvent("watchdog_tripped", map[string]any{"last_run": last.Format(time.RFC3339)})
+	if err := callWithTimeout(opTimeout, func() error {
+		return setChargingStateFn(powerkit.ChargingActionOn)
+	}); err != nil {
+		logger.Error("Watchdog failed to enable charging: %v", err)
+	} else {
+		s.noteChargingWrite(powerkit.ChargingActionOn)
+	}
+	if err := callWithTimeout(opTimeout, func() error {
+		return setAdapterStateFn(powerkit.AdapterActionOn)
+	}); err != nil {
+		logger.Error("Watchdog failed to enable adapter: %v", err)
+	}
+	return true
+}
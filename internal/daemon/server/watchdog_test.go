@@ -0,0 +1,73 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/peterneutron/powerkit-go/pkg/powerkit"
+)
+
+func TestCheckWatchdogFiresOnceAfterTimeout(t *testing.T) {
+	resetServerTestGlobals(t)
+
+	now := time.Date(2026, 4, 20, 10, 0, 0, 0, time.UTC)
+	clk := newFakeClock(now)
+	clock = clk
+
+	var chargingActions []powerkit.ChargingAction
+	setChargingStateFn = func(action powerkit.ChargingAction) error {
+		chargingActions = append(chargingActions, action)
+		return nil
+	}
+	var adapterActions []powerkit.AdapterAction
+	setAdapterStateFn = func(action powerkit.AdapterAction) error {
+		adapterActions = append(adapterActions, action)
+		return nil
+	}
+
+	d := &Daemon{}
+	d.markChargingLogicRun()
+
+	if d.checkWatchdog(now.Add(watchdogTimeout - time.Second)) {
+		t.Fatal("expected watchdog to stay quiet before the timeout")
+	}
+	if !d.checkWatchdog(now.Add(watchdogTimeout)) {
+		t.Fatal("expected watchdog to fire at the timeout")
+	}
+	if d.checkWatchdog(now.Add(2 * watchdogTimeout)) {
+		t.Fatal("expected watchdog to fire only once until logic recovers")
+	}
+
+	if len(chargingActions) != 1 || chargingActions[0] != powerkit.ChargingActionOn {
+		t.Fatalf("expected a single charging-on write, got %v", chargingActions)
+	}
+	if len(adapterActions) != 1 || adapterActions[0] != powerkit.AdapterActionOn {
+		t.Fatalf("expected a single adapter-on write, got %v", adapterActions)
+	}
+}
+
+func TestCheckWatchdogRearmsAfterSuccessfulRun(t *testing.T) {
+	resetServerTestGlobals(t)
+
+	now := time.Date(2026, 4, 20, 10, 0, 0, 0, time.UTC)
+	clk := newFakeClock(now)
+	clock = clk
+	setChargingStateFn = func(powerkit.ChargingAction) error { return nil }
+	setAdapterStateFn = func(powerkit.AdapterAction) error { return nil }
+
+	d := &Daemon{currentLimit: 80}
+	d.markChargingLogicRun()
+	if !d.checkWatchdog(now.Add(watchdogTimeout)) {
+		t.Fatal("expected watchdog to fire")
+	}
+
+	now = now.Add(watchdogTimeout)
+	clk.Set(now)
+	d.runChargingLogicLocked(testSystemInfo(70, true))
+	if d.watchdogTripped.Load() {
+		t.Fatal("expected a successful logic run to re-arm the watchdog")
+	}
+	if d.checkWatchdog(now.Add(time.Second)) {
+		t.Fatal("expected watchdog to stay quiet right after a successful run")
+	}
+}
@@ -0,0 +1,74 @@
+package server
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/peterneutron/powerkit-go/pkg/powerkit"
+
+	cfg "powergrid/internal/config"
+	"powergrid/internal/daemon/engine"
+	rpc "powergrid/internal/rpc"
+)
+
+// healthHistoryCap keeps about a year of daily health samples.
+const healthHistoryCap = 366
+
+// noteHealthSampleLocked records battery health against cycle count once per
+// local day and persists the history so the wear trend survives restarts.
+// Health is taken from the capacities rather than the rounded percentage so
+// small daily changes still count.
+func (s *Daemon) noteHealthSampleLocked(data *powerkit.IOKitData) {
+	if data == nil || !engine.BatteryPresent(data.Battery) || data.Battery.DesignCapacity <= 0 || data.Battery.MaxCapacity <= 0 {
+		return
+	}
+	now := clock.Now()
+	if n := len(s.healthHistory); n > 0 && localDay(time.Unix(s.healthHistory[n-1].Unix, 0)) == localDay(now) {
+		return
+	}
+
+	health := float64(data.Battery.MaxCapacity) / float64(data.Battery.DesignCapacity) * 100
+	s.healthHistory = append(s.healthHistory, cfg.HealthSample{
+		Unix:   now.Unix(),
+		Cycles: data.Battery.CycleCount,
+		Health: math.Round(health*100) / 100,
+	})
+	if n := len(s.healthHistory); n > healthHistoryCap {
+		s.healthHistory = s.healthHistory[n-healthHistoryCap:]
+	}
+	if err := writeHealthHistoryFn(s.healthHistory); err != nil {
+		logger.InfoLimited("Failed to persist battery health history: %v", err)
+	}
+}
+
+// wearRateLocked estimates capacity lost per 100 cycles from the stored
+// health history.
+func (s *Daemon) wearRateLocked() (float64, bool) {
+	samples := make([]engine.HealthSample, 0, len(s.healthHistory))
+	for _, h := range s.healthHistory {
+		samples = append(samples, engine.HealthSample{At: time.Unix(h.Unix, 0), Cycles: h.Cycles, Health: h.Health})
+	}
+	return engine.WearPer100Cycles(samples)
+}
+
+// GetWearTrend returns the daily battery health history with the wear rate
+// estimated from it, so users can judge whether their charge limit is
+// preserving capacity.
+func (s *Daemon) GetWearTrend(_ context.Context, _ *rpc.Empty) (*rpc.WearTrendResponse, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	resp := &rpc.WearTrendResponse{}
+	for _, h := range s.healthHistory {
+		resp.Samples = append(resp.Samples, &rpc.HealthSample{
+			Unix:          h.Unix,
+			CycleCount:    int32(h.Cycles),
+			HealthPercent: float32(h.Health),
+		})
+	}
+	rate, ok := s.wearRateLocked()
+	resp.RateKnown = ok
+	resp.LossPerHundredCycles = float32(rate)
+	return resp, nil
+}
@@ -0,0 +1,56 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/peterneutron/powerkit-go/pkg/powerkit"
+
+	cfg "powergrid/internal/config"
+	rpc "powergrid/internal/rpc"
+)
+
+func TestHealthSampledOncePerDay(t *testing.T) {
+	resetServerTestGlobals(t)
+	fc := newFakeClock(time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC))
+	clock = fc
+	systemLocationFn = func() *time.Location { return time.UTC }
+	writes := 0
+	writeHealthHistoryFn = func([]cfg.HealthSample) error {
+		writes++
+		return nil
+	}
+
+	d := &Daemon{}
+	reading := func(cycles, maxCapacity int) *powerkit.IOKitData {
+		data := &powerkit.IOKitData{}
+		data.Battery.CurrentCharge = 80
+		data.Battery.CycleCount = cycles
+		data.Battery.MaxCapacity = maxCapacity
+		data.Battery.DesignCapacity = 5000
+		return data
+	}
+
+	d.noteHealthSampleLocked(reading(100, 4900))
+	fc.Advance(time.Hour)
+	d.noteHealthSampleLocked(reading(101, 4890))
+	if len(d.healthHistory) != 1 || writes != 1 {
+		t.Fatalf("expected one sample per day, got %d samples and %d writes", len(d.healthHistory), writes)
+	}
+	fc.Advance(24 * time.Hour)
+	d.noteHealthSampleLocked(reading(150, 4850))
+	fc.Advance(24 * time.Hour)
+	d.noteHealthSampleLocked(reading(200, 4800))
+
+	resp, err := d.GetWearTrend(context.Background(), &rpc.Empty{})
+	if err != nil {
+		t.Fatalf("GetWearTrend returned error: %v", err)
+	}
+	if len(resp.GetSamples()) != 3 || resp.GetSamples()[0].GetHealthPercent() != 98 {
+		t.Fatalf("unexpected samples: %+v", resp.GetSamples())
+	}
+	if !resp.GetRateKnown() || resp.GetLossPerHundredCycles() != 2 {
+		t.Fatalf("unexpected wear rate: known=%t rate=%v", resp.GetRateKnown(), resp.GetLossPerHundredCycles())
+	}
+}
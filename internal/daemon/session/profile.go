@@ -7,25 +7,47 @@ import (
 
 type Profile struct {
 	Limit                          int
-	WantMagsafeLED                 bool
+	LEDMode                        cfg.LEDMode
+	LEDGreenOnlyWhenFull           bool
+	DischargeBandFloor             int
+	DischargeBandCeiling           int
 	WantDisableChargingBeforeSleep bool
+	WantPersistSleepPrevention     bool
 }
 
+// ProfileForNoUser applies before anyone logs in. A LoginWindowChargeLimit
+// wins outright, since it is the admin's policy for an empty login window.
+// Otherwise, with RememberLastUserChargeLimit on, the last console user's
+// limit stands in for a user limit so a reboot does not fall back to the
+// default.
 func ProfileForNoUser(defaultLimit int) Profile {
-	systemLimit := cfg.ReadSystemChargeLimit()
+	limit := cfg.ReadSystemLoginWindowChargeLimit()
+	if limit == 0 {
+		lastUserLimit := 0
+		if cfg.ReadSystemRememberLastUserChargeLimit() {
+			lastUserLimit = cfg.ReadSystemLastUserChargeLimit()
+		}
+		limit = cfg.EffectiveChargeLimit(lastUserLimit, cfg.ReadSystemChargeLimit(), defaultLimit)
+	}
 	return Profile{
-		Limit:                          cfg.EffectiveChargeLimit(0, systemLimit, defaultLimit),
-		WantMagsafeLED:                 false,
+		Limit:                          limit,
+		LEDMode:                        cfg.LEDModeSystem,
 		WantDisableChargingBeforeSleep: true,
+		WantPersistSleepPrevention:     true,
 	}
 }
 
 func ProfileForUser(u *consoleuser.ConsoleUser, defaultLimit int) Profile {
 	systemLimit := cfg.ReadSystemChargeLimit()
 	userLimit := cfg.ReadUserChargeLimit(u.HomeDir)
+	bandFloor, bandCeiling := cfg.ReadUserDischargeBand(u.HomeDir)
 	return Profile{
 		Limit:                          cfg.EffectiveChargeLimit(userLimit, systemLimit, defaultLimit),
-		WantMagsafeLED:                 cfg.ReadUserMagsafeLED(u.HomeDir),
+		LEDMode:                        cfg.ReadUserLEDMode(u.HomeDir),
+		LEDGreenOnlyWhenFull:           cfg.ReadUserLEDGreenOnlyWhenFull(u.HomeDir),
+		DischargeBandFloor:             bandFloor,
+		DischargeBandCeiling:           bandCeiling,
 		WantDisableChargingBeforeSleep: cfg.ReadUserDisableChargingBeforeSleep(u.HomeDir),
+		WantPersistSleepPrevention:     cfg.ReadUserPersistSleepPrevention(u.HomeDir),
 	}
 }
@@ -0,0 +1,50 @@
+// powergrid/internal/display/display.go
+
+// Package display detects attached external displays through the IOKit
+// registry, which unlike CoreGraphics works from a root daemon with no
+// window server connection.
+package display
+
+/*
+#cgo LDFLAGS: -framework IOKit -framework CoreFoundation
+#include <IOKit/IOKitLib.h>
+#include <CoreFoundation/CoreFoundation.h>
+
+// Apple Silicon publishes a DCPAVServiceProxy with Location "External" for
+// every display driven over USB-C, Thunderbolt or HDMI.
+static int pg_external_display_count(int *count) {
+	io_iterator_t iter;
+	kern_return_t kr = IOServiceGetMatchingServices(MACH_PORT_NULL, IOServiceMatching("DCPAVServiceProxy"), &iter);
+	if (kr != KERN_SUCCESS) {
+		return kr;
+	}
+	int n = 0;
+	io_service_t service;
+	while ((service = IOIteratorNext(iter)) != 0) {
+		CFTypeRef location = IORegistryEntryCreateCFProperty(service, CFSTR("Location"), kCFAllocatorDefault, 0);
+		if (location != NULL) {
+			if (CFGetTypeID(location) == CFStringGetTypeID() &&
+				CFStringCompare((CFStringRef)location, CFSTR("External"), 0) == kCFCompareEqualTo) {
+				n++;
+			}
+			CFRelease(location);
+		}
+		IOObjectRelease(service);
+	}
+	IOObjectRelease(iter);
+	*count = n;
+	return 0;
+}
+*/
+import "C"
+
+import "fmt"
+
+// ExternalCount returns how many external displays are attached.
+func ExternalCount() (int, error) {
+	var n C.int
+	if rc := C.pg_external_display_count(&n); rc != 0 {
+		return 0, fmt.Errorf("IOKit display lookup failed: 0x%x", uint32(rc))
+	}
+	return int(n), nil
+}
@@ -0,0 +1,151 @@
+// Package eventlog writes an on-disk audit trail of daemon decisions as JSON
+// lines, rotating the file once it grows past a size limit. It complements
+// os_log for debugging intermittent behavior after the fact.
+package eventlog
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultPath     = "/var/log/powergrid/events.log"
+	DefaultMaxBytes = 5 << 20
+	maxBackups      = 3
+)
+
+type entry struct {
+	Time   string         `json:"time"`
+	Event  string         `json:"event"`
+	Fields map[string]any `json:"fields,omitempty"`
+}
+
+// Log appends events to a file. A nil *Log is valid and discards everything,
+// which is how the daemon runs when the audit log is disabled.
+type Log struct {
+	mu       sync.Mutex
+	path     string
+	maxBytes int64
+	file     *os.File
+	size     int64
+	now      func() time.Time
+}
+
+// Open creates the log directory if needed and opens path for appending.
+// Empty path and non-positive maxBytes fall back to the defaults.
+func Open(path string, maxBytes int64) (*Log, error) {
+	if path == "" {
+		path = DefaultPath
+	}
+	if maxBytes <= 0 {
+		maxBytes = DefaultMaxBytes
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create event log directory: %w", err)
+	}
+
+	l := &Log{path: path, maxBytes: maxBytes, now: time.Now}
+	if err := l.openFile(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// Record appends a single event. Fields must be JSON-encodable.
+func (l *Log) Record(event string, fields map[string]any) error {
+	if l == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.file == nil {
+		return fmt.Errorf("event log %q is closed", l.path)
+	}
+
+	line, err := json.Marshal(entry{
+		Time:   l.now().UTC().Format(time.RFC3339Nano),
+		Event:  event,
+		Fields: fields,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode event %q: %w", event, err)
+	}
+	line = append(line, '\n')
+
+	if l.size > 0 && l.size+int64(len(line)) > l.maxBytes {
+		if err := l.rotate(); err != nil {
+			return err
+		}
+	}
+
+	n, err := l.file.Write(line)
+	l.size += int64(n)
+	if err != nil {
+		return fmt.Errorf("failed to write event log: %w", err)
+	}
+	return nil
+}
+
+// Close flushes and closes the underlying file.
+func (l *Log) Close() error {
+	if l == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.file == nil {
+		return nil
+	}
+	err := l.file.Close()
+	l.file = nil
+	return err
+}
+
+func (l *Log) openFile() error {
+	f, err := os.OpenFile(l.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open event log: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to stat event log: %w", err)
+	}
+	l.file = f
+	l.size = info.Size()
+	return nil
+}
+
+// rotate shifts events.log -> events.log.1 -> ... -> events.log.<maxBackups>,
+// dropping the oldest, and reopens a fresh file.
+func (l *Log) rotate() error {
+	if err := l.file.Close(); err != nil {
+		return fmt.Errorf("failed to close event log for rotation: %w", err)
+	}
+	l.file = nil
+
+	for i := maxBackups - 1; i >= 1; i-- {
+		src := fmt.Sprintf("%s.%d", l.path, i)
+		if _, err := os.Stat(src); err == nil {
+			_ = os.Rename(src, fmt.Sprintf("%s.%d", l.path, i+1))
+		}
+	}
+	if err := os.Rename(l.path, l.path+".1"); err != nil && !os.IsNotExist(err) {
+		// Keep appending to the oversized file rather than losing events.
+		if openErr := l.openFile(); openErr != nil {
+			return openErr
+		}
+		return fmt.Errorf("failed to rotate event log: %w", err)
+	}
+
+	return l.openFile()
+}
@@ -0,0 +1,107 @@
+package eventlog
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func readEntries(t *testing.T, path string) []entry {
+	t.Helper()
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("failed to open %s: %v", path, err)
+	}
+	defer f.Close()
+
+	var out []entry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			t.Fatalf("invalid JSON line %q: %v", scanner.Text(), err)
+		}
+		out = append(out, e)
+	}
+	return out
+}
+
+func TestRecordWritesJSONLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "events.log")
+	l, err := Open(path, 0)
+	if err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+	l.now = func() time.Time { return time.Date(2026, 4, 20, 10, 0, 0, 0, time.UTC) }
+
+	if err := l.Record("charging_disabled", map[string]any{"charge": 80, "limit": 80}); err != nil {
+		t.Fatalf("Record returned error: %v", err)
+	}
+	if err := l.Record("daemon_started", nil); err != nil {
+		t.Fatalf("Record returned error: %v", err)
+	}
+	if err := l.Close(); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+
+	entries := readEntries(t, path)
+	if len(entries) != 2 {
+		t.Fatalf("unexpected entry count: got=%d want=%d", len(entries), 2)
+	}
+	if entries[0].Time != "2026-04-20T10:00:00Z" || entries[0].Event != "charging_disabled" {
+		t.Fatalf("unexpected first entry: %+v", entries[0])
+	}
+	if entries[0].Fields["limit"] != float64(80) {
+		t.Fatalf("unexpected limit field: got=%v want=%v", entries[0].Fields["limit"], 80)
+	}
+	if entries[1].Fields != nil {
+		t.Fatalf("expected no fields on second entry, got %v", entries[1].Fields)
+	}
+}
+
+func TestRecordRotatesBySize(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.log")
+	l, err := Open(path, 200)
+	if err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+	defer l.Close()
+
+	for i := 0; i < 20; i++ {
+		if err := l.Record("battery_update", map[string]any{"seq": i}); err != nil {
+			t.Fatalf("Record returned error: %v", err)
+		}
+	}
+
+	for _, name := range []string{path, path + ".1", path + ".2", path + ".3"} {
+		info, err := os.Stat(name)
+		if err != nil {
+			t.Fatalf("expected %s to exist: %v", name, err)
+		}
+		if info.Size() > 200 {
+			t.Fatalf("unexpected size for %s: got=%d want<=%d", name, info.Size(), 200)
+		}
+	}
+	if _, err := os.Stat(path + ".4"); !os.IsNotExist(err) {
+		t.Fatalf("expected at most %d backups, stat err=%v", maxBackups, err)
+	}
+
+	current := readEntries(t, path)
+	if len(current) == 0 || current[len(current)-1].Fields["seq"] != float64(19) {
+		t.Fatalf("expected newest event in current file, got %+v", current)
+	}
+}
+
+func TestNilLogDiscards(t *testing.T) {
+	var l *Log
+	if err := l.Record("noop", nil); err != nil {
+		t.Fatalf("nil Record returned error: %v", err)
+	}
+	if err := l.Close(); err != nil {
+		t.Fatalf("nil Close returned error: %v", err)
+	}
+}
@@ -27,14 +27,18 @@ import (
 	"unsafe"
 )
 
-type Logger struct{ l C.os_log_t }
+type Logger struct {
+	l       C.os_log_t
+	repeats *repeatFilter
+	recent  *recentRing
+}
 
 func NewLogger(subsystem, category string) *Logger {
 	cs1 := C.CString(subsystem)
 	defer C.free(unsafe.Pointer(cs1))
 	cs2 := C.CString(category)
 	defer C.free(unsafe.Pointer(cs2))
-	return &Logger{C.make_logger(cs1, cs2)}
+	return &Logger{l: C.make_logger(cs1, cs2), repeats: newRepeatFilter(DefaultRepeatWindow), recent: newRecentRing(DefaultRecentCapacity)}
 }
 
 func (lg *Logger) Default(format string, a ...any) {
@@ -42,6 +46,7 @@ func (lg *Logger) Default(format string, a ...any) {
 	cs := C.CString(msg)
 	defer C.free(unsafe.Pointer(cs))
 	C.log_default_msg(lg.l, cs)
+	lg.recent.add("default", msg)
 }
 
 func (lg *Logger) Info(format string, a ...any) {
@@ -49,6 +54,21 @@ func (lg *Logger) Info(format string, a ...any) {
 	cs := C.CString(msg)
 	defer C.free(unsafe.Pointer(cs))
 	C.log_info_msg(lg.l, cs)
+	lg.recent.add("info", msg)
+}
+
+// InfoLimited logs at info level like Info, but collapses identical messages
+// repeated within DefaultRepeatWindow into one line with a repeat count. Use
+// it for messages emitted on every power event.
+func (lg *Logger) InfoLimited(format string, a ...any) {
+	msg := lg.repeats.filter(fmt.Sprintf(format, a...))
+	if msg == "" {
+		return
+	}
+	cs := C.CString(msg)
+	defer C.free(unsafe.Pointer(cs))
+	C.log_info_msg(lg.l, cs)
+	lg.recent.add("info", msg)
 }
 
 func (lg *Logger) Error(format string, a ...any) {
@@ -56,6 +76,7 @@ func (lg *Logger) Error(format string, a ...any) {
 	cs := C.CString(msg)
 	defer C.free(unsafe.Pointer(cs))
 	C.log_error_msg(lg.l, cs)
+	lg.recent.add("error", msg)
 }
 
 func (lg *Logger) Fault(format string, a ...any) {
@@ -63,4 +84,11 @@ func (lg *Logger) Fault(format string, a ...any) {
 	cs := C.CString(msg)
 	defer C.free(unsafe.Pointer(cs))
 	C.log_fault_msg(lg.l, cs)
+	lg.recent.add("fault", msg)
+}
+
+// Recent returns up to n of the newest messages this logger wrote, oldest
+// first. n <= 0 returns everything kept.
+func (lg *Logger) Recent(n int) []Entry {
+	return lg.recent.last(n)
 }
@@ -0,0 +1,67 @@
+package oslogger
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DefaultRepeatWindow is how long an identical message is held back after it
+// was last written by the *Limited logging methods.
+const DefaultRepeatWindow = time.Minute
+
+// repeatFilter collapses identical messages seen within a window. The first
+// occurrence passes through; repeats are counted and the count is attached
+// to the next occurrence written after the window has elapsed.
+type repeatFilter struct {
+	mu      sync.Mutex
+	window  time.Duration
+	now     func() time.Time
+	entries map[string]*repeatEntry
+}
+
+type repeatEntry struct {
+	lastEmit   time.Time
+	suppressed int
+}
+
+func newRepeatFilter(window time.Duration) *repeatFilter {
+	return &repeatFilter{window: window, now: time.Now, entries: map[string]*repeatEntry{}}
+}
+
+// filter returns the line to write for msg, or "" when it should be dropped.
+func (f *repeatFilter) filter(msg string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := f.now()
+	e, ok := f.entries[msg]
+	if !ok {
+		f.pruneLocked(now)
+		f.entries[msg] = &repeatEntry{lastEmit: now}
+		return msg
+	}
+	if now.Sub(e.lastEmit) < f.window {
+		e.suppressed++
+		return ""
+	}
+
+	out := msg
+	if e.suppressed > 0 {
+		out = fmt.Sprintf("%s (repeated %d more times)", msg, e.suppressed)
+	}
+	e.lastEmit = now
+	e.suppressed = 0
+	return out
+}
+
+// pruneLocked drops quiet entries so one-off messages don't accumulate.
+// Their pending counts are lost, which only understates noise that already
+// stopped.
+func (f *repeatFilter) pruneLocked(now time.Time) {
+	for msg, e := range f.entries {
+		if now.Sub(e.lastEmit) >= 2*f.window {
+			delete(f.entries, msg)
+		}
+	}
+}
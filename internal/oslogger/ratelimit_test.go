@@ -0,0 +1,49 @@
+package oslogger
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRepeatFilterCollapsesRepeats(t *testing.T) {
+	now := time.Date(2026, 4, 20, 10, 0, 0, 0, time.UTC)
+	f := newRepeatFilter(time.Minute)
+	f.now = func() time.Time { return now }
+
+	steps := []struct {
+		advance time.Duration
+		msg     string
+		want    string
+	}{
+		{msg: "running charging logic", want: "running charging logic"},
+		{advance: time.Second, msg: "running charging logic", want: ""},
+		{advance: time.Second, msg: "running charging logic", want: ""},
+		{advance: time.Second, msg: "grace period", want: "grace period"},
+		{advance: time.Minute, msg: "running charging logic", want: "running charging logic (repeated 2 more times)"},
+		{advance: time.Minute, msg: "running charging logic", want: "running charging logic"},
+	}
+
+	for i, step := range steps {
+		now = now.Add(step.advance)
+		if got := f.filter(step.msg); got != step.want {
+			t.Fatalf("step %d: unexpected output: got=%q want=%q", i, got, step.want)
+		}
+	}
+}
+
+func TestRepeatFilterPrunesQuietEntries(t *testing.T) {
+	now := time.Date(2026, 4, 20, 10, 0, 0, 0, time.UTC)
+	f := newRepeatFilter(time.Minute)
+	f.now = func() time.Time { return now }
+
+	f.filter("one-off")
+	now = now.Add(3 * time.Minute)
+	f.filter("another")
+
+	if _, ok := f.entries["one-off"]; ok {
+		t.Fatal("expected quiet entry to be pruned")
+	}
+	if len(f.entries) != 1 {
+		t.Fatalf("unexpected entry count: got=%d want=%d", len(f.entries), 1)
+	}
+}
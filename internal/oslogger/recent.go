@@ -0,0 +1,66 @@
+package oslogger
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultRecentCapacity is how many recent log lines a Logger keeps in memory
+// for Recent, alongside what it sends to os_log.
+const DefaultRecentCapacity = 500
+
+// Entry is one line kept for Recent.
+type Entry struct {
+	Time    time.Time
+	Level   string
+	Message string
+}
+
+type recentRing struct {
+	mu      sync.Mutex
+	now     func() time.Time
+	entries []Entry
+	next    int
+	full    bool
+}
+
+func newRecentRing(capacity int) *recentRing {
+	return &recentRing{now: time.Now, entries: make([]Entry, capacity)}
+}
+
+func (r *recentRing) add(level, msg string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.entries) == 0 {
+		return
+	}
+	r.entries[r.next] = Entry{Time: r.now(), Level: level, Message: msg}
+	r.next = (r.next + 1) % len(r.entries)
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+// last returns up to n of the newest entries, oldest first.
+func (r *recentRing) last(n int) []Entry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	size := r.next
+	if r.full {
+		size = len(r.entries)
+	}
+	if n <= 0 || n > size {
+		n = size
+	}
+	out := make([]Entry, 0, n)
+	for i := size - n; i < size; i++ {
+		idx := i
+		if r.full {
+			idx = (r.next + i) % len(r.entries)
+		}
+		out = append(out, r.entries[idx])
+	}
+	return out
+}
@@ -0,0 +1,47 @@
+package oslogger
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestRecentRingKeepsNewest(t *testing.T) {
+	r := newRecentRing(3)
+	for i := 1; i <= 5; i++ {
+		r.add("default", fmt.Sprintf("line %d", i))
+	}
+
+	tests := []struct {
+		name string
+		n    int
+		want []string
+	}{
+		{name: "all", n: 0, want: []string{"line 3", "line 4", "line 5"}},
+		{name: "newest two", n: 2, want: []string{"line 4", "line 5"}},
+		{name: "more than kept", n: 10, want: []string{"line 3", "line 4", "line 5"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := r.last(tc.n)
+			if len(got) != len(tc.want) {
+				t.Fatalf("unexpected entry count: got=%d want=%d", len(got), len(tc.want))
+			}
+			for i, e := range got {
+				if e.Message != tc.want[i] {
+					t.Fatalf("unexpected entry %d: got=%q want=%q", i, e.Message, tc.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestRecentRingBeforeWrap(t *testing.T) {
+	r := newRecentRing(3)
+	r.add("error", "only")
+
+	got := r.last(5)
+	if len(got) != 1 || got[0].Message != "only" || got[0].Level != "error" {
+		t.Fatalf("unexpected entries: got=%+v", got)
+	}
+}
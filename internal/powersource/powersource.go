@@ -0,0 +1,99 @@
+// powergrid/internal/powersource/powersource.go
+
+// Package powersource reads the power sources macOS knows about through the
+// IOPowerSources API, which, unlike the AppleSmartBattery registry entry,
+// also lists UPS units attached over USB.
+package powersource
+
+/*
+#cgo LDFLAGS: -framework IOKit -framework CoreFoundation
+#include <IOKit/ps/IOPowerSources.h>
+#include <IOKit/ps/IOPSKeys.h>
+#include <CoreFoundation/CoreFoundation.h>
+
+enum {
+	PG_PROVIDING_UNKNOWN = 0,
+	PG_PROVIDING_AC = 1,
+	PG_PROVIDING_BATTERY = 2,
+	PG_PROVIDING_UPS = 3,
+};
+
+static int pg_power_sources(int *internal, int *ups, int *providing) {
+	*internal = 0;
+	*ups = 0;
+	*providing = PG_PROVIDING_UNKNOWN;
+
+	CFTypeRef info = IOPSCopyPowerSourcesInfo();
+	if (info == NULL) {
+		return -1;
+	}
+	CFStringRef kind = IOPSGetProvidingPowerSourceType(info);
+	if (kind != NULL) {
+		if (CFStringCompare(kind, CFSTR(kIOPMACPowerKey), 0) == kCFCompareEqualTo) {
+			*providing = PG_PROVIDING_AC;
+		} else if (CFStringCompare(kind, CFSTR(kIOPMBatteryPowerKey), 0) == kCFCompareEqualTo) {
+			*providing = PG_PROVIDING_BATTERY;
+		} else if (CFStringCompare(kind, CFSTR(kIOPMUPSPowerKey), 0) == kCFCompareEqualTo) {
+			*providing = PG_PROVIDING_UPS;
+		}
+	}
+
+	CFArrayRef list = IOPSCopyPowerSourcesList(info);
+	if (list == NULL) {
+		CFRelease(info);
+		return -1;
+	}
+	CFIndex count = CFArrayGetCount(list);
+	for (CFIndex i = 0; i < count; i++) {
+		CFDictionaryRef desc = IOPSGetPowerSourceDescription(info, CFArrayGetValueAtIndex(list, i));
+		if (desc == NULL) {
+			continue;
+		}
+		CFStringRef type = CFDictionaryGetValue(desc, CFSTR(kIOPSTypeKey));
+		if (type == NULL || CFGetTypeID(type) != CFStringGetTypeID()) {
+			continue;
+		}
+		if (CFStringCompare(type, CFSTR(kIOPSInternalBatteryType), 0) == kCFCompareEqualTo) {
+			*internal = 1;
+		} else if (CFStringCompare(type, CFSTR(kIOPSUPSType), 0) == kCFCompareEqualTo) {
+			*ups = 1;
+		}
+	}
+	CFRelease(list);
+	CFRelease(info);
+	return 0;
+}
+*/
+import "C"
+
+import "errors"
+
+// Providing names the source currently powering the Mac.
+type Providing int
+
+const (
+	ProvidingUnknown Providing = iota
+	ProvidingAC
+	ProvidingBattery
+	ProvidingUPS
+)
+
+// Info describes the attached power sources.
+type Info struct {
+	InternalBattery bool
+	UPS             bool
+	Providing       Providing
+}
+
+// Read returns the power sources macOS currently reports.
+func Read() (Info, error) {
+	var internal, ups, providing C.int
+	if C.pg_power_sources(&internal, &ups, &providing) != 0 {
+		return Info{}, errors.New("IOPowerSources snapshot unavailable")
+	}
+	return Info{
+		InternalBattery: internal != 0,
+		UPS:             ups != 0,
+		Providing:       Providing(providing),
+	}, nil
+}
@@ -24,13 +24,16 @@ const (
 type PowerFeature int32
 
 const (
-	PowerFeature_POWER_FEATURE_UNSPECIFIED     PowerFeature = 0
-	PowerFeature_PREVENT_DISPLAY_SLEEP         PowerFeature = 1
-	PowerFeature_PREVENT_SYSTEM_SLEEP          PowerFeature = 2
-	PowerFeature_FORCE_DISCHARGE               PowerFeature = 3
-	PowerFeature_CONTROL_MAGSAFE_LED           PowerFeature = 4
-	PowerFeature_LOW_POWER_MODE                PowerFeature = 5 // Toggle macOS Low Power Mode
-	PowerFeature_DISABLE_CHARGING_BEFORE_SLEEP PowerFeature = 6 // Toggle disabling charging before sleep
+	PowerFeature_POWER_FEATURE_UNSPECIFIED        PowerFeature = 0
+	PowerFeature_PREVENT_DISPLAY_SLEEP            PowerFeature = 1
+	PowerFeature_PREVENT_SYSTEM_SLEEP             PowerFeature = 2
+	PowerFeature_FORCE_DISCHARGE                  PowerFeature = 3
+	PowerFeature_CONTROL_MAGSAFE_LED              PowerFeature = 4
+	PowerFeature_LOW_POWER_MODE                   PowerFeature = 5 // Toggle macOS Low Power Mode
+	PowerFeature_DISABLE_CHARGING_BEFORE_SLEEP    PowerFeature = 6 // Toggle disabling charging before sleep
+	PowerFeature_CHARGE_MANAGEMENT                PowerFeature = 7 // Toggle daemon charge management (off = passthrough)
+	PowerFeature_PERSIST_SLEEP_PREVENTION         PowerFeature = 8 // Toggle re-applying sleep prevention after wake
+	PowerFeature_MAGSAFE_LED_GREEN_ONLY_WHEN_FULL PowerFeature = 9 // Keep the LED amber below 100% even when held at the limit
 )
 
 // Enum value maps for PowerFeature.
@@ -43,15 +46,21 @@ var (
 		4: "CONTROL_MAGSAFE_LED",
 		5: "LOW_POWER_MODE",
 		6: "DISABLE_CHARGING_BEFORE_SLEEP",
+		7: "CHARGE_MANAGEMENT",
+		8: "PERSIST_SLEEP_PREVENTION",
+		9: "MAGSAFE_LED_GREEN_ONLY_WHEN_FULL",
 	}
 	PowerFeature_value = map[string]int32{
-		"POWER_FEATURE_UNSPECIFIED":     0,
-		"PREVENT_DISPLAY_SLEEP":         1,
-		"PREVENT_SYSTEM_SLEEP":          2,
-		"FORCE_DISCHARGE":               3,
-		"CONTROL_MAGSAFE_LED":           4,
-		"LOW_POWER_MODE":                5,
-		"DISABLE_CHARGING_BEFORE_SLEEP": 6,
+		"POWER_FEATURE_UNSPECIFIED":        0,
+		"PREVENT_DISPLAY_SLEEP":            1,
+		"PREVENT_SYSTEM_SLEEP":             2,
+		"FORCE_DISCHARGE":                  3,
+		"CONTROL_MAGSAFE_LED":              4,
+		"LOW_POWER_MODE":                   5,
+		"DISABLE_CHARGING_BEFORE_SLEEP":    6,
+		"CHARGE_MANAGEMENT":                7,
+		"PERSIST_SLEEP_PREVENTION":         8,
+		"MAGSAFE_LED_GREEN_ONLY_WHEN_FULL": 9,
 	}
 )
 
@@ -82,25 +91,327 @@ func (PowerFeature) EnumDescriptor() ([]byte, []int) {
 	return file_powergrid_proto_rawDescGZIP(), []int{0}
 }
 
+type ChargingPauseReason int32
+
+const (
+	ChargingPauseReason_CHARGING_PAUSE_REASON_NONE ChargingPauseReason = 0
+	ChargingPauseReason_PAUSE_AT_LIMIT             ChargingPauseReason = 1 // Charge is at or above the limit
+	ChargingPauseReason_PAUSE_FORCE_DISCHARGE      ChargingPauseReason = 2 // Adapter disabled to discharge on AC
+	ChargingPauseReason_PAUSE_BEFORE_SLEEP         ChargingPauseReason = 3 // Disable Charging before Sleep transition
+	ChargingPauseReason_PAUSE_EXTERNAL_HOLD        ChargingPauseReason = 4 // Held outside PowerGrid (see external_charge_hold)
+	ChargingPauseReason_PAUSE_WEAK_ADAPTER         ChargingPauseReason = 5 // Adapter rated below MinChargingAdapterWatts
+	ChargingPauseReason_PAUSE_MANUAL               ChargingPauseReason = 6 // Root manual charging override holds charging off
+)
+
+// Enum value maps for ChargingPauseReason.
+var (
+	ChargingPauseReason_name = map[int32]string{
+		0: "CHARGING_PAUSE_REASON_NONE",
+		1: "PAUSE_AT_LIMIT",
+		2: "PAUSE_FORCE_DISCHARGE",
+		3: "PAUSE_BEFORE_SLEEP",
+		4: "PAUSE_EXTERNAL_HOLD",
+		5: "PAUSE_WEAK_ADAPTER",
+		6: "PAUSE_MANUAL",
+	}
+	ChargingPauseReason_value = map[string]int32{
+		"CHARGING_PAUSE_REASON_NONE": 0,
+		"PAUSE_AT_LIMIT":             1,
+		"PAUSE_FORCE_DISCHARGE":      2,
+		"PAUSE_BEFORE_SLEEP":         3,
+		"PAUSE_EXTERNAL_HOLD":        4,
+		"PAUSE_WEAK_ADAPTER":         5,
+		"PAUSE_MANUAL":               6,
+	}
+)
+
+func (x ChargingPauseReason) Enum() *ChargingPauseReason {
+	p := new(ChargingPauseReason)
+	*p = x
+	return p
+}
+
+func (x ChargingPauseReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ChargingPauseReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_powergrid_proto_enumTypes[1].Descriptor()
+}
+
+func (ChargingPauseReason) Type() protoreflect.EnumType {
+	return &file_powergrid_proto_enumTypes[1]
+}
+
+func (x ChargingPauseReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ChargingPauseReason.Descriptor instead.
+func (ChargingPauseReason) EnumDescriptor() ([]byte, []int) {
+	return file_powergrid_proto_rawDescGZIP(), []int{1}
+}
+
+type BatteryState int32
+
+const (
+	BatteryState_BATTERY_STATE_UNSPECIFIED BatteryState = 0 // No battery reading yet
+	BatteryState_BATTERY_CHARGING          BatteryState = 1
+	BatteryState_BATTERY_DISCHARGING       BatteryState = 2
+	BatteryState_BATTERY_IDLE              BatteryState = 3 // Less than 0.5 W either way, or charging disabled; e.g. held at the limit
+)
+
+// Enum value maps for BatteryState.
+var (
+	BatteryState_name = map[int32]string{
+		0: "BATTERY_STATE_UNSPECIFIED",
+		1: "BATTERY_CHARGING",
+		2: "BATTERY_DISCHARGING",
+		3: "BATTERY_IDLE",
+	}
+	BatteryState_value = map[string]int32{
+		"BATTERY_STATE_UNSPECIFIED": 0,
+		"BATTERY_CHARGING":          1,
+		"BATTERY_DISCHARGING":       2,
+		"BATTERY_IDLE":              3,
+	}
+)
+
+func (x BatteryState) Enum() *BatteryState {
+	p := new(BatteryState)
+	*p = x
+	return p
+}
+
+func (x BatteryState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BatteryState) Descriptor() protoreflect.EnumDescriptor {
+	return file_powergrid_proto_enumTypes[2].Descriptor()
+}
+
+func (BatteryState) Type() protoreflect.EnumType {
+	return &file_powergrid_proto_enumTypes[2]
+}
+
+func (x BatteryState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BatteryState.Descriptor instead.
+func (BatteryState) EnumDescriptor() ([]byte, []int) {
+	return file_powergrid_proto_rawDescGZIP(), []int{2}
+}
+
+type PowerSource int32
+
+const (
+	PowerSource_POWER_SOURCE_UNKNOWN PowerSource = 0
+	PowerSource_POWER_SOURCE_AC      PowerSource = 1 // Wall power through an adapter
+	PowerSource_POWER_SOURCE_BATTERY PowerSource = 2 // Internal battery
+	PowerSource_POWER_SOURCE_UPS     PowerSource = 3 // An attached UPS on battery
+)
+
+// Enum value maps for PowerSource.
+var (
+	PowerSource_name = map[int32]string{
+		0: "POWER_SOURCE_UNKNOWN",
+		1: "POWER_SOURCE_AC",
+		2: "POWER_SOURCE_BATTERY",
+		3: "POWER_SOURCE_UPS",
+	}
+	PowerSource_value = map[string]int32{
+		"POWER_SOURCE_UNKNOWN": 0,
+		"POWER_SOURCE_AC":      1,
+		"POWER_SOURCE_BATTERY": 2,
+		"POWER_SOURCE_UPS":     3,
+	}
+)
+
+func (x PowerSource) Enum() *PowerSource {
+	p := new(PowerSource)
+	*p = x
+	return p
+}
+
+func (x PowerSource) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PowerSource) Descriptor() protoreflect.EnumDescriptor {
+	return file_powergrid_proto_enumTypes[3].Descriptor()
+}
+
+func (PowerSource) Type() protoreflect.EnumType {
+	return &file_powergrid_proto_enumTypes[3]
+}
+
+func (x PowerSource) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PowerSource.Descriptor instead.
+func (PowerSource) EnumDescriptor() ([]byte, []int) {
+	return file_powergrid_proto_rawDescGZIP(), []int{3}
+}
+
+type MagsafeLedSupport int32
+
+const (
+	MagsafeLedSupport_MAGSAFE_LED_SUPPORT_UNKNOWN MagsafeLedSupport = 0 // Startup probe timed out and is still running
+	MagsafeLedSupport_MAGSAFE_LED_SUPPORTED       MagsafeLedSupport = 1
+	MagsafeLedSupport_MAGSAFE_LED_UNSUPPORTED     MagsafeLedSupport = 2
+)
+
+// Enum value maps for MagsafeLedSupport.
+var (
+	MagsafeLedSupport_name = map[int32]string{
+		0: "MAGSAFE_LED_SUPPORT_UNKNOWN",
+		1: "MAGSAFE_LED_SUPPORTED",
+		2: "MAGSAFE_LED_UNSUPPORTED",
+	}
+	MagsafeLedSupport_value = map[string]int32{
+		"MAGSAFE_LED_SUPPORT_UNKNOWN": 0,
+		"MAGSAFE_LED_SUPPORTED":       1,
+		"MAGSAFE_LED_UNSUPPORTED":     2,
+	}
+)
+
+func (x MagsafeLedSupport) Enum() *MagsafeLedSupport {
+	p := new(MagsafeLedSupport)
+	*p = x
+	return p
+}
+
+func (x MagsafeLedSupport) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MagsafeLedSupport) Descriptor() protoreflect.EnumDescriptor {
+	return file_powergrid_proto_enumTypes[4].Descriptor()
+}
+
+func (MagsafeLedSupport) Type() protoreflect.EnumType {
+	return &file_powergrid_proto_enumTypes[4]
+}
+
+func (x MagsafeLedSupport) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MagsafeLedSupport.Descriptor instead.
+func (MagsafeLedSupport) EnumDescriptor() ([]byte, []int) {
+	return file_powergrid_proto_rawDescGZIP(), []int{4}
+}
+
+type MagsafeLedMode int32
+
+const (
+	MagsafeLedMode_MAGSAFE_LED_MODE_UNSPECIFIED MagsafeLedMode = 0
+	MagsafeLedMode_MAGSAFE_LED_MODE_AUTO        MagsafeLedMode = 1 // Show charging state relative to the limit
+	MagsafeLedMode_MAGSAFE_LED_MODE_OFF         MagsafeLedMode = 2 // Keep the LED dark
+	MagsafeLedMode_MAGSAFE_LED_MODE_SYSTEM      MagsafeLedMode = 3 // Leave the LED to macOS
+)
+
+// Enum value maps for MagsafeLedMode.
+var (
+	MagsafeLedMode_name = map[int32]string{
+		0: "MAGSAFE_LED_MODE_UNSPECIFIED",
+		1: "MAGSAFE_LED_MODE_AUTO",
+		2: "MAGSAFE_LED_MODE_OFF",
+		3: "MAGSAFE_LED_MODE_SYSTEM",
+	}
+	MagsafeLedMode_value = map[string]int32{
+		"MAGSAFE_LED_MODE_UNSPECIFIED": 0,
+		"MAGSAFE_LED_MODE_AUTO":        1,
+		"MAGSAFE_LED_MODE_OFF":         2,
+		"MAGSAFE_LED_MODE_SYSTEM":      3,
+	}
+)
+
+func (x MagsafeLedMode) Enum() *MagsafeLedMode {
+	p := new(MagsafeLedMode)
+	*p = x
+	return p
+}
+
+func (x MagsafeLedMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MagsafeLedMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_powergrid_proto_enumTypes[5].Descriptor()
+}
+
+func (MagsafeLedMode) Type() protoreflect.EnumType {
+	return &file_powergrid_proto_enumTypes[5]
+}
+
+func (x MagsafeLedMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MagsafeLedMode.Descriptor instead.
+func (MagsafeLedMode) EnumDescriptor() ([]byte, []int) {
+	return file_powergrid_proto_rawDescGZIP(), []int{5}
+}
+
 type MutationOperation int32
 
 const (
 	MutationOperation_MUTATION_OPERATION_UNSPECIFIED MutationOperation = 0
 	MutationOperation_SET_CHARGE_LIMIT               MutationOperation = 1
 	MutationOperation_SET_POWER_FEATURE              MutationOperation = 2
+	MutationOperation_APPLY_PROFILE                  MutationOperation = 3  // Apply the profile named by profile_name
+	MutationOperation_SET_PROFILE                    MutationOperation = 4  // Create or replace profile_name with profile
+	MutationOperation_SET_ADAPTER_LIMIT              MutationOperation = 5  // Map adapter_key (or the connected adapter) to limit; 0 removes
+	MutationOperation_CLEAR_OVERRIDES                MutationOperation = 6  // End force discharge, sleep prevention, connect grace and boost
+	MutationOperation_CHARGE_BOOST                   MutationOperation = 7  // Charge past the limit for boost_minutes; 0 cancels
+	MutationOperation_PIN_FULL_CHARGE                MutationOperation = 8  // Charge to 100% while pid runs; pid 0 removes the pin
+	MutationOperation_RESET_COUNTERS                 MutationOperation = 9  // Zero today's charging transition counters
+	MutationOperation_FULL_BY                        MutationOperation = 10 // Reach limit (0 means 100) by full_by_unix; full_by_unix 0 cancels
+	MutationOperation_SUSPEND_MANAGEMENT             MutationOperation = 11 // Leave charging to macOS for suspend_minutes, then resume; 0 resumes now
+	MutationOperation_SET_MAGSAFE_LED_MODE           MutationOperation = 12 // Store magsafe_led_mode for the console user and apply it
+	MutationOperation_SET_DISCHARGE_BAND             MutationOperation = 13 // Keep charge between discharge_band_floor and discharge_band_ceiling by discharging; 0, 0 clears
+	MutationOperation_MANUAL_CHARGING                MutationOperation = 14 // Root only: hold SMC charging at manual_charging, bypassing the limit; NONE releases
 )
 
 // Enum value maps for MutationOperation.
 var (
 	MutationOperation_name = map[int32]string{
-		0: "MUTATION_OPERATION_UNSPECIFIED",
-		1: "SET_CHARGE_LIMIT",
-		2: "SET_POWER_FEATURE",
+		0:  "MUTATION_OPERATION_UNSPECIFIED",
+		1:  "SET_CHARGE_LIMIT",
+		2:  "SET_POWER_FEATURE",
+		3:  "APPLY_PROFILE",
+		4:  "SET_PROFILE",
+		5:  "SET_ADAPTER_LIMIT",
+		6:  "CLEAR_OVERRIDES",
+		7:  "CHARGE_BOOST",
+		8:  "PIN_FULL_CHARGE",
+		9:  "RESET_COUNTERS",
+		10: "FULL_BY",
+		11: "SUSPEND_MANAGEMENT",
+		12: "SET_MAGSAFE_LED_MODE",
+		13: "SET_DISCHARGE_BAND",
+		14: "MANUAL_CHARGING",
 	}
 	MutationOperation_value = map[string]int32{
 		"MUTATION_OPERATION_UNSPECIFIED": 0,
 		"SET_CHARGE_LIMIT":               1,
 		"SET_POWER_FEATURE":              2,
+		"APPLY_PROFILE":                  3,
+		"SET_PROFILE":                    4,
+		"SET_ADAPTER_LIMIT":              5,
+		"CLEAR_OVERRIDES":                6,
+		"CHARGE_BOOST":                   7,
+		"PIN_FULL_CHARGE":                8,
+		"RESET_COUNTERS":                 9,
+		"FULL_BY":                        10,
+		"SUSPEND_MANAGEMENT":             11,
+		"SET_MAGSAFE_LED_MODE":           12,
+		"SET_DISCHARGE_BAND":             13,
+		"MANUAL_CHARGING":                14,
 	}
 )
 
@@ -115,11 +426,11 @@ func (x MutationOperation) String() string {
 }
 
 func (MutationOperation) Descriptor() protoreflect.EnumDescriptor {
-	return file_powergrid_proto_enumTypes[1].Descriptor()
+	return file_powergrid_proto_enumTypes[6].Descriptor()
 }
 
 func (MutationOperation) Type() protoreflect.EnumType {
-	return &file_powergrid_proto_enumTypes[1]
+	return &file_powergrid_proto_enumTypes[6]
 }
 
 func (x MutationOperation) Number() protoreflect.EnumNumber {
@@ -128,7 +439,166 @@ func (x MutationOperation) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use MutationOperation.Descriptor instead.
 func (MutationOperation) EnumDescriptor() ([]byte, []int) {
-	return file_powergrid_proto_rawDescGZIP(), []int{1}
+	return file_powergrid_proto_rawDescGZIP(), []int{6}
+}
+
+type ErrorReason int32
+
+const (
+	ErrorReason_ERROR_REASON_UNSPECIFIED   ErrorReason = 0
+	ErrorReason_ERROR_UNSUPPORTED_HARDWARE ErrorReason = 1 // Feature not available on this Mac; metadata: feature
+	ErrorReason_ERROR_VALUE_OUT_OF_RANGE   ErrorReason = 2 // metadata: field, value, min, max
+	ErrorReason_ERROR_SMC_BUSY             ErrorReason = 3 // SMC write failed or timed out; safe to retry
+	ErrorReason_ERROR_NO_CONSOLE_USER      ErrorReason = 4 // Per-user setting requested with nobody logged in
+	ErrorReason_ERROR_NO_BATTERY           ErrorReason = 5 // Operation needs a battery and none is present
+)
+
+// Enum value maps for ErrorReason.
+var (
+	ErrorReason_name = map[int32]string{
+		0: "ERROR_REASON_UNSPECIFIED",
+		1: "ERROR_UNSUPPORTED_HARDWARE",
+		2: "ERROR_VALUE_OUT_OF_RANGE",
+		3: "ERROR_SMC_BUSY",
+		4: "ERROR_NO_CONSOLE_USER",
+		5: "ERROR_NO_BATTERY",
+	}
+	ErrorReason_value = map[string]int32{
+		"ERROR_REASON_UNSPECIFIED":   0,
+		"ERROR_UNSUPPORTED_HARDWARE": 1,
+		"ERROR_VALUE_OUT_OF_RANGE":   2,
+		"ERROR_SMC_BUSY":             3,
+		"ERROR_NO_CONSOLE_USER":      4,
+		"ERROR_NO_BATTERY":           5,
+	}
+)
+
+func (x ErrorReason) Enum() *ErrorReason {
+	p := new(ErrorReason)
+	*p = x
+	return p
+}
+
+func (x ErrorReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ErrorReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_powergrid_proto_enumTypes[7].Descriptor()
+}
+
+func (ErrorReason) Type() protoreflect.EnumType {
+	return &file_powergrid_proto_enumTypes[7]
+}
+
+func (x ErrorReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ErrorReason.Descriptor instead.
+func (ErrorReason) EnumDescriptor() ([]byte, []int) {
+	return file_powergrid_proto_rawDescGZIP(), []int{7}
+}
+
+type EstimateConfidence int32
+
+const (
+	EstimateConfidence_ESTIMATE_CONFIDENCE_UNSPECIFIED EstimateConfidence = 0 // Charge has not moved enough to measure a rate
+	EstimateConfidence_ESTIMATE_CONFIDENCE_LOW         EstimateConfidence = 1 // Short or small change in charge
+	EstimateConfidence_ESTIMATE_CONFIDENCE_MEDIUM      EstimateConfidence = 2 // Long enough, but the rate varied
+	EstimateConfidence_ESTIMATE_CONFIDENCE_HIGH        EstimateConfidence = 3 // Long enough at a steady rate
+)
+
+// Enum value maps for EstimateConfidence.
+var (
+	EstimateConfidence_name = map[int32]string{
+		0: "ESTIMATE_CONFIDENCE_UNSPECIFIED",
+		1: "ESTIMATE_CONFIDENCE_LOW",
+		2: "ESTIMATE_CONFIDENCE_MEDIUM",
+		3: "ESTIMATE_CONFIDENCE_HIGH",
+	}
+	EstimateConfidence_value = map[string]int32{
+		"ESTIMATE_CONFIDENCE_UNSPECIFIED": 0,
+		"ESTIMATE_CONFIDENCE_LOW":         1,
+		"ESTIMATE_CONFIDENCE_MEDIUM":      2,
+		"ESTIMATE_CONFIDENCE_HIGH":        3,
+	}
+)
+
+func (x EstimateConfidence) Enum() *EstimateConfidence {
+	p := new(EstimateConfidence)
+	*p = x
+	return p
+}
+
+func (x EstimateConfidence) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EstimateConfidence) Descriptor() protoreflect.EnumDescriptor {
+	return file_powergrid_proto_enumTypes[8].Descriptor()
+}
+
+func (EstimateConfidence) Type() protoreflect.EnumType {
+	return &file_powergrid_proto_enumTypes[8]
+}
+
+func (x EstimateConfidence) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EstimateConfidence.Descriptor instead.
+func (EstimateConfidence) EnumDescriptor() ([]byte, []int) {
+	return file_powergrid_proto_rawDescGZIP(), []int{8}
+}
+
+type ManualCharging int32
+
+const (
+	ManualCharging_MANUAL_CHARGING_NONE ManualCharging = 0 // No override; sent with MANUAL_CHARGING it releases one
+	ManualCharging_MANUAL_CHARGING_ON   ManualCharging = 1 // Charging held enabled
+	ManualCharging_MANUAL_CHARGING_OFF  ManualCharging = 2 // Charging held disabled
+)
+
+// Enum value maps for ManualCharging.
+var (
+	ManualCharging_name = map[int32]string{
+		0: "MANUAL_CHARGING_NONE",
+		1: "MANUAL_CHARGING_ON",
+		2: "MANUAL_CHARGING_OFF",
+	}
+	ManualCharging_value = map[string]int32{
+		"MANUAL_CHARGING_NONE": 0,
+		"MANUAL_CHARGING_ON":   1,
+		"MANUAL_CHARGING_OFF":  2,
+	}
+)
+
+func (x ManualCharging) Enum() *ManualCharging {
+	p := new(ManualCharging)
+	*p = x
+	return p
+}
+
+func (x ManualCharging) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ManualCharging) Descriptor() protoreflect.EnumDescriptor {
+	return file_powergrid_proto_enumTypes[9].Descriptor()
+}
+
+func (ManualCharging) Type() protoreflect.EnumType {
+	return &file_powergrid_proto_enumTypes[9]
+}
+
+func (x ManualCharging) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ManualCharging.Descriptor instead.
+func (ManualCharging) EnumDescriptor() ([]byte, []int) {
+	return file_powergrid_proto_rawDescGZIP(), []int{9}
 }
 
 type Empty struct {
@@ -168,43 +638,93 @@ func (*Empty) Descriptor() ([]byte, []int) {
 }
 
 type StatusResponse struct {
-	state                            protoimpl.MessageState `protogen:"open.v1"`
-	CurrentCharge                    int32                  `protobuf:"varint,1,opt,name=current_charge,json=currentCharge,proto3" json:"current_charge,omitempty"`
-	IsCharging                       bool                   `protobuf:"varint,2,opt,name=is_charging,json=isCharging,proto3" json:"is_charging,omitempty"`
-	IsConnected                      bool                   `protobuf:"varint,3,opt,name=is_connected,json=isConnected,proto3" json:"is_connected,omitempty"`
-	ChargeLimit                      int32                  `protobuf:"varint,4,opt,name=charge_limit,json=chargeLimit,proto3" json:"charge_limit,omitempty"`
-	IsChargeLimited                  bool                   `protobuf:"varint,5,opt,name=is_charge_limited,json=isChargeLimited,proto3" json:"is_charge_limited,omitempty"`
-	CycleCount                       int32                  `protobuf:"varint,6,opt,name=cycle_count,json=cycleCount,proto3" json:"cycle_count,omitempty"`
-	AdapterDescription               string                 `protobuf:"bytes,7,opt,name=adapter_description,json=adapterDescription,proto3" json:"adapter_description,omitempty"`
-	BatteryWattage                   float32                `protobuf:"fixed32,8,opt,name=battery_wattage,json=batteryWattage,proto3" json:"battery_wattage,omitempty"`
-	AdapterWattage                   float32                `protobuf:"fixed32,9,opt,name=adapter_wattage,json=adapterWattage,proto3" json:"adapter_wattage,omitempty"`
-	SystemWattage                    float32                `protobuf:"fixed32,10,opt,name=system_wattage,json=systemWattage,proto3" json:"system_wattage,omitempty"`
-	HealthByMax                      int32                  `protobuf:"varint,11,opt,name=health_by_max,json=healthByMax,proto3" json:"health_by_max,omitempty"`                                                                      // IOKit.Calculations.HealthByMaxCapacity
-	AdapterInputVoltage              float32                `protobuf:"fixed32,12,opt,name=adapter_input_voltage,json=adapterInputVoltage,proto3" json:"adapter_input_voltage,omitempty"`                                             // IOKit.Adapter.InputVoltage (V)
-	AdapterInputAmperage             float32                `protobuf:"fixed32,13,opt,name=adapter_input_amperage,json=adapterInputAmperage,proto3" json:"adapter_input_amperage,omitempty"`                                          // IOKit.Adapter.InputAmperage (A)
-	PreventDisplaySleepActive        bool                   `protobuf:"varint,14,opt,name=prevent_display_sleep_active,json=preventDisplaySleepActive,proto3" json:"prevent_display_sleep_active,omitempty"`                          // Assertion active in this process
-	PreventSystemSleepActive         bool                   `protobuf:"varint,15,opt,name=prevent_system_sleep_active,json=preventSystemSleepActive,proto3" json:"prevent_system_sleep_active,omitempty"`                             // Assertion active in this process
-	ForceDischargeActive             bool                   `protobuf:"varint,16,opt,name=force_discharge_active,json=forceDischargeActive,proto3" json:"force_discharge_active,omitempty"`                                           // Adapter disabled via SMC
-	SmcChargingEnabled               bool                   `protobuf:"varint,17,opt,name=smc_charging_enabled,json=smcChargingEnabled,proto3" json:"smc_charging_enabled,omitempty"`                                                 // SMC.State.IsChargingEnabled
-	SmcAdapterEnabled                bool                   `protobuf:"varint,18,opt,name=smc_adapter_enabled,json=smcAdapterEnabled,proto3" json:"smc_adapter_enabled,omitempty"`                                                    // SMC.State.IsAdapterEnabled
-	AdapterMaxWatts                  int32                  `protobuf:"varint,19,opt,name=adapter_max_watts,json=adapterMaxWatts,proto3" json:"adapter_max_watts,omitempty"`                                                          // IOKit.Adapter.MaxWatts (W)
-	TimeToFullMinutes                int32                  `protobuf:"varint,20,opt,name=time_to_full_minutes,json=timeToFullMinutes,proto3" json:"time_to_full_minutes,omitempty"`                                                  // IOKit.Battery.TimeToFull (minutes)
-	TimeToEmptyMinutes               int32                  `protobuf:"varint,21,opt,name=time_to_empty_minutes,json=timeToEmptyMinutes,proto3" json:"time_to_empty_minutes,omitempty"`                                               // IOKit.Battery.TimeToEmpty (minutes)
-	MagsafeLedControlActive          bool                   `protobuf:"varint,22,opt,name=magsafe_led_control_active,json=magsafeLedControlActive,proto3" json:"magsafe_led_control_active,omitempty"`                                // Whether daemon is controlling MagSafe LED
-	MagsafeLedSupported              bool                   `protobuf:"varint,23,opt,name=magsafe_led_supported,json=magsafeLedSupported,proto3" json:"magsafe_led_supported,omitempty"`                                              // Hardware supports MagSafe LED control
-	LowPowerModeEnabled              bool                   `protobuf:"varint,24,opt,name=low_power_mode_enabled,json=lowPowerModeEnabled,proto3" json:"low_power_mode_enabled,omitempty"`                                            // macOS Low Power Mode is enabled
-	DisableChargingBeforeSleepActive bool                   `protobuf:"varint,25,opt,name=disable_charging_before_sleep_active,json=disableChargingBeforeSleepActive,proto3" json:"disable_charging_before_sleep_active,omitempty"`   // Whether daemon will disable charging before sleep
-	BatterySerialNumber              string                 `protobuf:"bytes,26,opt,name=battery_serial_number,json=batterySerialNumber,proto3" json:"battery_serial_number,omitempty"`                                               // Battery serial number
-	BatteryDesignCapacity            int32                  `protobuf:"varint,27,opt,name=battery_design_capacity,json=batteryDesignCapacity,proto3" json:"battery_design_capacity,omitempty"`                                        // mAh
-	BatteryMaxCapacity               int32                  `protobuf:"varint,28,opt,name=battery_max_capacity,json=batteryMaxCapacity,proto3" json:"battery_max_capacity,omitempty"`                                                 // mAh (current maximum)
-	BatteryNominalCapacity           int32                  `protobuf:"varint,29,opt,name=battery_nominal_capacity,json=batteryNominalCapacity,proto3" json:"battery_nominal_capacity,omitempty"`                                     // mAh (design nominal)
-	BatteryVoltage                   float32                `protobuf:"fixed32,30,opt,name=battery_voltage,json=batteryVoltage,proto3" json:"battery_voltage,omitempty"`                                                              // V
-	BatteryAmperage                  float32                `protobuf:"fixed32,31,opt,name=battery_amperage,json=batteryAmperage,proto3" json:"battery_amperage,omitempty"`                                                           // A
-	BatteryIndividualCellMillivolts  []int32                `protobuf:"varint,32,rep,packed,name=battery_individual_cell_millivolts,json=batteryIndividualCellMillivolts,proto3" json:"battery_individual_cell_millivolts,omitempty"` // Per-cell voltage in mV
-	BatteryTemperatureC              float32                `protobuf:"fixed32,33,opt,name=battery_temperature_c,json=batteryTemperatureC,proto3" json:"battery_temperature_c,omitempty"`                                             // °C
-	BatteryVoltageDriftMv            int32                  `protobuf:"varint,34,opt,name=battery_voltage_drift_mv,json=batteryVoltageDriftMv,proto3" json:"battery_voltage_drift_mv,omitempty"`                                      // Cell max-min drift in mV
-	BatteryBalanceState              string                 `protobuf:"bytes,35,opt,name=battery_balance_state,json=batteryBalanceState,proto3" json:"battery_balance_state,omitempty"`                                               // balanced | slight_imbalance | high_imbalance | unknown
-	LowPowerModeAvailable            bool                   `protobuf:"varint,36,opt,name=low_power_mode_available,json=lowPowerModeAvailable,proto3" json:"low_power_mode_available,omitempty"`                                      // macOS Low Power Mode can be controlled/read on this system
+	state                            protoimpl.MessageState  `protogen:"open.v1"`
+	CurrentCharge                    int32                   `protobuf:"varint,1,opt,name=current_charge,json=currentCharge,proto3" json:"current_charge,omitempty"`
+	IsCharging                       bool                    `protobuf:"varint,2,opt,name=is_charging,json=isCharging,proto3" json:"is_charging,omitempty"`
+	IsConnected                      bool                    `protobuf:"varint,3,opt,name=is_connected,json=isConnected,proto3" json:"is_connected,omitempty"`
+	ChargeLimit                      int32                   `protobuf:"varint,4,opt,name=charge_limit,json=chargeLimit,proto3" json:"charge_limit,omitempty"`
+	IsChargeLimited                  bool                    `protobuf:"varint,5,opt,name=is_charge_limited,json=isChargeLimited,proto3" json:"is_charge_limited,omitempty"` // SMC charging disabled for any reason; see charging_pause_reason
+	CycleCount                       int32                   `protobuf:"varint,6,opt,name=cycle_count,json=cycleCount,proto3" json:"cycle_count,omitempty"`
+	AdapterDescription               string                  `protobuf:"bytes,7,opt,name=adapter_description,json=adapterDescription,proto3" json:"adapter_description,omitempty"`
+	BatteryWattage                   float32                 `protobuf:"fixed32,8,opt,name=battery_wattage,json=batteryWattage,proto3" json:"battery_wattage,omitempty"` // W; positive charges the battery, negative drains it (see battery_state)
+	AdapterWattage                   float32                 `protobuf:"fixed32,9,opt,name=adapter_wattage,json=adapterWattage,proto3" json:"adapter_wattage,omitempty"`
+	SystemWattage                    float32                 `protobuf:"fixed32,10,opt,name=system_wattage,json=systemWattage,proto3" json:"system_wattage,omitempty"`
+	HealthByMax                      int32                   `protobuf:"varint,11,opt,name=health_by_max,json=healthByMax,proto3" json:"health_by_max,omitempty"`                                                                      // IOKit.Calculations.HealthByMaxCapacity
+	AdapterInputVoltage              float32                 `protobuf:"fixed32,12,opt,name=adapter_input_voltage,json=adapterInputVoltage,proto3" json:"adapter_input_voltage,omitempty"`                                             // IOKit.Adapter.InputVoltage (V)
+	AdapterInputAmperage             float32                 `protobuf:"fixed32,13,opt,name=adapter_input_amperage,json=adapterInputAmperage,proto3" json:"adapter_input_amperage,omitempty"`                                          // IOKit.Adapter.InputAmperage (A)
+	PreventDisplaySleepActive        bool                    `protobuf:"varint,14,opt,name=prevent_display_sleep_active,json=preventDisplaySleepActive,proto3" json:"prevent_display_sleep_active,omitempty"`                          // Assertion active in this process
+	PreventSystemSleepActive         bool                    `protobuf:"varint,15,opt,name=prevent_system_sleep_active,json=preventSystemSleepActive,proto3" json:"prevent_system_sleep_active,omitempty"`                             // Assertion active in this process
+	ForceDischargeActive             bool                    `protobuf:"varint,16,opt,name=force_discharge_active,json=forceDischargeActive,proto3" json:"force_discharge_active,omitempty"`                                           // Adapter disabled via SMC
+	SmcChargingEnabled               bool                    `protobuf:"varint,17,opt,name=smc_charging_enabled,json=smcChargingEnabled,proto3" json:"smc_charging_enabled,omitempty"`                                                 // SMC.State.IsChargingEnabled
+	SmcAdapterEnabled                bool                    `protobuf:"varint,18,opt,name=smc_adapter_enabled,json=smcAdapterEnabled,proto3" json:"smc_adapter_enabled,omitempty"`                                                    // SMC.State.IsAdapterEnabled
+	AdapterMaxWatts                  int32                   `protobuf:"varint,19,opt,name=adapter_max_watts,json=adapterMaxWatts,proto3" json:"adapter_max_watts,omitempty"`                                                          // IOKit.Adapter.MaxWatts (W)
+	TimeToFullMinutes                int32                   `protobuf:"varint,20,opt,name=time_to_full_minutes,json=timeToFullMinutes,proto3" json:"time_to_full_minutes,omitempty"`                                                  // IOKit.Battery.TimeToFull (minutes)
+	TimeToEmptyMinutes               int32                   `protobuf:"varint,21,opt,name=time_to_empty_minutes,json=timeToEmptyMinutes,proto3" json:"time_to_empty_minutes,omitempty"`                                               // IOKit.Battery.TimeToEmpty (minutes)
+	MagsafeLedControlActive          bool                    `protobuf:"varint,22,opt,name=magsafe_led_control_active,json=magsafeLedControlActive,proto3" json:"magsafe_led_control_active,omitempty"`                                // Whether daemon is controlling MagSafe LED
+	MagsafeLedSupported              bool                    `protobuf:"varint,23,opt,name=magsafe_led_supported,json=magsafeLedSupported,proto3" json:"magsafe_led_supported,omitempty"`                                              // Hardware supports MagSafe LED control
+	LowPowerModeEnabled              bool                    `protobuf:"varint,24,opt,name=low_power_mode_enabled,json=lowPowerModeEnabled,proto3" json:"low_power_mode_enabled,omitempty"`                                            // macOS Low Power Mode is enabled
+	DisableChargingBeforeSleepActive bool                    `protobuf:"varint,25,opt,name=disable_charging_before_sleep_active,json=disableChargingBeforeSleepActive,proto3" json:"disable_charging_before_sleep_active,omitempty"`   // Whether daemon will disable charging before sleep
+	BatterySerialNumber              string                  `protobuf:"bytes,26,opt,name=battery_serial_number,json=batterySerialNumber,proto3" json:"battery_serial_number,omitempty"`                                               // Battery serial number
+	BatteryDesignCapacity            int32                   `protobuf:"varint,27,opt,name=battery_design_capacity,json=batteryDesignCapacity,proto3" json:"battery_design_capacity,omitempty"`                                        // mAh
+	BatteryMaxCapacity               int32                   `protobuf:"varint,28,opt,name=battery_max_capacity,json=batteryMaxCapacity,proto3" json:"battery_max_capacity,omitempty"`                                                 // mAh (current maximum)
+	BatteryNominalCapacity           int32                   `protobuf:"varint,29,opt,name=battery_nominal_capacity,json=batteryNominalCapacity,proto3" json:"battery_nominal_capacity,omitempty"`                                     // mAh (design nominal)
+	BatteryVoltage                   float32                 `protobuf:"fixed32,30,opt,name=battery_voltage,json=batteryVoltage,proto3" json:"battery_voltage,omitempty"`                                                              // V, pack voltage
+	BatteryAmperage                  float32                 `protobuf:"fixed32,31,opt,name=battery_amperage,json=batteryAmperage,proto3" json:"battery_amperage,omitempty"`                                                           // A, instantaneous; positive while charging, negative while discharging
+	BatteryIndividualCellMillivolts  []int32                 `protobuf:"varint,32,rep,packed,name=battery_individual_cell_millivolts,json=batteryIndividualCellMillivolts,proto3" json:"battery_individual_cell_millivolts,omitempty"` // Per-cell voltage in mV
+	BatteryTemperatureC              float32                 `protobuf:"fixed32,33,opt,name=battery_temperature_c,json=batteryTemperatureC,proto3" json:"battery_temperature_c,omitempty"`                                             // °C
+	BatteryVoltageDriftMv            int32                   `protobuf:"varint,34,opt,name=battery_voltage_drift_mv,json=batteryVoltageDriftMv,proto3" json:"battery_voltage_drift_mv,omitempty"`                                      // Cell max-min drift in mV
+	BatteryBalanceState              string                  `protobuf:"bytes,35,opt,name=battery_balance_state,json=batteryBalanceState,proto3" json:"battery_balance_state,omitempty"`                                               // balanced | slight_imbalance | high_imbalance | unknown
+	LowPowerModeAvailable            bool                    `protobuf:"varint,36,opt,name=low_power_mode_available,json=lowPowerModeAvailable,proto3" json:"low_power_mode_available,omitempty"`                                      // macOS Low Power Mode can be controlled/read on this system
+	ManagementEnabled                bool                    `protobuf:"varint,37,opt,name=management_enabled,json=managementEnabled,proto3" json:"management_enabled,omitempty"`                                                      // False when the daemon is in unmanaged/passthrough mode
+	ExternalChargeHold               bool                    `protobuf:"varint,38,opt,name=external_charge_hold,json=externalChargeHold,proto3" json:"external_charge_hold,omitempty"`                                                 // Below the limit with charging allowed, yet not charging for 2 minutes (e.g. Optimized Battery Charging, thermal limits)
+	AdapterUnderperforming           bool                    `protobuf:"varint,39,opt,name=adapter_underperforming,json=adapterUnderperforming,proto3" json:"adapter_underperforming,omitempty"`                                       // adapter_wattage is below the configured share of adapter_max_watts while charging
+	ActiveProfile                    string                  `protobuf:"bytes,40,opt,name=active_profile,json=activeProfile,proto3" json:"active_profile,omitempty"`                                                                   // Last applied charge profile; empty after individual settings change
+	ChargingPauseReason              ChargingPauseReason     `protobuf:"varint,41,opt,name=charging_pause_reason,json=chargingPauseReason,proto3,enum=rpc.ChargingPauseReason" json:"charging_pause_reason,omitempty"`                 // Why charging is held off while on AC
+	AdapterKey                       string                  `protobuf:"bytes,42,opt,name=adapter_key,json=adapterKey,proto3" json:"adapter_key,omitempty"`                                                                            // Identity of the connected adapter; empty on battery
+	MatchedAdapterKey                string                  `protobuf:"bytes,43,opt,name=matched_adapter_key,json=matchedAdapterKey,proto3" json:"matched_adapter_key,omitempty"`                                                     // adapter_key when a per-adapter limit is in effect
+	EffectiveChargeLimit             int32                   `protobuf:"varint,44,opt,name=effective_charge_limit,json=effectiveChargeLimit,proto3" json:"effective_charge_limit,omitempty"`                                           // Limit enforced right now (per-adapter or charge_limit)
+	ForceDischargeFloor              int32                   `protobuf:"varint,45,opt,name=force_discharge_floor,json=forceDischargeFloor,proto3" json:"force_discharge_floor,omitempty"`                                              // Charge at which force discharge stops on its own
+	ForceDischargeFloorReached       bool                    `protobuf:"varint,46,opt,name=force_discharge_floor_reached,json=forceDischargeFloorReached,proto3" json:"force_discharge_floor_reached,omitempty"`                       // Force discharge was stopped at the floor; cleared on the next request
+	BatteryMissing                   bool                    `protobuf:"varint,47,opt,name=battery_missing,json=batteryMissing,proto3" json:"battery_missing,omitempty"`                                                               // IOKit reports no usable battery; charge control is suspended
+	BoostRemainingSeconds            int32                   `protobuf:"varint,48,opt,name=boost_remaining_seconds,json=boostRemainingSeconds,proto3" json:"boost_remaining_seconds,omitempty"`                                        // Time left on a charge boost; 0 when none is active
+	ChargedAtLimit                   bool                    `protobuf:"varint,49,opt,name=charged_at_limit,json=chargedAtLimit,proto3" json:"charged_at_limit,omitempty"`                                                             // Connected and at the enforced limit (or full); UIs can show "Charged" below 100%
+	ChargeManagerConflict            bool                    `protobuf:"varint,50,opt,name=charge_manager_conflict,json=chargeManagerConflict,proto3" json:"charge_manager_conflict,omitempty"`                                        // Another tool appears to be managing charging (best effort)
+	ChargeManagerConflictDetail      string                  `protobuf:"bytes,51,opt,name=charge_manager_conflict_detail,json=chargeManagerConflictDetail,proto3" json:"charge_manager_conflict_detail,omitempty"`                     // What triggered charge_manager_conflict
+	MagsafeLedSupport                MagsafeLedSupport       `protobuf:"varint,52,opt,name=magsafe_led_support,json=magsafeLedSupport,proto3,enum=rpc.MagsafeLedSupport" json:"magsafe_led_support,omitempty"`                         // Tri-state form of magsafe_led_supported; UNKNOWN while the probe is still running
+	ChargingStalled                  bool                    `protobuf:"varint,53,opt,name=charging_stalled,json=chargingStalled,proto3" json:"charging_stalled,omitempty"`                                                            // Charging expected for 10 minutes but charge has not risen (adapter, cable or port fault)
+	PinnedPid                        int32                   `protobuf:"varint,54,opt,name=pinned_pid,json=pinnedPid,proto3" json:"pinned_pid,omitempty"`                                                                              // Process holding charging to 100% (PIN_FULL_CHARGE); 0 when none
+	BatteryState                     BatteryState            `protobuf:"varint,55,opt,name=battery_state,json=batteryState,proto3,enum=rpc.BatteryState" json:"battery_state,omitempty"`                                               // Direction of battery power flow derived from battery_wattage and SMC flags
+	PersistSleepPreventionActive     bool                    `protobuf:"varint,56,opt,name=persist_sleep_prevention_active,json=persistSleepPreventionActive,proto3" json:"persist_sleep_prevention_active,omitempty"`                 // Sleep prevention is re-applied after wake; when off, waking clears it
+	PreventDisplaySleepAuto          bool                    `protobuf:"varint,57,opt,name=prevent_display_sleep_auto,json=preventDisplaySleepAuto,proto3" json:"prevent_display_sleep_auto,omitempty"`                                // Display sleep prevented because an external display is attached (PreventDisplaySleepWithExternalDisplay)
+	RampedChargeLimit                int32                   `protobuf:"varint,58,opt,name=ramped_charge_limit,json=rampedChargeLimit,proto3" json:"ramped_charge_limit,omitempty"`                                                    // Limit enforced while easing down to effective_charge_limit (ChargeLimitRampMinutes); 0 when no ramp is running
+	ActuallyCharging                 bool                    `protobuf:"varint,59,opt,name=actually_charging,json=actuallyCharging,proto3" json:"actually_charging,omitempty"`                                                         // Current is flowing into the battery (battery_amperage at least 0.05 A), unlike smc_charging_enabled or is_charging
+	MagsafeLedStates                 []string                `protobuf:"bytes,60,rep,name=magsafe_led_states,json=magsafeLedStates,proto3" json:"magsafe_led_states,omitempty"`                                                        // LED states the firmware honors (off, green, amber, error); empty when LED control is unsupported
+	FullByUnix                       int64                   `protobuf:"varint,61,opt,name=full_by_unix,json=fullByUnix,proto3" json:"full_by_unix,omitempty"`                                                                         // Deadline of the FULL_BY plan (Unix seconds); 0 when none
+	FullByTarget                     int32                   `protobuf:"varint,62,opt,name=full_by_target,json=fullByTarget,proto3" json:"full_by_target,omitempty"`                                                                   // Charge the FULL_BY plan aims for by full_by_unix
+	FullByStartUnix                  int64                   `protobuf:"varint,63,opt,name=full_by_start_unix,json=fullByStartUnix,proto3" json:"full_by_start_unix,omitempty"`                                                        // When the FULL_BY plan starts (or started) charging past the limit
+	MinChargeLimit                   int32                   `protobuf:"varint,64,opt,name=min_charge_limit,json=minChargeLimit,proto3" json:"min_charge_limit,omitempty"`                                                             // Lowest limit SET_CHARGE_LIMIT accepts (MinChargeLimit, default 60)
+	SuspendRemainingSeconds          int32                   `protobuf:"varint,65,opt,name=suspend_remaining_seconds,json=suspendRemainingSeconds,proto3" json:"suspend_remaining_seconds,omitempty"`                                  // Time left on a SUSPEND_MANAGEMENT suspension; 0 when managing normally
+	UpsAttached                      bool                    `protobuf:"varint,66,opt,name=ups_attached,json=upsAttached,proto3" json:"ups_attached,omitempty"`                                                                        // macOS lists a UPS power source (e.g. over USB)
+	PowerSource                      PowerSource             `protobuf:"varint,67,opt,name=power_source,json=powerSource,proto3,enum=rpc.PowerSource" json:"power_source,omitempty"`                                                   // What is powering the Mac right now, per IOPowerSources
+	MagsafeLedMode                   MagsafeLedMode          `protobuf:"varint,68,opt,name=magsafe_led_mode,json=magsafeLedMode,proto3,enum=rpc.MagsafeLedMode" json:"magsafe_led_mode,omitempty"`                                     // How the daemon drives the MagSafe LED for the console user
+	MagsafeLedGreenOnlyWhenFull      bool                    `protobuf:"varint,69,opt,name=magsafe_led_green_only_when_full,json=magsafeLedGreenOnlyWhenFull,proto3" json:"magsafe_led_green_only_when_full,omitempty"`                // Auto mode shows green only at 100%, amber below it
+	SafeMode                         bool                    `protobuf:"varint,70,opt,name=safe_mode,json=safeMode,proto3" json:"safe_mode,omitempty"`                                                                                 // SMC writes were denied since start; the daemon only reports status
+	WarmingUp                        bool                    `protobuf:"varint,71,opt,name=warming_up,json=warmingUp,proto3" json:"warming_up,omitempty"`                                                                              // Within the startup grace period; charging changes are held until readings settle
+	DischargeBandFloor               int32                   `protobuf:"varint,72,opt,name=discharge_band_floor,json=dischargeBandFloor,proto3" json:"discharge_band_floor,omitempty"`                                                 // Band discharge stops here; 0 when no band is set
+	DischargeBandCeiling             int32                   `protobuf:"varint,73,opt,name=discharge_band_ceiling,json=dischargeBandCeiling,proto3" json:"discharge_band_ceiling,omitempty"`                                           // Band discharge starts above this; 0 when no band is set
+	DischargeBandActive              bool                    `protobuf:"varint,74,opt,name=discharge_band_active,json=dischargeBandActive,proto3" json:"discharge_band_active,omitempty"`                                              // The band is discharging the battery now
+	SystemDisplaySleepPrevented      bool                    `protobuf:"varint,75,opt,name=system_display_sleep_prevented,json=systemDisplaySleepPrevented,proto3" json:"system_display_sleep_prevented,omitempty"`                    // Some process, PowerGrid included, holds a display sleep assertion (pmset -g assertions)
+	SystemSleepPrevented             bool                    `protobuf:"varint,76,opt,name=system_sleep_prevented,json=systemSleepPrevented,proto3" json:"system_sleep_prevented,omitempty"`                                           // Some process, PowerGrid included, holds a system sleep assertion (pmset -g assertions)
+	ExternalSleepAssertions          []string                `protobuf:"bytes,77,rep,name=external_sleep_assertions,json=externalSleepAssertions,proto3" json:"external_sleep_assertions,omitempty"`                                   // Sleep assertion holders other than PowerGrid, as "process (display|system)"
+	ManualCharging                   ManualCharging          `protobuf:"varint,78,opt,name=manual_charging,json=manualCharging,proto3,enum=rpc.ManualCharging" json:"manual_charging,omitempty"`                                       // Manual charging override holding the SMC state; NONE when the limit logic is in control
+	CriticalCharge                   bool                    `protobuf:"varint,79,opt,name=critical_charge,json=criticalCharge,proto3" json:"critical_charge,omitempty"`                                                               // Charge is at or below CriticalChargePercent and not charging; only set with the notify action, for clients to alert on
+	MagsafeLedCommittedState         string                  `protobuf:"bytes,80,opt,name=magsafe_led_committed_state,json=magsafeLedCommittedState,proto3" json:"magsafe_led_committed_state,omitempty"`                              // Last LED state written successfully (off, green, amber, error, system); empty before the first write
+	MagsafeLedTransitions            []*MagsafeLedTransition `protobuf:"bytes,81,rep,name=magsafe_led_transitions,json=magsafeLedTransitions,proto3" json:"magsafe_led_transitions,omitempty"`                                         // When each LED state was last written, newest first
+	MagsafeLedWritePending           bool                    `protobuf:"varint,82,opt,name=magsafe_led_write_pending,json=magsafeLedWritePending,proto3" json:"magsafe_led_write_pending,omitempty"`                                   // The last LED write failed; the next update rewrites even if the target is unchanged
+	MagsafeLedLastFailureUnix        int64                   `protobuf:"varint,83,opt,name=magsafe_led_last_failure_unix,json=magsafeLedLastFailureUnix,proto3" json:"magsafe_led_last_failure_unix,omitempty"`                        // When an LED write last failed; 0 if none has since start
+	MagsafeLedLastError              string                  `protobuf:"bytes,84,opt,name=magsafe_led_last_error,json=magsafeLedLastError,proto3" json:"magsafe_led_last_error,omitempty"`                                             // Error from that failed write
+	LimitCurrentlyEnforced           bool                    `protobuf:"varint,85,opt,name=limit_currently_enforced,json=limitCurrentlyEnforced,proto3" json:"limit_currently_enforced,omitempty"`                                     // Charging is held off because charge reached the effective limit, not for another reason; false while charge is below it
+	WearPerHundredCycles             float32                 `protobuf:"fixed32,86,opt,name=wear_per_hundred_cycles,json=wearPerHundredCycles,proto3" json:"wear_per_hundred_cycles,omitempty"`                                        // Capacity lost per 100 cycles, fitted over the daily health history (see GetWearTrend); 0 until it spans 10 cycles
 	unknownFields                    protoimpl.UnknownFields
 	sizeCache                        protoimpl.SizeCache
 }
@@ -491,96 +1011,1642 @@ func (x *StatusResponse) GetLowPowerModeAvailable() bool {
 	return false
 }
 
-type MutationRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Operation     MutationOperation      `protobuf:"varint,1,opt,name=operation,proto3,enum=rpc.MutationOperation" json:"operation,omitempty"`
-	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
-	Feature       PowerFeature           `protobuf:"varint,3,opt,name=feature,proto3,enum=rpc.PowerFeature" json:"feature,omitempty"`
-	Enable        bool                   `protobuf:"varint,4,opt,name=enable,proto3" json:"enable,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *StatusResponse) GetManagementEnabled() bool {
+	if x != nil {
+		return x.ManagementEnabled
+	}
+	return false
 }
 
-func (x *MutationRequest) Reset() {
-	*x = MutationRequest{}
-	mi := &file_powergrid_proto_msgTypes[2]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *StatusResponse) GetExternalChargeHold() bool {
+	if x != nil {
+		return x.ExternalChargeHold
+	}
+	return false
 }
 
-func (x *MutationRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *StatusResponse) GetAdapterUnderperforming() bool {
+	if x != nil {
+		return x.AdapterUnderperforming
+	}
+	return false
 }
 
-func (*MutationRequest) ProtoMessage() {}
+func (x *StatusResponse) GetActiveProfile() string {
+	if x != nil {
+		return x.ActiveProfile
+	}
+	return ""
+}
 
-func (x *MutationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_powergrid_proto_msgTypes[2]
+func (x *StatusResponse) GetChargingPauseReason() ChargingPauseReason {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.ChargingPauseReason
 	}
-	return mi.MessageOf(x)
+	return ChargingPauseReason_CHARGING_PAUSE_REASON_NONE
 }
 
-// Deprecated: Use MutationRequest.ProtoReflect.Descriptor instead.
-func (*MutationRequest) Descriptor() ([]byte, []int) {
-	return file_powergrid_proto_rawDescGZIP(), []int{2}
+func (x *StatusResponse) GetAdapterKey() string {
+	if x != nil {
+		return x.AdapterKey
+	}
+	return ""
 }
 
-func (x *MutationRequest) GetOperation() MutationOperation {
+func (x *StatusResponse) GetMatchedAdapterKey() string {
 	if x != nil {
-		return x.Operation
+		return x.MatchedAdapterKey
 	}
-	return MutationOperation_MUTATION_OPERATION_UNSPECIFIED
+	return ""
 }
 
-func (x *MutationRequest) GetLimit() int32 {
+func (x *StatusResponse) GetEffectiveChargeLimit() int32 {
 	if x != nil {
-		return x.Limit
+		return x.EffectiveChargeLimit
 	}
 	return 0
 }
 
-func (x *MutationRequest) GetFeature() PowerFeature {
+func (x *StatusResponse) GetForceDischargeFloor() int32 {
 	if x != nil {
-		return x.Feature
+		return x.ForceDischargeFloor
+	}
+	return 0
+}
+
+func (x *StatusResponse) GetForceDischargeFloorReached() bool {
+	if x != nil {
+		return x.ForceDischargeFloorReached
+	}
+	return false
+}
+
+func (x *StatusResponse) GetBatteryMissing() bool {
+	if x != nil {
+		return x.BatteryMissing
+	}
+	return false
+}
+
+func (x *StatusResponse) GetBoostRemainingSeconds() int32 {
+	if x != nil {
+		return x.BoostRemainingSeconds
+	}
+	return 0
+}
+
+func (x *StatusResponse) GetChargedAtLimit() bool {
+	if x != nil {
+		return x.ChargedAtLimit
+	}
+	return false
+}
+
+func (x *StatusResponse) GetChargeManagerConflict() bool {
+	if x != nil {
+		return x.ChargeManagerConflict
+	}
+	return false
+}
+
+func (x *StatusResponse) GetChargeManagerConflictDetail() string {
+	if x != nil {
+		return x.ChargeManagerConflictDetail
+	}
+	return ""
+}
+
+func (x *StatusResponse) GetMagsafeLedSupport() MagsafeLedSupport {
+	if x != nil {
+		return x.MagsafeLedSupport
+	}
+	return MagsafeLedSupport_MAGSAFE_LED_SUPPORT_UNKNOWN
+}
+
+func (x *StatusResponse) GetChargingStalled() bool {
+	if x != nil {
+		return x.ChargingStalled
+	}
+	return false
+}
+
+func (x *StatusResponse) GetPinnedPid() int32 {
+	if x != nil {
+		return x.PinnedPid
+	}
+	return 0
+}
+
+func (x *StatusResponse) GetBatteryState() BatteryState {
+	if x != nil {
+		return x.BatteryState
+	}
+	return BatteryState_BATTERY_STATE_UNSPECIFIED
+}
+
+func (x *StatusResponse) GetPersistSleepPreventionActive() bool {
+	if x != nil {
+		return x.PersistSleepPreventionActive
+	}
+	return false
+}
+
+func (x *StatusResponse) GetPreventDisplaySleepAuto() bool {
+	if x != nil {
+		return x.PreventDisplaySleepAuto
+	}
+	return false
+}
+
+func (x *StatusResponse) GetRampedChargeLimit() int32 {
+	if x != nil {
+		return x.RampedChargeLimit
+	}
+	return 0
+}
+
+func (x *StatusResponse) GetActuallyCharging() bool {
+	if x != nil {
+		return x.ActuallyCharging
+	}
+	return false
+}
+
+func (x *StatusResponse) GetMagsafeLedStates() []string {
+	if x != nil {
+		return x.MagsafeLedStates
+	}
+	return nil
+}
+
+func (x *StatusResponse) GetFullByUnix() int64 {
+	if x != nil {
+		return x.FullByUnix
+	}
+	return 0
+}
+
+func (x *StatusResponse) GetFullByTarget() int32 {
+	if x != nil {
+		return x.FullByTarget
+	}
+	return 0
+}
+
+func (x *StatusResponse) GetFullByStartUnix() int64 {
+	if x != nil {
+		return x.FullByStartUnix
+	}
+	return 0
+}
+
+func (x *StatusResponse) GetMinChargeLimit() int32 {
+	if x != nil {
+		return x.MinChargeLimit
+	}
+	return 0
+}
+
+func (x *StatusResponse) GetSuspendRemainingSeconds() int32 {
+	if x != nil {
+		return x.SuspendRemainingSeconds
+	}
+	return 0
+}
+
+func (x *StatusResponse) GetUpsAttached() bool {
+	if x != nil {
+		return x.UpsAttached
+	}
+	return false
+}
+
+func (x *StatusResponse) GetPowerSource() PowerSource {
+	if x != nil {
+		return x.PowerSource
+	}
+	return PowerSource_POWER_SOURCE_UNKNOWN
+}
+
+func (x *StatusResponse) GetMagsafeLedMode() MagsafeLedMode {
+	if x != nil {
+		return x.MagsafeLedMode
+	}
+	return MagsafeLedMode_MAGSAFE_LED_MODE_UNSPECIFIED
+}
+
+func (x *StatusResponse) GetMagsafeLedGreenOnlyWhenFull() bool {
+	if x != nil {
+		return x.MagsafeLedGreenOnlyWhenFull
+	}
+	return false
+}
+
+func (x *StatusResponse) GetSafeMode() bool {
+	if x != nil {
+		return x.SafeMode
+	}
+	return false
+}
+
+func (x *StatusResponse) GetWarmingUp() bool {
+	if x != nil {
+		return x.WarmingUp
+	}
+	return false
+}
+
+func (x *StatusResponse) GetDischargeBandFloor() int32 {
+	if x != nil {
+		return x.DischargeBandFloor
+	}
+	return 0
+}
+
+func (x *StatusResponse) GetDischargeBandCeiling() int32 {
+	if x != nil {
+		return x.DischargeBandCeiling
+	}
+	return 0
+}
+
+func (x *StatusResponse) GetDischargeBandActive() bool {
+	if x != nil {
+		return x.DischargeBandActive
+	}
+	return false
+}
+
+func (x *StatusResponse) GetSystemDisplaySleepPrevented() bool {
+	if x != nil {
+		return x.SystemDisplaySleepPrevented
+	}
+	return false
+}
+
+func (x *StatusResponse) GetSystemSleepPrevented() bool {
+	if x != nil {
+		return x.SystemSleepPrevented
+	}
+	return false
+}
+
+func (x *StatusResponse) GetExternalSleepAssertions() []string {
+	if x != nil {
+		return x.ExternalSleepAssertions
+	}
+	return nil
+}
+
+func (x *StatusResponse) GetManualCharging() ManualCharging {
+	if x != nil {
+		return x.ManualCharging
+	}
+	return ManualCharging_MANUAL_CHARGING_NONE
+}
+
+func (x *StatusResponse) GetCriticalCharge() bool {
+	if x != nil {
+		return x.CriticalCharge
+	}
+	return false
+}
+
+func (x *StatusResponse) GetMagsafeLedCommittedState() string {
+	if x != nil {
+		return x.MagsafeLedCommittedState
+	}
+	return ""
+}
+
+func (x *StatusResponse) GetMagsafeLedTransitions() []*MagsafeLedTransition {
+	if x != nil {
+		return x.MagsafeLedTransitions
+	}
+	return nil
+}
+
+func (x *StatusResponse) GetMagsafeLedWritePending() bool {
+	if x != nil {
+		return x.MagsafeLedWritePending
+	}
+	return false
+}
+
+func (x *StatusResponse) GetMagsafeLedLastFailureUnix() int64 {
+	if x != nil {
+		return x.MagsafeLedLastFailureUnix
+	}
+	return 0
+}
+
+func (x *StatusResponse) GetMagsafeLedLastError() string {
+	if x != nil {
+		return x.MagsafeLedLastError
+	}
+	return ""
+}
+
+func (x *StatusResponse) GetLimitCurrentlyEnforced() bool {
+	if x != nil {
+		return x.LimitCurrentlyEnforced
+	}
+	return false
+}
+
+func (x *StatusResponse) GetWearPerHundredCycles() float32 {
+	if x != nil {
+		return x.WearPerHundredCycles
+	}
+	return 0
+}
+
+type MutationRequest struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Operation            MutationOperation      `protobuf:"varint,1,opt,name=operation,proto3,enum=rpc.MutationOperation" json:"operation,omitempty"`
+	Limit                int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Feature              PowerFeature           `protobuf:"varint,3,opt,name=feature,proto3,enum=rpc.PowerFeature" json:"feature,omitempty"`
+	Enable               bool                   `protobuf:"varint,4,opt,name=enable,proto3" json:"enable,omitempty"`
+	ProfileName          string                 `protobuf:"bytes,5,opt,name=profile_name,json=profileName,proto3" json:"profile_name,omitempty"`
+	Profile              *ChargeProfile         `protobuf:"bytes,6,opt,name=profile,proto3" json:"profile,omitempty"`
+	AdapterKey           string                 `protobuf:"bytes,7,opt,name=adapter_key,json=adapterKey,proto3" json:"adapter_key,omitempty"`
+	BoostMinutes         int32                  `protobuf:"varint,8,opt,name=boost_minutes,json=boostMinutes,proto3" json:"boost_minutes,omitempty"`
+	Pid                  int32                  `protobuf:"varint,9,opt,name=pid,proto3" json:"pid,omitempty"`
+	FullByUnix           int64                  `protobuf:"varint,10,opt,name=full_by_unix,json=fullByUnix,proto3" json:"full_by_unix,omitempty"`
+	SuspendMinutes       int32                  `protobuf:"varint,11,opt,name=suspend_minutes,json=suspendMinutes,proto3" json:"suspend_minutes,omitempty"`
+	MagsafeLedMode       MagsafeLedMode         `protobuf:"varint,12,opt,name=magsafe_led_mode,json=magsafeLedMode,proto3,enum=rpc.MagsafeLedMode" json:"magsafe_led_mode,omitempty"`
+	DischargeBandFloor   int32                  `protobuf:"varint,13,opt,name=discharge_band_floor,json=dischargeBandFloor,proto3" json:"discharge_band_floor,omitempty"`           // SET_DISCHARGE_BAND: discharge stops at this charge
+	DischargeBandCeiling int32                  `protobuf:"varint,14,opt,name=discharge_band_ceiling,json=dischargeBandCeiling,proto3" json:"discharge_band_ceiling,omitempty"`     // SET_DISCHARGE_BAND: discharge starts above this charge
+	ManualCharging       ManualCharging         `protobuf:"varint,15,opt,name=manual_charging,json=manualCharging,proto3,enum=rpc.ManualCharging" json:"manual_charging,omitempty"` // MANUAL_CHARGING: state to hold, or NONE to release
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *MutationRequest) Reset() {
+	*x = MutationRequest{}
+	mi := &file_powergrid_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MutationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MutationRequest) ProtoMessage() {}
+
+func (x *MutationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_powergrid_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MutationRequest.ProtoReflect.Descriptor instead.
+func (*MutationRequest) Descriptor() ([]byte, []int) {
+	return file_powergrid_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *MutationRequest) GetOperation() MutationOperation {
+	if x != nil {
+		return x.Operation
+	}
+	return MutationOperation_MUTATION_OPERATION_UNSPECIFIED
+}
+
+func (x *MutationRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *MutationRequest) GetFeature() PowerFeature {
+	if x != nil {
+		return x.Feature
 	}
 	return PowerFeature_POWER_FEATURE_UNSPECIFIED
 }
 
-func (x *MutationRequest) GetEnable() bool {
+func (x *MutationRequest) GetEnable() bool {
+	if x != nil {
+		return x.Enable
+	}
+	return false
+}
+
+func (x *MutationRequest) GetProfileName() string {
+	if x != nil {
+		return x.ProfileName
+	}
+	return ""
+}
+
+func (x *MutationRequest) GetProfile() *ChargeProfile {
+	if x != nil {
+		return x.Profile
+	}
+	return nil
+}
+
+func (x *MutationRequest) GetAdapterKey() string {
+	if x != nil {
+		return x.AdapterKey
+	}
+	return ""
+}
+
+func (x *MutationRequest) GetBoostMinutes() int32 {
+	if x != nil {
+		return x.BoostMinutes
+	}
+	return 0
+}
+
+func (x *MutationRequest) GetPid() int32 {
+	if x != nil {
+		return x.Pid
+	}
+	return 0
+}
+
+func (x *MutationRequest) GetFullByUnix() int64 {
+	if x != nil {
+		return x.FullByUnix
+	}
+	return 0
+}
+
+func (x *MutationRequest) GetSuspendMinutes() int32 {
+	if x != nil {
+		return x.SuspendMinutes
+	}
+	return 0
+}
+
+func (x *MutationRequest) GetMagsafeLedMode() MagsafeLedMode {
+	if x != nil {
+		return x.MagsafeLedMode
+	}
+	return MagsafeLedMode_MAGSAFE_LED_MODE_UNSPECIFIED
+}
+
+func (x *MutationRequest) GetDischargeBandFloor() int32 {
+	if x != nil {
+		return x.DischargeBandFloor
+	}
+	return 0
+}
+
+func (x *MutationRequest) GetDischargeBandCeiling() int32 {
+	if x != nil {
+		return x.DischargeBandCeiling
+	}
+	return 0
+}
+
+func (x *MutationRequest) GetManualCharging() ManualCharging {
+	if x != nil {
+		return x.ManualCharging
+	}
+	return ManualCharging_MANUAL_CHARGING_NONE
+}
+
+type VersionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BuildId       string                 `protobuf:"bytes,1,opt,name=build_id,json=buildId,proto3" json:"build_id,omitempty"`       // Daemon build identifier (e.g., SHA-256 of executable)
+	GitCommit     string                 `protobuf:"bytes,2,opt,name=git_commit,json=gitCommit,proto3" json:"git_commit,omitempty"` // Full commit hash the daemon was built from; empty when unknown
+	BuildTime     string                 `protobuf:"bytes,3,opt,name=build_time,json=buildTime,proto3" json:"build_time,omitempty"` // RFC 3339 build timestamp; empty when unknown
+	Version       string                 `protobuf:"bytes,4,opt,name=version,proto3" json:"version,omitempty"`                      // Semantic version of the release, separate from build_id
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VersionResponse) Reset() {
+	*x = VersionResponse{}
+	mi := &file_powergrid_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VersionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VersionResponse) ProtoMessage() {}
+
+func (x *VersionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_powergrid_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VersionResponse.ProtoReflect.Descriptor instead.
+func (*VersionResponse) Descriptor() ([]byte, []int) {
+	return file_powergrid_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *VersionResponse) GetBuildId() string {
+	if x != nil {
+		return x.BuildId
+	}
+	return ""
+}
+
+func (x *VersionResponse) GetGitCommit() string {
+	if x != nil {
+		return x.GitCommit
+	}
+	return ""
+}
+
+func (x *VersionResponse) GetBuildTime() string {
+	if x != nil {
+		return x.BuildTime
+	}
+	return ""
+}
+
+func (x *VersionResponse) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+type DaemonInfoResponse struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	BuildId             string                 `protobuf:"bytes,1,opt,name=build_id,json=buildId,proto3" json:"build_id,omitempty"`
+	AuthMode            string                 `protobuf:"bytes,2,opt,name=auth_mode,json=authMode,proto3" json:"auth_mode,omitempty"`
+	MagsafeLedSupported bool                   `protobuf:"varint,3,opt,name=magsafe_led_supported,json=magsafeLedSupported,proto3" json:"magsafe_led_supported,omitempty"`
+	BuildIdSource       string                 `protobuf:"bytes,4,opt,name=build_id_source,json=buildIdSource,proto3" json:"build_id_source,omitempty"` // git, override, fallback, unknown
+	BuildDirty          bool                   `protobuf:"varint,5,opt,name=build_dirty,json=buildDirty,proto3" json:"build_dirty,omitempty"`
+	ApiMajor            uint32                 `protobuf:"varint,6,opt,name=api_major,json=apiMajor,proto3" json:"api_major,omitempty"`
+	ApiMinor            uint32                 `protobuf:"varint,7,opt,name=api_minor,json=apiMinor,proto3" json:"api_minor,omitempty"`
+	Capabilities        []string               `protobuf:"bytes,8,rep,name=capabilities,proto3" json:"capabilities,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *DaemonInfoResponse) Reset() {
+	*x = DaemonInfoResponse{}
+	mi := &file_powergrid_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DaemonInfoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DaemonInfoResponse) ProtoMessage() {}
+
+func (x *DaemonInfoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_powergrid_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DaemonInfoResponse.ProtoReflect.Descriptor instead.
+func (*DaemonInfoResponse) Descriptor() ([]byte, []int) {
+	return file_powergrid_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *DaemonInfoResponse) GetBuildId() string {
+	if x != nil {
+		return x.BuildId
+	}
+	return ""
+}
+
+func (x *DaemonInfoResponse) GetAuthMode() string {
+	if x != nil {
+		return x.AuthMode
+	}
+	return ""
+}
+
+func (x *DaemonInfoResponse) GetMagsafeLedSupported() bool {
+	if x != nil {
+		return x.MagsafeLedSupported
+	}
+	return false
+}
+
+func (x *DaemonInfoResponse) GetBuildIdSource() string {
+	if x != nil {
+		return x.BuildIdSource
+	}
+	return ""
+}
+
+func (x *DaemonInfoResponse) GetBuildDirty() bool {
+	if x != nil {
+		return x.BuildDirty
+	}
+	return false
+}
+
+func (x *DaemonInfoResponse) GetApiMajor() uint32 {
+	if x != nil {
+		return x.ApiMajor
+	}
+	return 0
+}
+
+func (x *DaemonInfoResponse) GetApiMinor() uint32 {
+	if x != nil {
+		return x.ApiMinor
+	}
+	return 0
+}
+
+func (x *DaemonInfoResponse) GetCapabilities() []string {
+	if x != nil {
+		return x.Capabilities
+	}
+	return nil
+}
+
+type AdapterDetailsResponse struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Connected          bool                   `protobuf:"varint,1,opt,name=connected,proto3" json:"connected,omitempty"`                                             // False when running on battery; remaining fields are zero
+	Description        string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`                                          // IOKit.Adapter.Description
+	MaxWatts           int32                  `protobuf:"varint,3,opt,name=max_watts,json=maxWatts,proto3" json:"max_watts,omitempty"`                               // IOKit.Adapter.MaxWatts (W)
+	MaxVoltage         float32                `protobuf:"fixed32,4,opt,name=max_voltage,json=maxVoltage,proto3" json:"max_voltage,omitempty"`                        // IOKit.Adapter.MaxVoltage (V)
+	MaxAmperage        float32                `protobuf:"fixed32,5,opt,name=max_amperage,json=maxAmperage,proto3" json:"max_amperage,omitempty"`                     // IOKit.Adapter.MaxAmperage (A)
+	InputVoltage       float32                `protobuf:"fixed32,6,opt,name=input_voltage,json=inputVoltage,proto3" json:"input_voltage,omitempty"`                  // IOKit.Adapter.InputVoltage (V)
+	InputAmperage      float32                `protobuf:"fixed32,7,opt,name=input_amperage,json=inputAmperage,proto3" json:"input_amperage,omitempty"`               // IOKit.Adapter.InputAmperage (A)
+	InputWatts         float32                `protobuf:"fixed32,8,opt,name=input_watts,json=inputWatts,proto3" json:"input_watts,omitempty"`                        // IOKit.Calculations.AdapterPower (W)
+	TelemetryAvailable bool                   `protobuf:"varint,9,opt,name=telemetry_available,json=telemetryAvailable,proto3" json:"telemetry_available,omitempty"` // IOKit.Adapter.TelemetryAvailable
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *AdapterDetailsResponse) Reset() {
+	*x = AdapterDetailsResponse{}
+	mi := &file_powergrid_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdapterDetailsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdapterDetailsResponse) ProtoMessage() {}
+
+func (x *AdapterDetailsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_powergrid_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdapterDetailsResponse.ProtoReflect.Descriptor instead.
+func (*AdapterDetailsResponse) Descriptor() ([]byte, []int) {
+	return file_powergrid_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *AdapterDetailsResponse) GetConnected() bool {
+	if x != nil {
+		return x.Connected
+	}
+	return false
+}
+
+func (x *AdapterDetailsResponse) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *AdapterDetailsResponse) GetMaxWatts() int32 {
+	if x != nil {
+		return x.MaxWatts
+	}
+	return 0
+}
+
+func (x *AdapterDetailsResponse) GetMaxVoltage() float32 {
+	if x != nil {
+		return x.MaxVoltage
+	}
+	return 0
+}
+
+func (x *AdapterDetailsResponse) GetMaxAmperage() float32 {
+	if x != nil {
+		return x.MaxAmperage
+	}
+	return 0
+}
+
+func (x *AdapterDetailsResponse) GetInputVoltage() float32 {
+	if x != nil {
+		return x.InputVoltage
+	}
+	return 0
+}
+
+func (x *AdapterDetailsResponse) GetInputAmperage() float32 {
+	if x != nil {
+		return x.InputAmperage
+	}
+	return 0
+}
+
+func (x *AdapterDetailsResponse) GetInputWatts() float32 {
+	if x != nil {
+		return x.InputWatts
+	}
+	return 0
+}
+
+func (x *AdapterDetailsResponse) GetTelemetryAvailable() bool {
+	if x != nil {
+		return x.TelemetryAvailable
+	}
+	return false
+}
+
+type ChargeProfile struct {
+	state                      protoimpl.MessageState `protogen:"open.v1"`
+	Name                       string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	ChargeLimit                int32                  `protobuf:"varint,2,opt,name=charge_limit,json=chargeLimit,proto3" json:"charge_limit,omitempty"` // MinChargeLimit-100
+	ControlMagsafeLed          bool                   `protobuf:"varint,3,opt,name=control_magsafe_led,json=controlMagsafeLed,proto3" json:"control_magsafe_led,omitempty"`
+	DisableChargingBeforeSleep bool                   `protobuf:"varint,4,opt,name=disable_charging_before_sleep,json=disableChargingBeforeSleep,proto3" json:"disable_charging_before_sleep,omitempty"`
+	unknownFields              protoimpl.UnknownFields
+	sizeCache                  protoimpl.SizeCache
+}
+
+func (x *ChargeProfile) Reset() {
+	*x = ChargeProfile{}
+	mi := &file_powergrid_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChargeProfile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChargeProfile) ProtoMessage() {}
+
+func (x *ChargeProfile) ProtoReflect() protoreflect.Message {
+	mi := &file_powergrid_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChargeProfile.ProtoReflect.Descriptor instead.
+func (*ChargeProfile) Descriptor() ([]byte, []int) {
+	return file_powergrid_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ChargeProfile) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ChargeProfile) GetChargeLimit() int32 {
+	if x != nil {
+		return x.ChargeLimit
+	}
+	return 0
+}
+
+func (x *ChargeProfile) GetControlMagsafeLed() bool {
+	if x != nil {
+		return x.ControlMagsafeLed
+	}
+	return false
+}
+
+func (x *ChargeProfile) GetDisableChargingBeforeSleep() bool {
+	if x != nil {
+		return x.DisableChargingBeforeSleep
+	}
+	return false
+}
+
+type ProfileListResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Profiles      []*ChargeProfile       `protobuf:"bytes,1,rep,name=profiles,proto3" json:"profiles,omitempty"`                                // Built-in profiles merged with the console user's stored ones, sorted by name
+	ActiveProfile string                 `protobuf:"bytes,2,opt,name=active_profile,json=activeProfile,proto3" json:"active_profile,omitempty"` // Empty when no profile is active
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProfileListResponse) Reset() {
+	*x = ProfileListResponse{}
+	mi := &file_powergrid_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProfileListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProfileListResponse) ProtoMessage() {}
+
+func (x *ProfileListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_powergrid_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProfileListResponse.ProtoReflect.Descriptor instead.
+func (*ProfileListResponse) Descriptor() ([]byte, []int) {
+	return file_powergrid_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ProfileListResponse) GetProfiles() []*ChargeProfile {
+	if x != nil {
+		return x.Profiles
+	}
+	return nil
+}
+
+func (x *ProfileListResponse) GetActiveProfile() string {
+	if x != nil {
+		return x.ActiveProfile
+	}
+	return ""
+}
+
+type EffectiveSetting struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`       // Preference key, e.g. ChargeLimit
+	Value         string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`   // Resolved value as text
+	Source        string                 `protobuf:"bytes,3,opt,name=source,proto3" json:"source,omitempty"` // user | system | default
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EffectiveSetting) Reset() {
+	*x = EffectiveSetting{}
+	mi := &file_powergrid_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EffectiveSetting) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EffectiveSetting) ProtoMessage() {}
+
+func (x *EffectiveSetting) ProtoReflect() protoreflect.Message {
+	mi := &file_powergrid_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EffectiveSetting.ProtoReflect.Descriptor instead.
+func (*EffectiveSetting) Descriptor() ([]byte, []int) {
+	return file_powergrid_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *EffectiveSetting) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *EffectiveSetting) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *EffectiveSetting) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+type EffectiveSettingsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Settings      []*EffectiveSetting    `protobuf:"bytes,1,rep,name=settings,proto3" json:"settings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EffectiveSettingsResponse) Reset() {
+	*x = EffectiveSettingsResponse{}
+	mi := &file_powergrid_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EffectiveSettingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EffectiveSettingsResponse) ProtoMessage() {}
+
+func (x *EffectiveSettingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_powergrid_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EffectiveSettingsResponse.ProtoReflect.Descriptor instead.
+func (*EffectiveSettingsResponse) Descriptor() ([]byte, []int) {
+	return file_powergrid_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *EffectiveSettingsResponse) GetSettings() []*EffectiveSetting {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+type LogsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Lines         int32                  `protobuf:"varint,1,opt,name=lines,proto3" json:"lines,omitempty"` // Newest entries to return; 0 or less returns everything kept
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogsRequest) Reset() {
+	*x = LogsRequest{}
+	mi := &file_powergrid_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogsRequest) ProtoMessage() {}
+
+func (x *LogsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_powergrid_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogsRequest.ProtoReflect.Descriptor instead.
+func (*LogsRequest) Descriptor() ([]byte, []int) {
+	return file_powergrid_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *LogsRequest) GetLines() int32 {
+	if x != nil {
+		return x.Lines
+	}
+	return 0
+}
+
+type LogEntry struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	TimestampUnixMs int64                  `protobuf:"varint,1,opt,name=timestamp_unix_ms,json=timestampUnixMs,proto3" json:"timestamp_unix_ms,omitempty"`
+	Level           string                 `protobuf:"bytes,2,opt,name=level,proto3" json:"level,omitempty"` // default | info | error | fault
+	Message         string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *LogEntry) Reset() {
+	*x = LogEntry{}
+	mi := &file_powergrid_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogEntry) ProtoMessage() {}
+
+func (x *LogEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_powergrid_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogEntry.ProtoReflect.Descriptor instead.
+func (*LogEntry) Descriptor() ([]byte, []int) {
+	return file_powergrid_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *LogEntry) GetTimestampUnixMs() int64 {
+	if x != nil {
+		return x.TimestampUnixMs
+	}
+	return 0
+}
+
+func (x *LogEntry) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+func (x *LogEntry) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type LogsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*LogEntry            `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"` // Oldest first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogsResponse) Reset() {
+	*x = LogsResponse{}
+	mi := &file_powergrid_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogsResponse) ProtoMessage() {}
+
+func (x *LogsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_powergrid_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogsResponse.ProtoReflect.Descriptor instead.
+func (*LogsResponse) Descriptor() ([]byte, []int) {
+	return file_powergrid_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *LogsResponse) GetEntries() []*LogEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type Diagnostic struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` // e.g. health, smc_failures
+	Value         string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Diagnostic) Reset() {
+	*x = Diagnostic{}
+	mi := &file_powergrid_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Diagnostic) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Diagnostic) ProtoMessage() {}
+
+func (x *Diagnostic) ProtoReflect() protoreflect.Message {
+	mi := &file_powergrid_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Diagnostic.ProtoReflect.Descriptor instead.
+func (*Diagnostic) Descriptor() ([]byte, []int) {
+	return file_powergrid_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Diagnostic) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Diagnostic) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type SupportBundleResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	GeneratedUnixMs int64                  `protobuf:"varint,1,opt,name=generated_unix_ms,json=generatedUnixMs,proto3" json:"generated_unix_ms,omitempty"`
+	DaemonInfo      *DaemonInfoResponse    `protobuf:"bytes,2,opt,name=daemon_info,json=daemonInfo,proto3" json:"daemon_info,omitempty"`
+	HardwareModel   string                 `protobuf:"bytes,3,opt,name=hardware_model,json=hardwareModel,proto3" json:"hardware_model,omitempty"` // sysctl hw.model, e.g. Mac15,6
+	OsVersion       string                 `protobuf:"bytes,4,opt,name=os_version,json=osVersion,proto3" json:"os_version,omitempty"`             // sysctl kern.osproductversion
+	Status          *StatusResponse        `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	Settings        []*EffectiveSetting    `protobuf:"bytes,6,rep,name=settings,proto3" json:"settings,omitempty"`
+	Logs            []*LogEntry            `protobuf:"bytes,7,rep,name=logs,proto3" json:"logs,omitempty"`                                  // Newest support_bundle log lines, oldest first
+	Diagnostics     []*Diagnostic          `protobuf:"bytes,8,rep,name=diagnostics,proto3" json:"diagnostics,omitempty"`                    // Health and internal state
+	ConsoleUser     string                 `protobuf:"bytes,9,opt,name=console_user,json=consoleUser,proto3" json:"console_user,omitempty"` // Hash standing in for the console user's name; empty when nobody is logged in
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *SupportBundleResponse) Reset() {
+	*x = SupportBundleResponse{}
+	mi := &file_powergrid_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SupportBundleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SupportBundleResponse) ProtoMessage() {}
+
+func (x *SupportBundleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_powergrid_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SupportBundleResponse.ProtoReflect.Descriptor instead.
+func (*SupportBundleResponse) Descriptor() ([]byte, []int) {
+	return file_powergrid_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SupportBundleResponse) GetGeneratedUnixMs() int64 {
+	if x != nil {
+		return x.GeneratedUnixMs
+	}
+	return 0
+}
+
+func (x *SupportBundleResponse) GetDaemonInfo() *DaemonInfoResponse {
+	if x != nil {
+		return x.DaemonInfo
+	}
+	return nil
+}
+
+func (x *SupportBundleResponse) GetHardwareModel() string {
+	if x != nil {
+		return x.HardwareModel
+	}
+	return ""
+}
+
+func (x *SupportBundleResponse) GetOsVersion() string {
+	if x != nil {
+		return x.OsVersion
+	}
+	return ""
+}
+
+func (x *SupportBundleResponse) GetStatus() *StatusResponse {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *SupportBundleResponse) GetSettings() []*EffectiveSetting {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+func (x *SupportBundleResponse) GetLogs() []*LogEntry {
+	if x != nil {
+		return x.Logs
+	}
+	return nil
+}
+
+func (x *SupportBundleResponse) GetDiagnostics() []*Diagnostic {
+	if x != nil {
+		return x.Diagnostics
+	}
+	return nil
+}
+
+func (x *SupportBundleResponse) GetConsoleUser() string {
+	if x != nil {
+		return x.ConsoleUser
+	}
+	return ""
+}
+
+type CountersResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Day              string                 `protobuf:"bytes,1,opt,name=day,proto3" json:"day,omitempty"`                                                    // Local date the counts cover, YYYY-MM-DD
+	ChargingEnabled  int32                  `protobuf:"varint,2,opt,name=charging_enabled,json=chargingEnabled,proto3" json:"charging_enabled,omitempty"`    // Times the charging logic re-enabled charging that day
+	ChargingDisabled int32                  `protobuf:"varint,3,opt,name=charging_disabled,json=chargingDisabled,proto3" json:"charging_disabled,omitempty"` // Times the charging logic disabled charging that day
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *CountersResponse) Reset() {
+	*x = CountersResponse{}
+	mi := &file_powergrid_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CountersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountersResponse) ProtoMessage() {}
+
+func (x *CountersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_powergrid_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CountersResponse.ProtoReflect.Descriptor instead.
+func (*CountersResponse) Descriptor() ([]byte, []int) {
+	return file_powergrid_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CountersResponse) GetDay() string {
+	if x != nil {
+		return x.Day
+	}
+	return ""
+}
+
+func (x *CountersResponse) GetChargingEnabled() int32 {
+	if x != nil {
+		return x.ChargingEnabled
+	}
+	return 0
+}
+
+func (x *CountersResponse) GetChargingDisabled() int32 {
+	if x != nil {
+		return x.ChargingDisabled
+	}
+	return 0
+}
+
+type SMCKeysRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Keys          []string               `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"` // Four-character SMC keys, e.g. CHTE; at most 16
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SMCKeysRequest) Reset() {
+	*x = SMCKeysRequest{}
+	mi := &file_powergrid_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SMCKeysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SMCKeysRequest) ProtoMessage() {}
+
+func (x *SMCKeysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_powergrid_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SMCKeysRequest.ProtoReflect.Descriptor instead.
+func (*SMCKeysRequest) Descriptor() ([]byte, []int) {
+	return file_powergrid_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *SMCKeysRequest) GetKeys() []string {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+type SMCKeyValue struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	DataType      string                 `protobuf:"bytes,2,opt,name=data_type,json=dataType,proto3" json:"data_type,omitempty"` // SMC type code, e.g. ui8, flt
+	DataSize      int32                  `protobuf:"varint,3,opt,name=data_size,json=dataSize,proto3" json:"data_size,omitempty"`
+	Data          []byte                 `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"` // Raw, undecoded bytes
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SMCKeyValue) Reset() {
+	*x = SMCKeyValue{}
+	mi := &file_powergrid_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SMCKeyValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SMCKeyValue) ProtoMessage() {}
+
+func (x *SMCKeyValue) ProtoReflect() protoreflect.Message {
+	mi := &file_powergrid_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SMCKeyValue.ProtoReflect.Descriptor instead.
+func (*SMCKeyValue) Descriptor() ([]byte, []int) {
+	return file_powergrid_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *SMCKeyValue) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *SMCKeyValue) GetDataType() string {
+	if x != nil {
+		return x.DataType
+	}
+	return ""
+}
+
+func (x *SMCKeyValue) GetDataSize() int32 {
+	if x != nil {
+		return x.DataSize
+	}
+	return 0
+}
+
+func (x *SMCKeyValue) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type SMCKeysResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Values        []*SMCKeyValue         `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"` // In request order; keys the SMC does not know are omitted
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SMCKeysResponse) Reset() {
+	*x = SMCKeysResponse{}
+	mi := &file_powergrid_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SMCKeysResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SMCKeysResponse) ProtoMessage() {}
+
+func (x *SMCKeysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_powergrid_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SMCKeysResponse.ProtoReflect.Descriptor instead.
+func (*SMCKeysResponse) Descriptor() ([]byte, []int) {
+	return file_powergrid_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *SMCKeysResponse) GetValues() []*SMCKeyValue {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+type UserSettings struct {
+	state                      protoimpl.MessageState `protogen:"open.v1"`
+	Username                   string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Uid                        uint32                 `protobuf:"varint,2,opt,name=uid,proto3" json:"uid,omitempty"`
+	ChargeLimit                int32                  `protobuf:"varint,3,opt,name=charge_limit,json=chargeLimit,proto3" json:"charge_limit,omitempty"`                              // Saved ChargeLimit; 0 when unset
+	EffectiveChargeLimit       int32                  `protobuf:"varint,4,opt,name=effective_charge_limit,json=effectiveChargeLimit,proto3" json:"effective_charge_limit,omitempty"` // Limit that applies at login after system and default fallbacks
+	MagsafeLedMode             MagsafeLedMode         `protobuf:"varint,5,opt,name=magsafe_led_mode,json=magsafeLedMode,proto3,enum=rpc.MagsafeLedMode" json:"magsafe_led_mode,omitempty"`
+	DisableChargingBeforeSleep bool                   `protobuf:"varint,6,opt,name=disable_charging_before_sleep,json=disableChargingBeforeSleep,proto3" json:"disable_charging_before_sleep,omitempty"`
+	unknownFields              protoimpl.UnknownFields
+	sizeCache                  protoimpl.SizeCache
+}
+
+func (x *UserSettings) Reset() {
+	*x = UserSettings{}
+	mi := &file_powergrid_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserSettings) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserSettings) ProtoMessage() {}
+
+func (x *UserSettings) ProtoReflect() protoreflect.Message {
+	mi := &file_powergrid_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserSettings.ProtoReflect.Descriptor instead.
+func (*UserSettings) Descriptor() ([]byte, []int) {
+	return file_powergrid_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *UserSettings) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *UserSettings) GetUid() uint32 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *UserSettings) GetChargeLimit() int32 {
+	if x != nil {
+		return x.ChargeLimit
+	}
+	return 0
+}
+
+func (x *UserSettings) GetEffectiveChargeLimit() int32 {
 	if x != nil {
-		return x.Enable
+		return x.EffectiveChargeLimit
+	}
+	return 0
+}
+
+func (x *UserSettings) GetMagsafeLedMode() MagsafeLedMode {
+	if x != nil {
+		return x.MagsafeLedMode
+	}
+	return MagsafeLedMode_MAGSAFE_LED_MODE_UNSPECIFIED
+}
+
+func (x *UserSettings) GetDisableChargingBeforeSleep() bool {
+	if x != nil {
+		return x.DisableChargingBeforeSleep
 	}
 	return false
 }
 
-type VersionResponse struct {
+type UserSettingsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	BuildId       string                 `protobuf:"bytes,1,opt,name=build_id,json=buildId,proto3" json:"build_id,omitempty"` // Daemon build identifier (e.g., SHA-256 of executable)
+	Users         []*UserSettings        `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"` // Local accounts with a PowerGrid preferences file, by username
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *VersionResponse) Reset() {
-	*x = VersionResponse{}
-	mi := &file_powergrid_proto_msgTypes[3]
+func (x *UserSettingsResponse) Reset() {
+	*x = UserSettingsResponse{}
+	mi := &file_powergrid_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *VersionResponse) String() string {
+func (x *UserSettingsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*VersionResponse) ProtoMessage() {}
+func (*UserSettingsResponse) ProtoMessage() {}
 
-func (x *VersionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_powergrid_proto_msgTypes[3]
+func (x *UserSettingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_powergrid_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -591,47 +2657,47 @@ func (x *VersionResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use VersionResponse.ProtoReflect.Descriptor instead.
-func (*VersionResponse) Descriptor() ([]byte, []int) {
-	return file_powergrid_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use UserSettingsResponse.ProtoReflect.Descriptor instead.
+func (*UserSettingsResponse) Descriptor() ([]byte, []int) {
+	return file_powergrid_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *VersionResponse) GetBuildId() string {
+func (x *UserSettingsResponse) GetUsers() []*UserSettings {
 	if x != nil {
-		return x.BuildId
+		return x.Users
 	}
-	return ""
+	return nil
 }
 
-type DaemonInfoResponse struct {
-	state               protoimpl.MessageState `protogen:"open.v1"`
-	BuildId             string                 `protobuf:"bytes,1,opt,name=build_id,json=buildId,proto3" json:"build_id,omitempty"`
-	AuthMode            string                 `protobuf:"bytes,2,opt,name=auth_mode,json=authMode,proto3" json:"auth_mode,omitempty"`
-	MagsafeLedSupported bool                   `protobuf:"varint,3,opt,name=magsafe_led_supported,json=magsafeLedSupported,proto3" json:"magsafe_led_supported,omitempty"`
-	BuildIdSource       string                 `protobuf:"bytes,4,opt,name=build_id_source,json=buildIdSource,proto3" json:"build_id_source,omitempty"` // git, override, fallback, unknown
-	BuildDirty          bool                   `protobuf:"varint,5,opt,name=build_dirty,json=buildDirty,proto3" json:"build_dirty,omitempty"`
-	ApiMajor            uint32                 `protobuf:"varint,6,opt,name=api_major,json=apiMajor,proto3" json:"api_major,omitempty"`
-	ApiMinor            uint32                 `protobuf:"varint,7,opt,name=api_minor,json=apiMinor,proto3" json:"api_minor,omitempty"`
-	Capabilities        []string               `protobuf:"bytes,8,rep,name=capabilities,proto3" json:"capabilities,omitempty"`
-	unknownFields       protoimpl.UnknownFields
-	sizeCache           protoimpl.SizeCache
+type TimeEstimatesResponse struct {
+	state                      protoimpl.MessageState `protogen:"open.v1"`
+	IokitTimeToFullMinutes     int32                  `protobuf:"varint,1,opt,name=iokit_time_to_full_minutes,json=iokitTimeToFullMinutes,proto3" json:"iokit_time_to_full_minutes,omitempty"`             // IOKit.Battery.TimeToFull, unsmoothed
+	IokitTimeToEmptyMinutes    int32                  `protobuf:"varint,2,opt,name=iokit_time_to_empty_minutes,json=iokitTimeToEmptyMinutes,proto3" json:"iokit_time_to_empty_minutes,omitempty"`          // IOKit.Battery.TimeToEmpty, unsmoothed
+	SmoothedTimeToLimitMinutes int32                  `protobuf:"varint,3,opt,name=smoothed_time_to_limit_minutes,json=smoothedTimeToLimitMinutes,proto3" json:"smoothed_time_to_limit_minutes,omitempty"` // From the observed charge rate to target_charge; 0 unless charge is rising
+	SmoothedTimeToEmptyMinutes int32                  `protobuf:"varint,4,opt,name=smoothed_time_to_empty_minutes,json=smoothedTimeToEmptyMinutes,proto3" json:"smoothed_time_to_empty_minutes,omitempty"` // From the observed charge rate to 0%; 0 unless charge is falling
+	Confidence                 EstimateConfidence     `protobuf:"varint,5,opt,name=confidence,proto3,enum=rpc.EstimateConfidence" json:"confidence,omitempty"`
+	TargetCharge               int32                  `protobuf:"varint,6,opt,name=target_charge,json=targetCharge,proto3" json:"target_charge,omitempty"`                  // Charge the daemon lets the battery reach (effective limit)
+	SampleCount                int32                  `protobuf:"varint,7,opt,name=sample_count,json=sampleCount,proto3" json:"sample_count,omitempty"`                     // Charge samples behind the smoothed estimate
+	SampleSpanSeconds          int32                  `protobuf:"varint,8,opt,name=sample_span_seconds,json=sampleSpanSeconds,proto3" json:"sample_span_seconds,omitempty"` // Time those samples cover
+	unknownFields              protoimpl.UnknownFields
+	sizeCache                  protoimpl.SizeCache
 }
 
-func (x *DaemonInfoResponse) Reset() {
-	*x = DaemonInfoResponse{}
-	mi := &file_powergrid_proto_msgTypes[4]
+func (x *TimeEstimatesResponse) Reset() {
+	*x = TimeEstimatesResponse{}
+	mi := &file_powergrid_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DaemonInfoResponse) String() string {
+func (x *TimeEstimatesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DaemonInfoResponse) ProtoMessage() {}
+func (*TimeEstimatesResponse) ProtoMessage() {}
 
-func (x *DaemonInfoResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_powergrid_proto_msgTypes[4]
+func (x *TimeEstimatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_powergrid_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -642,73 +2708,345 @@ func (x *DaemonInfoResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DaemonInfoResponse.ProtoReflect.Descriptor instead.
-func (*DaemonInfoResponse) Descriptor() ([]byte, []int) {
-	return file_powergrid_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use TimeEstimatesResponse.ProtoReflect.Descriptor instead.
+func (*TimeEstimatesResponse) Descriptor() ([]byte, []int) {
+	return file_powergrid_proto_rawDescGZIP(), []int{21}
 }
 
-func (x *DaemonInfoResponse) GetBuildId() string {
+func (x *TimeEstimatesResponse) GetIokitTimeToFullMinutes() int32 {
 	if x != nil {
-		return x.BuildId
+		return x.IokitTimeToFullMinutes
 	}
-	return ""
+	return 0
 }
 
-func (x *DaemonInfoResponse) GetAuthMode() string {
+func (x *TimeEstimatesResponse) GetIokitTimeToEmptyMinutes() int32 {
 	if x != nil {
-		return x.AuthMode
+		return x.IokitTimeToEmptyMinutes
 	}
-	return ""
+	return 0
 }
 
-func (x *DaemonInfoResponse) GetMagsafeLedSupported() bool {
+func (x *TimeEstimatesResponse) GetSmoothedTimeToLimitMinutes() int32 {
 	if x != nil {
-		return x.MagsafeLedSupported
+		return x.SmoothedTimeToLimitMinutes
 	}
-	return false
+	return 0
 }
 
-func (x *DaemonInfoResponse) GetBuildIdSource() string {
+func (x *TimeEstimatesResponse) GetSmoothedTimeToEmptyMinutes() int32 {
 	if x != nil {
-		return x.BuildIdSource
+		return x.SmoothedTimeToEmptyMinutes
+	}
+	return 0
+}
+
+func (x *TimeEstimatesResponse) GetConfidence() EstimateConfidence {
+	if x != nil {
+		return x.Confidence
+	}
+	return EstimateConfidence_ESTIMATE_CONFIDENCE_UNSPECIFIED
+}
+
+func (x *TimeEstimatesResponse) GetTargetCharge() int32 {
+	if x != nil {
+		return x.TargetCharge
+	}
+	return 0
+}
+
+func (x *TimeEstimatesResponse) GetSampleCount() int32 {
+	if x != nil {
+		return x.SampleCount
+	}
+	return 0
+}
+
+func (x *TimeEstimatesResponse) GetSampleSpanSeconds() int32 {
+	if x != nil {
+		return x.SampleSpanSeconds
+	}
+	return 0
+}
+
+type MagsafeLedTransition struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	State         string                 `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"`                                 // off, green, amber, error or system
+	AppliedUnix   int64                  `protobuf:"varint,2,opt,name=applied_unix,json=appliedUnix,proto3" json:"applied_unix,omitempty"` // When the daemon last wrote this state
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MagsafeLedTransition) Reset() {
+	*x = MagsafeLedTransition{}
+	mi := &file_powergrid_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MagsafeLedTransition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MagsafeLedTransition) ProtoMessage() {}
+
+func (x *MagsafeLedTransition) ProtoReflect() protoreflect.Message {
+	mi := &file_powergrid_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MagsafeLedTransition.ProtoReflect.Descriptor instead.
+func (*MagsafeLedTransition) Descriptor() ([]byte, []int) {
+	return file_powergrid_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *MagsafeLedTransition) GetState() string {
+	if x != nil {
+		return x.State
 	}
 	return ""
 }
 
-func (x *DaemonInfoResponse) GetBuildDirty() bool {
+func (x *MagsafeLedTransition) GetAppliedUnix() int64 {
 	if x != nil {
-		return x.BuildDirty
+		return x.AppliedUnix
+	}
+	return 0
+}
+
+type SessionStatsResponse struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Connected          bool                   `protobuf:"varint,1,opt,name=connected,proto3" json:"connected,omitempty"`                                                // Adapter connected; after unplug the totals stay until the next plug-in
+	StartedUnix        int64                  `protobuf:"varint,2,opt,name=started_unix,json=startedUnix,proto3" json:"started_unix,omitempty"`                         // Plug-in time, or daemon start if already connected then; 0 before any session
+	EnergyAddedWh      float32                `protobuf:"fixed32,3,opt,name=energy_added_wh,json=energyAddedWh,proto3" json:"energy_added_wh,omitempty"`                // Energy into the battery while it was charging
+	AdapterEnergyWh    float32                `protobuf:"fixed32,4,opt,name=adapter_energy_wh,json=adapterEnergyWh,proto3" json:"adapter_energy_wh,omitempty"`          // Adapter energy over the same intervals, system load included
+	AverageChargeWatts float32                `protobuf:"fixed32,5,opt,name=average_charge_watts,json=averageChargeWatts,proto3" json:"average_charge_watts,omitempty"` // energy_added_wh over charging_seconds
+	PeakChargeWatts    float32                `protobuf:"fixed32,6,opt,name=peak_charge_watts,json=peakChargeWatts,proto3" json:"peak_charge_watts,omitempty"`          // Highest battery charge power seen
+	Efficiency         float32                `protobuf:"fixed32,7,opt,name=efficiency,proto3" json:"efficiency,omitempty"`                                             // energy_added_wh / adapter_energy_wh, 0 to 1
+	ChargingSeconds    int32                  `protobuf:"varint,8,opt,name=charging_seconds,json=chargingSeconds,proto3" json:"charging_seconds,omitempty"`             // Time the battery was charging; gaps over 5 minutes, such as sleep, are left out
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *SessionStatsResponse) Reset() {
+	*x = SessionStatsResponse{}
+	mi := &file_powergrid_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SessionStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionStatsResponse) ProtoMessage() {}
+
+func (x *SessionStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_powergrid_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionStatsResponse.ProtoReflect.Descriptor instead.
+func (*SessionStatsResponse) Descriptor() ([]byte, []int) {
+	return file_powergrid_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *SessionStatsResponse) GetConnected() bool {
+	if x != nil {
+		return x.Connected
 	}
 	return false
 }
 
-func (x *DaemonInfoResponse) GetApiMajor() uint32 {
+func (x *SessionStatsResponse) GetStartedUnix() int64 {
 	if x != nil {
-		return x.ApiMajor
+		return x.StartedUnix
 	}
 	return 0
 }
 
-func (x *DaemonInfoResponse) GetApiMinor() uint32 {
+func (x *SessionStatsResponse) GetEnergyAddedWh() float32 {
 	if x != nil {
-		return x.ApiMinor
+		return x.EnergyAddedWh
 	}
 	return 0
 }
 
-func (x *DaemonInfoResponse) GetCapabilities() []string {
+func (x *SessionStatsResponse) GetAdapterEnergyWh() float32 {
 	if x != nil {
-		return x.Capabilities
+		return x.AdapterEnergyWh
+	}
+	return 0
+}
+
+func (x *SessionStatsResponse) GetAverageChargeWatts() float32 {
+	if x != nil {
+		return x.AverageChargeWatts
+	}
+	return 0
+}
+
+func (x *SessionStatsResponse) GetPeakChargeWatts() float32 {
+	if x != nil {
+		return x.PeakChargeWatts
+	}
+	return 0
+}
+
+func (x *SessionStatsResponse) GetEfficiency() float32 {
+	if x != nil {
+		return x.Efficiency
+	}
+	return 0
+}
+
+func (x *SessionStatsResponse) GetChargingSeconds() int32 {
+	if x != nil {
+		return x.ChargingSeconds
+	}
+	return 0
+}
+
+type HealthSample struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Unix          int64                  `protobuf:"varint,1,opt,name=unix,proto3" json:"unix,omitempty"` // When the sample was taken; at most one per local day
+	CycleCount    int32                  `protobuf:"varint,2,opt,name=cycle_count,json=cycleCount,proto3" json:"cycle_count,omitempty"`
+	HealthPercent float32                `protobuf:"fixed32,3,opt,name=health_percent,json=healthPercent,proto3" json:"health_percent,omitempty"` // IOKit max capacity as a share of design capacity
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HealthSample) Reset() {
+	*x = HealthSample{}
+	mi := &file_powergrid_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HealthSample) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HealthSample) ProtoMessage() {}
+
+func (x *HealthSample) ProtoReflect() protoreflect.Message {
+	mi := &file_powergrid_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HealthSample.ProtoReflect.Descriptor instead.
+func (*HealthSample) Descriptor() ([]byte, []int) {
+	return file_powergrid_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *HealthSample) GetUnix() int64 {
+	if x != nil {
+		return x.Unix
+	}
+	return 0
+}
+
+func (x *HealthSample) GetCycleCount() int32 {
+	if x != nil {
+		return x.CycleCount
+	}
+	return 0
+}
+
+func (x *HealthSample) GetHealthPercent() float32 {
+	if x != nil {
+		return x.HealthPercent
+	}
+	return 0
+}
+
+type WearTrendResponse struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Samples              []*HealthSample        `protobuf:"bytes,1,rep,name=samples,proto3" json:"samples,omitempty"`                                                             // Oldest first, about a year at most
+	RateKnown            bool                   `protobuf:"varint,2,opt,name=rate_known,json=rateKnown,proto3" json:"rate_known,omitempty"`                                       // False until the samples span 10 cycles
+	LossPerHundredCycles float32                `protobuf:"fixed32,3,opt,name=loss_per_hundred_cycles,json=lossPerHundredCycles,proto3" json:"loss_per_hundred_cycles,omitempty"` // Least-squares fit of health against cycle count; negative if health rose
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *WearTrendResponse) Reset() {
+	*x = WearTrendResponse{}
+	mi := &file_powergrid_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WearTrendResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WearTrendResponse) ProtoMessage() {}
+
+func (x *WearTrendResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_powergrid_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WearTrendResponse.ProtoReflect.Descriptor instead.
+func (*WearTrendResponse) Descriptor() ([]byte, []int) {
+	return file_powergrid_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *WearTrendResponse) GetSamples() []*HealthSample {
+	if x != nil {
+		return x.Samples
 	}
 	return nil
 }
 
+func (x *WearTrendResponse) GetRateKnown() bool {
+	if x != nil {
+		return x.RateKnown
+	}
+	return false
+}
+
+func (x *WearTrendResponse) GetLossPerHundredCycles() float32 {
+	if x != nil {
+		return x.LossPerHundredCycles
+	}
+	return 0
+}
+
 var File_powergrid_proto protoreflect.FileDescriptor
 
 const file_powergrid_proto_rawDesc = "" +
 	"\n" +
 	"\x0fpowergrid.proto\x12\x03rpc\"\a\n" +
-	"\x05Empty\"\x94\x0e\n" +
+	"\x05Empty\"\xe6\"\n" +
 	"\x0eStatusResponse\x12%\n" +
 	"\x0ecurrent_charge\x18\x01 \x01(\x05R\rcurrentCharge\x12\x1f\n" +
 	"\vis_charging\x18\x02 \x01(\bR\n" +
@@ -748,14 +3086,87 @@ const file_powergrid_proto_rawDesc = "" +
 	"\x15battery_temperature_c\x18! \x01(\x02R\x13batteryTemperatureC\x127\n" +
 	"\x18battery_voltage_drift_mv\x18\" \x01(\x05R\x15batteryVoltageDriftMv\x122\n" +
 	"\x15battery_balance_state\x18# \x01(\tR\x13batteryBalanceState\x127\n" +
-	"\x18low_power_mode_available\x18$ \x01(\bR\x15lowPowerModeAvailable\"\xa2\x01\n" +
+	"\x18low_power_mode_available\x18$ \x01(\bR\x15lowPowerModeAvailable\x12-\n" +
+	"\x12management_enabled\x18% \x01(\bR\x11managementEnabled\x120\n" +
+	"\x14external_charge_hold\x18& \x01(\bR\x12externalChargeHold\x127\n" +
+	"\x17adapter_underperforming\x18' \x01(\bR\x16adapterUnderperforming\x12%\n" +
+	"\x0eactive_profile\x18( \x01(\tR\ractiveProfile\x12L\n" +
+	"\x15charging_pause_reason\x18) \x01(\x0e2\x18.rpc.ChargingPauseReasonR\x13chargingPauseReason\x12\x1f\n" +
+	"\vadapter_key\x18* \x01(\tR\n" +
+	"adapterKey\x12.\n" +
+	"\x13matched_adapter_key\x18+ \x01(\tR\x11matchedAdapterKey\x124\n" +
+	"\x16effective_charge_limit\x18, \x01(\x05R\x14effectiveChargeLimit\x122\n" +
+	"\x15force_discharge_floor\x18- \x01(\x05R\x13forceDischargeFloor\x12A\n" +
+	"\x1dforce_discharge_floor_reached\x18. \x01(\bR\x1aforceDischargeFloorReached\x12'\n" +
+	"\x0fbattery_missing\x18/ \x01(\bR\x0ebatteryMissing\x126\n" +
+	"\x17boost_remaining_seconds\x180 \x01(\x05R\x15boostRemainingSeconds\x12(\n" +
+	"\x10charged_at_limit\x181 \x01(\bR\x0echargedAtLimit\x126\n" +
+	"\x17charge_manager_conflict\x182 \x01(\bR\x15chargeManagerConflict\x12C\n" +
+	"\x1echarge_manager_conflict_detail\x183 \x01(\tR\x1bchargeManagerConflictDetail\x12F\n" +
+	"\x13magsafe_led_support\x184 \x01(\x0e2\x16.rpc.MagsafeLedSupportR\x11magsafeLedSupport\x12)\n" +
+	"\x10charging_stalled\x185 \x01(\bR\x0fchargingStalled\x12\x1d\n" +
+	"\n" +
+	"pinned_pid\x186 \x01(\x05R\tpinnedPid\x126\n" +
+	"\rbattery_state\x187 \x01(\x0e2\x11.rpc.BatteryStateR\fbatteryState\x12E\n" +
+	"\x1fpersist_sleep_prevention_active\x188 \x01(\bR\x1cpersistSleepPreventionActive\x12;\n" +
+	"\x1aprevent_display_sleep_auto\x189 \x01(\bR\x17preventDisplaySleepAuto\x12.\n" +
+	"\x13ramped_charge_limit\x18: \x01(\x05R\x11rampedChargeLimit\x12+\n" +
+	"\x11actually_charging\x18; \x01(\bR\x10actuallyCharging\x12,\n" +
+	"\x12magsafe_led_states\x18< \x03(\tR\x10magsafeLedStates\x12 \n" +
+	"\ffull_by_unix\x18= \x01(\x03R\n" +
+	"fullByUnix\x12$\n" +
+	"\x0efull_by_target\x18> \x01(\x05R\ffullByTarget\x12+\n" +
+	"\x12full_by_start_unix\x18? \x01(\x03R\x0ffullByStartUnix\x12(\n" +
+	"\x10min_charge_limit\x18@ \x01(\x05R\x0eminChargeLimit\x12:\n" +
+	"\x19suspend_remaining_seconds\x18A \x01(\x05R\x17suspendRemainingSeconds\x12!\n" +
+	"\fups_attached\x18B \x01(\bR\vupsAttached\x123\n" +
+	"\fpower_source\x18C \x01(\x0e2\x10.rpc.PowerSourceR\vpowerSource\x12=\n" +
+	"\x10magsafe_led_mode\x18D \x01(\x0e2\x13.rpc.MagsafeLedModeR\x0emagsafeLedMode\x12E\n" +
+	" magsafe_led_green_only_when_full\x18E \x01(\bR\x1bmagsafeLedGreenOnlyWhenFull\x12\x1b\n" +
+	"\tsafe_mode\x18F \x01(\bR\bsafeMode\x12\x1d\n" +
+	"\n" +
+	"warming_up\x18G \x01(\bR\twarmingUp\x120\n" +
+	"\x14discharge_band_floor\x18H \x01(\x05R\x12dischargeBandFloor\x124\n" +
+	"\x16discharge_band_ceiling\x18I \x01(\x05R\x14dischargeBandCeiling\x122\n" +
+	"\x15discharge_band_active\x18J \x01(\bR\x13dischargeBandActive\x12C\n" +
+	"\x1esystem_display_sleep_prevented\x18K \x01(\bR\x1bsystemDisplaySleepPrevented\x124\n" +
+	"\x16system_sleep_prevented\x18L \x01(\bR\x14systemSleepPrevented\x12:\n" +
+	"\x19external_sleep_assertions\x18M \x03(\tR\x17externalSleepAssertions\x12<\n" +
+	"\x0fmanual_charging\x18N \x01(\x0e2\x13.rpc.ManualChargingR\x0emanualCharging\x12'\n" +
+	"\x0fcritical_charge\x18O \x01(\bR\x0ecriticalCharge\x12=\n" +
+	"\x1bmagsafe_led_committed_state\x18P \x01(\tR\x18magsafeLedCommittedState\x12Q\n" +
+	"\x17magsafe_led_transitions\x18Q \x03(\v2\x19.rpc.MagsafeLedTransitionR\x15magsafeLedTransitions\x129\n" +
+	"\x19magsafe_led_write_pending\x18R \x01(\bR\x16magsafeLedWritePending\x12@\n" +
+	"\x1dmagsafe_led_last_failure_unix\x18S \x01(\x03R\x19magsafeLedLastFailureUnix\x123\n" +
+	"\x16magsafe_led_last_error\x18T \x01(\tR\x13magsafeLedLastError\x128\n" +
+	"\x18limit_currently_enforced\x18U \x01(\bR\x16limitCurrentlyEnforced\x125\n" +
+	"\x17wear_per_hundred_cycles\x18V \x01(\x02R\x14wearPerHundredCycles\"\xfb\x04\n" +
 	"\x0fMutationRequest\x124\n" +
 	"\toperation\x18\x01 \x01(\x0e2\x16.rpc.MutationOperationR\toperation\x12\x14\n" +
 	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12+\n" +
 	"\afeature\x18\x03 \x01(\x0e2\x11.rpc.PowerFeatureR\afeature\x12\x16\n" +
-	"\x06enable\x18\x04 \x01(\bR\x06enable\",\n" +
+	"\x06enable\x18\x04 \x01(\bR\x06enable\x12!\n" +
+	"\fprofile_name\x18\x05 \x01(\tR\vprofileName\x12,\n" +
+	"\aprofile\x18\x06 \x01(\v2\x12.rpc.ChargeProfileR\aprofile\x12\x1f\n" +
+	"\vadapter_key\x18\a \x01(\tR\n" +
+	"adapterKey\x12#\n" +
+	"\rboost_minutes\x18\b \x01(\x05R\fboostMinutes\x12\x10\n" +
+	"\x03pid\x18\t \x01(\x05R\x03pid\x12 \n" +
+	"\ffull_by_unix\x18\n" +
+	" \x01(\x03R\n" +
+	"fullByUnix\x12'\n" +
+	"\x0fsuspend_minutes\x18\v \x01(\x05R\x0esuspendMinutes\x12=\n" +
+	"\x10magsafe_led_mode\x18\f \x01(\x0e2\x13.rpc.MagsafeLedModeR\x0emagsafeLedMode\x120\n" +
+	"\x14discharge_band_floor\x18\r \x01(\x05R\x12dischargeBandFloor\x124\n" +
+	"\x16discharge_band_ceiling\x18\x0e \x01(\x05R\x14dischargeBandCeiling\x12<\n" +
+	"\x0fmanual_charging\x18\x0f \x01(\x0e2\x13.rpc.ManualChargingR\x0emanualCharging\"\x84\x01\n" +
 	"\x0fVersionResponse\x12\x19\n" +
-	"\bbuild_id\x18\x01 \x01(\tR\abuildId\"\xa7\x02\n" +
+	"\bbuild_id\x18\x01 \x01(\tR\abuildId\x12\x1d\n" +
+	"\n" +
+	"git_commit\x18\x02 \x01(\tR\tgitCommit\x12\x1d\n" +
+	"\n" +
+	"build_time\x18\x03 \x01(\tR\tbuildTime\x12\x18\n" +
+	"\aversion\x18\x04 \x01(\tR\aversion\"\xa7\x02\n" +
 	"\x12DaemonInfoResponse\x12\x19\n" +
 	"\bbuild_id\x18\x01 \x01(\tR\abuildId\x12\x1b\n" +
 	"\tauth_mode\x18\x02 \x01(\tR\bauthMode\x122\n" +
@@ -765,7 +3176,114 @@ const file_powergrid_proto_rawDesc = "" +
 	"buildDirty\x12\x1b\n" +
 	"\tapi_major\x18\x06 \x01(\rR\bapiMajor\x12\x1b\n" +
 	"\tapi_minor\x18\a \x01(\rR\bapiMinor\x12\"\n" +
-	"\fcapabilities\x18\b \x03(\tR\fcapabilities*\xc7\x01\n" +
+	"\fcapabilities\x18\b \x03(\tR\fcapabilities\"\xd7\x02\n" +
+	"\x16AdapterDetailsResponse\x12\x1c\n" +
+	"\tconnected\x18\x01 \x01(\bR\tconnected\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x1b\n" +
+	"\tmax_watts\x18\x03 \x01(\x05R\bmaxWatts\x12\x1f\n" +
+	"\vmax_voltage\x18\x04 \x01(\x02R\n" +
+	"maxVoltage\x12!\n" +
+	"\fmax_amperage\x18\x05 \x01(\x02R\vmaxAmperage\x12#\n" +
+	"\rinput_voltage\x18\x06 \x01(\x02R\finputVoltage\x12%\n" +
+	"\x0einput_amperage\x18\a \x01(\x02R\rinputAmperage\x12\x1f\n" +
+	"\vinput_watts\x18\b \x01(\x02R\n" +
+	"inputWatts\x12/\n" +
+	"\x13telemetry_available\x18\t \x01(\bR\x12telemetryAvailable\"\xb9\x01\n" +
+	"\rChargeProfile\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12!\n" +
+	"\fcharge_limit\x18\x02 \x01(\x05R\vchargeLimit\x12.\n" +
+	"\x13control_magsafe_led\x18\x03 \x01(\bR\x11controlMagsafeLed\x12A\n" +
+	"\x1ddisable_charging_before_sleep\x18\x04 \x01(\bR\x1adisableChargingBeforeSleep\"l\n" +
+	"\x13ProfileListResponse\x12.\n" +
+	"\bprofiles\x18\x01 \x03(\v2\x12.rpc.ChargeProfileR\bprofiles\x12%\n" +
+	"\x0eactive_profile\x18\x02 \x01(\tR\ractiveProfile\"R\n" +
+	"\x10EffectiveSetting\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\x12\x16\n" +
+	"\x06source\x18\x03 \x01(\tR\x06source\"N\n" +
+	"\x19EffectiveSettingsResponse\x121\n" +
+	"\bsettings\x18\x01 \x03(\v2\x15.rpc.EffectiveSettingR\bsettings\"#\n" +
+	"\vLogsRequest\x12\x14\n" +
+	"\x05lines\x18\x01 \x01(\x05R\x05lines\"f\n" +
+	"\bLogEntry\x12*\n" +
+	"\x11timestamp_unix_ms\x18\x01 \x01(\x03R\x0ftimestampUnixMs\x12\x14\n" +
+	"\x05level\x18\x02 \x01(\tR\x05level\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"7\n" +
+	"\fLogsResponse\x12'\n" +
+	"\aentries\x18\x01 \x03(\v2\r.rpc.LogEntryR\aentries\"6\n" +
+	"\n" +
+	"Diagnostic\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\"\x9c\x03\n" +
+	"\x15SupportBundleResponse\x12*\n" +
+	"\x11generated_unix_ms\x18\x01 \x01(\x03R\x0fgeneratedUnixMs\x128\n" +
+	"\vdaemon_info\x18\x02 \x01(\v2\x17.rpc.DaemonInfoResponseR\n" +
+	"daemonInfo\x12%\n" +
+	"\x0ehardware_model\x18\x03 \x01(\tR\rhardwareModel\x12\x1d\n" +
+	"\n" +
+	"os_version\x18\x04 \x01(\tR\tosVersion\x12+\n" +
+	"\x06status\x18\x05 \x01(\v2\x13.rpc.StatusResponseR\x06status\x121\n" +
+	"\bsettings\x18\x06 \x03(\v2\x15.rpc.EffectiveSettingR\bsettings\x12!\n" +
+	"\x04logs\x18\a \x03(\v2\r.rpc.LogEntryR\x04logs\x121\n" +
+	"\vdiagnostics\x18\b \x03(\v2\x0f.rpc.DiagnosticR\vdiagnostics\x12!\n" +
+	"\fconsole_user\x18\t \x01(\tR\vconsoleUser\"|\n" +
+	"\x10CountersResponse\x12\x10\n" +
+	"\x03day\x18\x01 \x01(\tR\x03day\x12)\n" +
+	"\x10charging_enabled\x18\x02 \x01(\x05R\x0fchargingEnabled\x12+\n" +
+	"\x11charging_disabled\x18\x03 \x01(\x05R\x10chargingDisabled\"$\n" +
+	"\x0eSMCKeysRequest\x12\x12\n" +
+	"\x04keys\x18\x01 \x03(\tR\x04keys\"m\n" +
+	"\vSMCKeyValue\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x1b\n" +
+	"\tdata_type\x18\x02 \x01(\tR\bdataType\x12\x1b\n" +
+	"\tdata_size\x18\x03 \x01(\x05R\bdataSize\x12\x12\n" +
+	"\x04data\x18\x04 \x01(\fR\x04data\";\n" +
+	"\x0fSMCKeysResponse\x12(\n" +
+	"\x06values\x18\x01 \x03(\v2\x10.rpc.SMCKeyValueR\x06values\"\x97\x02\n" +
+	"\fUserSettings\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12\x10\n" +
+	"\x03uid\x18\x02 \x01(\rR\x03uid\x12!\n" +
+	"\fcharge_limit\x18\x03 \x01(\x05R\vchargeLimit\x124\n" +
+	"\x16effective_charge_limit\x18\x04 \x01(\x05R\x14effectiveChargeLimit\x12=\n" +
+	"\x10magsafe_led_mode\x18\x05 \x01(\x0e2\x13.rpc.MagsafeLedModeR\x0emagsafeLedMode\x12A\n" +
+	"\x1ddisable_charging_before_sleep\x18\x06 \x01(\bR\x1adisableChargingBeforeSleep\"?\n" +
+	"\x14UserSettingsResponse\x12'\n" +
+	"\x05users\x18\x01 \x03(\v2\x11.rpc.UserSettingsR\x05users\"\xca\x03\n" +
+	"\x15TimeEstimatesResponse\x12:\n" +
+	"\x1aiokit_time_to_full_minutes\x18\x01 \x01(\x05R\x16iokitTimeToFullMinutes\x12<\n" +
+	"\x1biokit_time_to_empty_minutes\x18\x02 \x01(\x05R\x17iokitTimeToEmptyMinutes\x12B\n" +
+	"\x1esmoothed_time_to_limit_minutes\x18\x03 \x01(\x05R\x1asmoothedTimeToLimitMinutes\x12B\n" +
+	"\x1esmoothed_time_to_empty_minutes\x18\x04 \x01(\x05R\x1asmoothedTimeToEmptyMinutes\x127\n" +
+	"\n" +
+	"confidence\x18\x05 \x01(\x0e2\x17.rpc.EstimateConfidenceR\n" +
+	"confidence\x12#\n" +
+	"\rtarget_charge\x18\x06 \x01(\x05R\ftargetCharge\x12!\n" +
+	"\fsample_count\x18\a \x01(\x05R\vsampleCount\x12.\n" +
+	"\x13sample_span_seconds\x18\b \x01(\x05R\x11sampleSpanSeconds\"O\n" +
+	"\x14MagsafeLedTransition\x12\x14\n" +
+	"\x05state\x18\x01 \x01(\tR\x05state\x12!\n" +
+	"\fapplied_unix\x18\x02 \x01(\x03R\vappliedUnix\"\xd4\x02\n" +
+	"\x14SessionStatsResponse\x12\x1c\n" +
+	"\tconnected\x18\x01 \x01(\bR\tconnected\x12!\n" +
+	"\fstarted_unix\x18\x02 \x01(\x03R\vstartedUnix\x12&\n" +
+	"\x0fenergy_added_wh\x18\x03 \x01(\x02R\renergyAddedWh\x12*\n" +
+	"\x11adapter_energy_wh\x18\x04 \x01(\x02R\x0fadapterEnergyWh\x120\n" +
+	"\x14average_charge_watts\x18\x05 \x01(\x02R\x12averageChargeWatts\x12*\n" +
+	"\x11peak_charge_watts\x18\x06 \x01(\x02R\x0fpeakChargeWatts\x12\x1e\n" +
+	"\n" +
+	"efficiency\x18\a \x01(\x02R\n" +
+	"efficiency\x12)\n" +
+	"\x10charging_seconds\x18\b \x01(\x05R\x0fchargingSeconds\"j\n" +
+	"\fHealthSample\x12\x12\n" +
+	"\x04unix\x18\x01 \x01(\x03R\x04unix\x12\x1f\n" +
+	"\vcycle_count\x18\x02 \x01(\x05R\n" +
+	"cycleCount\x12%\n" +
+	"\x0ehealth_percent\x18\x03 \x01(\x02R\rhealthPercent\"\x96\x01\n" +
+	"\x11WearTrendResponse\x12+\n" +
+	"\asamples\x18\x01 \x03(\v2\x11.rpc.HealthSampleR\asamples\x12\x1d\n" +
+	"\n" +
+	"rate_known\x18\x02 \x01(\bR\trateKnown\x125\n" +
+	"\x17loss_per_hundred_cycles\x18\x03 \x01(\x02R\x14lossPerHundredCycles*\xa2\x02\n" +
 	"\fPowerFeature\x12\x1d\n" +
 	"\x19POWER_FEATURE_UNSPECIFIED\x10\x00\x12\x19\n" +
 	"\x15PREVENT_DISPLAY_SLEEP\x10\x01\x12\x18\n" +
@@ -773,11 +3291,70 @@ const file_powergrid_proto_rawDesc = "" +
 	"\x0fFORCE_DISCHARGE\x10\x03\x12\x17\n" +
 	"\x13CONTROL_MAGSAFE_LED\x10\x04\x12\x12\n" +
 	"\x0eLOW_POWER_MODE\x10\x05\x12!\n" +
-	"\x1dDISABLE_CHARGING_BEFORE_SLEEP\x10\x06*d\n" +
+	"\x1dDISABLE_CHARGING_BEFORE_SLEEP\x10\x06\x12\x15\n" +
+	"\x11CHARGE_MANAGEMENT\x10\a\x12\x1c\n" +
+	"\x18PERSIST_SLEEP_PREVENTION\x10\b\x12$\n" +
+	" MAGSAFE_LED_GREEN_ONLY_WHEN_FULL\x10\t*\xbf\x01\n" +
+	"\x13ChargingPauseReason\x12\x1e\n" +
+	"\x1aCHARGING_PAUSE_REASON_NONE\x10\x00\x12\x12\n" +
+	"\x0ePAUSE_AT_LIMIT\x10\x01\x12\x19\n" +
+	"\x15PAUSE_FORCE_DISCHARGE\x10\x02\x12\x16\n" +
+	"\x12PAUSE_BEFORE_SLEEP\x10\x03\x12\x17\n" +
+	"\x13PAUSE_EXTERNAL_HOLD\x10\x04\x12\x16\n" +
+	"\x12PAUSE_WEAK_ADAPTER\x10\x05\x12\x10\n" +
+	"\fPAUSE_MANUAL\x10\x06*n\n" +
+	"\fBatteryState\x12\x1d\n" +
+	"\x19BATTERY_STATE_UNSPECIFIED\x10\x00\x12\x14\n" +
+	"\x10BATTERY_CHARGING\x10\x01\x12\x17\n" +
+	"\x13BATTERY_DISCHARGING\x10\x02\x12\x10\n" +
+	"\fBATTERY_IDLE\x10\x03*l\n" +
+	"\vPowerSource\x12\x18\n" +
+	"\x14POWER_SOURCE_UNKNOWN\x10\x00\x12\x13\n" +
+	"\x0fPOWER_SOURCE_AC\x10\x01\x12\x18\n" +
+	"\x14POWER_SOURCE_BATTERY\x10\x02\x12\x14\n" +
+	"\x10POWER_SOURCE_UPS\x10\x03*l\n" +
+	"\x11MagsafeLedSupport\x12\x1f\n" +
+	"\x1bMAGSAFE_LED_SUPPORT_UNKNOWN\x10\x00\x12\x19\n" +
+	"\x15MAGSAFE_LED_SUPPORTED\x10\x01\x12\x1b\n" +
+	"\x17MAGSAFE_LED_UNSUPPORTED\x10\x02*\x84\x01\n" +
+	"\x0eMagsafeLedMode\x12 \n" +
+	"\x1cMAGSAFE_LED_MODE_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15MAGSAFE_LED_MODE_AUTO\x10\x01\x12\x18\n" +
+	"\x14MAGSAFE_LED_MODE_OFF\x10\x02\x12\x1b\n" +
+	"\x17MAGSAFE_LED_MODE_SYSTEM\x10\x03*\xdb\x02\n" +
 	"\x11MutationOperation\x12\"\n" +
 	"\x1eMUTATION_OPERATION_UNSPECIFIED\x10\x00\x12\x14\n" +
 	"\x10SET_CHARGE_LIMIT\x10\x01\x12\x15\n" +
-	"\x11SET_POWER_FEATURE\x10\x022\xd2\x01\n" +
+	"\x11SET_POWER_FEATURE\x10\x02\x12\x11\n" +
+	"\rAPPLY_PROFILE\x10\x03\x12\x0f\n" +
+	"\vSET_PROFILE\x10\x04\x12\x15\n" +
+	"\x11SET_ADAPTER_LIMIT\x10\x05\x12\x13\n" +
+	"\x0fCLEAR_OVERRIDES\x10\x06\x12\x10\n" +
+	"\fCHARGE_BOOST\x10\a\x12\x13\n" +
+	"\x0fPIN_FULL_CHARGE\x10\b\x12\x12\n" +
+	"\x0eRESET_COUNTERS\x10\t\x12\v\n" +
+	"\aFULL_BY\x10\n" +
+	"\x12\x16\n" +
+	"\x12SUSPEND_MANAGEMENT\x10\v\x12\x18\n" +
+	"\x14SET_MAGSAFE_LED_MODE\x10\f\x12\x16\n" +
+	"\x12SET_DISCHARGE_BAND\x10\r\x12\x13\n" +
+	"\x0fMANUAL_CHARGING\x10\x0e*\xae\x01\n" +
+	"\vErrorReason\x12\x1c\n" +
+	"\x18ERROR_REASON_UNSPECIFIED\x10\x00\x12\x1e\n" +
+	"\x1aERROR_UNSUPPORTED_HARDWARE\x10\x01\x12\x1c\n" +
+	"\x18ERROR_VALUE_OUT_OF_RANGE\x10\x02\x12\x12\n" +
+	"\x0eERROR_SMC_BUSY\x10\x03\x12\x19\n" +
+	"\x15ERROR_NO_CONSOLE_USER\x10\x04\x12\x14\n" +
+	"\x10ERROR_NO_BATTERY\x10\x05*\x94\x01\n" +
+	"\x12EstimateConfidence\x12#\n" +
+	"\x1fESTIMATE_CONFIDENCE_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17ESTIMATE_CONFIDENCE_LOW\x10\x01\x12\x1e\n" +
+	"\x1aESTIMATE_CONFIDENCE_MEDIUM\x10\x02\x12\x1c\n" +
+	"\x18ESTIMATE_CONFIDENCE_HIGH\x10\x03*[\n" +
+	"\x0eManualCharging\x12\x18\n" +
+	"\x14MANUAL_CHARGING_NONE\x10\x00\x12\x16\n" +
+	"\x12MANUAL_CHARGING_ON\x10\x01\x12\x17\n" +
+	"\x13MANUAL_CHARGING_OFF\x10\x022\xf3\x06\n" +
 	"\tPowerGrid\x12,\n" +
 	"\tGetStatus\x12\n" +
 	".rpc.Empty\x1a\x13.rpc.StatusResponse\x121\n" +
@@ -787,7 +3364,29 @@ const file_powergrid_proto_rawDesc = "" +
 	"GetVersion\x12\n" +
 	".rpc.Empty\x1a\x14.rpc.VersionResponse\x124\n" +
 	"\rGetDaemonInfo\x12\n" +
-	".rpc.Empty\x1a\x17.rpc.DaemonInfoResponseB\x18Z\x16powergrid/internal/rpcb\x06proto3"
+	".rpc.Empty\x1a\x17.rpc.DaemonInfoResponse\x12<\n" +
+	"\x11GetAdapterDetails\x12\n" +
+	".rpc.Empty\x1a\x1b.rpc.AdapterDetailsResponse\x124\n" +
+	"\fListProfiles\x12\n" +
+	".rpc.Empty\x1a\x18.rpc.ProfileListResponse\x12B\n" +
+	"\x14GetEffectiveSettings\x12\n" +
+	".rpc.Empty\x1a\x1e.rpc.EffectiveSettingsResponse\x12.\n" +
+	"\aGetLogs\x12\x10.rpc.LogsRequest\x1a\x11.rpc.LogsResponse\x12*\n" +
+	"\aRefresh\x12\n" +
+	".rpc.Empty\x1a\x13.rpc.StatusResponse\x12:\n" +
+	"\x10GetSupportBundle\x12\n" +
+	".rpc.Empty\x1a\x1a.rpc.SupportBundleResponse\x120\n" +
+	"\vGetCounters\x12\n" +
+	".rpc.Empty\x1a\x15.rpc.CountersResponse\x128\n" +
+	"\vReadSMCKeys\x12\x13.rpc.SMCKeysRequest\x1a\x14.rpc.SMCKeysResponse\x129\n" +
+	"\x10ListUserSettings\x12\n" +
+	".rpc.Empty\x1a\x19.rpc.UserSettingsResponse\x12:\n" +
+	"\x10GetTimeEstimates\x12\n" +
+	".rpc.Empty\x1a\x1a.rpc.TimeEstimatesResponse\x128\n" +
+	"\x0fGetSessionStats\x12\n" +
+	".rpc.Empty\x1a\x19.rpc.SessionStatsResponse\x122\n" +
+	"\fGetWearTrend\x12\n" +
+	".rpc.Empty\x1a\x16.rpc.WearTrendResponseB\x18Z\x16powergrid/internal/rpcb\x06proto3"
 
 var (
 	file_powergrid_proto_rawDescOnce sync.Once
@@ -801,33 +3400,109 @@ func file_powergrid_proto_rawDescGZIP() []byte {
 	return file_powergrid_proto_rawDescData
 }
 
-var file_powergrid_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_powergrid_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_powergrid_proto_enumTypes = make([]protoimpl.EnumInfo, 10)
+var file_powergrid_proto_msgTypes = make([]protoimpl.MessageInfo, 26)
 var file_powergrid_proto_goTypes = []any{
-	(PowerFeature)(0),          // 0: rpc.PowerFeature
-	(MutationOperation)(0),     // 1: rpc.MutationOperation
-	(*Empty)(nil),              // 2: rpc.Empty
-	(*StatusResponse)(nil),     // 3: rpc.StatusResponse
-	(*MutationRequest)(nil),    // 4: rpc.MutationRequest
-	(*VersionResponse)(nil),    // 5: rpc.VersionResponse
-	(*DaemonInfoResponse)(nil), // 6: rpc.DaemonInfoResponse
+	(PowerFeature)(0),                 // 0: rpc.PowerFeature
+	(ChargingPauseReason)(0),          // 1: rpc.ChargingPauseReason
+	(BatteryState)(0),                 // 2: rpc.BatteryState
+	(PowerSource)(0),                  // 3: rpc.PowerSource
+	(MagsafeLedSupport)(0),            // 4: rpc.MagsafeLedSupport
+	(MagsafeLedMode)(0),               // 5: rpc.MagsafeLedMode
+	(MutationOperation)(0),            // 6: rpc.MutationOperation
+	(ErrorReason)(0),                  // 7: rpc.ErrorReason
+	(EstimateConfidence)(0),           // 8: rpc.EstimateConfidence
+	(ManualCharging)(0),               // 9: rpc.ManualCharging
+	(*Empty)(nil),                     // 10: rpc.Empty
+	(*StatusResponse)(nil),            // 11: rpc.StatusResponse
+	(*MutationRequest)(nil),           // 12: rpc.MutationRequest
+	(*VersionResponse)(nil),           // 13: rpc.VersionResponse
+	(*DaemonInfoResponse)(nil),        // 14: rpc.DaemonInfoResponse
+	(*AdapterDetailsResponse)(nil),    // 15: rpc.AdapterDetailsResponse
+	(*ChargeProfile)(nil),             // 16: rpc.ChargeProfile
+	(*ProfileListResponse)(nil),       // 17: rpc.ProfileListResponse
+	(*EffectiveSetting)(nil),          // 18: rpc.EffectiveSetting
+	(*EffectiveSettingsResponse)(nil), // 19: rpc.EffectiveSettingsResponse
+	(*LogsRequest)(nil),               // 20: rpc.LogsRequest
+	(*LogEntry)(nil),                  // 21: rpc.LogEntry
+	(*LogsResponse)(nil),              // 22: rpc.LogsResponse
+	(*Diagnostic)(nil),                // 23: rpc.Diagnostic
+	(*SupportBundleResponse)(nil),     // 24: rpc.SupportBundleResponse
+	(*CountersResponse)(nil),          // 25: rpc.CountersResponse
+	(*SMCKeysRequest)(nil),            // 26: rpc.SMCKeysRequest
+	(*SMCKeyValue)(nil),               // 27: rpc.SMCKeyValue
+	(*SMCKeysResponse)(nil),           // 28: rpc.SMCKeysResponse
+	(*UserSettings)(nil),              // 29: rpc.UserSettings
+	(*UserSettingsResponse)(nil),      // 30: rpc.UserSettingsResponse
+	(*TimeEstimatesResponse)(nil),     // 31: rpc.TimeEstimatesResponse
+	(*MagsafeLedTransition)(nil),      // 32: rpc.MagsafeLedTransition
+	(*SessionStatsResponse)(nil),      // 33: rpc.SessionStatsResponse
+	(*HealthSample)(nil),              // 34: rpc.HealthSample
+	(*WearTrendResponse)(nil),         // 35: rpc.WearTrendResponse
 }
 var file_powergrid_proto_depIdxs = []int32{
-	1, // 0: rpc.MutationRequest.operation:type_name -> rpc.MutationOperation
-	0, // 1: rpc.MutationRequest.feature:type_name -> rpc.PowerFeature
-	2, // 2: rpc.PowerGrid.GetStatus:input_type -> rpc.Empty
-	4, // 3: rpc.PowerGrid.ApplyMutation:input_type -> rpc.MutationRequest
-	2, // 4: rpc.PowerGrid.GetVersion:input_type -> rpc.Empty
-	2, // 5: rpc.PowerGrid.GetDaemonInfo:input_type -> rpc.Empty
-	3, // 6: rpc.PowerGrid.GetStatus:output_type -> rpc.StatusResponse
-	2, // 7: rpc.PowerGrid.ApplyMutation:output_type -> rpc.Empty
-	5, // 8: rpc.PowerGrid.GetVersion:output_type -> rpc.VersionResponse
-	6, // 9: rpc.PowerGrid.GetDaemonInfo:output_type -> rpc.DaemonInfoResponse
-	6, // [6:10] is the sub-list for method output_type
-	2, // [2:6] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	1,  // 0: rpc.StatusResponse.charging_pause_reason:type_name -> rpc.ChargingPauseReason
+	4,  // 1: rpc.StatusResponse.magsafe_led_support:type_name -> rpc.MagsafeLedSupport
+	2,  // 2: rpc.StatusResponse.battery_state:type_name -> rpc.BatteryState
+	3,  // 3: rpc.StatusResponse.power_source:type_name -> rpc.PowerSource
+	5,  // 4: rpc.StatusResponse.magsafe_led_mode:type_name -> rpc.MagsafeLedMode
+	9,  // 5: rpc.StatusResponse.manual_charging:type_name -> rpc.ManualCharging
+	32, // 6: rpc.StatusResponse.magsafe_led_transitions:type_name -> rpc.MagsafeLedTransition
+	6,  // 7: rpc.MutationRequest.operation:type_name -> rpc.MutationOperation
+	0,  // 8: rpc.MutationRequest.feature:type_name -> rpc.PowerFeature
+	16, // 9: rpc.MutationRequest.profile:type_name -> rpc.ChargeProfile
+	5,  // 10: rpc.MutationRequest.magsafe_led_mode:type_name -> rpc.MagsafeLedMode
+	9,  // 11: rpc.MutationRequest.manual_charging:type_name -> rpc.ManualCharging
+	16, // 12: rpc.ProfileListResponse.profiles:type_name -> rpc.ChargeProfile
+	18, // 13: rpc.EffectiveSettingsResponse.settings:type_name -> rpc.EffectiveSetting
+	21, // 14: rpc.LogsResponse.entries:type_name -> rpc.LogEntry
+	14, // 15: rpc.SupportBundleResponse.daemon_info:type_name -> rpc.DaemonInfoResponse
+	11, // 16: rpc.SupportBundleResponse.status:type_name -> rpc.StatusResponse
+	18, // 17: rpc.SupportBundleResponse.settings:type_name -> rpc.EffectiveSetting
+	21, // 18: rpc.SupportBundleResponse.logs:type_name -> rpc.LogEntry
+	23, // 19: rpc.SupportBundleResponse.diagnostics:type_name -> rpc.Diagnostic
+	27, // 20: rpc.SMCKeysResponse.values:type_name -> rpc.SMCKeyValue
+	5,  // 21: rpc.UserSettings.magsafe_led_mode:type_name -> rpc.MagsafeLedMode
+	29, // 22: rpc.UserSettingsResponse.users:type_name -> rpc.UserSettings
+	8,  // 23: rpc.TimeEstimatesResponse.confidence:type_name -> rpc.EstimateConfidence
+	34, // 24: rpc.WearTrendResponse.samples:type_name -> rpc.HealthSample
+	10, // 25: rpc.PowerGrid.GetStatus:input_type -> rpc.Empty
+	12, // 26: rpc.PowerGrid.ApplyMutation:input_type -> rpc.MutationRequest
+	10, // 27: rpc.PowerGrid.GetVersion:input_type -> rpc.Empty
+	10, // 28: rpc.PowerGrid.GetDaemonInfo:input_type -> rpc.Empty
+	10, // 29: rpc.PowerGrid.GetAdapterDetails:input_type -> rpc.Empty
+	10, // 30: rpc.PowerGrid.ListProfiles:input_type -> rpc.Empty
+	10, // 31: rpc.PowerGrid.GetEffectiveSettings:input_type -> rpc.Empty
+	20, // 32: rpc.PowerGrid.GetLogs:input_type -> rpc.LogsRequest
+	10, // 33: rpc.PowerGrid.Refresh:input_type -> rpc.Empty
+	10, // 34: rpc.PowerGrid.GetSupportBundle:input_type -> rpc.Empty
+	10, // 35: rpc.PowerGrid.GetCounters:input_type -> rpc.Empty
+	26, // 36: rpc.PowerGrid.ReadSMCKeys:input_type -> rpc.SMCKeysRequest
+	10, // 37: rpc.PowerGrid.ListUserSettings:input_type -> rpc.Empty
+	10, // 38: rpc.PowerGrid.GetTimeEstimates:input_type -> rpc.Empty
+	10, // 39: rpc.PowerGrid.GetSessionStats:input_type -> rpc.Empty
+	10, // 40: rpc.PowerGrid.GetWearTrend:input_type -> rpc.Empty
+	11, // 41: rpc.PowerGrid.GetStatus:output_type -> rpc.StatusResponse
+	10, // 42: rpc.PowerGrid.ApplyMutation:output_type -> rpc.Empty
+	13, // 43: rpc.PowerGrid.GetVersion:output_type -> rpc.VersionResponse
+	14, // 44: rpc.PowerGrid.GetDaemonInfo:output_type -> rpc.DaemonInfoResponse
+	15, // 45: rpc.PowerGrid.GetAdapterDetails:output_type -> rpc.AdapterDetailsResponse
+	17, // 46: rpc.PowerGrid.ListProfiles:output_type -> rpc.ProfileListResponse
+	19, // 47: rpc.PowerGrid.GetEffectiveSettings:output_type -> rpc.EffectiveSettingsResponse
+	22, // 48: rpc.PowerGrid.GetLogs:output_type -> rpc.LogsResponse
+	11, // 49: rpc.PowerGrid.Refresh:output_type -> rpc.StatusResponse
+	24, // 50: rpc.PowerGrid.GetSupportBundle:output_type -> rpc.SupportBundleResponse
+	25, // 51: rpc.PowerGrid.GetCounters:output_type -> rpc.CountersResponse
+	28, // 52: rpc.PowerGrid.ReadSMCKeys:output_type -> rpc.SMCKeysResponse
+	30, // 53: rpc.PowerGrid.ListUserSettings:output_type -> rpc.UserSettingsResponse
+	31, // 54: rpc.PowerGrid.GetTimeEstimates:output_type -> rpc.TimeEstimatesResponse
+	33, // 55: rpc.PowerGrid.GetSessionStats:output_type -> rpc.SessionStatsResponse
+	35, // 56: rpc.PowerGrid.GetWearTrend:output_type -> rpc.WearTrendResponse
+	41, // [41:57] is the sub-list for method output_type
+	25, // [25:41] is the sub-list for method input_type
+	25, // [25:25] is the sub-list for extension type_name
+	25, // [25:25] is the sub-list for extension extendee
+	0,  // [0:25] is the sub-list for field type_name
 }
 
 func init() { file_powergrid_proto_init() }
@@ -840,8 +3515,8 @@ func file_powergrid_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_powergrid_proto_rawDesc), len(file_powergrid_proto_rawDesc)),
-			NumEnums:      2,
-			NumMessages:   5,
+			NumEnums:      10,
+			NumMessages:   26,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,10 +19,22 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	PowerGrid_GetStatus_FullMethodName     = "/rpc.PowerGrid/GetStatus"
-	PowerGrid_ApplyMutation_FullMethodName = "/rpc.PowerGrid/ApplyMutation"
-	PowerGrid_GetVersion_FullMethodName    = "/rpc.PowerGrid/GetVersion"
-	PowerGrid_GetDaemonInfo_FullMethodName = "/rpc.PowerGrid/GetDaemonInfo"
+	PowerGrid_GetStatus_FullMethodName            = "/rpc.PowerGrid/GetStatus"
+	PowerGrid_ApplyMutation_FullMethodName        = "/rpc.PowerGrid/ApplyMutation"
+	PowerGrid_GetVersion_FullMethodName           = "/rpc.PowerGrid/GetVersion"
+	PowerGrid_GetDaemonInfo_FullMethodName        = "/rpc.PowerGrid/GetDaemonInfo"
+	PowerGrid_GetAdapterDetails_FullMethodName    = "/rpc.PowerGrid/GetAdapterDetails"
+	PowerGrid_ListProfiles_FullMethodName         = "/rpc.PowerGrid/ListProfiles"
+	PowerGrid_GetEffectiveSettings_FullMethodName = "/rpc.PowerGrid/GetEffectiveSettings"
+	PowerGrid_GetLogs_FullMethodName              = "/rpc.PowerGrid/GetLogs"
+	PowerGrid_Refresh_FullMethodName              = "/rpc.PowerGrid/Refresh"
+	PowerGrid_GetSupportBundle_FullMethodName     = "/rpc.PowerGrid/GetSupportBundle"
+	PowerGrid_GetCounters_FullMethodName          = "/rpc.PowerGrid/GetCounters"
+	PowerGrid_ReadSMCKeys_FullMethodName          = "/rpc.PowerGrid/ReadSMCKeys"
+	PowerGrid_ListUserSettings_FullMethodName     = "/rpc.PowerGrid/ListUserSettings"
+	PowerGrid_GetTimeEstimates_FullMethodName     = "/rpc.PowerGrid/GetTimeEstimates"
+	PowerGrid_GetSessionStats_FullMethodName      = "/rpc.PowerGrid/GetSessionStats"
+	PowerGrid_GetWearTrend_FullMethodName         = "/rpc.PowerGrid/GetWearTrend"
 )
 
 // PowerGridClient is the client API for PowerGrid service.
@@ -33,6 +45,18 @@ type PowerGridClient interface {
 	ApplyMutation(ctx context.Context, in *MutationRequest, opts ...grpc.CallOption) (*Empty, error)
 	GetVersion(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*VersionResponse, error)
 	GetDaemonInfo(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*DaemonInfoResponse, error)
+	GetAdapterDetails(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*AdapterDetailsResponse, error)
+	ListProfiles(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ProfileListResponse, error)
+	GetEffectiveSettings(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*EffectiveSettingsResponse, error)
+	GetLogs(ctx context.Context, in *LogsRequest, opts ...grpc.CallOption) (*LogsResponse, error)
+	Refresh(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*StatusResponse, error)
+	GetSupportBundle(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*SupportBundleResponse, error)
+	GetCounters(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*CountersResponse, error)
+	ReadSMCKeys(ctx context.Context, in *SMCKeysRequest, opts ...grpc.CallOption) (*SMCKeysResponse, error)
+	ListUserSettings(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*UserSettingsResponse, error)
+	GetTimeEstimates(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*TimeEstimatesResponse, error)
+	GetSessionStats(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*SessionStatsResponse, error)
+	GetWearTrend(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*WearTrendResponse, error)
 }
 
 type powerGridClient struct {
@@ -83,6 +107,126 @@ func (c *powerGridClient) GetDaemonInfo(ctx context.Context, in *Empty, opts ...
 	return out, nil
 }
 
+func (c *powerGridClient) GetAdapterDetails(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*AdapterDetailsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdapterDetailsResponse)
+	err := c.cc.Invoke(ctx, PowerGrid_GetAdapterDetails_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *powerGridClient) ListProfiles(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ProfileListResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ProfileListResponse)
+	err := c.cc.Invoke(ctx, PowerGrid_ListProfiles_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *powerGridClient) GetEffectiveSettings(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*EffectiveSettingsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EffectiveSettingsResponse)
+	err := c.cc.Invoke(ctx, PowerGrid_GetEffectiveSettings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *powerGridClient) GetLogs(ctx context.Context, in *LogsRequest, opts ...grpc.CallOption) (*LogsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LogsResponse)
+	err := c.cc.Invoke(ctx, PowerGrid_GetLogs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *powerGridClient) Refresh(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*StatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, PowerGrid_Refresh_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *powerGridClient) GetSupportBundle(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*SupportBundleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SupportBundleResponse)
+	err := c.cc.Invoke(ctx, PowerGrid_GetSupportBundle_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *powerGridClient) GetCounters(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*CountersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CountersResponse)
+	err := c.cc.Invoke(ctx, PowerGrid_GetCounters_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *powerGridClient) ReadSMCKeys(ctx context.Context, in *SMCKeysRequest, opts ...grpc.CallOption) (*SMCKeysResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SMCKeysResponse)
+	err := c.cc.Invoke(ctx, PowerGrid_ReadSMCKeys_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *powerGridClient) ListUserSettings(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*UserSettingsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserSettingsResponse)
+	err := c.cc.Invoke(ctx, PowerGrid_ListUserSettings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *powerGridClient) GetTimeEstimates(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*TimeEstimatesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TimeEstimatesResponse)
+	err := c.cc.Invoke(ctx, PowerGrid_GetTimeEstimates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *powerGridClient) GetSessionStats(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*SessionStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SessionStatsResponse)
+	err := c.cc.Invoke(ctx, PowerGrid_GetSessionStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *powerGridClient) GetWearTrend(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*WearTrendResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WearTrendResponse)
+	err := c.cc.Invoke(ctx, PowerGrid_GetWearTrend_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PowerGridServer is the server API for PowerGrid service.
 // All implementations must embed UnimplementedPowerGridServer
 // for forward compatibility.
@@ -91,6 +235,18 @@ type PowerGridServer interface {
 	ApplyMutation(context.Context, *MutationRequest) (*Empty, error)
 	GetVersion(context.Context, *Empty) (*VersionResponse, error)
 	GetDaemonInfo(context.Context, *Empty) (*DaemonInfoResponse, error)
+	GetAdapterDetails(context.Context, *Empty) (*AdapterDetailsResponse, error)
+	ListProfiles(context.Context, *Empty) (*ProfileListResponse, error)
+	GetEffectiveSettings(context.Context, *Empty) (*EffectiveSettingsResponse, error)
+	GetLogs(context.Context, *LogsRequest) (*LogsResponse, error)
+	Refresh(context.Context, *Empty) (*StatusResponse, error)
+	GetSupportBundle(context.Context, *Empty) (*SupportBundleResponse, error)
+	GetCounters(context.Context, *Empty) (*CountersResponse, error)
+	ReadSMCKeys(context.Context, *SMCKeysRequest) (*SMCKeysResponse, error)
+	ListUserSettings(context.Context, *Empty) (*UserSettingsResponse, error)
+	GetTimeEstimates(context.Context, *Empty) (*TimeEstimatesResponse, error)
+	GetSessionStats(context.Context, *Empty) (*SessionStatsResponse, error)
+	GetWearTrend(context.Context, *Empty) (*WearTrendResponse, error)
 	mustEmbedUnimplementedPowerGridServer()
 }
 
@@ -113,6 +269,42 @@ func (UnimplementedPowerGridServer) GetVersion(context.Context, *Empty) (*Versio
 func (UnimplementedPowerGridServer) GetDaemonInfo(context.Context, *Empty) (*DaemonInfoResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetDaemonInfo not implemented")
 }
+func (UnimplementedPowerGridServer) GetAdapterDetails(context.Context, *Empty) (*AdapterDetailsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAdapterDetails not implemented")
+}
+func (UnimplementedPowerGridServer) ListProfiles(context.Context, *Empty) (*ProfileListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListProfiles not implemented")
+}
+func (UnimplementedPowerGridServer) GetEffectiveSettings(context.Context, *Empty) (*EffectiveSettingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEffectiveSettings not implemented")
+}
+func (UnimplementedPowerGridServer) GetLogs(context.Context, *LogsRequest) (*LogsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLogs not implemented")
+}
+func (UnimplementedPowerGridServer) Refresh(context.Context, *Empty) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Refresh not implemented")
+}
+func (UnimplementedPowerGridServer) GetSupportBundle(context.Context, *Empty) (*SupportBundleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSupportBundle not implemented")
+}
+func (UnimplementedPowerGridServer) GetCounters(context.Context, *Empty) (*CountersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCounters not implemented")
+}
+func (UnimplementedPowerGridServer) ReadSMCKeys(context.Context, *SMCKeysRequest) (*SMCKeysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReadSMCKeys not implemented")
+}
+func (UnimplementedPowerGridServer) ListUserSettings(context.Context, *Empty) (*UserSettingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUserSettings not implemented")
+}
+func (UnimplementedPowerGridServer) GetTimeEstimates(context.Context, *Empty) (*TimeEstimatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTimeEstimates not implemented")
+}
+func (UnimplementedPowerGridServer) GetSessionStats(context.Context, *Empty) (*SessionStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSessionStats not implemented")
+}
+func (UnimplementedPowerGridServer) GetWearTrend(context.Context, *Empty) (*WearTrendResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWearTrend not implemented")
+}
 func (UnimplementedPowerGridServer) mustEmbedUnimplementedPowerGridServer() {}
 func (UnimplementedPowerGridServer) testEmbeddedByValue()                   {}
 
@@ -206,6 +398,222 @@ func _PowerGrid_GetDaemonInfo_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PowerGrid_GetAdapterDetails_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PowerGridServer).GetAdapterDetails(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PowerGrid_GetAdapterDetails_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PowerGridServer).GetAdapterDetails(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PowerGrid_ListProfiles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PowerGridServer).ListProfiles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PowerGrid_ListProfiles_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PowerGridServer).ListProfiles(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PowerGrid_GetEffectiveSettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PowerGridServer).GetEffectiveSettings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PowerGrid_GetEffectiveSettings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PowerGridServer).GetEffectiveSettings(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PowerGrid_GetLogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LogsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PowerGridServer).GetLogs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PowerGrid_GetLogs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PowerGridServer).GetLogs(ctx, req.(*LogsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PowerGrid_Refresh_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PowerGridServer).Refresh(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PowerGrid_Refresh_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PowerGridServer).Refresh(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PowerGrid_GetSupportBundle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PowerGridServer).GetSupportBundle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PowerGrid_GetSupportBundle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PowerGridServer).GetSupportBundle(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PowerGrid_GetCounters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PowerGridServer).GetCounters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PowerGrid_GetCounters_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PowerGridServer).GetCounters(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PowerGrid_ReadSMCKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SMCKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PowerGridServer).ReadSMCKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PowerGrid_ReadSMCKeys_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PowerGridServer).ReadSMCKeys(ctx, req.(*SMCKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PowerGrid_ListUserSettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PowerGridServer).ListUserSettings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PowerGrid_ListUserSettings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PowerGridServer).ListUserSettings(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PowerGrid_GetTimeEstimates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PowerGridServer).GetTimeEstimates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PowerGrid_GetTimeEstimates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PowerGridServer).GetTimeEstimates(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PowerGrid_GetSessionStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PowerGridServer).GetSessionStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PowerGrid_GetSessionStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PowerGridServer).GetSessionStats(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PowerGrid_GetWearTrend_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PowerGridServer).GetWearTrend(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PowerGrid_GetWearTrend_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PowerGridServer).GetWearTrend(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // PowerGrid_ServiceDesc is the grpc.ServiceDesc for PowerGrid service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -229,6 +637,54 @@ var PowerGrid_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetDaemonInfo",
 			Handler:    _PowerGrid_GetDaemonInfo_Handler,
 		},
+		{
+			MethodName: "GetAdapterDetails",
+			Handler:    _PowerGrid_GetAdapterDetails_Handler,
+		},
+		{
+			MethodName: "ListProfiles",
+			Handler:    _PowerGrid_ListProfiles_Handler,
+		},
+		{
+			MethodName: "GetEffectiveSettings",
+			Handler:    _PowerGrid_GetEffectiveSettings_Handler,
+		},
+		{
+			MethodName: "GetLogs",
+			Handler:    _PowerGrid_GetLogs_Handler,
+		},
+		{
+			MethodName: "Refresh",
+			Handler:    _PowerGrid_Refresh_Handler,
+		},
+		{
+			MethodName: "GetSupportBundle",
+			Handler:    _PowerGrid_GetSupportBundle_Handler,
+		},
+		{
+			MethodName: "GetCounters",
+			Handler:    _PowerGrid_GetCounters_Handler,
+		},
+		{
+			MethodName: "ReadSMCKeys",
+			Handler:    _PowerGrid_ReadSMCKeys_Handler,
+		},
+		{
+			MethodName: "ListUserSettings",
+			Handler:    _PowerGrid_ListUserSettings_Handler,
+		},
+		{
+			MethodName: "GetTimeEstimates",
+			Handler:    _PowerGrid_GetTimeEstimates_Handler,
+		},
+		{
+			MethodName: "GetSessionStats",
+			Handler:    _PowerGrid_GetSessionStats_Handler,
+		},
+		{
+			MethodName: "GetWearTrend",
+			Handler:    _PowerGrid_GetWearTrend_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "powergrid.proto",
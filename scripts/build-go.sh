@@ -170,6 +170,24 @@ fi
 
 echo "Daemon BuildID: ${DAEMON_BUILD_ID} (source=${BUILD_ID_SOURCE}, dirty=${BUILD_DIRTY})"
 
+echo "--- Collecting release metadata ---"
+GIT_COMMIT="${DAEMON_GIT_COMMIT:-}"
+if [ -z "${GIT_COMMIT}" ] && command -v git >/dev/null 2>&1 && [ -d "${PROJECT_ROOT}/.git" ]; then
+    GIT_COMMIT=$(git -C "${PROJECT_ROOT}" rev-parse HEAD 2>/dev/null || true)
+fi
+# Honor SOURCE_DATE_EPOCH so reproducible builds get a stable timestamp.
+if [ -n "${SOURCE_DATE_EPOCH:-}" ]; then
+    BUILD_TIME=$(date -u -r "${SOURCE_DATE_EPOCH}" +%Y-%m-%dT%H:%M:%SZ)
+else
+    BUILD_TIME=$(date -u +%Y-%m-%dT%H:%M:%SZ)
+fi
+DAEMON_VERSION="${DAEMON_VERSION:-}"
+if [ -z "${DAEMON_VERSION}" ] && command -v git >/dev/null 2>&1 && [ -d "${PROJECT_ROOT}/.git" ]; then
+    DAEMON_VERSION=$(git -C "${PROJECT_ROOT}" describe --tags --match 'v[0-9]*' --abbrev=0 2>/dev/null || true)
+    DAEMON_VERSION="${DAEMON_VERSION#v}"
+fi
+echo "Version: ${DAEMON_VERSION:-unknown} (commit=${GIT_COMMIT:-unknown}, built=${BUILD_TIME})"
+
 # Keep the Go/Cgo deployment target aligned with project target.
 export MACOSX_DEPLOYMENT_TARGET="${MACOSX_DEPLOYMENT_TARGET:-15.5}"
 export CGO_CFLAGS="$(append_flag "${CGO_CFLAGS:-}" "-mmacosx-version-min=${MACOSX_DEPLOYMENT_TARGET}")"
@@ -177,7 +195,7 @@ export CGO_LDFLAGS="$(append_flag "${CGO_LDFLAGS:-}" "-mmacosx-version-min=${MAC
 
 echo "--- Building powergrid-daemon ---"
 "${GO_BIN_RESOLVED}" build \
-    -ldflags "-X 'main.BuildID=${DAEMON_BUILD_ID}' -X 'main.BuildIDSource=${BUILD_ID_SOURCE}' -X 'main.BuildDirty=${BUILD_DIRTY}'" \
+    -ldflags "-X 'main.BuildID=${DAEMON_BUILD_ID}' -X 'main.BuildIDSource=${BUILD_ID_SOURCE}' -X 'main.BuildDirty=${BUILD_DIRTY}' -X 'main.GitCommit=${GIT_COMMIT}' -X 'main.BuildTime=${BUILD_TIME}' -X 'main.Version=${DAEMON_VERSION}'" \
     -o "${BUILD_OUTPUT_DIR}/powergrid-daemon" \
     "${DAEMON_SOURCE_DIR}"
 
@@ -186,6 +204,9 @@ cat > "${DAEMON_BUILDMETA_PATH}" <<META
 build_id=${DAEMON_BUILD_ID}
 build_id_source=${BUILD_ID_SOURCE}
 build_dirty=${BUILD_DIRTY}
+git_commit=${GIT_COMMIT}
+build_time=${BUILD_TIME}
+version=${DAEMON_VERSION}
 META
 
 echo "--- Building powergrid-helper ---"